@@ -13,7 +13,7 @@ import (
 	lol "github.com/kris-nova/lolgopher"
 )
 
-func initLogger(level int, colorValue string, logBuffer *bytes.Buffer, dumpLogsValue bool) {
+func initLogger(level int, colorValue string, logBuffer *bytes.Buffer, dumpLogsValue, logsToStderr bool) {
 	logger.Layout = "2006-01-02 15:04:05"
 
 	var bitwiseLevel int
@@ -33,24 +33,34 @@ func initLogger(level int, colorValue string, logBuffer *bytes.Buffer, dumpLogsV
 	}
 	logger.BitwiseLevel = bitwiseLevel
 
+	// stdout is reserved for machine-readable output when logsToStderr is set
+	var (
+		fabulousOutput = lol.NewLolWriter()
+		colorOutput    = color.Output
+		plainOutput    = io.Writer(os.Stdout)
+	)
+	if logsToStderr {
+		fabulousOutput, colorOutput, plainOutput = color.Error, color.Error, os.Stderr
+	}
+
 	if dumpLogsValue {
 		switch colorValue {
 		case "fabulous":
-			logger.Writer = io.MultiWriter(lol.NewLolWriter(), logBuffer)
+			logger.Writer = io.MultiWriter(fabulousOutput, logBuffer)
 		case "true":
-			logger.Writer = io.MultiWriter(color.Output, logBuffer)
+			logger.Writer = io.MultiWriter(colorOutput, logBuffer)
 		default:
-			logger.Writer = io.MultiWriter(os.Stdout, logBuffer)
+			logger.Writer = io.MultiWriter(plainOutput, logBuffer)
 		}
 
 	} else {
 		switch colorValue {
 		case "fabulous":
-			logger.Writer = lol.NewLolWriter()
+			logger.Writer = fabulousOutput
 		case "true":
-			logger.Writer = color.Output
+			logger.Writer = colorOutput
 		default:
-			logger.Writer = os.Stdout
+			logger.Writer = plainOutput
 		}
 	}
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/ctl/register"
 
 	"github.com/weaveworks/eksctl/pkg/actions/anywhere"
+	"github.com/weaveworks/eksctl/pkg/ctl/apply"
 	"github.com/weaveworks/eksctl/pkg/ctl/associate"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/ctl/completion"
@@ -20,12 +22,14 @@ import (
 	"github.com/weaveworks/eksctl/pkg/ctl/drain"
 	"github.com/weaveworks/eksctl/pkg/ctl/enable"
 	"github.com/weaveworks/eksctl/pkg/ctl/get"
+	"github.com/weaveworks/eksctl/pkg/ctl/replace"
 	"github.com/weaveworks/eksctl/pkg/ctl/scale"
 	"github.com/weaveworks/eksctl/pkg/ctl/set"
 	"github.com/weaveworks/eksctl/pkg/ctl/unset"
 	"github.com/weaveworks/eksctl/pkg/ctl/update"
 	"github.com/weaveworks/eksctl/pkg/ctl/upgrade"
 	"github.com/weaveworks/eksctl/pkg/ctl/utils"
+	"github.com/weaveworks/eksctl/pkg/utils/events"
 )
 
 func addCommands(rootCmd *cobra.Command, flagGrouping *cmdutils.FlagGrouping) {
@@ -40,6 +44,7 @@ func addCommands(rootCmd *cobra.Command, flagGrouping *cmdutils.FlagGrouping) {
 	rootCmd.AddCommand(unset.Command(flagGrouping))
 	rootCmd.AddCommand(scale.Command(flagGrouping))
 	rootCmd.AddCommand(drain.Command(flagGrouping))
+	rootCmd.AddCommand(replace.Command(flagGrouping))
 	rootCmd.AddCommand(enable.Command(flagGrouping))
 	rootCmd.AddCommand(register.Command(flagGrouping))
 	rootCmd.AddCommand(deregister.Command(flagGrouping))
@@ -48,6 +53,8 @@ func addCommands(rootCmd *cobra.Command, flagGrouping *cmdutils.FlagGrouping) {
 	//Ensures "eksctl --help" presents eksctl anywhere as a command, but adds no subcommands since we invoke the binary.
 	rootCmd.AddCommand(cmdutils.NewVerbCmd("anywhere", "EKS anywhere", ""))
 
+	cmdutils.AddResourceCmd(flagGrouping, rootCmd, apply.ApplyCmd)
+	cmdutils.AddResourceCmd(flagGrouping, rootCmd, apply.DiffCmd)
 	cmdutils.AddResourceCmd(flagGrouping, rootCmd, infoCmd)
 	cmdutils.AddResourceCmd(flagGrouping, rootCmd, versionCmd)
 }
@@ -91,11 +98,16 @@ func main() {
 
 	dumpLogsValue := rootCmd.PersistentFlags().BoolP("dumpLogs", "d", false, "dump logs to disk on failure if set to true")
 
+	outputEvents := rootCmd.PersistentFlags().String("output-events", "", fmt.Sprintf("emit structured events for long-running operations to stdout and write logs to stderr (valid options: %s)", events.FormatJSONLines))
+
 	logBuffer := new(bytes.Buffer)
 
 	cobra.OnInitialize(func() {
-		initLogger(*loggerLevel, *colorValue, logBuffer, *dumpLogsValue)
+		initLogger(*loggerLevel, *colorValue, logBuffer, *dumpLogsValue, *outputEvents != "")
 	})
+	rootCmd.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
+		return events.Configure(*outputEvents, os.Stdout)
+	}
 
 	rootCmd.SetUsageFunc(flagGrouping.Usage)
 
@@ -107,6 +119,10 @@ func main() {
 			}
 		}
 
+		var exitCoder interface{ ExitCode() int }
+		if errors.As(err, &exitCoder) {
+			os.Exit(exitCoder.ExitCode())
+		}
 		os.Exit(1)
 	}
 }
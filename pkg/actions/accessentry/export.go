@@ -0,0 +1,73 @@
+package accessentry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// Export converts access entry summaries to access entries that can be loaded from a ClusterConfig file
+// by `eksctl create accessentry`.
+// Fields that EKS sets by default, such as the username, are omitted so that the entries can be used on other clusters.
+func Export(summaries []Summary) ([]api.AccessEntry, error) {
+	var accessEntries []api.AccessEntry
+	for _, s := range summaries {
+		principalARN, err := arn.Parse(s.PrincipalARN)
+		if err != nil {
+			return nil, fmt.Errorf("parsing principal ARN %q: %w", s.PrincipalARN, err)
+		}
+		ae := api.AccessEntry{
+			PrincipalARN: api.ARN(principalARN),
+		}
+		switch entryType := api.AccessEntryType(s.Type); entryType {
+		case "", api.AccessEntryTypeStandard:
+			ae.KubernetesGroups = s.KubernetesGroups
+			if !isDefaultUsername(principalARN, s.KubernetesUsername) {
+				ae.KubernetesUsername = s.KubernetesUsername
+			}
+			ae.AccessPolicies = s.AccessPolicies
+		case api.AccessEntryTypeEC2:
+			ae.Type = s.Type
+			ae.AccessPolicies = s.AccessPolicies
+		default:
+			// the username, groups and policies of node entries are managed by EKS.
+			ae.Type = s.Type
+		}
+		if len(ae.AccessPolicies) == 0 {
+			ae.AccessPolicies = nil
+		}
+		accessEntries = append(accessEntries, ae)
+	}
+	return accessEntries, nil
+}
+
+// IsNodeType reports whether accessEntryType is the type of an access entry for the nodes of a cluster.
+func IsNodeType(accessEntryType string) bool {
+	switch api.AccessEntryType(accessEntryType) {
+	case "", api.AccessEntryTypeStandard:
+		return false
+	default:
+		return true
+	}
+}
+
+// isDefaultUsername reports whether username is the username EKS assigns to principalARN when none is specified.
+func isDefaultUsername(principalARN arn.ARN, username string) bool {
+	if username == "" || username == principalARN.String() {
+		return true
+	}
+	parts := strings.Split(principalARN.Resource, "/")
+	if len(parts) < 2 || parts[0] != "role" {
+		return false
+	}
+	assumedRoleARN := arn.ARN{
+		Partition: principalARN.Partition,
+		Service:   "sts",
+		AccountID: principalARN.AccountID,
+		Resource:  fmt.Sprintf("assumed-role/%s/{{SessionName}}", parts[len(parts)-1]),
+	}
+	return username == assumedRoleARN.String()
+}
@@ -0,0 +1,69 @@
+package accessentry_test
+
+import (
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("Export", func() {
+	It("converts summaries to access entries that can be loaded from a config file", func() {
+		adminPolicy := api.AccessPolicy{
+			PolicyARN: api.MustParseARN("arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy"),
+			AccessScope: api.AccessScope{
+				Type: ekstypes.AccessScopeTypeCluster,
+			},
+		}
+		accessEntries, err := accessentry.Export([]accessentry.Summary{
+			{
+				PrincipalARN:       "arn:aws:iam::111122223333:role/admin",
+				Type:               "STANDARD",
+				KubernetesUsername: "arn:aws:sts::111122223333:assumed-role/admin/{{SessionName}}",
+				AccessPolicies:     []api.AccessPolicy{adminPolicy},
+			},
+			{
+				PrincipalARN:       "arn:aws:iam::111122223333:user/dev",
+				Type:               "STANDARD",
+				KubernetesGroups:   []string{"dev"},
+				KubernetesUsername: "developer",
+				AccessPolicies:     []api.AccessPolicy{},
+			},
+			{
+				PrincipalARN:       "arn:aws:iam::111122223333:role/node-role",
+				Type:               "EC2_LINUX",
+				KubernetesGroups:   []string{"system:nodes"},
+				KubernetesUsername: "system:node:{{EC2PrivateDNSName}}",
+				AccessPolicies:     []api.AccessPolicy{},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(accessEntries).To(Equal([]api.AccessEntry{
+			{
+				PrincipalARN:   api.MustParseARN("arn:aws:iam::111122223333:role/admin"),
+				AccessPolicies: []api.AccessPolicy{adminPolicy},
+			},
+			{
+				PrincipalARN:       api.MustParseARN("arn:aws:iam::111122223333:user/dev"),
+				KubernetesGroups:   []string{"dev"},
+				KubernetesUsername: "developer",
+			},
+			{
+				PrincipalARN: api.MustParseARN("arn:aws:iam::111122223333:role/node-role"),
+				Type:         "EC2_LINUX",
+			},
+		}))
+	})
+
+	DescribeTable("IsNodeType", func(accessEntryType string, expected bool) {
+		Expect(accessentry.IsNodeType(accessEntryType)).To(Equal(expected))
+	},
+		Entry("standard", "STANDARD", false),
+		Entry("unset", "", false),
+		Entry("EC2 Linux", "EC2_LINUX", true),
+		Entry("EC2 Windows", "EC2_WINDOWS", true),
+		Entry("Auto Mode", "EC2", true),
+	)
+})
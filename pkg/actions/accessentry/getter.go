@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
@@ -29,9 +30,11 @@ func NewGetter(clusterName string, eksAPI awsapi.EKS) *Getter {
 }
 
 type Summary struct {
-	PrincipalARN     string             `json:"principalARN"`
-	KubernetesGroups []string           `json:"kubernetesGroups,omitempty"`
-	AccessPolicies   []api.AccessPolicy `json:"accessPolicies,omitempty"`
+	PrincipalARN       string             `json:"principalARN"`
+	Type               string             `json:"type,omitempty"`
+	KubernetesGroups   []string           `json:"kubernetesGroups,omitempty"`
+	KubernetesUsername string             `json:"kubernetesUsername,omitempty"`
+	AccessPolicies     []api.AccessPolicy `json:"accessPolicies,omitempty"`
 }
 
 func (aeg *Getter) Get(ctx context.Context, principalARN api.ARN) ([]Summary, error) {
@@ -73,7 +76,9 @@ func (aeg *Getter) getIndividualEntry(ctx context.Context, principalARN string)
 	if err != nil {
 		return Summary{}, fmt.Errorf("calling EKS API to describe access entry with principal ARN %s: %w", principalARN, err)
 	}
+	summary.Type = aws.ToString(entry.AccessEntry.Type)
 	summary.KubernetesGroups = entry.AccessEntry.KubernetesGroups
+	summary.KubernetesUsername = aws.ToString(entry.AccessEntry.Username)
 
 	// fetch associated polices
 	policies, err := aeg.eksAPI.ListAssociatedAccessPolicies(ctx, &eks.ListAssociatedAccessPoliciesInput{
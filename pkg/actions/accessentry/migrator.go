@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -225,10 +226,75 @@ func (m *Migrator) doGetIAMIdentityMappings(ctx context.Context) ([]iam.Identity
 	return cmEntries, nil
 }
 
-func doFilterAccessEntries(cmEntries []iam.Identity, accessEntries []Summary) ([]api.AccessEntry, bool) {
+// MigrationStatus describes what happens to an aws-auth identity when migrating to access entries.
+type MigrationStatus string
+
+const (
+	// MigrationStatusCreate means an access entry will be created for the identity.
+	MigrationStatusCreate MigrationStatus = "create"
+	// MigrationStatusExists means an access entry already exists for the identity.
+	MigrationStatusExists MigrationStatus = "exists"
+	// MigrationStatusDuplicate means the identity is a duplicate of another identity in aws-auth.
+	MigrationStatusDuplicate MigrationStatus = "duplicate"
+	// MigrationStatusUnsupported means the identity cannot be migrated to an access entry.
+	MigrationStatusUnsupported MigrationStatus = "unsupported"
+)
 
-	skipAPImode := false
-	var toDoEntries []api.AccessEntry
+// IdentityMigration describes how an aws-auth identity is migrated to an access entry.
+type IdentityMigration struct {
+	ARN         string           `json:"arn,omitempty"`
+	Account     string           `json:"account,omitempty"`
+	Username    string           `json:"username,omitempty"`
+	Groups      []string         `json:"groups,omitempty"`
+	Status      MigrationStatus  `json:"status"`
+	AccessEntry *api.AccessEntry `json:"accessEntry,omitempty"`
+	// Reason explains the status, and how RBAC changes for the identity.
+	Reason string `json:"reason,omitempty"`
+
+	// warning is logged when the identity is skipped during the migration.
+	warning string
+}
+
+// MigrationReport describes the changes that MigrateToAccessEntry would make to a cluster.
+type MigrationReport struct {
+	CurrentAuthenticationMode ekstypes.AuthenticationMode `json:"currentAuthenticationMode"`
+	TargetAuthenticationMode  ekstypes.AuthenticationMode `json:"targetAuthenticationMode"`
+	// SwitchesToAPI is true when the authentication mode would be updated to API and aws-auth deleted,
+	// which requires all identities to be migrated.
+	SwitchesToAPI bool                `json:"switchesToAPI"`
+	Identities    []IdentityMigration `json:"identities"`
+}
+
+// Report returns how each aws-auth identity would be migrated to an access entry, without making any changes to the cluster.
+func (m *Migrator) Report(ctx context.Context) (*MigrationReport, error) {
+	if m.tgAuthMode != ekstypes.AuthenticationModeApi && m.tgAuthMode != ekstypes.AuthenticationModeApiAndConfigMap {
+		return nil, fmt.Errorf("target authentication mode is invalid, must be either %s or %s", ekstypes.AuthenticationModeApi, ekstypes.AuthenticationModeApiAndConfigMap)
+	}
+	report := &MigrationReport{
+		CurrentAuthenticationMode: m.curAuthMode,
+		TargetAuthenticationMode:  m.tgAuthMode,
+	}
+	if m.curAuthMode == ekstypes.AuthenticationModeApi {
+		return report, nil
+	}
+
+	curAccessEntries, err := m.aeGetter.Get(ctx, api.ARN{})
+	if err != nil && m.curAuthMode != ekstypes.AuthenticationModeConfigMap {
+		return nil, fmt.Errorf("fetching existing access entries: %w", err)
+	}
+	cmEntries, err := m.doGetIAMIdentityMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Identities = planIdentityMigrations(cmEntries, curAccessEntries)
+	report.SwitchesToAPI = m.tgAuthMode == ekstypes.AuthenticationModeApi && !slices.ContainsFunc(report.Identities, func(im IdentityMigration) bool {
+		return im.Status == MigrationStatusUnsupported
+	})
+	return report, nil
+}
+
+// planIdentityMigrations returns how each of cmEntries is migrated, given the existing accessEntries.
+func planIdentityMigrations(cmEntries []iam.Identity, accessEntries []Summary) []IdentityMigration {
 	uniqueCmEntries := map[string]struct{}{}
 	aeArns := map[string]struct{}{}
 
@@ -237,38 +303,75 @@ func doFilterAccessEntries(cmEntries []iam.Identity, accessEntries []Summary) ([
 		aeArns[ae.PrincipalARN] = struct{}{}
 	}
 
+	var migrations []IdentityMigration
 	for _, cme := range cmEntries {
-		if _, ok := uniqueCmEntries[cme.ARN()]; !ok { // Check if cmEntry is not duplicate
-			uniqueCmEntries[cme.ARN()] = struct{}{} // Add ARN to cmEntries map
-
-			if _, ok := aeArns[cme.ARN()]; !ok { // Check if the principal ARN is not present in existing access entries
-				switch cme.Type() {
-				case iam.ResourceTypeRole:
-					if strings.Contains(cme.ARN(), ":role/aws-service-role/") { // Check if the principal ARN is service-linked-role
-						logger.Warning("found service-linked role iamidentitymapping \"%s\", can not create access entry, skipping", cme.ARN())
-						skipAPImode = true
-					} else if cme.Username() == authconfigmap.RoleNodeGroupUsername {
-						aeEntry := doBuildNodeRoleAccessEntry(cme)
-						toDoEntries = append(toDoEntries, *aeEntry)
-					} else if aeEntry := doBuildAccessEntry(cme); aeEntry != nil {
-						toDoEntries = append(toDoEntries, *aeEntry)
-					} else {
-						skipAPImode = true
-					}
-				case iam.ResourceTypeUser:
-					if aeEntry := doBuildAccessEntry(cme); aeEntry != nil {
-						toDoEntries = append(toDoEntries, *aeEntry)
-					} else {
-						skipAPImode = true
-					}
-				case iam.ResourceTypeAccount:
-					logger.Warning("found account iamidentitymapping %q, cannot create access entry, skipping", cme.Account())
-					skipAPImode = true
-				}
+		im := IdentityMigration{
+			ARN:      cme.ARN(),
+			Account:  cme.Account(),
+			Username: cme.Username(),
+			Groups:   cme.Groups(),
+		}
+		if _, ok := uniqueCmEntries[cme.ARN()]; ok && cme.Type() != iam.ResourceTypeAccount {
+			im.Status = MigrationStatusDuplicate
+			im.Reason = "an earlier mapping for the same ARN is migrated instead"
+			migrations = append(migrations, im)
+			continue
+		}
+		uniqueCmEntries[cme.ARN()] = struct{}{} // Add ARN to cmEntries map
+
+		if _, ok := aeArns[cme.ARN()]; ok && cme.Type() != iam.ResourceTypeAccount { // Check if the principal ARN is present in existing access entries
+			im.Status = MigrationStatusExists
+			im.Reason = "an access entry already exists for this principal, the mapping is not migrated"
+			migrations = append(migrations, im)
+			continue
+		}
+
+		switch cme.Type() {
+		case iam.ResourceTypeRole:
+			if strings.Contains(cme.ARN(), ":role/aws-service-role/") { // Check if the principal ARN is service-linked-role
+				im.Status = MigrationStatusUnsupported
+				im.Reason = "access entries cannot be created for service-linked roles"
+				im.warning = fmt.Sprintf("found service-linked role iamidentitymapping \"%s\", can not create access entry, skipping", cme.ARN())
+			} else if cme.Username() == authconfigmap.RoleNodeGroupUsername {
+				im.Status = MigrationStatusCreate
+				im.AccessEntry = doBuildNodeRoleAccessEntry(cme)
+				im.Reason = fmt.Sprintf("node role, EKS grants the permissions of %s nodes to the access entry", im.AccessEntry.Type)
+			} else {
+				im.AccessEntry, im.Reason = doBuildAccessEntry(cme)
+			}
+		case iam.ResourceTypeUser:
+			im.AccessEntry, im.Reason = doBuildAccessEntry(cme)
+		case iam.ResourceTypeAccount:
+			im.Status = MigrationStatusUnsupported
+			im.Reason = "account mappings cannot be migrated, an access entry must be created for each IAM principal of the account"
+			im.warning = fmt.Sprintf("found account iamidentitymapping %q, cannot create access entry, skipping", cme.Account())
+		}
+		if im.Status == "" {
+			if im.AccessEntry != nil {
+				im.Status = MigrationStatusCreate
 			} else {
-				logger.Warning("%s already exists in access entry, skipping", cme.ARN())
+				im.Status = MigrationStatusUnsupported
+				im.warning = fmt.Sprintf("at least one group name associated with %q starts with \"system:\", can not create access entry, skipping", cme.ARN())
 			}
 		}
+		migrations = append(migrations, im)
+	}
+	return migrations
+}
+
+func doFilterAccessEntries(cmEntries []iam.Identity, accessEntries []Summary) ([]api.AccessEntry, bool) {
+	skipAPImode := false
+	var toDoEntries []api.AccessEntry
+	for _, im := range planIdentityMigrations(cmEntries, accessEntries) {
+		switch im.Status {
+		case MigrationStatusCreate:
+			toDoEntries = append(toDoEntries, *im.AccessEntry)
+		case MigrationStatusExists:
+			logger.Warning("%s already exists in access entry, skipping", im.ARN)
+		case MigrationStatusUnsupported:
+			logger.Warning(im.warning)
+			skipAPImode = true
+		}
 	}
 
 	return toDoEntries, skipAPImode
@@ -296,13 +399,13 @@ func doBuildNodeRoleAccessEntry(cme iam.Identity) *api.AccessEntry {
 	}
 }
 
-func doBuildAccessEntry(cme iam.Identity) *api.AccessEntry {
-	containsSys := false
+// doBuildAccessEntry returns the access entry for cme, or nil if it cannot be migrated, and the reason.
+func doBuildAccessEntry(cme iam.Identity) (*api.AccessEntry, string) {
+	var systemGroup string
 
 	for _, group := range cme.Groups() {
 		if strings.HasPrefix(group, "system:") {
-			containsSys = true
-			if group == "system:masters" { // Cluster Admin Role
+			if group == authconfigmap.GroupMasters { // Cluster Admin Role
 				return &api.AccessEntry{
 					PrincipalARN: api.MustParseARN(cme.ARN()),
 					Type:         "STANDARD",
@@ -315,14 +418,16 @@ func doBuildAccessEntry(cme iam.Identity) *api.AccessEntry {
 						},
 					},
 					KubernetesUsername: cme.Username(),
-				}
+				}, fmt.Sprintf("%s is replaced by the AmazonEKSClusterAdminPolicy access policy, other groups are dropped", authconfigmap.GroupMasters)
+			}
+			if systemGroup == "" {
+				systemGroup = group
 			}
 		}
 	}
 
-	if containsSys { // Check if any GroupName start with "system:"" in name
-		logger.Warning("at least one group name associated with %q starts with \"system:\", can not create access entry, skipping", cme.ARN())
-		return nil
+	if systemGroup != "" { // Check if any GroupName start with "system:"" in name
+		return nil, fmt.Sprintf("group %q starts with \"system:\", which access entries do not support", systemGroup)
 	}
 
 	return &api.AccessEntry{
@@ -330,8 +435,7 @@ func doBuildAccessEntry(cme iam.Identity) *api.AccessEntry {
 		Type:               "STANDARD",
 		KubernetesGroups:   cme.Groups(),
 		KubernetesUsername: cme.Username(),
-	}
-
+	}, "the username and groups are kept, RBAC bindings for them still apply"
 }
 
 func doDeleteAWSAuthConfigMap(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
@@ -423,3 +423,175 @@ var _ = Describe("Migrate Access Entry", func() {
 		}),
 	)
 })
+
+var _ = Describe("Migration report", func() {
+	var (
+		mockProvider  *mockprovider.MockProvider
+		fakeClientset *fake.Clientset
+		fakeAEGetter  *fakes.FakeGetterInterface
+	)
+
+	mockRole := func(name, roleARN string) {
+		mockProvider.MockIAM().
+			On("GetRole", mock.Anything, &awsiam.GetRoleInput{RoleName: aws.String(name)}).
+			Return(&awsiam.GetRoleOutput{Role: &iamtypes.Role{Arn: aws.String(roleARN)}}, nil)
+	}
+
+	createAWSAuth := func(roles []iam.RoleIdentity, users []iam.UserIdentity, accounts []string) {
+		data := map[string]string{}
+		for key, value := range map[string]interface{}{"mapRoles": roles, "mapUsers": users, "mapAccounts": accounts} {
+			bytes, err := yaml.Marshal(value)
+			Expect(err).NotTo(HaveOccurred())
+			data[key] = string(bytes)
+		}
+		_, err := fakeClientset.CoreV1().ConfigMaps(authconfigmap.ObjectNamespace).Create(context.Background(), &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: authconfigmap.ObjectName,
+			},
+			Data: data,
+		}, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	newMigrator := func(curAuthMode, tgAuthMode ekstypes.AuthenticationMode) *accessentry.Migrator {
+		return accessentry.NewMigrator("test-cluster", mockProvider.MockEKS(), mockProvider.MockIAM(), fakeClientset,
+			&accessentry.Creator{ClusterName: "test-cluster"}, fakeAEGetter, curAuthMode, tgAuthMode)
+	}
+
+	BeforeEach(func() {
+		mockProvider = mockprovider.NewMockProvider()
+		fakeClientset = fake.NewSimpleClientset()
+		fakeAEGetter = &fakes.FakeGetterInterface{}
+		fakeAEGetter.GetReturns([]accessentry.Summary{
+			{PrincipalARN: "arn:aws:iam::111122223333:role/existing"},
+		}, nil)
+	})
+
+	It("reports how each identity would be migrated, without making any changes", func() {
+		mockRole("node-role", "arn:aws:iam::111122223333:role/node-role")
+		mockRole("dev", "arn:aws:iam::111122223333:role/dev")
+		mockRole("existing", "arn:aws:iam::111122223333:role/existing")
+		mockRole("AWSServiceRoleForAmazonEMRContainers", "arn:aws:iam::111122223333:role/aws-service-role/emr-containers.amazonaws.com/AWSServiceRoleForAmazonEMRContainers")
+		mockProvider.MockIAM().
+			On("GetUser", mock.Anything, &awsiam.GetUserInput{UserName: aws.String("admin")}).
+			Return(&awsiam.GetUserOutput{User: &iamtypes.User{Arn: aws.String("arn:aws:iam::111122223333:user/admin")}}, nil)
+		createAWSAuth([]iam.RoleIdentity{
+			{
+				RoleARN: "arn:aws:iam::111122223333:role/node-role",
+				KubernetesIdentity: iam.KubernetesIdentity{
+					KubernetesUsername: authconfigmap.RoleNodeGroupUsername,
+					KubernetesGroups:   authconfigmap.RoleNodeGroupGroups,
+				},
+			},
+			{
+				RoleARN: "arn:aws:iam::111122223333:role/dev",
+				KubernetesIdentity: iam.KubernetesIdentity{
+					KubernetesUsername: "dev",
+					KubernetesGroups:   []string{"developers"},
+				},
+			},
+			{
+				RoleARN: "arn:aws:iam::111122223333:role/dev",
+				KubernetesIdentity: iam.KubernetesIdentity{
+					KubernetesUsername: "dev-2",
+				},
+			},
+			{
+				RoleARN: "arn:aws:iam::111122223333:role/existing",
+				KubernetesIdentity: iam.KubernetesIdentity{
+					KubernetesUsername: "existing",
+				},
+			},
+			{
+				RoleARN: "arn:aws:iam::111122223333:role/aws-service-role/emr-containers.amazonaws.com/AWSServiceRoleForAmazonEMRContainers",
+				KubernetesIdentity: iam.KubernetesIdentity{
+					KubernetesUsername: "emr-containers",
+				},
+			},
+		}, []iam.UserIdentity{
+			{
+				UserARN: "arn:aws:iam::111122223333:user/admin",
+				KubernetesIdentity: iam.KubernetesIdentity{
+					KubernetesUsername: "admin",
+					KubernetesGroups:   []string{"system:masters"},
+				},
+			},
+		}, []string{"444455556666"})
+
+		report, err := newMigrator(ekstypes.AuthenticationModeApiAndConfigMap, ekstypes.AuthenticationModeApi).Report(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.SwitchesToAPI).To(BeFalse())
+
+		var statuses []accessentry.MigrationStatus
+		for _, im := range report.Identities {
+			statuses = append(statuses, im.Status)
+			Expect(im.Reason).NotTo(BeEmpty())
+		}
+		Expect(statuses).To(Equal([]accessentry.MigrationStatus{
+			accessentry.MigrationStatusCreate,
+			accessentry.MigrationStatusCreate,
+			accessentry.MigrationStatusDuplicate,
+			accessentry.MigrationStatusExists,
+			accessentry.MigrationStatusUnsupported,
+			accessentry.MigrationStatusCreate,
+			accessentry.MigrationStatusUnsupported,
+		}))
+		Expect(report.Identities[0].AccessEntry.Type).To(Equal("EC2_LINUX"))
+		Expect(report.Identities[1].AccessEntry.KubernetesGroups).To(Equal([]string{"developers"}))
+		Expect(report.Identities[5].AccessEntry.AccessPolicies).To(HaveLen(1))
+		Expect(report.Identities[5].AccessEntry.AccessPolicies[0].PolicyARN.String()).To(Equal("arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy"))
+		Expect(report.Identities[6].Account).To(Equal("444455556666"))
+		Expect(report.Identities[6].Reason).To(ContainSubstring("account mappings cannot be migrated"))
+
+		Expect(mockProvider.MockEKS().Calls).To(BeEmpty())
+		_, err = fakeClientset.CoreV1().ConfigMaps(authconfigmap.ObjectNamespace).Get(context.Background(), authconfigmap.ObjectName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("reports identities with non-standard system groups as unsupported", func() {
+		mockRole("test", "arn:aws:iam::111122223333:role/test")
+		createAWSAuth([]iam.RoleIdentity{
+			{
+				RoleARN: "arn:aws:iam::111122223333:role/test",
+				KubernetesIdentity: iam.KubernetesIdentity{
+					KubernetesGroups: []string{"viewers", "system:monitoring"},
+				},
+			},
+		}, nil, nil)
+
+		report, err := newMigrator(ekstypes.AuthenticationModeConfigMap, ekstypes.AuthenticationModeApi).Report(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Identities).To(HaveLen(1))
+		Expect(report.Identities[0].Status).To(Equal(accessentry.MigrationStatusUnsupported))
+		Expect(report.Identities[0].Reason).To(Equal(`group "system:monitoring" starts with "system:", which access entries do not support`))
+		Expect(report.SwitchesToAPI).To(BeFalse())
+	})
+
+	It("reports a switch to API mode when all identities can be migrated", func() {
+		mockRole("node-role", "arn:aws:iam::111122223333:role/node-role")
+		createAWSAuth([]iam.RoleIdentity{
+			{
+				RoleARN: "arn:aws:iam::111122223333:role/node-role",
+				KubernetesIdentity: iam.KubernetesIdentity{
+					KubernetesUsername: authconfigmap.RoleNodeGroupUsername,
+					KubernetesGroups:   authconfigmap.RoleNodeGroupGroups,
+				},
+			},
+		}, nil, nil)
+
+		report, err := newMigrator(ekstypes.AuthenticationModeApiAndConfigMap, ekstypes.AuthenticationModeApi).Report(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.SwitchesToAPI).To(BeTrue())
+
+		report, err = newMigrator(ekstypes.AuthenticationModeApiAndConfigMap, ekstypes.AuthenticationModeApiAndConfigMap).Report(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.SwitchesToAPI).To(BeFalse())
+	})
+
+	It("reports nothing when the cluster already uses API mode", func() {
+		report, err := newMigrator(ekstypes.AuthenticationModeApi, ekstypes.AuthenticationModeApi).Report(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Identities).To(BeEmpty())
+		Expect(fakeAEGetter.GetCallCount()).To(Equal(0))
+	})
+})
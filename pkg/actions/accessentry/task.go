@@ -41,6 +41,10 @@ type accessEntryTask struct {
 
 func (t *accessEntryTask) Describe() string { return t.info }
 
+func (t *accessEntryTask) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: tasks.KindAccessEntry, StackName: MakeStackName(t.clusterName, t.accessEntry)}
+}
+
 func (t *accessEntryTask) Do(errorCh chan error) error {
 	defer close(errorCh)
 	rs := builder.NewAccessEntryResourceSet(t.clusterName, t.accessEntry)
@@ -89,6 +93,10 @@ func (t *deleteUnownedAccessEntryTask) Describe() string {
 	return t.info
 }
 
+func (t *deleteUnownedAccessEntryTask) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: tasks.KindAccessEntry}
+}
+
 func (t *deleteUnownedAccessEntryTask) Do(errorCh chan error) error {
 	defer close(errorCh)
 
@@ -115,6 +123,10 @@ func (t *deleteOwnedAccessEntryTask) Describe() string {
 	return t.info
 }
 
+func (t *deleteOwnedAccessEntryTask) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: tasks.KindAccessEntry, StackName: t.stackName}
+}
+
 func (t *deleteOwnedAccessEntryTask) Do(errorCh chan error) error {
 	stack, err := t.stackRemover.DescribeStack(t.ctx, &cfntypes.Stack{StackName: &t.stackName})
 	if err != nil {
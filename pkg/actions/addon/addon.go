@@ -7,8 +7,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"github.com/hashicorp/go-version"
 	"github.com/kris-nova/logger"
 	kubeclient "k8s.io/client-go/kubernetes"
@@ -18,6 +20,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+	"github.com/weaveworks/eksctl/pkg/utils/events"
 )
 
 // StackManager manages CloudFormation stacks for addons.
@@ -66,7 +69,16 @@ func (a *Manager) waitForAddonToBeActive(ctx context.Context, addon *api.Addon,
 		!a.clusterConfig.HasNodes() {
 		return nil
 	}
-	activeWaiter := eks.NewAddonActiveWaiter(a.eksAPI)
+	statusTracker := &events.StatusTracker{Type: events.AddonStatus, Kind: "addon"}
+	activeWaiter := eks.NewAddonActiveWaiter(a.eksAPI, func(o *eks.AddonActiveWaiterOptions) {
+		defaultRetryer := o.Retryable
+		o.Retryable = func(ctx context.Context, in *eks.DescribeAddonInput, out *eks.DescribeAddonOutput, err error) (bool, error) {
+			if out != nil && out.Addon != nil {
+				statusTracker.Update(addon.Name, string(out.Addon.Status), addonHealthIssues(out.Addon.Health))
+			}
+			return defaultRetryer(ctx, in, out, err)
+		}
+	})
 	input := &eks.DescribeAddonInput{
 		ClusterName: &a.clusterConfig.Metadata.Name,
 		AddonName:   &addon.Name,
@@ -93,6 +105,17 @@ func (a *Manager) waitForAddonToBeActive(ctx context.Context, addon *api.Addon,
 	return nil
 }
 
+func addonHealthIssues(health *ekstypes.AddonHealth) string {
+	if health == nil {
+		return ""
+	}
+	var issues []string
+	for _, issue := range health.Issues {
+		issues = append(issues, fmt.Sprintf("%s: %s", issue.Code, aws.ToString(issue.Message)))
+	}
+	return strings.Join(issues, "; ")
+}
+
 type versionNotFoundError struct {
 	addonName    string
 	addonVersion string
@@ -0,0 +1,108 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"github.com/xeipuuv/gojsonschema"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// ValidateConfigurationValues validates the configurationValues of addons against the configuration schema
+// published for the addon version that will be installed.
+func (a *Manager) ValidateConfigurationValues(ctx context.Context, addons []*api.Addon) error {
+	var errs []error
+	for _, addon := range addons {
+		if addon.ConfigurationValues == "" {
+			continue
+		}
+		if err := a.validateConfigurationValues(ctx, addon); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (a *Manager) validateConfigurationValues(ctx context.Context, addon *api.Addon) error {
+	addonVersion, err := a.resolveVersionForValidation(ctx, addon)
+	if err != nil {
+		return err
+	}
+	output, err := a.eksAPI.DescribeAddonConfiguration(ctx, &eks.DescribeAddonConfigurationInput{
+		AddonName:    aws.String(addon.Name),
+		AddonVersion: aws.String(addonVersion),
+	})
+	if err != nil {
+		return fmt.Errorf("describing configuration schema for addon %q version %s: %w", addon.Name, addonVersion, err)
+	}
+	if aws.ToString(output.ConfigurationSchema) == "" {
+		logger.Debug("no configuration schema found for addon %q version %s, skipping validation of configurationValues", addon.Name, addonVersion)
+		return nil
+	}
+
+	// configurationValues are accepted in JSON or YAML, and YAML is a superset of JSON
+	configurationValues, err := yaml.YAMLToJSON([]byte(addon.ConfigurationValues))
+	if err != nil {
+		return fmt.Errorf("invalid configuration for %q addon: configurationValues: %q is not valid, supported format(s) are: JSON and YAML", addon.Name, addon.ConfigurationValues)
+	}
+	result, err := gojsonschema.Validate(gojsonschema.NewStringLoader(*output.ConfigurationSchema), gojsonschema.NewBytesLoader(configurationValues))
+	if err != nil {
+		return fmt.Errorf("validating configurationValues of addon %q against the configuration schema for version %s: %w", addon.Name, addonVersion, err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	var fieldErrors []string
+	for _, resultErr := range result.Errors() {
+		fieldErrors = append(fieldErrors, fmt.Sprintf("%s: %s", configurationValuesFieldPath(resultErr.Field()), resultErr.Description()))
+	}
+	return fmt.Errorf("invalid configuration for %q addon version %s: %s", addon.Name, addonVersion, strings.Join(fieldErrors, "; "))
+}
+
+// resolveVersionForValidation returns the addon version that will be installed. When no version is set,
+// the installed version is preserved on update, and the default version is installed on create.
+func (a *Manager) resolveVersionForValidation(ctx context.Context, addon *api.Addon) (string, error) {
+	if addon.Version == "" {
+		output, err := a.eksAPI.DescribeAddon(ctx, &eks.DescribeAddonInput{
+			ClusterName: aws.String(a.clusterConfig.Metadata.Name),
+			AddonName:   aws.String(addon.Name),
+		})
+		var notFoundErr *ekstypes.ResourceNotFoundException
+		if err == nil {
+			return aws.ToString(output.Addon.AddonVersion), nil
+		} else if !errors.As(err, &notFoundErr) {
+			return "", fmt.Errorf("failed to describe addon %q: %w", addon.Name, err)
+		}
+	}
+	addonVersion, _, err := a.getLatestMatchingVersion(ctx, addon)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch version of addon %q: %w", addon.Name, err)
+	}
+	return addonVersion, nil
+}
+
+// configurationValuesFieldPath converts a field reported by gojsonschema, e.g. "tolerations.0.key",
+// to a path relative to the addon, e.g. "configurationValues.tolerations[0].key".
+func configurationValuesFieldPath(field string) string {
+	path := "configurationValues"
+	if field == gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+		return path
+	}
+	for _, segment := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(segment); err == nil {
+			path += "[" + segment + "]"
+		} else {
+			path += "." + segment
+		}
+	}
+	return path
+}
@@ -0,0 +1,180 @@
+package addon_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+const coreDNSConfigurationSchema = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"replicaCount": {"type": "integer"},
+		"tolerations": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"properties": {
+					"key": {"type": "string"},
+					"effect": {"type": "string", "enum": ["NoSchedule", "NoExecute"]}
+				}
+			}
+		}
+	}
+}`
+
+var _ = Describe("ValidateConfigurationValues", func() {
+	var (
+		manager                         *addon.Manager
+		mockProvider                    *mockprovider.MockProvider
+		describeAddonConfigurationInput *awseks.DescribeAddonConfigurationInput
+	)
+
+	BeforeEach(func() {
+		var err error
+		mockProvider = mockprovider.NewMockProvider()
+		manager, err = addon.New(&api.ClusterConfig{Metadata: &api.ClusterMeta{
+			Version: "1.30",
+			Name:    "my-cluster",
+		}}, mockProvider.EKS(), nil, false, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		mockProvider.MockEKS().On("DescribeAddonVersions", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonVersionsOutput{
+			Addons: []ekstypes.AddonInfo{
+				{
+					AddonName: aws.String("coredns"),
+					AddonVersions: []ekstypes.AddonVersionInfo{
+						{
+							AddonVersion: aws.String("v1.11.1-eksbuild.9"),
+						},
+						{
+							AddonVersion: aws.String("v1.11.1-eksbuild.8"),
+							Compatibilities: []ekstypes.Compatibility{
+								{
+									DefaultVersion: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		}, nil)
+	})
+
+	mockConfigurationSchema := func() {
+		mockProvider.MockEKS().On("DescribeAddonConfiguration", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			describeAddonConfigurationInput = args[1].(*awseks.DescribeAddonConfigurationInput)
+		}).Return(&awseks.DescribeAddonConfigurationOutput{
+			AddonName:           aws.String("coredns"),
+			ConfigurationSchema: aws.String(coreDNSConfigurationSchema),
+		}, nil)
+	}
+
+	mockAddonNotInstalled := func() {
+		mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.Anything).Return(nil, &ekstypes.ResourceNotFoundException{
+			Message: aws.String("not found"),
+		})
+	}
+
+	It("accepts valid JSON and YAML configuration values", func() {
+		mockConfigurationSchema()
+		err := manager.ValidateConfigurationValues(context.Background(), []*api.Addon{
+			{
+				Name:                "coredns",
+				Version:             "latest",
+				ConfigurationValues: `{"replicaCount": 3}`,
+			},
+			{
+				Name:                "coredns",
+				Version:             "latest",
+				ConfigurationValues: "tolerations:\n- key: node-role\n  effect: NoSchedule\n",
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*describeAddonConfigurationInput.AddonVersion).To(Equal("v1.11.1-eksbuild.9"))
+	})
+
+	It("reports schema violations as field paths", func() {
+		mockConfigurationSchema()
+		err := manager.ValidateConfigurationValues(context.Background(), []*api.Addon{
+			{
+				Name:                "coredns",
+				Version:             "latest",
+				ConfigurationValues: "replicaCount: three\ntolerations:\n- key: node-role\n  effect: Sometimes\nreplicas: 2\n",
+			},
+		})
+		Expect(err).To(MatchError(ContainSubstring(`invalid configuration for "coredns" addon version v1.11.1-eksbuild.9: `)))
+		Expect(err).To(MatchError(ContainSubstring("configurationValues: Additional property replicas is not allowed")))
+		Expect(err).To(MatchError(ContainSubstring("configurationValues.replicaCount: Invalid type. Expected: integer, given: string")))
+		Expect(err).To(MatchError(ContainSubstring("configurationValues.tolerations[0].effect: tolerations.0.effect must be one of the following")))
+	})
+
+	It("validates against the default version when the addon is not installed and no version is set", func() {
+		mockConfigurationSchema()
+		mockAddonNotInstalled()
+
+		err := manager.ValidateConfigurationValues(context.Background(), []*api.Addon{
+			{
+				Name:                "coredns",
+				ConfigurationValues: `{"replicaCount": 2}`,
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*describeAddonConfigurationInput.AddonVersion).To(Equal("v1.11.1-eksbuild.8"))
+	})
+
+	It("validates against the installed version when the addon is installed and no version is set", func() {
+		mockConfigurationSchema()
+		mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonOutput{
+			Addon: &ekstypes.Addon{
+				AddonName:    aws.String("coredns"),
+				AddonVersion: aws.String("v1.11.1-eksbuild.4"),
+			},
+		}, nil)
+
+		err := manager.ValidateConfigurationValues(context.Background(), []*api.Addon{
+			{
+				Name:                "coredns",
+				ConfigurationValues: `{"replicaCount": 2}`,
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*describeAddonConfigurationInput.AddonVersion).To(Equal("v1.11.1-eksbuild.4"))
+	})
+
+	It("skips addons without configuration values", func() {
+		err := manager.ValidateConfigurationValues(context.Background(), []*api.Addon{
+			{
+				Name: "coredns",
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mockProvider.MockEKS().AssertNotCalled(GinkgoT(), "DescribeAddonConfiguration", mock.Anything, mock.Anything)
+	})
+
+	It("returns an error when the configuration schema cannot be described", func() {
+		mockProvider.MockEKS().On("DescribeAddonConfiguration", mock.Anything, mock.Anything).Return(nil, errors.New("throttled"))
+
+		err := manager.ValidateConfigurationValues(context.Background(), []*api.Addon{
+			{
+				Name:                "coredns",
+				Version:             "v1.11.1-eksbuild.9",
+				ConfigurationValues: `{"replicaCount": 2}`,
+			},
+		})
+		Expect(err).To(MatchError(ContainSubstring(`describing configuration schema for addon "coredns" version v1.11.1-eksbuild.9: throttled`)))
+	})
+})
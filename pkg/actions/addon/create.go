@@ -20,6 +20,7 @@ import (
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/vpc"
 )
 
 var (
@@ -101,6 +102,9 @@ func (a *Manager) Create(ctx context.Context, addon *api.Addon, iamRoleCreator I
 	if err != nil {
 		return err
 	}
+	if err := a.setCustomNetworkingConfig(addon); err != nil {
+		return err
+	}
 	var configurationValues *string
 	if addon.ConfigurationValues != "" {
 		configurationValues = &addon.ConfigurationValues
@@ -496,3 +500,23 @@ func makeIPv6VPCCNIPolicyDocument(partition string) map[string]interface{} {
 		},
 	}
 }
+
+// setCustomNetworkingConfig enables custom networking in the configuration values of the vpc-cni addon
+// when vpc.podSubnets is set.
+func (a *Manager) setCustomNetworkingConfig(addon *api.Addon) error {
+	configurationValues, err := DesiredConfigurationValues(a.clusterConfig, addon)
+	if err != nil {
+		return err
+	}
+	addon.ConfigurationValues = configurationValues
+	return nil
+}
+
+// DesiredConfigurationValues returns the configuration values that eksctl sends to EKS for addon, i.e. those in
+// the config file with custom networking enabled for the vpc-cni addon when vpc.podSubnets is set.
+func DesiredConfigurationValues(clusterConfig *api.ClusterConfig, addon *api.Addon) (string, error) {
+	if addon.CanonicalName() != api.VPCCNIAddon || clusterConfig.VPC == nil || clusterConfig.VPC.PodSubnets == nil {
+		return addon.ConfigurationValues, nil
+	}
+	return vpc.WithCustomNetworkingEnv(addon.ConfigurationValues)
+}
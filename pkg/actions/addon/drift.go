@@ -0,0 +1,217 @@
+package addon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// FieldDrift is a field of an addon whose live value differs from the value in the config file.
+type FieldDrift struct {
+	Field   string `json:"field"`
+	Live    string `json:"live,omitempty"`
+	Desired string `json:"desired,omitempty"`
+}
+
+// Drift is the drift report of an addon in the config file.
+type Drift struct {
+	Name      string       `json:"name"`
+	Installed bool         `json:"installed"`
+	Fields    []FieldDrift `json:"fields,omitempty"`
+}
+
+// HasDrifted reports whether the live addon differs from the config file.
+func (d Drift) HasDrifted() bool {
+	return !d.Installed || len(d.Fields) > 0
+}
+
+// CheckDrift compares each addon with the live addon on the cluster.
+func (a *Manager) CheckDrift(ctx context.Context, addons []*api.Addon) ([]Drift, error) {
+	var drifts []Drift
+	for _, addon := range addons {
+		summary, err := a.Get(ctx, &api.Addon{Name: addon.Name})
+		if err != nil {
+			var notFoundErr *ekstypes.ResourceNotFoundException
+			if errors.As(err, &notFoundErr) {
+				drifts = append(drifts, Drift{Name: addon.Name})
+				continue
+			}
+			return nil, err
+		}
+		fields := DiffSummary(a.clusterConfig, addon, summary)
+		if addon.ResolveConflicts != "" {
+			liveResolveConflicts, err := a.lastResolveConflicts(ctx, addon.Name)
+			if err != nil {
+				return nil, err
+			}
+			if liveResolveConflicts != "" && !strings.EqualFold(liveResolveConflicts, string(addon.ResolveConflicts)) {
+				fields = append(fields, FieldDrift{Field: "resolveConflicts", Live: liveResolveConflicts, Desired: string(addon.ResolveConflicts)})
+			}
+		}
+		drifts = append(drifts, Drift{
+			Name:      addon.Name,
+			Installed: true,
+			Fields:    fields,
+		})
+	}
+	return drifts, nil
+}
+
+// resolveConflictsLookback is the number of most recent updates of an addon searched for its resolve conflicts policy.
+const resolveConflictsLookback = 10
+
+// lastResolveConflicts returns the resolve conflicts policy of the most recent update of an addon, as EKS does not
+// return it when describing the addon. EKS lists the most recent updates first, so the search stops at the first update
+// that sets the policy, and only the last resolveConflictsLookback updates are searched. It returns an empty string if
+// none of them set the policy.
+func (a *Manager) lastResolveConflicts(ctx context.Context, addonName string) (string, error) {
+	output, err := a.eksAPI.ListUpdates(ctx, &eks.ListUpdatesInput{
+		Name:       aws.String(a.clusterConfig.Metadata.Name),
+		AddonName:  aws.String(addonName),
+		MaxResults: aws.Int32(resolveConflictsLookback),
+	})
+	if err != nil {
+		return "", fmt.Errorf("listing updates of addon %q: %w", addonName, err)
+	}
+	for _, updateID := range output.UpdateIds {
+		update, err := a.eksAPI.DescribeUpdate(ctx, &eks.DescribeUpdateInput{
+			Name:      aws.String(a.clusterConfig.Metadata.Name),
+			AddonName: aws.String(addonName),
+			UpdateId:  aws.String(updateID),
+		})
+		if err != nil {
+			return "", fmt.Errorf("describing update %q of addon %q: %w", updateID, addonName, err)
+		}
+		for _, param := range update.Update.Params {
+			if param.Type == ekstypes.UpdateParamTypeResolveConflicts {
+				return aws.ToString(param.Value), nil
+			}
+		}
+	}
+	logger.Debug("no recent update of addon %q sets resolveConflicts, skipping comparison of resolveConflicts", addonName)
+	return "", nil
+}
+
+// DiffSummary compares an addon in the config file with the live addon and returns the fields that differ.
+// The version, service account role ARN and pod identity associations are only compared when they are set in
+// the config file. Configuration values are compared as eksctl sends them to EKS, see DesiredConfigurationValues.
+func DiffSummary(clusterConfig *api.ClusterConfig, desired *api.Addon, live Summary) []FieldDrift {
+	var fields []FieldDrift
+	desiredConfigurationValues, err := DesiredConfigurationValues(clusterConfig, desired)
+	if err != nil {
+		// creating or updating the addon fails on the same error, so report the values as written
+		logger.Debug("comparing configurationValues of addon %q as written: %v", desired.Name, err)
+		desiredConfigurationValues = desired.ConfigurationValues
+	}
+	if desired.Version != "" && desired.Version != "latest" && !addonVersionMatches(desired.Version, live.Version) {
+		fields = append(fields, FieldDrift{Field: "version", Live: live.Version, Desired: desired.Version})
+	}
+	if desired.ServiceAccountRoleARN != "" && desired.ServiceAccountRoleARN != live.IAMRole {
+		fields = append(fields, FieldDrift{Field: "serviceAccountRoleARN", Live: live.IAMRole, Desired: desired.ServiceAccountRoleARN})
+	}
+	if !configurationValuesEqual(desiredConfigurationValues, live.ConfigurationValues) {
+		fields = append(fields, FieldDrift{Field: "configurationValues", Live: live.ConfigurationValues, Desired: desiredConfigurationValues})
+	}
+	if desired.PodIdentityAssociations != nil {
+		fields = append(fields, diffPodIdentityAssociations(*desired.PodIdentityAssociations, live.PodIdentityAssociations)...)
+	}
+	return fields
+}
+
+func diffPodIdentityAssociations(desired []api.PodIdentityAssociation, live []PodIdentityAssociationSummary) []FieldDrift {
+	var (
+		fields                []FieldDrift
+		livePIAs, desiredPIAs []string
+	)
+	liveRoleARNs := map[string]string{}
+	for _, p := range live {
+		name := fmt.Sprintf("%s/%s", p.Namespace, p.ServiceAccount)
+		livePIAs = append(livePIAs, name)
+		liveRoleARNs[name] = p.RoleARN
+	}
+	for _, p := range desired {
+		desiredPIAs = append(desiredPIAs, p.NameString())
+	}
+	sort.Strings(livePIAs)
+	sort.Strings(desiredPIAs)
+	if !slices.Equal(livePIAs, desiredPIAs) {
+		return append(fields, FieldDrift{
+			Field:   "podIdentityAssociations",
+			Live:    strings.Join(livePIAs, ","),
+			Desired: strings.Join(desiredPIAs, ","),
+		})
+	}
+	for _, p := range desired {
+		if p.RoleARN != "" && p.RoleARN != liveRoleARNs[p.NameString()] {
+			fields = append(fields, FieldDrift{
+				Field:   fmt.Sprintf("podIdentityAssociations[%s].roleARN", p.NameString()),
+				Live:    liveRoleARNs[p.NameString()],
+				Desired: p.RoleARN,
+			})
+		}
+	}
+	return fields
+}
+
+// addonVersionMatches compares addon versions, allowing the desired version to omit the eksbuild suffix
+// and the leading "v".
+func addonVersionMatches(desired, current string) bool {
+	normalize := func(v string) string {
+		return strings.TrimPrefix(strings.ToLower(v), "v")
+	}
+	desired, current = normalize(desired), normalize(current)
+	if desired == current {
+		return true
+	}
+	if strings.Contains(desired, "-eksbuild") {
+		return false
+	}
+	return strings.HasPrefix(current, desired+"-")
+}
+
+// configurationValuesEqual compares two JSON or YAML documents semantically.
+func configurationValuesEqual(desired, current string) bool {
+	if strings.TrimSpace(desired) == strings.TrimSpace(current) {
+		return true
+	}
+	desiredValue, err := parseConfigurationValues(desired)
+	if err != nil {
+		return false
+	}
+	currentValue, err := parseConfigurationValues(current)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(desiredValue, currentValue)
+}
+
+func parseConfigurationValues(values string) (interface{}, error) {
+	var parsed interface{}
+	if strings.TrimSpace(values) == "" {
+		return map[string]interface{}{}, nil
+	}
+	asJSON, err := yaml.YAMLToJSON([]byte(values))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(asJSON, &parsed); err != nil {
+		return nil, err
+	}
+	if parsed == nil {
+		return map[string]interface{}{}, nil
+	}
+	return parsed, nil
+}
@@ -0,0 +1,205 @@
+package addon_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("CheckDrift", func() {
+	var (
+		manager      *addon.Manager
+		mockProvider *mockprovider.MockProvider
+	)
+
+	BeforeEach(func() {
+		var err error
+		mockProvider = mockprovider.NewMockProvider()
+		manager, err = addon.New(&api.ClusterConfig{Metadata: &api.ClusterMeta{
+			Version: "1.30",
+			Name:    "my-cluster",
+		}}, mockProvider.EKS(), nil, false, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		mockProvider.MockEKS().On("DescribeAddonVersions", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonVersionsOutput{}, nil)
+		mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.Anything).Return(func(_ context.Context, input *awseks.DescribeAddonInput, _ ...func(*awseks.Options)) (*awseks.DescribeAddonOutput, error) {
+			switch *input.AddonName {
+			case "coredns":
+				return &awseks.DescribeAddonOutput{
+					Addon: &ekstypes.Addon{
+						AddonName:           aws.String("coredns"),
+						AddonVersion:        aws.String("v1.11.1-eksbuild.9"),
+						ConfigurationValues: aws.String(`{"replicaCount":3,"tolerations":[{"key":"a"}]}`),
+						Status:              ekstypes.AddonStatusActive,
+					},
+				}, nil
+			case "vpc-cni":
+				return &awseks.DescribeAddonOutput{
+					Addon: &ekstypes.Addon{
+						AddonName:               aws.String("vpc-cni"),
+						AddonVersion:            aws.String("v1.18.0-eksbuild.1"),
+						ServiceAccountRoleArn:   aws.String("arn:aws:iam::111122223333:role/live"),
+						PodIdentityAssociations: []string{"arn:aws:eks:us-west-2:111122223333:podidentityassociation/my-cluster/a-1"},
+						Status:                  ekstypes.AddonStatusActive,
+					},
+				}, nil
+			default:
+				return nil, &ekstypes.ResourceNotFoundException{Message: aws.String("not found")}
+			}
+		})
+		mockProvider.MockEKS().On("DescribePodIdentityAssociation", mock.Anything, mock.Anything).Return(&awseks.DescribePodIdentityAssociationOutput{
+			Association: &ekstypes.PodIdentityAssociation{
+				AssociationId:  aws.String("a-1"),
+				Namespace:      aws.String("kube-system"),
+				ServiceAccount: aws.String("aws-node"),
+				RoleArn:        aws.String("arn:aws:iam::111122223333:role/live-pia"),
+			},
+		}, nil)
+	})
+
+	It("reports addons that are in sync", func() {
+		drifts, err := manager.CheckDrift(context.Background(), []*api.Addon{
+			{
+				Name:                "coredns",
+				Version:             "1.11.1",
+				ConfigurationValues: "tolerations:\n- key: a\nreplicaCount: 3\n",
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drifts).To(Equal([]addon.Drift{{Name: "coredns", Installed: true}}))
+		Expect(drifts[0].HasDrifted()).To(BeFalse())
+	})
+
+	It("reports drifted fields and addons that are not installed", func() {
+		drifts, err := manager.CheckDrift(context.Background(), []*api.Addon{
+			{
+				Name:                "coredns",
+				Version:             "v1.11.3-eksbuild.1",
+				ConfigurationValues: `{"replicaCount": 2}`,
+			},
+			{
+				Name:                  "vpc-cni",
+				ServiceAccountRoleARN: "arn:aws:iam::111122223333:role/desired",
+				PodIdentityAssociations: &[]api.PodIdentityAssociation{
+					{
+						Namespace:          "kube-system",
+						ServiceAccountName: "aws-node",
+						RoleARN:            "arn:aws:iam::111122223333:role/desired-pia",
+					},
+				},
+			},
+			{
+				Name: "aws-ebs-csi-driver",
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drifts).To(Equal([]addon.Drift{
+			{
+				Name:      "coredns",
+				Installed: true,
+				Fields: []addon.FieldDrift{
+					{Field: "version", Live: "v1.11.1-eksbuild.9", Desired: "v1.11.3-eksbuild.1"},
+					{Field: "configurationValues", Live: `{"replicaCount":3,"tolerations":[{"key":"a"}]}`, Desired: `{"replicaCount": 2}`},
+				},
+			},
+			{
+				Name:      "vpc-cni",
+				Installed: true,
+				Fields: []addon.FieldDrift{
+					{Field: "serviceAccountRoleARN", Live: "arn:aws:iam::111122223333:role/live", Desired: "arn:aws:iam::111122223333:role/desired"},
+					{Field: "podIdentityAssociations[kube-system/aws-node].roleARN", Live: "arn:aws:iam::111122223333:role/live-pia", Desired: "arn:aws:iam::111122223333:role/desired-pia"},
+				},
+			},
+			{
+				Name: "aws-ebs-csi-driver",
+			},
+		}))
+		Expect(drifts[2].HasDrifted()).To(BeTrue())
+	})
+
+	It("compares resolveConflicts with the most recent addon update that sets it", func() {
+		updates := map[string]*ekstypes.Update{
+			"newest": {
+				Params: []ekstypes.UpdateParam{{Type: ekstypes.UpdateParamTypeConfigurationValues, Value: aws.String(`{"replicaCount":3}`)}},
+			},
+			"new": {
+				Params: []ekstypes.UpdateParam{
+					{Type: ekstypes.UpdateParamTypeAddonVersion, Value: aws.String("v1.11.1-eksbuild.9")},
+					{Type: ekstypes.UpdateParamTypeResolveConflicts, Value: aws.String("OVERWRITE")},
+				},
+			},
+			"old": {
+				Params: []ekstypes.UpdateParam{{Type: ekstypes.UpdateParamTypeResolveConflicts, Value: aws.String("PRESERVE")}},
+			},
+		}
+		mockProvider.MockEKS().On("ListUpdates", mock.Anything, &awseks.ListUpdatesInput{
+			Name:       aws.String("my-cluster"),
+			AddonName:  aws.String("coredns"),
+			MaxResults: aws.Int32(10),
+		}).Return(&awseks.ListUpdatesOutput{
+			UpdateIds: []string{"newest", "new", "old"},
+		}, nil)
+		mockProvider.MockEKS().On("DescribeUpdate", mock.Anything, mock.Anything).Return(func(_ context.Context, input *awseks.DescribeUpdateInput, _ ...func(*awseks.Options)) (*awseks.DescribeUpdateOutput, error) {
+			return &awseks.DescribeUpdateOutput{Update: updates[*input.UpdateId]}, nil
+		})
+
+		drifts, err := manager.CheckDrift(context.Background(), []*api.Addon{
+			{
+				Name:                "coredns",
+				ConfigurationValues: `{"replicaCount":3,"tolerations":[{"key":"a"}]}`,
+				ResolveConflicts:    ekstypes.ResolveConflictsPreserve,
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drifts).To(Equal([]addon.Drift{
+			{
+				Name:      "coredns",
+				Installed: true,
+				Fields: []addon.FieldDrift{
+					{Field: "resolveConflicts", Live: "OVERWRITE", Desired: "PRESERVE"},
+				},
+			},
+		}))
+		mockProvider.MockEKS().AssertNumberOfCalls(GinkgoT(), "DescribeUpdate", 2)
+	})
+
+	It("compares vpc-cni configurationValues with custom networking enabled when vpc.podSubnets is set", func() {
+		mockProvider = mockprovider.NewMockProvider()
+		clusterConfig := api.NewClusterConfig()
+		clusterConfig.Metadata.Name = "my-cluster"
+		clusterConfig.Metadata.Version = "1.30"
+		clusterConfig.VPC.PodSubnets = &api.PodSubnets{}
+		var err error
+		manager, err = addon.New(clusterConfig, mockProvider.EKS(), nil, false, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		mockProvider.MockEKS().On("DescribeAddonVersions", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonVersionsOutput{}, nil)
+		mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonOutput{
+			Addon: &ekstypes.Addon{
+				AddonName:           aws.String("vpc-cni"),
+				AddonVersion:        aws.String("v1.18.0-eksbuild.1"),
+				ConfigurationValues: aws.String(`{"env":{"AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG":"true","ENABLE_PREFIX_DELEGATION":"true","ENI_CONFIG_LABEL_DEF":"topology.kubernetes.io/zone"}}`),
+				Status:              ekstypes.AddonStatusActive,
+			},
+		}, nil)
+
+		drifts, err := manager.CheckDrift(context.Background(), []*api.Addon{
+			{
+				Name:                "vpc-cni",
+				ConfigurationValues: `{"env":{"ENABLE_PREFIX_DELEGATION":"true"}}`,
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drifts).To(Equal([]addon.Drift{{Name: "vpc-cni", Installed: true}}))
+	})
+})
@@ -46,7 +46,7 @@ func (a *Manager) Get(ctx context.Context, addon *api.Addon) (Summary, error) {
 	})
 
 	if err != nil {
-		return Summary{}, fmt.Errorf("failed to get addon %q: %v", addon.Name, err)
+		return Summary{}, fmt.Errorf("failed to get addon %q: %w", addon.Name, err)
 	}
 
 	var issues []Issue
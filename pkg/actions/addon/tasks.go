@@ -88,6 +88,7 @@ func CreateAddonTasks(ctx context.Context, cfg *api.ClusterConfig, clusterProvid
 	if vpcCNIAddon != nil && api.IsEnabled(cfg.IAM.WithOIDC) {
 		updateVPCCNI = &tasks.GenericTask{
 			Description: "update VPC CNI to use IRSA if required",
+			Resource:    tasks.ResourceInfo{Kind: tasks.KindAddon},
 			Doer: func() error {
 				addonManager, err := createAddonManager(ctx, clusterProvider, cfg)
 				if err != nil {
@@ -119,6 +120,10 @@ type createAddonTask struct {
 
 func (t *createAddonTask) Describe() string { return t.info }
 
+func (t *createAddonTask) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: tasks.KindAddon}
+}
+
 func (t *createAddonTask) Do(errorCh chan error) error {
 	addonManager, err := createAddonManager(t.ctx, t.clusterProvider, t.cfg)
 	if err != nil {
@@ -206,6 +211,10 @@ type deleteAddonIAMTask struct {
 
 func (t *deleteAddonIAMTask) Describe() string { return t.info }
 
+func (t *deleteAddonIAMTask) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: tasks.KindAddon, StackName: *t.stack.StackName}
+}
+
 func (t *deleteAddonIAMTask) Do(errorCh chan error) error {
 	errMsg := fmt.Sprintf("deleting addon IAM %q", *t.stack.StackName)
 	if t.wait {
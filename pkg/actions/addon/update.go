@@ -28,6 +28,9 @@ type PodIdentityIAMUpdater interface {
 func (a *Manager) Update(ctx context.Context, addon *api.Addon, podIdentityIAMUpdater PodIdentityIAMUpdater, waitTimeout time.Duration) error {
 	logger.Debug("addon: %v", addon)
 
+	if err := a.setCustomNetworkingConfig(addon); err != nil {
+		return err
+	}
 	var configurationValues *string
 	if addon.ConfigurationValues != "" {
 		configurationValues = &addon.ConfigurationValues
@@ -0,0 +1,178 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/hashicorp/go-version"
+	"github.com/kris-nova/logger"
+)
+
+// VersionCompatibility holds the latest and default versions of an addon for a Kubernetes version.
+type VersionCompatibility struct {
+	KubernetesVersion string
+	LatestVersion     string
+	DefaultVersion    string
+}
+
+// UpgradePlan describes how an installed addon is affected by a control plane upgrade.
+type UpgradePlan struct {
+	Name           string
+	CurrentVersion string
+	Current        VersionCompatibility
+	Target         VersionCompatibility
+	// MajorVersionChange is true when the latest version for the target Kubernetes version has a different major
+	// version than the installed version
+	MajorVersionChange bool
+	// BlocksUpgrade is true when the installed version is not compatible with the target Kubernetes version
+	BlocksUpgrade bool
+	Reason        string
+}
+
+// PlanUpgrade returns an UpgradePlan for every addon installed on the cluster, for an upgrade of the control plane
+// from the Kubernetes version in the cluster config to targetVersion.
+func (a *Manager) PlanUpgrade(ctx context.Context, targetVersion string) ([]UpgradePlan, error) {
+	currentKubernetesVersion := a.clusterConfig.Metadata.Version
+	logger.Info("planning addon upgrades for Kubernetes %s to %s", currentKubernetesVersion, targetVersion)
+	output, err := a.eksAPI.ListAddons(ctx, &eks.ListAddonsInput{
+		ClusterName: &a.clusterConfig.Metadata.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list addons: %v", err)
+	}
+
+	var plans []UpgradePlan
+	for _, addonName := range output.Addons {
+		addonOutput, err := a.eksAPI.DescribeAddon(ctx, &eks.DescribeAddonInput{
+			ClusterName: &a.clusterConfig.Metadata.Name,
+			AddonName:   aws.String(addonName),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get addon %q: %v", addonName, err)
+		}
+		currentVersions, err := a.compatibleVersions(ctx, addonName, currentKubernetesVersion)
+		if err != nil {
+			return nil, err
+		}
+		targetVersions, err := a.compatibleVersions(ctx, addonName, targetVersion)
+		if err != nil {
+			return nil, err
+		}
+		plan, err := a.planAddonUpgrade(addonName, aws.ToString(addonOutput.Addon.AddonVersion), currentVersions, targetVersions)
+		if err != nil {
+			return nil, err
+		}
+		plan.Current.KubernetesVersion = currentKubernetesVersion
+		plan.Target.KubernetesVersion = targetVersion
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+func (a *Manager) planAddonUpgrade(addonName, addonVersion string, currentVersions, targetVersions []ekstypes.AddonVersionInfo) (UpgradePlan, error) {
+	plan := UpgradePlan{
+		Name:           addonName,
+		CurrentVersion: addonVersion,
+	}
+	var err error
+	if plan.Current, err = a.versionCompatibility(currentVersions); err != nil {
+		return UpgradePlan{}, err
+	}
+	if plan.Target, err = a.versionCompatibility(targetVersions); err != nil {
+		return UpgradePlan{}, err
+	}
+
+	if plan.Target.LatestVersion == "" {
+		plan.BlocksUpgrade = true
+		plan.Reason = "no versions are available for the target Kubernetes version"
+		return plan, nil
+	}
+
+	if installed, err := a.parseVersion(addonVersion); err != nil {
+		logger.Debug("could not parse version %q, skipping major version comparison: %v", addonVersion, err)
+	} else if latest, err := a.parseVersion(plan.Target.LatestVersion); err == nil {
+		plan.MajorVersionChange = installed.Segments()[0] != latest.Segments()[0]
+	}
+
+	if containsVersion(targetVersions, addonVersion) {
+		return plan, nil
+	}
+	plan.BlocksUpgrade = true
+	var common []ekstypes.AddonVersionInfo
+	for _, v := range currentVersions {
+		if containsVersion(targetVersions, aws.ToString(v.AddonVersion)) {
+			common = append(common, v)
+		}
+	}
+	compatibleWithBoth, err := a.latestVersion(common)
+	if err != nil {
+		return UpgradePlan{}, err
+	}
+	if compatibleWithBoth != "" {
+		plan.Reason = fmt.Sprintf("update to %s before upgrading the control plane", compatibleWithBoth)
+	} else {
+		plan.Reason = fmt.Sprintf("no version is compatible with both Kubernetes versions; update to %s right after upgrading the control plane", plan.Target.LatestVersion)
+	}
+	return plan, nil
+}
+
+// compatibleVersions returns the versions of an addon that are compatible with kubernetesVersion.
+func (a *Manager) compatibleVersions(ctx context.Context, addonName, kubernetesVersion string) ([]ekstypes.AddonVersionInfo, error) {
+	output, err := a.eksAPI.DescribeAddonVersions(ctx, &eks.DescribeAddonVersionsInput{
+		AddonName:         aws.String(addonName),
+		KubernetesVersion: aws.String(kubernetesVersion),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe versions of addon %q for Kubernetes %s: %v", addonName, kubernetesVersion, err)
+	}
+	if len(output.Addons) == 0 {
+		return nil, nil
+	}
+	return output.Addons[0].AddonVersions, nil
+}
+
+func (a *Manager) versionCompatibility(versions []ekstypes.AddonVersionInfo) (VersionCompatibility, error) {
+	latest, err := a.latestVersion(versions)
+	if err != nil {
+		return VersionCompatibility{}, err
+	}
+	compatibility := VersionCompatibility{LatestVersion: latest}
+	for _, v := range versions {
+		if len(v.Compatibilities) > 0 && v.Compatibilities[0].DefaultVersion {
+			compatibility.DefaultVersion = aws.ToString(v.AddonVersion)
+			break
+		}
+	}
+	return compatibility, nil
+}
+
+func (a *Manager) latestVersion(versions []ekstypes.AddonVersionInfo) (string, error) {
+	var parsed []*version.Version
+	for _, v := range versions {
+		p, err := a.parseVersion(aws.ToString(v.AddonVersion))
+		if err != nil {
+			return "", err
+		}
+		parsed = append(parsed, p)
+	}
+	if len(parsed) == 0 {
+		return "", nil
+	}
+	sort.SliceStable(parsed, func(i, j int) bool {
+		return parsed[j].LessThan(parsed[i])
+	})
+	return parsed[0].Original(), nil
+}
+
+func containsVersion(versions []ekstypes.AddonVersionInfo, addonVersion string) bool {
+	for _, v := range versions {
+		if aws.ToString(v.AddonVersion) == addonVersion {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package addon_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("PlanUpgrade", func() {
+	type addonVersions struct {
+		installed string
+		// versions maps a Kubernetes version to the compatible addon versions, the first one being the default
+		versions map[string][]string
+	}
+
+	var (
+		manager      *addon.Manager
+		mockProvider *mockprovider.MockProvider
+	)
+
+	mockAddons := func(addons map[string]addonVersions) {
+		var addonNames []string
+		for name := range addons {
+			addonNames = append(addonNames, name)
+		}
+		mockProvider.MockEKS().On("ListAddons", mock.Anything, mock.Anything).Return(&awseks.ListAddonsOutput{
+			Addons: addonNames,
+		}, nil)
+		mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.Anything).Return(func(_ context.Context, input *awseks.DescribeAddonInput, _ ...func(*awseks.Options)) (*awseks.DescribeAddonOutput, error) {
+			return &awseks.DescribeAddonOutput{
+				Addon: &ekstypes.Addon{
+					AddonName:    input.AddonName,
+					AddonVersion: aws.String(addons[*input.AddonName].installed),
+				},
+			}, nil
+		})
+		mockProvider.MockEKS().On("DescribeAddonVersions", mock.Anything, mock.Anything).Return(func(_ context.Context, input *awseks.DescribeAddonVersionsInput, _ ...func(*awseks.Options)) (*awseks.DescribeAddonVersionsOutput, error) {
+			versions := addons[*input.AddonName].versions[*input.KubernetesVersion]
+			if len(versions) == 0 {
+				return &awseks.DescribeAddonVersionsOutput{}, nil
+			}
+			var versionInfos []ekstypes.AddonVersionInfo
+			for i, v := range versions {
+				versionInfos = append(versionInfos, ekstypes.AddonVersionInfo{
+					AddonVersion: aws.String(v),
+					Compatibilities: []ekstypes.Compatibility{
+						{
+							ClusterVersion: input.KubernetesVersion,
+							DefaultVersion: i == 0,
+						},
+					},
+				})
+			}
+			return &awseks.DescribeAddonVersionsOutput{
+				Addons: []ekstypes.AddonInfo{
+					{
+						AddonName:     input.AddonName,
+						AddonVersions: versionInfos,
+					},
+				},
+			}, nil
+		})
+	}
+
+	BeforeEach(func() {
+		var err error
+		mockProvider = mockprovider.NewMockProvider()
+		manager, err = addon.New(&api.ClusterConfig{Metadata: &api.ClusterMeta{
+			Version: "1.29",
+			Name:    "my-cluster",
+		}}, mockProvider.EKS(), nil, false, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the versions compatible with the current and target Kubernetes versions", func() {
+		mockAddons(map[string]addonVersions{
+			"vpc-cni": {
+				installed: "v1.16.0-eksbuild.1",
+				versions: map[string][]string{
+					"1.29": {"v1.16.0-eksbuild.1", "v1.17.1-eksbuild.1", "v1.15.0-eksbuild.2"},
+					"1.30": {"v1.17.1-eksbuild.1", "v1.16.0-eksbuild.1", "v1.18.0-eksbuild.1"},
+				},
+			},
+		})
+
+		plans, err := manager.PlanUpgrade(context.Background(), "1.30")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plans).To(Equal([]addon.UpgradePlan{
+			{
+				Name:           "vpc-cni",
+				CurrentVersion: "v1.16.0-eksbuild.1",
+				Current: addon.VersionCompatibility{
+					KubernetesVersion: "1.29",
+					LatestVersion:     "v1.17.1-eksbuild.1",
+					DefaultVersion:    "v1.16.0-eksbuild.1",
+				},
+				Target: addon.VersionCompatibility{
+					KubernetesVersion: "1.30",
+					LatestVersion:     "v1.18.0-eksbuild.1",
+					DefaultVersion:    "v1.17.1-eksbuild.1",
+				},
+			},
+		}))
+	})
+
+	It("flags major version changes", func() {
+		mockAddons(map[string]addonVersions{
+			"aws-ebs-csi-driver": {
+				installed: "v1.30.0-eksbuild.1",
+				versions: map[string][]string{
+					"1.29": {"v1.30.0-eksbuild.1"},
+					"1.30": {"v1.30.0-eksbuild.1", "v2.0.0-eksbuild.1"},
+				},
+			},
+		})
+
+		plans, err := manager.PlanUpgrade(context.Background(), "1.30")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plans).To(HaveLen(1))
+		Expect(plans[0].MajorVersionChange).To(BeTrue())
+		Expect(plans[0].BlocksUpgrade).To(BeFalse())
+	})
+
+	It("suggests a version compatible with both Kubernetes versions when the installed version blocks the upgrade", func() {
+		mockAddons(map[string]addonVersions{
+			"kube-proxy": {
+				installed: "v1.29.0-eksbuild.1",
+				versions: map[string][]string{
+					"1.29": {"v1.29.0-eksbuild.1", "v1.29.3-eksbuild.2", "v1.29.1-eksbuild.1"},
+					"1.30": {"v1.30.0-eksbuild.3", "v1.29.1-eksbuild.1", "v1.29.3-eksbuild.2"},
+				},
+			},
+		})
+
+		plans, err := manager.PlanUpgrade(context.Background(), "1.30")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plans).To(HaveLen(1))
+		Expect(plans[0].BlocksUpgrade).To(BeTrue())
+		Expect(plans[0].Reason).To(Equal("update to v1.29.3-eksbuild.2 before upgrading the control plane"))
+	})
+
+	It("suggests updating after the control plane upgrade when no version is compatible with both Kubernetes versions", func() {
+		mockAddons(map[string]addonVersions{
+			"coredns": {
+				installed: "v1.10.1-eksbuild.1",
+				versions: map[string][]string{
+					"1.29": {"v1.10.1-eksbuild.1"},
+					"1.30": {"v1.11.1-eksbuild.4"},
+				},
+			},
+		})
+
+		plans, err := manager.PlanUpgrade(context.Background(), "1.30")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plans).To(HaveLen(1))
+		Expect(plans[0].BlocksUpgrade).To(BeTrue())
+		Expect(plans[0].Reason).To(Equal("no version is compatible with both Kubernetes versions; update to v1.11.1-eksbuild.4 right after upgrading the control plane"))
+	})
+
+	It("blocks the upgrade when the addon has no versions for the target Kubernetes version", func() {
+		mockAddons(map[string]addonVersions{
+			"my-addon": {
+				installed: "v1.0.0",
+				versions: map[string][]string{
+					"1.29": {"v1.0.0"},
+				},
+			},
+		})
+
+		plans, err := manager.PlanUpgrade(context.Background(), "1.30")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plans).To(HaveLen(1))
+		Expect(plans[0].BlocksUpgrade).To(BeTrue())
+		Expect(plans[0].Target.LatestVersion).To(BeEmpty())
+		Expect(plans[0].Reason).To(Equal("no versions are available for the target Kubernetes version"))
+	})
+
+	It("returns an error when listing addons fails", func() {
+		mockProvider.MockEKS().On("ListAddons", mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+
+		_, err := manager.PlanUpgrade(context.Background(), "1.30")
+		Expect(err).To(MatchError(ContainSubstring("failed to list addons: access denied")))
+	})
+})
@@ -0,0 +1,404 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+// Executor carries out the individual changes of a plan.
+//
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate -o fakes/fake_executor.go . Executor
+type Executor interface {
+	UpdateLogging(ctx context.Context) error
+	UpdateEndpointAccess(ctx context.Context) error
+	UpdateZonalShiftConfig(ctx context.Context) error
+
+	CreateNodeGroups(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup) error
+	ScaleNodeGroup(ctx context.Context, ng *api.NodeGroupBase) error
+	DeleteNodeGroups(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup) error
+
+	CreateAddon(ctx context.Context, addon *api.Addon) error
+	UpdateAddon(ctx context.Context, addon *api.Addon) error
+	DeleteAddon(ctx context.Context, addon *api.Addon) error
+
+	CreateAccessEntries(ctx context.Context, accessEntries []api.AccessEntry) error
+	DeleteAccessEntries(ctx context.Context, accessEntries []api.AccessEntry) error
+
+	CreatePodIdentityAssociations(ctx context.Context, podIdentityAssociations []api.PodIdentityAssociation) error
+	UpdatePodIdentityAssociations(ctx context.Context, podIdentityAssociations []api.PodIdentityAssociation) error
+	DeletePodIdentityAssociations(ctx context.Context, podIdentityAssociations []api.PodIdentityAssociation) error
+
+	CreateFargateProfiles(ctx context.Context, profiles []*api.FargateProfile) error
+	DeleteFargateProfile(ctx context.Context, name string) error
+}
+
+// Reconciler turns a Plan into a TaskTree that brings the cluster in line with its config file.
+type Reconciler struct {
+	ClusterConfig *api.ClusterConfig
+	Executor      Executor
+	// Prune enables deletion of resources that exist in the cluster but are missing from the config file
+	Prune bool
+}
+
+// Tasks returns the tasks required to apply plan. Updates that touch only immutable fields and,
+// unless Prune is set, deletions are logged and skipped.
+func (r *Reconciler) Tasks(ctx context.Context, plan *Plan) *tasks.TaskTree {
+	taskTree := &tasks.TaskTree{Parallel: false}
+
+	r.logSkippedChanges(plan)
+
+	appendIfNotEmpty := func(subTree *tasks.TaskTree) {
+		if subTree.Len() > 0 {
+			taskTree.Append(subTree)
+		}
+	}
+
+	appendIfNotEmpty(r.clusterSettingsTasks(ctx, plan))
+	appendIfNotEmpty(r.accessEntryTasks(ctx, plan))
+	appendIfNotEmpty(r.addonTasks(ctx, plan))
+	appendIfNotEmpty(r.nodeGroupAndFargateTasks(ctx, plan))
+	appendIfNotEmpty(r.podIdentityAssociationTasks(ctx, plan))
+	if r.Prune {
+		appendIfNotEmpty(r.pruneTasks(ctx, plan))
+	}
+	return taskTree
+}
+
+func (r *Reconciler) logSkippedChanges(plan *Plan) {
+	for _, c := range plan.Changes {
+		switch {
+		case c.Action == ActionDelete && !r.Prune:
+			logger.Info("%s %q exists in the cluster but not in the config file, rerun with --prune to delete it", c.Kind, c.Name)
+		case c.Action == ActionUpdate:
+			// Fargate profiles are immutable as a whole and are recreated instead
+			if c.Kind == KindFargateProfile {
+				continue
+			}
+			for _, f := range c.Fields {
+				if f.Immutable {
+					logger.Warning("%s %q: field %q cannot be updated in place (current: %q, desired: %q)", c.Kind, c.Name, f.Field, f.Current, f.Desired)
+				}
+			}
+		}
+	}
+}
+
+func newSubTree(parallel bool) *tasks.TaskTree {
+	return &tasks.TaskTree{Parallel: parallel, IsSubTask: true}
+}
+
+func (r *Reconciler) clusterSettingsTasks(ctx context.Context, plan *Plan) *tasks.TaskTree {
+	// EKS only allows a single cluster config update at a time
+	taskTree := newSubTree(false)
+	if len(plan.Select(KindLogging, ActionUpdate)) > 0 {
+		taskTree.Append(&tasks.GenericTask{
+			Description: "update CloudWatch logging configuration",
+			Doer:        func() error { return r.Executor.UpdateLogging(ctx) },
+		})
+	}
+	if len(plan.Select(KindEndpointAccess, ActionUpdate)) > 0 {
+		taskTree.Append(&tasks.GenericTask{
+			Description: "update cluster endpoint access",
+			Doer:        func() error { return r.Executor.UpdateEndpointAccess(ctx) },
+		})
+	}
+	if len(plan.Select(KindZonalShift, ActionUpdate)) > 0 {
+		taskTree.Append(&tasks.GenericTask{
+			Description: "update zonal shift config",
+			Doer:        func() error { return r.Executor.UpdateZonalShiftConfig(ctx) },
+		})
+	}
+	return taskTree
+}
+
+func (r *Reconciler) accessEntryTasks(ctx context.Context, plan *Plan) *tasks.TaskTree {
+	taskTree := newSubTree(false)
+	// EKS has no API to replace the groups and policies of an access entry in one call,
+	// so changed entries are recreated
+	toRecreate := r.findAccessEntries(plan.Select(KindAccessEntry, ActionUpdate))
+	if len(toRecreate) > 0 {
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("delete %d outdated access entr(ies)", len(toRecreate)),
+			Doer:        func() error { return r.Executor.DeleteAccessEntries(ctx, toRecreate) },
+		})
+	}
+	toCreate := append(r.findAccessEntries(plan.Select(KindAccessEntry, ActionCreate)), toRecreate...)
+	if len(toCreate) > 0 {
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("create %d access entr(ies)", len(toCreate)),
+			Doer:        func() error { return r.Executor.CreateAccessEntries(ctx, toCreate) },
+		})
+	}
+	return taskTree
+}
+
+func (r *Reconciler) addonTasks(ctx context.Context, plan *Plan) *tasks.TaskTree {
+	taskTree := newSubTree(false)
+	otherAddons := newSubTree(true)
+
+	appendAddonTask := func(a *api.Addon, action Action) {
+		task := &tasks.GenericTask{
+			Description: fmt.Sprintf("%s addon %s", action, a.Name),
+			Doer: func() error {
+				if action == ActionCreate {
+					return r.Executor.CreateAddon(ctx, a)
+				}
+				return r.Executor.UpdateAddon(ctx, a)
+			},
+		}
+		// other addons may depend on the EKS Pod Identity Agent for their IAM permissions
+		if a.CanonicalName() == api.PodIdentityAgentAddon {
+			taskTree.Append(task)
+		} else {
+			otherAddons.Append(task)
+		}
+	}
+
+	for _, action := range []Action{ActionCreate, ActionUpdate} {
+		for _, c := range plan.Select(KindAddon, action) {
+			if a := r.findAddon(c.Name); a != nil {
+				appendAddonTask(a, action)
+			}
+		}
+	}
+	if otherAddons.Len() > 0 {
+		taskTree.Append(otherAddons)
+	}
+	return taskTree
+}
+
+func (r *Reconciler) nodeGroupAndFargateTasks(ctx context.Context, plan *Plan) *tasks.TaskTree {
+	taskTree := newSubTree(true)
+
+	var (
+		nodeGroups        []*api.NodeGroup
+		managedNodeGroups []*api.ManagedNodeGroup
+	)
+	for _, c := range plan.Select(KindNodeGroup, ActionCreate) {
+		if ng := r.findNodeGroup(c.Name); ng != nil {
+			nodeGroups = append(nodeGroups, ng)
+		}
+	}
+	for _, c := range plan.Select(KindManagedNodeGroup, ActionCreate) {
+		if ng := r.findManagedNodeGroup(c.Name); ng != nil {
+			managedNodeGroups = append(managedNodeGroups, ng)
+		}
+	}
+	if len(nodeGroups)+len(managedNodeGroups) > 0 {
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("create %d nodegroup(s)", len(nodeGroups)+len(managedNodeGroups)),
+			Doer:        func() error { return r.Executor.CreateNodeGroups(ctx, nodeGroups, managedNodeGroups) },
+		})
+	}
+
+	scaleChanges := append(plan.Select(KindNodeGroup, ActionUpdate), plan.Select(KindManagedNodeGroup, ActionUpdate)...)
+	for _, c := range scaleChanges {
+		ng := r.findNodeGroupBase(c.Name)
+		if ng == nil || !c.HasMutableFields() {
+			continue
+		}
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("scale nodegroup %s", ng.Name),
+			Doer:        func() error { return r.Executor.ScaleNodeGroup(ctx, ng) },
+		})
+	}
+
+	// Fargate profiles can only be created or deleted one at a time
+	fargateTasks := newSubTree(false)
+	var profilesToCreate []*api.FargateProfile
+	for _, c := range plan.Select(KindFargateProfile, ActionUpdate) {
+		fp := r.findFargateProfile(c.Name)
+		if fp == nil {
+			continue
+		}
+		fargateTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("delete outdated Fargate profile %s", fp.Name),
+			Doer:        func() error { return r.Executor.DeleteFargateProfile(ctx, fp.Name) },
+		})
+		profilesToCreate = append(profilesToCreate, fp)
+	}
+	for _, c := range plan.Select(KindFargateProfile, ActionCreate) {
+		if fp := r.findFargateProfile(c.Name); fp != nil {
+			profilesToCreate = append(profilesToCreate, fp)
+		}
+	}
+	if len(profilesToCreate) > 0 {
+		fargateTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("create %d Fargate profile(s)", len(profilesToCreate)),
+			Doer:        func() error { return r.Executor.CreateFargateProfiles(ctx, profilesToCreate) },
+		})
+	}
+	if fargateTasks.Len() > 0 {
+		taskTree.Append(fargateTasks)
+	}
+	return taskTree
+}
+
+func (r *Reconciler) podIdentityAssociationTasks(ctx context.Context, plan *Plan) *tasks.TaskTree {
+	taskTree := newSubTree(true)
+	if toCreate := r.findPodIdentityAssociations(plan.Select(KindPodIdentityAssociation, ActionCreate)); len(toCreate) > 0 {
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("create %d pod identity association(s)", len(toCreate)),
+			Doer:        func() error { return r.Executor.CreatePodIdentityAssociations(ctx, toCreate) },
+		})
+	}
+	if toUpdate := r.findPodIdentityAssociations(plan.Select(KindPodIdentityAssociation, ActionUpdate)); len(toUpdate) > 0 {
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("update %d pod identity association(s)", len(toUpdate)),
+			Doer:        func() error { return r.Executor.UpdatePodIdentityAssociations(ctx, toUpdate) },
+		})
+	}
+	return taskTree
+}
+
+func (r *Reconciler) pruneTasks(ctx context.Context, plan *Plan) *tasks.TaskTree {
+	taskTree := newSubTree(false)
+
+	var podIdentityAssociations []api.PodIdentityAssociation
+	for _, c := range plan.Select(KindPodIdentityAssociation, ActionDelete) {
+		namespace, serviceAccountName, _ := strings.Cut(c.Name, "/")
+		podIdentityAssociations = append(podIdentityAssociations, api.PodIdentityAssociation{
+			Namespace:          namespace,
+			ServiceAccountName: serviceAccountName,
+		})
+	}
+	if len(podIdentityAssociations) > 0 {
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("delete %d pod identity association(s)", len(podIdentityAssociations)),
+			Doer:        func() error { return r.Executor.DeletePodIdentityAssociations(ctx, podIdentityAssociations) },
+		})
+	}
+
+	for _, c := range plan.Select(KindFargateProfile, ActionDelete) {
+		name := c.Name
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("delete Fargate profile %s", name),
+			Doer:        func() error { return r.Executor.DeleteFargateProfile(ctx, name) },
+		})
+	}
+
+	var (
+		nodeGroups        []*api.NodeGroup
+		managedNodeGroups []*api.ManagedNodeGroup
+	)
+	for _, c := range plan.Select(KindNodeGroup, ActionDelete) {
+		nodeGroups = append(nodeGroups, &api.NodeGroup{NodeGroupBase: &api.NodeGroupBase{Name: c.Name}})
+	}
+	for _, c := range plan.Select(KindManagedNodeGroup, ActionDelete) {
+		managedNodeGroups = append(managedNodeGroups, &api.ManagedNodeGroup{NodeGroupBase: &api.NodeGroupBase{Name: c.Name}})
+	}
+	if len(nodeGroups)+len(managedNodeGroups) > 0 {
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("delete %d nodegroup(s)", len(nodeGroups)+len(managedNodeGroups)),
+			Doer:        func() error { return r.Executor.DeleteNodeGroups(ctx, nodeGroups, managedNodeGroups) },
+		})
+	}
+
+	for _, c := range plan.Select(KindAddon, ActionDelete) {
+		a := &api.Addon{Name: c.Name}
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("delete addon %s", a.Name),
+			Doer:        func() error { return r.Executor.DeleteAddon(ctx, a) },
+		})
+	}
+
+	var accessEntries []api.AccessEntry
+	for _, c := range plan.Select(KindAccessEntry, ActionDelete) {
+		var principalARN api.ARN
+		if err := principalARN.Set(c.Name); err != nil {
+			logger.Warning("skipping deletion of access entry: %v", err)
+			continue
+		}
+		accessEntries = append(accessEntries, api.AccessEntry{PrincipalARN: principalARN})
+	}
+	if len(accessEntries) > 0 {
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("delete %d access entr(ies)", len(accessEntries)),
+			Doer:        func() error { return r.Executor.DeleteAccessEntries(ctx, accessEntries) },
+		})
+	}
+	return taskTree
+}
+
+func (r *Reconciler) findNodeGroup(name string) *api.NodeGroup {
+	for _, ng := range r.ClusterConfig.NodeGroups {
+		if ng.Name == name {
+			return ng
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) findManagedNodeGroup(name string) *api.ManagedNodeGroup {
+	for _, ng := range r.ClusterConfig.ManagedNodeGroups {
+		if ng.Name == name {
+			return ng
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) findNodeGroupBase(name string) *api.NodeGroupBase {
+	if ng := r.findNodeGroup(name); ng != nil {
+		return ng.NodeGroupBase
+	}
+	if ng := r.findManagedNodeGroup(name); ng != nil {
+		return ng.NodeGroupBase
+	}
+	return nil
+}
+
+func (r *Reconciler) findAddon(name string) *api.Addon {
+	for _, a := range r.ClusterConfig.Addons {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) findFargateProfile(name string) *api.FargateProfile {
+	for _, fp := range r.ClusterConfig.FargateProfiles {
+		if fp.Name == name {
+			return fp
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) findAccessEntries(changes []Change) []api.AccessEntry {
+	if r.ClusterConfig.AccessConfig == nil {
+		return nil
+	}
+	var accessEntries []api.AccessEntry
+	for _, c := range changes {
+		for _, e := range r.ClusterConfig.AccessConfig.AccessEntries {
+			if e.PrincipalARN.String() == c.Name {
+				accessEntries = append(accessEntries, e)
+			}
+		}
+	}
+	return accessEntries
+}
+
+func (r *Reconciler) findPodIdentityAssociations(changes []Change) []api.PodIdentityAssociation {
+	if r.ClusterConfig.IAM == nil {
+		return nil
+	}
+	var podIdentityAssociations []api.PodIdentityAssociation
+	for _, c := range changes {
+		for _, p := range r.ClusterConfig.IAM.PodIdentityAssociations {
+			if p.NameString() == c.Name {
+				podIdentityAssociations = append(podIdentityAssociations, p)
+			}
+		}
+	}
+	return podIdentityAssociations
+}
@@ -0,0 +1,80 @@
+package apply_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/apply"
+	"github.com/weaveworks/eksctl/pkg/actions/apply/fakes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		cfg          *api.ClusterConfig
+		fakeExecutor *fakes.FakeExecutor
+		plan         *apply.Plan
+	)
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "cluster"
+		ng := api.NewManagedNodeGroup()
+		ng.Name = "mng"
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{ng}
+		cfg.Addons = []*api.Addon{{Name: "vpc-cni"}}
+		fakeExecutor = new(fakes.FakeExecutor)
+
+		plan = &apply.Plan{
+			ClusterName: "cluster",
+			Changes: []apply.Change{
+				{Kind: apply.KindManagedNodeGroup, Name: "mng", Action: apply.ActionCreate},
+				{Kind: apply.KindAddon, Name: "vpc-cni", Action: apply.ActionUpdate, Fields: []apply.FieldChange{{Field: "version"}}},
+				{Kind: apply.KindNodeGroup, Name: "old", Action: apply.ActionDelete},
+			},
+		}
+	})
+
+	It("creates and updates resources but skips deletions without prune", func() {
+		reconciler := &apply.Reconciler{ClusterConfig: cfg, Executor: fakeExecutor}
+		taskTree := reconciler.Tasks(context.Background(), plan)
+		Expect(taskTree.DoAllSync()).To(BeEmpty())
+
+		Expect(fakeExecutor.CreateNodeGroupsCallCount()).To(Equal(1))
+		_, nodeGroups, managedNodeGroups := fakeExecutor.CreateNodeGroupsArgsForCall(0)
+		Expect(nodeGroups).To(BeEmpty())
+		Expect(managedNodeGroups).To(ConsistOf(HaveField("Name", "mng")))
+
+		Expect(fakeExecutor.UpdateAddonCallCount()).To(Equal(1))
+		_, a := fakeExecutor.UpdateAddonArgsForCall(0)
+		Expect(a.Name).To(Equal("vpc-cni"))
+
+		Expect(fakeExecutor.DeleteNodeGroupsCallCount()).To(BeZero())
+	})
+
+	It("deletes resources missing from the config file with prune", func() {
+		reconciler := &apply.Reconciler{ClusterConfig: cfg, Executor: fakeExecutor, Prune: true}
+		taskTree := reconciler.Tasks(context.Background(), plan)
+		Expect(taskTree.DoAllSync()).To(BeEmpty())
+
+		Expect(fakeExecutor.DeleteNodeGroupsCallCount()).To(Equal(1))
+		_, nodeGroups, managedNodeGroups := fakeExecutor.DeleteNodeGroupsArgsForCall(0)
+		Expect(nodeGroups).To(ConsistOf(HaveField("Name", "old")))
+		Expect(managedNodeGroups).To(BeEmpty())
+	})
+
+	It("does not scale nodegroups when only immutable fields differ", func() {
+		plan.Changes = []apply.Change{
+			{
+				Kind:   apply.KindManagedNodeGroup,
+				Name:   "mng",
+				Action: apply.ActionUpdate,
+				Fields: []apply.FieldChange{{Field: "instanceType", Immutable: true}},
+			},
+		}
+		reconciler := &apply.Reconciler{ClusterConfig: cfg, Executor: fakeExecutor}
+		Expect(reconciler.Tasks(context.Background(), plan).Len()).To(BeZero())
+	})
+})
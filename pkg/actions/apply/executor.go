@@ -0,0 +1,201 @@
+package apply
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/weaveworks/eksctl/pkg/accessentry"
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	fargateactions "github.com/weaveworks/eksctl/pkg/actions/fargate"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+)
+
+// DrainOptions controls how nodegroups are drained before they are deleted.
+type DrainOptions struct {
+	Enabled               bool
+	MaxGracePeriod        time.Duration
+	PodEvictionWaitPeriod time.Duration
+	DisableEviction       bool
+	Parallel              int
+}
+
+// ClusterExecutor applies changes to a live cluster using the same actions as the individual
+// create, update and delete commands.
+type ClusterExecutor struct {
+	ClusterConfig    *api.ClusterConfig
+	ClusterProvider  *eks.ClusterProvider
+	StackManager     manager.StackManager
+	ClientSet        kubernetes.Interface
+	AddonManager     *addon.Manager
+	InstanceSelector eks.InstanceSelector
+	WaitTimeout      time.Duration
+	Drain            DrainOptions
+}
+
+// UpdateLogging implements Executor.
+func (e *ClusterExecutor) UpdateLogging(ctx context.Context) error {
+	return e.ClusterProvider.UpdateClusterConfigForLogging(ctx, e.ClusterConfig)
+}
+
+// UpdateEndpointAccess implements Executor.
+func (e *ClusterExecutor) UpdateEndpointAccess(ctx context.Context) error {
+	return e.ClusterProvider.UpdateClusterConfigForEndpoints(ctx, e.ClusterConfig)
+}
+
+// UpdateZonalShiftConfig implements Executor.
+func (e *ClusterExecutor) UpdateZonalShiftConfig(ctx context.Context) error {
+	return e.ClusterProvider.UpdateClusterConfig(ctx, &awseks.UpdateClusterConfigInput{
+		Name: aws.String(e.ClusterConfig.Metadata.Name),
+		ZonalShiftConfig: &ekstypes.ZonalShiftConfigRequest{
+			Enabled: e.ClusterConfig.ZonalShiftConfig.Enabled,
+		},
+	})
+}
+
+// CreateNodeGroups implements Executor.
+func (e *ClusterExecutor) CreateNodeGroups(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup) error {
+	// nodegroup creation filters the nodegroups in the config it is given, so it works on a copy
+	cfg := e.ClusterConfig.DeepCopy()
+	cfg.NodeGroups = nodeGroups
+	cfg.ManagedNodeGroups = managedNodeGroups
+	return nodegroup.New(cfg, e.ClusterProvider, e.ClientSet, e.InstanceSelector).Create(ctx, nodegroup.CreateOpts{
+		ConfigFileProvided: true,
+	}, filter.NewNodeGroupFilter())
+}
+
+// ScaleNodeGroup implements Executor.
+func (e *ClusterExecutor) ScaleNodeGroup(ctx context.Context, ng *api.NodeGroupBase) error {
+	return nodegroup.New(e.ClusterConfig, e.ClusterProvider, e.ClientSet, e.InstanceSelector).Scale(ctx, ng, true)
+}
+
+// DeleteNodeGroups implements Executor.
+func (e *ClusterExecutor) DeleteNodeGroups(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup) error {
+	accessEntry := &accessentry.Service{ClusterStateGetter: e.ClusterProvider}
+	updateAuthConfigMap := !accessEntry.IsAWSAuthDisabled()
+	if updateAuthConfigMap {
+		for _, ng := range nodeGroups {
+			if err := e.ClusterProvider.GetNodeGroupIAM(ctx, e.StackManager, ng); err != nil {
+				logger.Warning("continuing with deletion, error getting instance role ARN for nodegroup %q: %v", ng.Name, err)
+			}
+		}
+	}
+
+	if e.Drain.Enabled {
+		drainer := &nodegroup.Drainer{ClientSet: e.ClientSet}
+		drainCtx, cancel := context.WithTimeout(ctx, e.WaitTimeout)
+		defer cancel()
+		if err := drainer.Drain(drainCtx, &nodegroup.DrainInput{
+			NodeGroups:            cmdutils.ToKubeNodeGroups(nodeGroups, managedNodeGroups),
+			MaxGracePeriod:        e.Drain.MaxGracePeriod,
+			PodEvictionWaitPeriod: e.Drain.PodEvictionWaitPeriod,
+			DisableEviction:       e.Drain.DisableEviction,
+			Parallel:              e.Drain.Parallel,
+		}); err != nil {
+			return err
+		}
+	}
+
+	deleter := &nodegroup.Deleter{
+		StackHelper:          e.StackManager,
+		NodeGroupDeleter:     e.ClusterProvider.AWSProvider.EKS(),
+		ClusterName:          e.ClusterConfig.Metadata.Name,
+		AuthConfigMapUpdater: &authconfigmap.Updater{ClientSet: e.ClientSet},
+	}
+	return deleter.Delete(ctx, nodeGroups, managedNodeGroups, nodegroup.DeleteOptions{
+		Wait:                true,
+		UpdateAuthConfigMap: updateAuthConfigMap,
+	})
+}
+
+// CreateAddon implements Executor.
+func (e *ClusterExecutor) CreateAddon(ctx context.Context, a *api.Addon) error {
+	return e.AddonManager.Create(ctx, a, &podidentityassociation.IAMRoleCreator{
+		ClusterName:  e.ClusterConfig.Metadata.Name,
+		StackCreator: e.StackManager,
+	}, e.WaitTimeout)
+}
+
+// UpdateAddon implements Executor.
+func (e *ClusterExecutor) UpdateAddon(ctx context.Context, a *api.Addon) error {
+	return e.AddonManager.Update(ctx, a, &addon.PodIdentityAssociationUpdater{
+		ClusterName: e.ClusterConfig.Metadata.Name,
+		IAMRoleCreator: &podidentityassociation.IAMRoleCreator{
+			ClusterName:  e.ClusterConfig.Metadata.Name,
+			StackCreator: e.StackManager,
+		},
+		IAMRoleUpdater: &podidentityassociation.IAMRoleUpdater{
+			StackUpdater: e.StackManager,
+		},
+		EKSPodIdentityDescriber: e.ClusterProvider.AWSProvider.EKS(),
+		StackDeleter:            e.StackManager,
+	}, e.WaitTimeout)
+}
+
+// DeleteAddon implements Executor.
+func (e *ClusterExecutor) DeleteAddon(ctx context.Context, a *api.Addon) error {
+	return e.AddonManager.Delete(ctx, a)
+}
+
+// CreateAccessEntries implements Executor.
+func (e *ClusterExecutor) CreateAccessEntries(ctx context.Context, accessEntries []api.AccessEntry) error {
+	creator := &accessentryactions.Creator{
+		ClusterName:  e.ClusterConfig.Metadata.Name,
+		StackCreator: e.StackManager,
+	}
+	return creator.Create(ctx, accessEntries)
+}
+
+// DeleteAccessEntries implements Executor.
+func (e *ClusterExecutor) DeleteAccessEntries(ctx context.Context, accessEntries []api.AccessEntry) error {
+	return accessentryactions.NewRemover(e.ClusterConfig.Metadata.Name, e.StackManager, e.ClusterProvider.AWSProvider.EKS()).Delete(ctx, accessEntries)
+}
+
+// CreatePodIdentityAssociations implements Executor.
+func (e *ClusterExecutor) CreatePodIdentityAssociations(ctx context.Context, podIdentityAssociations []api.PodIdentityAssociation) error {
+	return podidentityassociation.NewCreator(e.ClusterConfig.Metadata.Name, e.StackManager, e.ClusterProvider.AWSProvider.EKS(), e.ClientSet).
+		CreatePodIdentityAssociations(ctx, podIdentityAssociations)
+}
+
+// UpdatePodIdentityAssociations implements Executor.
+func (e *ClusterExecutor) UpdatePodIdentityAssociations(ctx context.Context, podIdentityAssociations []api.PodIdentityAssociation) error {
+	updater := &podidentityassociation.Updater{
+		ClusterName:  e.ClusterConfig.Metadata.Name,
+		APIUpdater:   e.ClusterProvider.AWSProvider.EKS(),
+		StackUpdater: e.StackManager,
+	}
+	return updater.Update(ctx, podIdentityAssociations)
+}
+
+// DeletePodIdentityAssociations implements Executor.
+func (e *ClusterExecutor) DeletePodIdentityAssociations(ctx context.Context, podIdentityAssociations []api.PodIdentityAssociation) error {
+	return podidentityassociation.NewDeleter(e.ClusterConfig.Metadata.Name, e.StackManager, e.ClusterProvider.AWSProvider.EKS(), e.ClientSet).
+		Delete(ctx, podidentityassociation.ToIdentifiers(podIdentityAssociations))
+}
+
+// CreateFargateProfiles implements Executor.
+func (e *ClusterExecutor) CreateFargateProfiles(ctx context.Context, profiles []*api.FargateProfile) error {
+	cfg := e.ClusterConfig.DeepCopy()
+	cfg.FargateProfiles = profiles
+	return fargateactions.New(cfg, e.ClusterProvider, e.StackManager).Create(ctx)
+}
+
+// DeleteFargateProfile implements Executor.
+func (e *ClusterExecutor) DeleteFargateProfile(ctx context.Context, name string) error {
+	client := fargate.NewFromProvider(e.ClusterConfig.Metadata.Name, e.ClusterProvider.AWSProvider, e.StackManager)
+	return client.DeleteProfile(ctx, name, true)
+}
@@ -0,0 +1,1303 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/weaveworks/eksctl/pkg/actions/apply"
+	"github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+type FakeExecutor struct {
+	CreateAccessEntriesStub        func(context.Context, []v1alpha5.AccessEntry) error
+	createAccessEntriesMutex       sync.RWMutex
+	createAccessEntriesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []v1alpha5.AccessEntry
+	}
+	createAccessEntriesReturns struct {
+		result1 error
+	}
+	createAccessEntriesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateAddonStub        func(context.Context, *v1alpha5.Addon) error
+	createAddonMutex       sync.RWMutex
+	createAddonArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha5.Addon
+	}
+	createAddonReturns struct {
+		result1 error
+	}
+	createAddonReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateFargateProfilesStub        func(context.Context, []*v1alpha5.FargateProfile) error
+	createFargateProfilesMutex       sync.RWMutex
+	createFargateProfilesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []*v1alpha5.FargateProfile
+	}
+	createFargateProfilesReturns struct {
+		result1 error
+	}
+	createFargateProfilesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateNodeGroupsStub        func(context.Context, []*v1alpha5.NodeGroup, []*v1alpha5.ManagedNodeGroup) error
+	createNodeGroupsMutex       sync.RWMutex
+	createNodeGroupsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []*v1alpha5.NodeGroup
+		arg3 []*v1alpha5.ManagedNodeGroup
+	}
+	createNodeGroupsReturns struct {
+		result1 error
+	}
+	createNodeGroupsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreatePodIdentityAssociationsStub        func(context.Context, []v1alpha5.PodIdentityAssociation) error
+	createPodIdentityAssociationsMutex       sync.RWMutex
+	createPodIdentityAssociationsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []v1alpha5.PodIdentityAssociation
+	}
+	createPodIdentityAssociationsReturns struct {
+		result1 error
+	}
+	createPodIdentityAssociationsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteAccessEntriesStub        func(context.Context, []v1alpha5.AccessEntry) error
+	deleteAccessEntriesMutex       sync.RWMutex
+	deleteAccessEntriesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []v1alpha5.AccessEntry
+	}
+	deleteAccessEntriesReturns struct {
+		result1 error
+	}
+	deleteAccessEntriesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteAddonStub        func(context.Context, *v1alpha5.Addon) error
+	deleteAddonMutex       sync.RWMutex
+	deleteAddonArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha5.Addon
+	}
+	deleteAddonReturns struct {
+		result1 error
+	}
+	deleteAddonReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteFargateProfileStub        func(context.Context, string) error
+	deleteFargateProfileMutex       sync.RWMutex
+	deleteFargateProfileArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteFargateProfileReturns struct {
+		result1 error
+	}
+	deleteFargateProfileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteNodeGroupsStub        func(context.Context, []*v1alpha5.NodeGroup, []*v1alpha5.ManagedNodeGroup) error
+	deleteNodeGroupsMutex       sync.RWMutex
+	deleteNodeGroupsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []*v1alpha5.NodeGroup
+		arg3 []*v1alpha5.ManagedNodeGroup
+	}
+	deleteNodeGroupsReturns struct {
+		result1 error
+	}
+	deleteNodeGroupsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeletePodIdentityAssociationsStub        func(context.Context, []v1alpha5.PodIdentityAssociation) error
+	deletePodIdentityAssociationsMutex       sync.RWMutex
+	deletePodIdentityAssociationsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []v1alpha5.PodIdentityAssociation
+	}
+	deletePodIdentityAssociationsReturns struct {
+		result1 error
+	}
+	deletePodIdentityAssociationsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ScaleNodeGroupStub        func(context.Context, *v1alpha5.NodeGroupBase) error
+	scaleNodeGroupMutex       sync.RWMutex
+	scaleNodeGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha5.NodeGroupBase
+	}
+	scaleNodeGroupReturns struct {
+		result1 error
+	}
+	scaleNodeGroupReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateAddonStub        func(context.Context, *v1alpha5.Addon) error
+	updateAddonMutex       sync.RWMutex
+	updateAddonArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha5.Addon
+	}
+	updateAddonReturns struct {
+		result1 error
+	}
+	updateAddonReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateEndpointAccessStub        func(context.Context) error
+	updateEndpointAccessMutex       sync.RWMutex
+	updateEndpointAccessArgsForCall []struct {
+		arg1 context.Context
+	}
+	updateEndpointAccessReturns struct {
+		result1 error
+	}
+	updateEndpointAccessReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateLoggingStub        func(context.Context) error
+	updateLoggingMutex       sync.RWMutex
+	updateLoggingArgsForCall []struct {
+		arg1 context.Context
+	}
+	updateLoggingReturns struct {
+		result1 error
+	}
+	updateLoggingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdatePodIdentityAssociationsStub        func(context.Context, []v1alpha5.PodIdentityAssociation) error
+	updatePodIdentityAssociationsMutex       sync.RWMutex
+	updatePodIdentityAssociationsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []v1alpha5.PodIdentityAssociation
+	}
+	updatePodIdentityAssociationsReturns struct {
+		result1 error
+	}
+	updatePodIdentityAssociationsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateZonalShiftConfigStub        func(context.Context) error
+	updateZonalShiftConfigMutex       sync.RWMutex
+	updateZonalShiftConfigArgsForCall []struct {
+		arg1 context.Context
+	}
+	updateZonalShiftConfigReturns struct {
+		result1 error
+	}
+	updateZonalShiftConfigReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeExecutor) CreateAccessEntries(arg1 context.Context, arg2 []v1alpha5.AccessEntry) error {
+	var arg2Copy []v1alpha5.AccessEntry
+	if arg2 != nil {
+		arg2Copy = make([]v1alpha5.AccessEntry, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.createAccessEntriesMutex.Lock()
+	ret, specificReturn := fake.createAccessEntriesReturnsOnCall[len(fake.createAccessEntriesArgsForCall)]
+	fake.createAccessEntriesArgsForCall = append(fake.createAccessEntriesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []v1alpha5.AccessEntry
+	}{arg1, arg2Copy})
+	stub := fake.CreateAccessEntriesStub
+	fakeReturns := fake.createAccessEntriesReturns
+	fake.recordInvocation("CreateAccessEntries", []interface{}{arg1, arg2Copy})
+	fake.createAccessEntriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) CreateAccessEntriesCallCount() int {
+	fake.createAccessEntriesMutex.RLock()
+	defer fake.createAccessEntriesMutex.RUnlock()
+	return len(fake.createAccessEntriesArgsForCall)
+}
+
+func (fake *FakeExecutor) CreateAccessEntriesCalls(stub func(context.Context, []v1alpha5.AccessEntry) error) {
+	fake.createAccessEntriesMutex.Lock()
+	defer fake.createAccessEntriesMutex.Unlock()
+	fake.CreateAccessEntriesStub = stub
+}
+
+func (fake *FakeExecutor) CreateAccessEntriesArgsForCall(i int) (context.Context, []v1alpha5.AccessEntry) {
+	fake.createAccessEntriesMutex.RLock()
+	defer fake.createAccessEntriesMutex.RUnlock()
+	argsForCall := fake.createAccessEntriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeExecutor) CreateAccessEntriesReturns(result1 error) {
+	fake.createAccessEntriesMutex.Lock()
+	defer fake.createAccessEntriesMutex.Unlock()
+	fake.CreateAccessEntriesStub = nil
+	fake.createAccessEntriesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) CreateAccessEntriesReturnsOnCall(i int, result1 error) {
+	fake.createAccessEntriesMutex.Lock()
+	defer fake.createAccessEntriesMutex.Unlock()
+	fake.CreateAccessEntriesStub = nil
+	if fake.createAccessEntriesReturnsOnCall == nil {
+		fake.createAccessEntriesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createAccessEntriesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) CreateAddon(arg1 context.Context, arg2 *v1alpha5.Addon) error {
+	fake.createAddonMutex.Lock()
+	ret, specificReturn := fake.createAddonReturnsOnCall[len(fake.createAddonArgsForCall)]
+	fake.createAddonArgsForCall = append(fake.createAddonArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha5.Addon
+	}{arg1, arg2})
+	stub := fake.CreateAddonStub
+	fakeReturns := fake.createAddonReturns
+	fake.recordInvocation("CreateAddon", []interface{}{arg1, arg2})
+	fake.createAddonMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) CreateAddonCallCount() int {
+	fake.createAddonMutex.RLock()
+	defer fake.createAddonMutex.RUnlock()
+	return len(fake.createAddonArgsForCall)
+}
+
+func (fake *FakeExecutor) CreateAddonCalls(stub func(context.Context, *v1alpha5.Addon) error) {
+	fake.createAddonMutex.Lock()
+	defer fake.createAddonMutex.Unlock()
+	fake.CreateAddonStub = stub
+}
+
+func (fake *FakeExecutor) CreateAddonArgsForCall(i int) (context.Context, *v1alpha5.Addon) {
+	fake.createAddonMutex.RLock()
+	defer fake.createAddonMutex.RUnlock()
+	argsForCall := fake.createAddonArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeExecutor) CreateAddonReturns(result1 error) {
+	fake.createAddonMutex.Lock()
+	defer fake.createAddonMutex.Unlock()
+	fake.CreateAddonStub = nil
+	fake.createAddonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) CreateAddonReturnsOnCall(i int, result1 error) {
+	fake.createAddonMutex.Lock()
+	defer fake.createAddonMutex.Unlock()
+	fake.CreateAddonStub = nil
+	if fake.createAddonReturnsOnCall == nil {
+		fake.createAddonReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createAddonReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) CreateFargateProfiles(arg1 context.Context, arg2 []*v1alpha5.FargateProfile) error {
+	var arg2Copy []*v1alpha5.FargateProfile
+	if arg2 != nil {
+		arg2Copy = make([]*v1alpha5.FargateProfile, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.createFargateProfilesMutex.Lock()
+	ret, specificReturn := fake.createFargateProfilesReturnsOnCall[len(fake.createFargateProfilesArgsForCall)]
+	fake.createFargateProfilesArgsForCall = append(fake.createFargateProfilesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []*v1alpha5.FargateProfile
+	}{arg1, arg2Copy})
+	stub := fake.CreateFargateProfilesStub
+	fakeReturns := fake.createFargateProfilesReturns
+	fake.recordInvocation("CreateFargateProfiles", []interface{}{arg1, arg2Copy})
+	fake.createFargateProfilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) CreateFargateProfilesCallCount() int {
+	fake.createFargateProfilesMutex.RLock()
+	defer fake.createFargateProfilesMutex.RUnlock()
+	return len(fake.createFargateProfilesArgsForCall)
+}
+
+func (fake *FakeExecutor) CreateFargateProfilesCalls(stub func(context.Context, []*v1alpha5.FargateProfile) error) {
+	fake.createFargateProfilesMutex.Lock()
+	defer fake.createFargateProfilesMutex.Unlock()
+	fake.CreateFargateProfilesStub = stub
+}
+
+func (fake *FakeExecutor) CreateFargateProfilesArgsForCall(i int) (context.Context, []*v1alpha5.FargateProfile) {
+	fake.createFargateProfilesMutex.RLock()
+	defer fake.createFargateProfilesMutex.RUnlock()
+	argsForCall := fake.createFargateProfilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeExecutor) CreateFargateProfilesReturns(result1 error) {
+	fake.createFargateProfilesMutex.Lock()
+	defer fake.createFargateProfilesMutex.Unlock()
+	fake.CreateFargateProfilesStub = nil
+	fake.createFargateProfilesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) CreateFargateProfilesReturnsOnCall(i int, result1 error) {
+	fake.createFargateProfilesMutex.Lock()
+	defer fake.createFargateProfilesMutex.Unlock()
+	fake.CreateFargateProfilesStub = nil
+	if fake.createFargateProfilesReturnsOnCall == nil {
+		fake.createFargateProfilesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createFargateProfilesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) CreateNodeGroups(arg1 context.Context, arg2 []*v1alpha5.NodeGroup, arg3 []*v1alpha5.ManagedNodeGroup) error {
+	var arg2Copy []*v1alpha5.NodeGroup
+	if arg2 != nil {
+		arg2Copy = make([]*v1alpha5.NodeGroup, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []*v1alpha5.ManagedNodeGroup
+	if arg3 != nil {
+		arg3Copy = make([]*v1alpha5.ManagedNodeGroup, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.createNodeGroupsMutex.Lock()
+	ret, specificReturn := fake.createNodeGroupsReturnsOnCall[len(fake.createNodeGroupsArgsForCall)]
+	fake.createNodeGroupsArgsForCall = append(fake.createNodeGroupsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []*v1alpha5.NodeGroup
+		arg3 []*v1alpha5.ManagedNodeGroup
+	}{arg1, arg2Copy, arg3Copy})
+	stub := fake.CreateNodeGroupsStub
+	fakeReturns := fake.createNodeGroupsReturns
+	fake.recordInvocation("CreateNodeGroups", []interface{}{arg1, arg2Copy, arg3Copy})
+	fake.createNodeGroupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) CreateNodeGroupsCallCount() int {
+	fake.createNodeGroupsMutex.RLock()
+	defer fake.createNodeGroupsMutex.RUnlock()
+	return len(fake.createNodeGroupsArgsForCall)
+}
+
+func (fake *FakeExecutor) CreateNodeGroupsCalls(stub func(context.Context, []*v1alpha5.NodeGroup, []*v1alpha5.ManagedNodeGroup) error) {
+	fake.createNodeGroupsMutex.Lock()
+	defer fake.createNodeGroupsMutex.Unlock()
+	fake.CreateNodeGroupsStub = stub
+}
+
+func (fake *FakeExecutor) CreateNodeGroupsArgsForCall(i int) (context.Context, []*v1alpha5.NodeGroup, []*v1alpha5.ManagedNodeGroup) {
+	fake.createNodeGroupsMutex.RLock()
+	defer fake.createNodeGroupsMutex.RUnlock()
+	argsForCall := fake.createNodeGroupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeExecutor) CreateNodeGroupsReturns(result1 error) {
+	fake.createNodeGroupsMutex.Lock()
+	defer fake.createNodeGroupsMutex.Unlock()
+	fake.CreateNodeGroupsStub = nil
+	fake.createNodeGroupsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) CreateNodeGroupsReturnsOnCall(i int, result1 error) {
+	fake.createNodeGroupsMutex.Lock()
+	defer fake.createNodeGroupsMutex.Unlock()
+	fake.CreateNodeGroupsStub = nil
+	if fake.createNodeGroupsReturnsOnCall == nil {
+		fake.createNodeGroupsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createNodeGroupsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) CreatePodIdentityAssociations(arg1 context.Context, arg2 []v1alpha5.PodIdentityAssociation) error {
+	var arg2Copy []v1alpha5.PodIdentityAssociation
+	if arg2 != nil {
+		arg2Copy = make([]v1alpha5.PodIdentityAssociation, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.createPodIdentityAssociationsMutex.Lock()
+	ret, specificReturn := fake.createPodIdentityAssociationsReturnsOnCall[len(fake.createPodIdentityAssociationsArgsForCall)]
+	fake.createPodIdentityAssociationsArgsForCall = append(fake.createPodIdentityAssociationsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []v1alpha5.PodIdentityAssociation
+	}{arg1, arg2Copy})
+	stub := fake.CreatePodIdentityAssociationsStub
+	fakeReturns := fake.createPodIdentityAssociationsReturns
+	fake.recordInvocation("CreatePodIdentityAssociations", []interface{}{arg1, arg2Copy})
+	fake.createPodIdentityAssociationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) CreatePodIdentityAssociationsCallCount() int {
+	fake.createPodIdentityAssociationsMutex.RLock()
+	defer fake.createPodIdentityAssociationsMutex.RUnlock()
+	return len(fake.createPodIdentityAssociationsArgsForCall)
+}
+
+func (fake *FakeExecutor) CreatePodIdentityAssociationsCalls(stub func(context.Context, []v1alpha5.PodIdentityAssociation) error) {
+	fake.createPodIdentityAssociationsMutex.Lock()
+	defer fake.createPodIdentityAssociationsMutex.Unlock()
+	fake.CreatePodIdentityAssociationsStub = stub
+}
+
+func (fake *FakeExecutor) CreatePodIdentityAssociationsArgsForCall(i int) (context.Context, []v1alpha5.PodIdentityAssociation) {
+	fake.createPodIdentityAssociationsMutex.RLock()
+	defer fake.createPodIdentityAssociationsMutex.RUnlock()
+	argsForCall := fake.createPodIdentityAssociationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeExecutor) CreatePodIdentityAssociationsReturns(result1 error) {
+	fake.createPodIdentityAssociationsMutex.Lock()
+	defer fake.createPodIdentityAssociationsMutex.Unlock()
+	fake.CreatePodIdentityAssociationsStub = nil
+	fake.createPodIdentityAssociationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) CreatePodIdentityAssociationsReturnsOnCall(i int, result1 error) {
+	fake.createPodIdentityAssociationsMutex.Lock()
+	defer fake.createPodIdentityAssociationsMutex.Unlock()
+	fake.CreatePodIdentityAssociationsStub = nil
+	if fake.createPodIdentityAssociationsReturnsOnCall == nil {
+		fake.createPodIdentityAssociationsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createPodIdentityAssociationsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) DeleteAccessEntries(arg1 context.Context, arg2 []v1alpha5.AccessEntry) error {
+	var arg2Copy []v1alpha5.AccessEntry
+	if arg2 != nil {
+		arg2Copy = make([]v1alpha5.AccessEntry, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.deleteAccessEntriesMutex.Lock()
+	ret, specificReturn := fake.deleteAccessEntriesReturnsOnCall[len(fake.deleteAccessEntriesArgsForCall)]
+	fake.deleteAccessEntriesArgsForCall = append(fake.deleteAccessEntriesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []v1alpha5.AccessEntry
+	}{arg1, arg2Copy})
+	stub := fake.DeleteAccessEntriesStub
+	fakeReturns := fake.deleteAccessEntriesReturns
+	fake.recordInvocation("DeleteAccessEntries", []interface{}{arg1, arg2Copy})
+	fake.deleteAccessEntriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) DeleteAccessEntriesCallCount() int {
+	fake.deleteAccessEntriesMutex.RLock()
+	defer fake.deleteAccessEntriesMutex.RUnlock()
+	return len(fake.deleteAccessEntriesArgsForCall)
+}
+
+func (fake *FakeExecutor) DeleteAccessEntriesCalls(stub func(context.Context, []v1alpha5.AccessEntry) error) {
+	fake.deleteAccessEntriesMutex.Lock()
+	defer fake.deleteAccessEntriesMutex.Unlock()
+	fake.DeleteAccessEntriesStub = stub
+}
+
+func (fake *FakeExecutor) DeleteAccessEntriesArgsForCall(i int) (context.Context, []v1alpha5.AccessEntry) {
+	fake.deleteAccessEntriesMutex.RLock()
+	defer fake.deleteAccessEntriesMutex.RUnlock()
+	argsForCall := fake.deleteAccessEntriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeExecutor) DeleteAccessEntriesReturns(result1 error) {
+	fake.deleteAccessEntriesMutex.Lock()
+	defer fake.deleteAccessEntriesMutex.Unlock()
+	fake.DeleteAccessEntriesStub = nil
+	fake.deleteAccessEntriesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) DeleteAccessEntriesReturnsOnCall(i int, result1 error) {
+	fake.deleteAccessEntriesMutex.Lock()
+	defer fake.deleteAccessEntriesMutex.Unlock()
+	fake.DeleteAccessEntriesStub = nil
+	if fake.deleteAccessEntriesReturnsOnCall == nil {
+		fake.deleteAccessEntriesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteAccessEntriesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) DeleteAddon(arg1 context.Context, arg2 *v1alpha5.Addon) error {
+	fake.deleteAddonMutex.Lock()
+	ret, specificReturn := fake.deleteAddonReturnsOnCall[len(fake.deleteAddonArgsForCall)]
+	fake.deleteAddonArgsForCall = append(fake.deleteAddonArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha5.Addon
+	}{arg1, arg2})
+	stub := fake.DeleteAddonStub
+	fakeReturns := fake.deleteAddonReturns
+	fake.recordInvocation("DeleteAddon", []interface{}{arg1, arg2})
+	fake.deleteAddonMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) DeleteAddonCallCount() int {
+	fake.deleteAddonMutex.RLock()
+	defer fake.deleteAddonMutex.RUnlock()
+	return len(fake.deleteAddonArgsForCall)
+}
+
+func (fake *FakeExecutor) DeleteAddonCalls(stub func(context.Context, *v1alpha5.Addon) error) {
+	fake.deleteAddonMutex.Lock()
+	defer fake.deleteAddonMutex.Unlock()
+	fake.DeleteAddonStub = stub
+}
+
+func (fake *FakeExecutor) DeleteAddonArgsForCall(i int) (context.Context, *v1alpha5.Addon) {
+	fake.deleteAddonMutex.RLock()
+	defer fake.deleteAddonMutex.RUnlock()
+	argsForCall := fake.deleteAddonArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeExecutor) DeleteAddonReturns(result1 error) {
+	fake.deleteAddonMutex.Lock()
+	defer fake.deleteAddonMutex.Unlock()
+	fake.DeleteAddonStub = nil
+	fake.deleteAddonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) DeleteAddonReturnsOnCall(i int, result1 error) {
+	fake.deleteAddonMutex.Lock()
+	defer fake.deleteAddonMutex.Unlock()
+	fake.DeleteAddonStub = nil
+	if fake.deleteAddonReturnsOnCall == nil {
+		fake.deleteAddonReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteAddonReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) DeleteFargateProfile(arg1 context.Context, arg2 string) error {
+	fake.deleteFargateProfileMutex.Lock()
+	ret, specificReturn := fake.deleteFargateProfileReturnsOnCall[len(fake.deleteFargateProfileArgsForCall)]
+	fake.deleteFargateProfileArgsForCall = append(fake.deleteFargateProfileArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteFargateProfileStub
+	fakeReturns := fake.deleteFargateProfileReturns
+	fake.recordInvocation("DeleteFargateProfile", []interface{}{arg1, arg2})
+	fake.deleteFargateProfileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) DeleteFargateProfileCallCount() int {
+	fake.deleteFargateProfileMutex.RLock()
+	defer fake.deleteFargateProfileMutex.RUnlock()
+	return len(fake.deleteFargateProfileArgsForCall)
+}
+
+func (fake *FakeExecutor) DeleteFargateProfileCalls(stub func(context.Context, string) error) {
+	fake.deleteFargateProfileMutex.Lock()
+	defer fake.deleteFargateProfileMutex.Unlock()
+	fake.DeleteFargateProfileStub = stub
+}
+
+func (fake *FakeExecutor) DeleteFargateProfileArgsForCall(i int) (context.Context, string) {
+	fake.deleteFargateProfileMutex.RLock()
+	defer fake.deleteFargateProfileMutex.RUnlock()
+	argsForCall := fake.deleteFargateProfileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeExecutor) DeleteFargateProfileReturns(result1 error) {
+	fake.deleteFargateProfileMutex.Lock()
+	defer fake.deleteFargateProfileMutex.Unlock()
+	fake.DeleteFargateProfileStub = nil
+	fake.deleteFargateProfileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) DeleteFargateProfileReturnsOnCall(i int, result1 error) {
+	fake.deleteFargateProfileMutex.Lock()
+	defer fake.deleteFargateProfileMutex.Unlock()
+	fake.DeleteFargateProfileStub = nil
+	if fake.deleteFargateProfileReturnsOnCall == nil {
+		fake.deleteFargateProfileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteFargateProfileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) DeleteNodeGroups(arg1 context.Context, arg2 []*v1alpha5.NodeGroup, arg3 []*v1alpha5.ManagedNodeGroup) error {
+	var arg2Copy []*v1alpha5.NodeGroup
+	if arg2 != nil {
+		arg2Copy = make([]*v1alpha5.NodeGroup, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []*v1alpha5.ManagedNodeGroup
+	if arg3 != nil {
+		arg3Copy = make([]*v1alpha5.ManagedNodeGroup, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.deleteNodeGroupsMutex.Lock()
+	ret, specificReturn := fake.deleteNodeGroupsReturnsOnCall[len(fake.deleteNodeGroupsArgsForCall)]
+	fake.deleteNodeGroupsArgsForCall = append(fake.deleteNodeGroupsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []*v1alpha5.NodeGroup
+		arg3 []*v1alpha5.ManagedNodeGroup
+	}{arg1, arg2Copy, arg3Copy})
+	stub := fake.DeleteNodeGroupsStub
+	fakeReturns := fake.deleteNodeGroupsReturns
+	fake.recordInvocation("DeleteNodeGroups", []interface{}{arg1, arg2Copy, arg3Copy})
+	fake.deleteNodeGroupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) DeleteNodeGroupsCallCount() int {
+	fake.deleteNodeGroupsMutex.RLock()
+	defer fake.deleteNodeGroupsMutex.RUnlock()
+	return len(fake.deleteNodeGroupsArgsForCall)
+}
+
+func (fake *FakeExecutor) DeleteNodeGroupsCalls(stub func(context.Context, []*v1alpha5.NodeGroup, []*v1alpha5.ManagedNodeGroup) error) {
+	fake.deleteNodeGroupsMutex.Lock()
+	defer fake.deleteNodeGroupsMutex.Unlock()
+	fake.DeleteNodeGroupsStub = stub
+}
+
+func (fake *FakeExecutor) DeleteNodeGroupsArgsForCall(i int) (context.Context, []*v1alpha5.NodeGroup, []*v1alpha5.ManagedNodeGroup) {
+	fake.deleteNodeGroupsMutex.RLock()
+	defer fake.deleteNodeGroupsMutex.RUnlock()
+	argsForCall := fake.deleteNodeGroupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeExecutor) DeleteNodeGroupsReturns(result1 error) {
+	fake.deleteNodeGroupsMutex.Lock()
+	defer fake.deleteNodeGroupsMutex.Unlock()
+	fake.DeleteNodeGroupsStub = nil
+	fake.deleteNodeGroupsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) DeleteNodeGroupsReturnsOnCall(i int, result1 error) {
+	fake.deleteNodeGroupsMutex.Lock()
+	defer fake.deleteNodeGroupsMutex.Unlock()
+	fake.DeleteNodeGroupsStub = nil
+	if fake.deleteNodeGroupsReturnsOnCall == nil {
+		fake.deleteNodeGroupsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteNodeGroupsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) DeletePodIdentityAssociations(arg1 context.Context, arg2 []v1alpha5.PodIdentityAssociation) error {
+	var arg2Copy []v1alpha5.PodIdentityAssociation
+	if arg2 != nil {
+		arg2Copy = make([]v1alpha5.PodIdentityAssociation, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.deletePodIdentityAssociationsMutex.Lock()
+	ret, specificReturn := fake.deletePodIdentityAssociationsReturnsOnCall[len(fake.deletePodIdentityAssociationsArgsForCall)]
+	fake.deletePodIdentityAssociationsArgsForCall = append(fake.deletePodIdentityAssociationsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []v1alpha5.PodIdentityAssociation
+	}{arg1, arg2Copy})
+	stub := fake.DeletePodIdentityAssociationsStub
+	fakeReturns := fake.deletePodIdentityAssociationsReturns
+	fake.recordInvocation("DeletePodIdentityAssociations", []interface{}{arg1, arg2Copy})
+	fake.deletePodIdentityAssociationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) DeletePodIdentityAssociationsCallCount() int {
+	fake.deletePodIdentityAssociationsMutex.RLock()
+	defer fake.deletePodIdentityAssociationsMutex.RUnlock()
+	return len(fake.deletePodIdentityAssociationsArgsForCall)
+}
+
+func (fake *FakeExecutor) DeletePodIdentityAssociationsCalls(stub func(context.Context, []v1alpha5.PodIdentityAssociation) error) {
+	fake.deletePodIdentityAssociationsMutex.Lock()
+	defer fake.deletePodIdentityAssociationsMutex.Unlock()
+	fake.DeletePodIdentityAssociationsStub = stub
+}
+
+func (fake *FakeExecutor) DeletePodIdentityAssociationsArgsForCall(i int) (context.Context, []v1alpha5.PodIdentityAssociation) {
+	fake.deletePodIdentityAssociationsMutex.RLock()
+	defer fake.deletePodIdentityAssociationsMutex.RUnlock()
+	argsForCall := fake.deletePodIdentityAssociationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeExecutor) DeletePodIdentityAssociationsReturns(result1 error) {
+	fake.deletePodIdentityAssociationsMutex.Lock()
+	defer fake.deletePodIdentityAssociationsMutex.Unlock()
+	fake.DeletePodIdentityAssociationsStub = nil
+	fake.deletePodIdentityAssociationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) DeletePodIdentityAssociationsReturnsOnCall(i int, result1 error) {
+	fake.deletePodIdentityAssociationsMutex.Lock()
+	defer fake.deletePodIdentityAssociationsMutex.Unlock()
+	fake.DeletePodIdentityAssociationsStub = nil
+	if fake.deletePodIdentityAssociationsReturnsOnCall == nil {
+		fake.deletePodIdentityAssociationsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePodIdentityAssociationsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) ScaleNodeGroup(arg1 context.Context, arg2 *v1alpha5.NodeGroupBase) error {
+	fake.scaleNodeGroupMutex.Lock()
+	ret, specificReturn := fake.scaleNodeGroupReturnsOnCall[len(fake.scaleNodeGroupArgsForCall)]
+	fake.scaleNodeGroupArgsForCall = append(fake.scaleNodeGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha5.NodeGroupBase
+	}{arg1, arg2})
+	stub := fake.ScaleNodeGroupStub
+	fakeReturns := fake.scaleNodeGroupReturns
+	fake.recordInvocation("ScaleNodeGroup", []interface{}{arg1, arg2})
+	fake.scaleNodeGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) ScaleNodeGroupCallCount() int {
+	fake.scaleNodeGroupMutex.RLock()
+	defer fake.scaleNodeGroupMutex.RUnlock()
+	return len(fake.scaleNodeGroupArgsForCall)
+}
+
+func (fake *FakeExecutor) ScaleNodeGroupCalls(stub func(context.Context, *v1alpha5.NodeGroupBase) error) {
+	fake.scaleNodeGroupMutex.Lock()
+	defer fake.scaleNodeGroupMutex.Unlock()
+	fake.ScaleNodeGroupStub = stub
+}
+
+func (fake *FakeExecutor) ScaleNodeGroupArgsForCall(i int) (context.Context, *v1alpha5.NodeGroupBase) {
+	fake.scaleNodeGroupMutex.RLock()
+	defer fake.scaleNodeGroupMutex.RUnlock()
+	argsForCall := fake.scaleNodeGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeExecutor) ScaleNodeGroupReturns(result1 error) {
+	fake.scaleNodeGroupMutex.Lock()
+	defer fake.scaleNodeGroupMutex.Unlock()
+	fake.ScaleNodeGroupStub = nil
+	fake.scaleNodeGroupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) ScaleNodeGroupReturnsOnCall(i int, result1 error) {
+	fake.scaleNodeGroupMutex.Lock()
+	defer fake.scaleNodeGroupMutex.Unlock()
+	fake.ScaleNodeGroupStub = nil
+	if fake.scaleNodeGroupReturnsOnCall == nil {
+		fake.scaleNodeGroupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.scaleNodeGroupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) UpdateAddon(arg1 context.Context, arg2 *v1alpha5.Addon) error {
+	fake.updateAddonMutex.Lock()
+	ret, specificReturn := fake.updateAddonReturnsOnCall[len(fake.updateAddonArgsForCall)]
+	fake.updateAddonArgsForCall = append(fake.updateAddonArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha5.Addon
+	}{arg1, arg2})
+	stub := fake.UpdateAddonStub
+	fakeReturns := fake.updateAddonReturns
+	fake.recordInvocation("UpdateAddon", []interface{}{arg1, arg2})
+	fake.updateAddonMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) UpdateAddonCallCount() int {
+	fake.updateAddonMutex.RLock()
+	defer fake.updateAddonMutex.RUnlock()
+	return len(fake.updateAddonArgsForCall)
+}
+
+func (fake *FakeExecutor) UpdateAddonCalls(stub func(context.Context, *v1alpha5.Addon) error) {
+	fake.updateAddonMutex.Lock()
+	defer fake.updateAddonMutex.Unlock()
+	fake.UpdateAddonStub = stub
+}
+
+func (fake *FakeExecutor) UpdateAddonArgsForCall(i int) (context.Context, *v1alpha5.Addon) {
+	fake.updateAddonMutex.RLock()
+	defer fake.updateAddonMutex.RUnlock()
+	argsForCall := fake.updateAddonArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeExecutor) UpdateAddonReturns(result1 error) {
+	fake.updateAddonMutex.Lock()
+	defer fake.updateAddonMutex.Unlock()
+	fake.UpdateAddonStub = nil
+	fake.updateAddonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) UpdateAddonReturnsOnCall(i int, result1 error) {
+	fake.updateAddonMutex.Lock()
+	defer fake.updateAddonMutex.Unlock()
+	fake.UpdateAddonStub = nil
+	if fake.updateAddonReturnsOnCall == nil {
+		fake.updateAddonReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateAddonReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) UpdateEndpointAccess(arg1 context.Context) error {
+	fake.updateEndpointAccessMutex.Lock()
+	ret, specificReturn := fake.updateEndpointAccessReturnsOnCall[len(fake.updateEndpointAccessArgsForCall)]
+	fake.updateEndpointAccessArgsForCall = append(fake.updateEndpointAccessArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.UpdateEndpointAccessStub
+	fakeReturns := fake.updateEndpointAccessReturns
+	fake.recordInvocation("UpdateEndpointAccess", []interface{}{arg1})
+	fake.updateEndpointAccessMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) UpdateEndpointAccessCallCount() int {
+	fake.updateEndpointAccessMutex.RLock()
+	defer fake.updateEndpointAccessMutex.RUnlock()
+	return len(fake.updateEndpointAccessArgsForCall)
+}
+
+func (fake *FakeExecutor) UpdateEndpointAccessCalls(stub func(context.Context) error) {
+	fake.updateEndpointAccessMutex.Lock()
+	defer fake.updateEndpointAccessMutex.Unlock()
+	fake.UpdateEndpointAccessStub = stub
+}
+
+func (fake *FakeExecutor) UpdateEndpointAccessArgsForCall(i int) context.Context {
+	fake.updateEndpointAccessMutex.RLock()
+	defer fake.updateEndpointAccessMutex.RUnlock()
+	argsForCall := fake.updateEndpointAccessArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeExecutor) UpdateEndpointAccessReturns(result1 error) {
+	fake.updateEndpointAccessMutex.Lock()
+	defer fake.updateEndpointAccessMutex.Unlock()
+	fake.UpdateEndpointAccessStub = nil
+	fake.updateEndpointAccessReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) UpdateEndpointAccessReturnsOnCall(i int, result1 error) {
+	fake.updateEndpointAccessMutex.Lock()
+	defer fake.updateEndpointAccessMutex.Unlock()
+	fake.UpdateEndpointAccessStub = nil
+	if fake.updateEndpointAccessReturnsOnCall == nil {
+		fake.updateEndpointAccessReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateEndpointAccessReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) UpdateLogging(arg1 context.Context) error {
+	fake.updateLoggingMutex.Lock()
+	ret, specificReturn := fake.updateLoggingReturnsOnCall[len(fake.updateLoggingArgsForCall)]
+	fake.updateLoggingArgsForCall = append(fake.updateLoggingArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.UpdateLoggingStub
+	fakeReturns := fake.updateLoggingReturns
+	fake.recordInvocation("UpdateLogging", []interface{}{arg1})
+	fake.updateLoggingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) UpdateLoggingCallCount() int {
+	fake.updateLoggingMutex.RLock()
+	defer fake.updateLoggingMutex.RUnlock()
+	return len(fake.updateLoggingArgsForCall)
+}
+
+func (fake *FakeExecutor) UpdateLoggingCalls(stub func(context.Context) error) {
+	fake.updateLoggingMutex.Lock()
+	defer fake.updateLoggingMutex.Unlock()
+	fake.UpdateLoggingStub = stub
+}
+
+func (fake *FakeExecutor) UpdateLoggingArgsForCall(i int) context.Context {
+	fake.updateLoggingMutex.RLock()
+	defer fake.updateLoggingMutex.RUnlock()
+	argsForCall := fake.updateLoggingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeExecutor) UpdateLoggingReturns(result1 error) {
+	fake.updateLoggingMutex.Lock()
+	defer fake.updateLoggingMutex.Unlock()
+	fake.UpdateLoggingStub = nil
+	fake.updateLoggingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) UpdateLoggingReturnsOnCall(i int, result1 error) {
+	fake.updateLoggingMutex.Lock()
+	defer fake.updateLoggingMutex.Unlock()
+	fake.UpdateLoggingStub = nil
+	if fake.updateLoggingReturnsOnCall == nil {
+		fake.updateLoggingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateLoggingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) UpdatePodIdentityAssociations(arg1 context.Context, arg2 []v1alpha5.PodIdentityAssociation) error {
+	var arg2Copy []v1alpha5.PodIdentityAssociation
+	if arg2 != nil {
+		arg2Copy = make([]v1alpha5.PodIdentityAssociation, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.updatePodIdentityAssociationsMutex.Lock()
+	ret, specificReturn := fake.updatePodIdentityAssociationsReturnsOnCall[len(fake.updatePodIdentityAssociationsArgsForCall)]
+	fake.updatePodIdentityAssociationsArgsForCall = append(fake.updatePodIdentityAssociationsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []v1alpha5.PodIdentityAssociation
+	}{arg1, arg2Copy})
+	stub := fake.UpdatePodIdentityAssociationsStub
+	fakeReturns := fake.updatePodIdentityAssociationsReturns
+	fake.recordInvocation("UpdatePodIdentityAssociations", []interface{}{arg1, arg2Copy})
+	fake.updatePodIdentityAssociationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) UpdatePodIdentityAssociationsCallCount() int {
+	fake.updatePodIdentityAssociationsMutex.RLock()
+	defer fake.updatePodIdentityAssociationsMutex.RUnlock()
+	return len(fake.updatePodIdentityAssociationsArgsForCall)
+}
+
+func (fake *FakeExecutor) UpdatePodIdentityAssociationsCalls(stub func(context.Context, []v1alpha5.PodIdentityAssociation) error) {
+	fake.updatePodIdentityAssociationsMutex.Lock()
+	defer fake.updatePodIdentityAssociationsMutex.Unlock()
+	fake.UpdatePodIdentityAssociationsStub = stub
+}
+
+func (fake *FakeExecutor) UpdatePodIdentityAssociationsArgsForCall(i int) (context.Context, []v1alpha5.PodIdentityAssociation) {
+	fake.updatePodIdentityAssociationsMutex.RLock()
+	defer fake.updatePodIdentityAssociationsMutex.RUnlock()
+	argsForCall := fake.updatePodIdentityAssociationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeExecutor) UpdatePodIdentityAssociationsReturns(result1 error) {
+	fake.updatePodIdentityAssociationsMutex.Lock()
+	defer fake.updatePodIdentityAssociationsMutex.Unlock()
+	fake.UpdatePodIdentityAssociationsStub = nil
+	fake.updatePodIdentityAssociationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) UpdatePodIdentityAssociationsReturnsOnCall(i int, result1 error) {
+	fake.updatePodIdentityAssociationsMutex.Lock()
+	defer fake.updatePodIdentityAssociationsMutex.Unlock()
+	fake.UpdatePodIdentityAssociationsStub = nil
+	if fake.updatePodIdentityAssociationsReturnsOnCall == nil {
+		fake.updatePodIdentityAssociationsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePodIdentityAssociationsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) UpdateZonalShiftConfig(arg1 context.Context) error {
+	fake.updateZonalShiftConfigMutex.Lock()
+	ret, specificReturn := fake.updateZonalShiftConfigReturnsOnCall[len(fake.updateZonalShiftConfigArgsForCall)]
+	fake.updateZonalShiftConfigArgsForCall = append(fake.updateZonalShiftConfigArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.UpdateZonalShiftConfigStub
+	fakeReturns := fake.updateZonalShiftConfigReturns
+	fake.recordInvocation("UpdateZonalShiftConfig", []interface{}{arg1})
+	fake.updateZonalShiftConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExecutor) UpdateZonalShiftConfigCallCount() int {
+	fake.updateZonalShiftConfigMutex.RLock()
+	defer fake.updateZonalShiftConfigMutex.RUnlock()
+	return len(fake.updateZonalShiftConfigArgsForCall)
+}
+
+func (fake *FakeExecutor) UpdateZonalShiftConfigCalls(stub func(context.Context) error) {
+	fake.updateZonalShiftConfigMutex.Lock()
+	defer fake.updateZonalShiftConfigMutex.Unlock()
+	fake.UpdateZonalShiftConfigStub = stub
+}
+
+func (fake *FakeExecutor) UpdateZonalShiftConfigArgsForCall(i int) context.Context {
+	fake.updateZonalShiftConfigMutex.RLock()
+	defer fake.updateZonalShiftConfigMutex.RUnlock()
+	argsForCall := fake.updateZonalShiftConfigArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeExecutor) UpdateZonalShiftConfigReturns(result1 error) {
+	fake.updateZonalShiftConfigMutex.Lock()
+	defer fake.updateZonalShiftConfigMutex.Unlock()
+	fake.UpdateZonalShiftConfigStub = nil
+	fake.updateZonalShiftConfigReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) UpdateZonalShiftConfigReturnsOnCall(i int, result1 error) {
+	fake.updateZonalShiftConfigMutex.Lock()
+	defer fake.updateZonalShiftConfigMutex.Unlock()
+	fake.UpdateZonalShiftConfigStub = nil
+	if fake.updateZonalShiftConfigReturnsOnCall == nil {
+		fake.updateZonalShiftConfigReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateZonalShiftConfigReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExecutor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createAccessEntriesMutex.RLock()
+	defer fake.createAccessEntriesMutex.RUnlock()
+	fake.createAddonMutex.RLock()
+	defer fake.createAddonMutex.RUnlock()
+	fake.createFargateProfilesMutex.RLock()
+	defer fake.createFargateProfilesMutex.RUnlock()
+	fake.createNodeGroupsMutex.RLock()
+	defer fake.createNodeGroupsMutex.RUnlock()
+	fake.createPodIdentityAssociationsMutex.RLock()
+	defer fake.createPodIdentityAssociationsMutex.RUnlock()
+	fake.deleteAccessEntriesMutex.RLock()
+	defer fake.deleteAccessEntriesMutex.RUnlock()
+	fake.deleteAddonMutex.RLock()
+	defer fake.deleteAddonMutex.RUnlock()
+	fake.deleteFargateProfileMutex.RLock()
+	defer fake.deleteFargateProfileMutex.RUnlock()
+	fake.deleteNodeGroupsMutex.RLock()
+	defer fake.deleteNodeGroupsMutex.RUnlock()
+	fake.deletePodIdentityAssociationsMutex.RLock()
+	defer fake.deletePodIdentityAssociationsMutex.RUnlock()
+	fake.scaleNodeGroupMutex.RLock()
+	defer fake.scaleNodeGroupMutex.RUnlock()
+	fake.updateAddonMutex.RLock()
+	defer fake.updateAddonMutex.RUnlock()
+	fake.updateEndpointAccessMutex.RLock()
+	defer fake.updateEndpointAccessMutex.RUnlock()
+	fake.updateLoggingMutex.RLock()
+	defer fake.updateLoggingMutex.RUnlock()
+	fake.updatePodIdentityAssociationsMutex.RLock()
+	defer fake.updatePodIdentityAssociationsMutex.RUnlock()
+	fake.updateZonalShiftConfigMutex.RLock()
+	defer fake.updateZonalShiftConfigMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeExecutor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ apply.Executor = new(FakeExecutor)
@@ -0,0 +1,487 @@
+package apply
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// ResourceKind identifies the kind of resource a Change applies to.
+type ResourceKind string
+
+const (
+	KindNodeGroup              ResourceKind = "nodegroup"
+	KindManagedNodeGroup       ResourceKind = "managednodegroup"
+	KindAddon                  ResourceKind = "addon"
+	KindAccessEntry            ResourceKind = "accessentry"
+	KindPodIdentityAssociation ResourceKind = "podidentityassociation"
+	KindFargateProfile         ResourceKind = "fargateprofile"
+	KindLogging                ResourceKind = "logging"
+	KindEndpointAccess         ResourceKind = "endpointaccess"
+	KindZonalShift             ResourceKind = "zonalshift"
+)
+
+// Action is the operation required to reconcile a resource.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// FieldChange describes a single field that differs between the config file and the cluster.
+type FieldChange struct {
+	Field   string `json:"field"`
+	Current string `json:"current,omitempty"`
+	Desired string `json:"desired,omitempty"`
+	// Immutable is set for fields that cannot be updated in place
+	Immutable bool `json:"immutable,omitempty"`
+}
+
+// Change is a single action required to bring a resource in line with the config file.
+type Change struct {
+	Kind   ResourceKind  `json:"kind"`
+	Name   string        `json:"name"`
+	Action Action        `json:"action"`
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// HasMutableFields reports whether the change contains at least one field that can be updated in place.
+func (c Change) HasMutableFields() bool {
+	for _, f := range c.Fields {
+		if !f.Immutable {
+			return true
+		}
+	}
+	return false
+}
+
+// Plan is the set of changes required to reconcile a cluster with its config file.
+type Plan struct {
+	ClusterName string   `json:"clusterName"`
+	Changes     []Change `json:"changes"`
+}
+
+// HasChanges reports whether the plan contains any change.
+func (p *Plan) HasChanges() bool {
+	return len(p.Changes) > 0
+}
+
+// Select returns all changes of the given kind and action.
+func (p *Plan) Select(kind ResourceKind, action Action) []Change {
+	var changes []Change
+	for _, c := range p.Changes {
+		if c.Kind == kind && c.Action == action {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// LiveState holds the state of the resources reconciled by apply, as reported by AWS.
+type LiveState struct {
+	NodeGroups              []*nodegroup.Summary
+	Addons                  []addon.Summary
+	AccessEntries           []accessentry.Summary
+	PodIdentityAssociations []podidentityassociation.Summary
+	FargateProfiles         []*api.FargateProfile
+	EnabledLogTypes         []string
+	EndpointPrivateAccess   bool
+	EndpointPublicAccess    bool
+	ZonalShiftEnabled       *bool
+	// CallerARN is the ARN of the IAM identity running apply
+	CallerARN string
+}
+
+// ComputePlan compares the desired cluster config with the live state and returns the changes needed
+// to reconcile them. Resources that only exist in the cluster are reported as deletions; it is up to
+// the caller to decide whether those are acted upon.
+func ComputePlan(cfg *api.ClusterConfig, live *LiveState) *Plan {
+	plan := &Plan{
+		ClusterName: cfg.Metadata.Name,
+	}
+	plan.Changes = append(plan.Changes, diffClusterSettings(cfg, live)...)
+	plan.Changes = append(plan.Changes, diffNodeGroups(cfg, live)...)
+	plan.Changes = append(plan.Changes, diffAddons(cfg, live)...)
+	plan.Changes = append(plan.Changes, diffAccessEntries(cfg, live)...)
+	plan.Changes = append(plan.Changes, diffPodIdentityAssociations(cfg, live)...)
+	plan.Changes = append(plan.Changes, diffFargateProfiles(cfg, live)...)
+	return plan
+}
+
+func diffClusterSettings(cfg *api.ClusterConfig, live *LiveState) []Change {
+	var changes []Change
+
+	desiredLogTypes := sets.New[string]()
+	if cfg.HasClusterCloudWatchLogging() {
+		desiredLogTypes.Insert(cfg.CloudWatch.ClusterLogging.EnableTypes...)
+	}
+	currentLogTypes := sets.New[string](live.EnabledLogTypes...)
+	if !desiredLogTypes.Equal(currentLogTypes) {
+		changes = append(changes, Change{
+			Kind:   KindLogging,
+			Name:   cfg.Metadata.Name,
+			Action: ActionUpdate,
+			Fields: []FieldChange{
+				{
+					Field:   "cloudWatch.clusterLogging.enableTypes",
+					Current: strings.Join(sets.List(currentLogTypes), ","),
+					Desired: strings.Join(sets.List(desiredLogTypes), ","),
+				},
+			},
+		})
+	}
+
+	if cfg.VPC != nil && cfg.VPC.ClusterEndpoints != nil {
+		var fields []FieldChange
+		endpoints := cfg.VPC.ClusterEndpoints
+		if endpoints.PrivateAccess != nil && *endpoints.PrivateAccess != live.EndpointPrivateAccess {
+			fields = append(fields, boolFieldChange("vpc.clusterEndpoints.privateAccess", live.EndpointPrivateAccess, *endpoints.PrivateAccess))
+		}
+		if endpoints.PublicAccess != nil && *endpoints.PublicAccess != live.EndpointPublicAccess {
+			fields = append(fields, boolFieldChange("vpc.clusterEndpoints.publicAccess", live.EndpointPublicAccess, *endpoints.PublicAccess))
+		}
+		if len(fields) > 0 {
+			changes = append(changes, Change{
+				Kind:   KindEndpointAccess,
+				Name:   cfg.Metadata.Name,
+				Action: ActionUpdate,
+				Fields: fields,
+			})
+		}
+	}
+
+	if cfg.ZonalShiftConfig != nil && cfg.ZonalShiftConfig.Enabled != nil {
+		desired := *cfg.ZonalShiftConfig.Enabled
+		current := api.IsEnabled(live.ZonalShiftEnabled)
+		if desired != current {
+			changes = append(changes, Change{
+				Kind:   KindZonalShift,
+				Name:   cfg.Metadata.Name,
+				Action: ActionUpdate,
+				Fields: []FieldChange{boolFieldChange("zonalShiftConfig.enabled", current, desired)},
+			})
+		}
+	}
+
+	return changes
+}
+
+func diffNodeGroups(cfg *api.ClusterConfig, live *LiveState) []Change {
+	var changes []Change
+	liveByName := map[string]*nodegroup.Summary{}
+	for _, ng := range live.NodeGroups {
+		liveByName[ng.Name] = ng
+	}
+
+	desiredNames := sets.New[string]()
+	diffNodeGroup := func(kind ResourceKind, ng *api.NodeGroupBase) {
+		desiredNames.Insert(ng.Name)
+		current, ok := liveByName[ng.Name]
+		if !ok {
+			changes = append(changes, Change{Kind: kind, Name: ng.Name, Action: ActionCreate})
+			return
+		}
+		var fields []FieldChange
+		if ng.ScalingConfig != nil {
+			fields = appendIntFieldChange(fields, "minSize", current.MinSize, ng.MinSize)
+			fields = appendIntFieldChange(fields, "maxSize", current.MaxSize, ng.MaxSize)
+			fields = appendIntFieldChange(fields, "desiredCapacity", current.DesiredCapacity, ng.DesiredCapacity)
+		}
+		if ng.InstanceType != "" && current.InstanceType != "" && ng.InstanceType != current.InstanceType {
+			fields = append(fields, FieldChange{
+				Field:     "instanceType",
+				Current:   current.InstanceType,
+				Desired:   ng.InstanceType,
+				Immutable: true,
+			})
+		}
+		if len(fields) > 0 {
+			changes = append(changes, Change{Kind: kind, Name: ng.Name, Action: ActionUpdate, Fields: fields})
+		}
+	}
+
+	for _, ng := range cfg.NodeGroups {
+		diffNodeGroup(KindNodeGroup, ng.NodeGroupBase)
+	}
+	for _, ng := range cfg.ManagedNodeGroups {
+		diffNodeGroup(KindManagedNodeGroup, ng.NodeGroupBase)
+	}
+
+	for _, ng := range live.NodeGroups {
+		if desiredNames.Has(ng.Name) {
+			continue
+		}
+		kind := KindNodeGroup
+		if ng.NodeGroupType == api.NodeGroupTypeManaged {
+			kind = KindManagedNodeGroup
+		}
+		changes = append(changes, Change{Kind: kind, Name: ng.Name, Action: ActionDelete})
+	}
+	return changes
+}
+
+func diffAddons(cfg *api.ClusterConfig, live *LiveState) []Change {
+	var changes []Change
+	liveByName := map[string]addon.Summary{}
+	for _, a := range live.Addons {
+		liveByName[strings.ToLower(a.Name)] = a
+	}
+
+	desiredNames := sets.New[string]()
+	for _, a := range cfg.Addons {
+		desiredNames.Insert(a.CanonicalName())
+		current, ok := liveByName[a.CanonicalName()]
+		if !ok {
+			changes = append(changes, Change{Kind: KindAddon, Name: a.Name, Action: ActionCreate})
+			continue
+		}
+		if fields := diffAddon(cfg, a, current); len(fields) > 0 {
+			changes = append(changes, Change{Kind: KindAddon, Name: a.Name, Action: ActionUpdate, Fields: fields})
+		}
+	}
+
+	for _, a := range live.Addons {
+		// default addons provide pod networking and DNS, pruning them would break the cluster
+		if !desiredNames.Has(strings.ToLower(a.Name)) && !isDefaultAddon(a.Name) {
+			changes = append(changes, Change{Kind: KindAddon, Name: a.Name, Action: ActionDelete})
+		}
+	}
+	return changes
+}
+
+func isDefaultAddon(name string) bool {
+	for addonName, addonInfo := range api.KnownAddons {
+		if addonInfo.IsDefault && strings.EqualFold(addonName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func diffAddon(cfg *api.ClusterConfig, desired *api.Addon, current addon.Summary) []FieldChange {
+	var fields []FieldChange
+	for _, d := range addon.DiffSummary(cfg, desired, current) {
+		fields = append(fields, FieldChange{Field: d.Field, Current: d.Live, Desired: d.Desired})
+	}
+	return fields
+}
+
+// isProtectedAccessEntry reports whether an access entry must never be deleted by apply: entries for nodes and
+// Fargate pods are created by eksctl and EKS for nodegroups and Fargate profiles, and the entry of the principal
+// running apply, normally the cluster creator, grants the access apply itself relies on.
+func isProtectedAccessEntry(entry accessentry.Summary, callerARN string) bool {
+	return accessentry.IsNodeType(entry.Type) || isSamePrincipal(entry.PrincipalARN, callerARN)
+}
+
+// isSamePrincipal reports whether principalARN, the IAM principal of an access entry, refers to the identity
+// identified by callerARN; assumed-role ARNs are matched against the role they were assumed from.
+func isSamePrincipal(principalARN, callerARN string) bool {
+	if principalARN == "" || callerARN == "" {
+		return false
+	}
+	if principalARN == callerARN {
+		return true
+	}
+	principal, err := arn.Parse(principalARN)
+	if err != nil {
+		return false
+	}
+	caller, err := arn.Parse(callerARN)
+	if err != nil || caller.AccountID != principal.AccountID {
+		return false
+	}
+	callerParts := strings.Split(caller.Resource, "/")
+	if caller.Service != "sts" || callerParts[0] != "assumed-role" || len(callerParts) < 3 {
+		return false
+	}
+	// role paths are not part of assumed-role ARNs
+	principalParts := strings.Split(principal.Resource, "/")
+	return principalParts[0] == "role" && principalParts[len(principalParts)-1] == callerParts[1]
+}
+
+func diffAccessEntries(cfg *api.ClusterConfig, live *LiveState) []Change {
+	var changes []Change
+	liveByARN := map[string]accessentry.Summary{}
+	for _, e := range live.AccessEntries {
+		liveByARN[e.PrincipalARN] = e
+	}
+
+	desiredARNs := sets.New[string]()
+	if cfg.AccessConfig != nil {
+		for _, e := range cfg.AccessConfig.AccessEntries {
+			arn := e.PrincipalARN.String()
+			desiredARNs.Insert(arn)
+			current, ok := liveByARN[arn]
+			if !ok {
+				changes = append(changes, Change{Kind: KindAccessEntry, Name: arn, Action: ActionCreate})
+				continue
+			}
+			var fields []FieldChange
+			if len(e.KubernetesGroups) > 0 && !sets.New[string](e.KubernetesGroups...).Equal(sets.New[string](current.KubernetesGroups...)) {
+				fields = append(fields, FieldChange{
+					Field:   "kubernetesGroups",
+					Current: strings.Join(sets.List(sets.New[string](current.KubernetesGroups...)), ","),
+					Desired: strings.Join(sets.List(sets.New[string](e.KubernetesGroups...)), ","),
+				})
+			}
+			currentPolicies, desiredPolicies := accessPolicyKeys(current.AccessPolicies), accessPolicyKeys(e.AccessPolicies)
+			if !currentPolicies.Equal(desiredPolicies) {
+				fields = append(fields, FieldChange{
+					Field:   "accessPolicies",
+					Current: strings.Join(sets.List(currentPolicies), ","),
+					Desired: strings.Join(sets.List(desiredPolicies), ","),
+				})
+			}
+			if len(fields) > 0 {
+				changes = append(changes, Change{Kind: KindAccessEntry, Name: arn, Action: ActionUpdate, Fields: fields})
+			}
+		}
+	}
+
+	for _, e := range live.AccessEntries {
+		if !desiredARNs.Has(e.PrincipalARN) && !isProtectedAccessEntry(e, live.CallerARN) {
+			changes = append(changes, Change{Kind: KindAccessEntry, Name: e.PrincipalARN, Action: ActionDelete})
+		}
+	}
+	return changes
+}
+
+func accessPolicyKeys(policies []api.AccessPolicy) sets.Set[string] {
+	keys := sets.New[string]()
+	for _, p := range policies {
+		namespaces := slices.Clone(p.AccessScope.Namespaces)
+		sort.Strings(namespaces)
+		key := fmt.Sprintf("%s[%s", p.PolicyARN.String(), p.AccessScope.Type)
+		if len(namespaces) > 0 {
+			key += ":" + strings.Join(namespaces, ";")
+		}
+		keys.Insert(key + "]")
+	}
+	return keys
+}
+
+func diffPodIdentityAssociations(cfg *api.ClusterConfig, live *LiveState) []Change {
+	var changes []Change
+	liveByName := map[string]podidentityassociation.Summary{}
+	for _, p := range live.PodIdentityAssociations {
+		// associations owned by addons are reconciled as part of the addon
+		if p.OwnerARN != "" {
+			continue
+		}
+		liveByName[fmt.Sprintf("%s/%s", p.Namespace, p.ServiceAccountName)] = p
+	}
+
+	desiredNames := sets.New[string]()
+	if cfg.IAM != nil {
+		for _, p := range cfg.IAM.PodIdentityAssociations {
+			name := p.NameString()
+			desiredNames.Insert(name)
+			current, ok := liveByName[name]
+			if !ok {
+				changes = append(changes, Change{Kind: KindPodIdentityAssociation, Name: name, Action: ActionCreate})
+				continue
+			}
+			if p.RoleARN != "" && p.RoleARN != current.RoleARN {
+				changes = append(changes, Change{
+					Kind:   KindPodIdentityAssociation,
+					Name:   name,
+					Action: ActionUpdate,
+					Fields: []FieldChange{{Field: "roleARN", Current: current.RoleARN, Desired: p.RoleARN}},
+				})
+			}
+		}
+	}
+
+	for _, p := range live.PodIdentityAssociations {
+		name := fmt.Sprintf("%s/%s", p.Namespace, p.ServiceAccountName)
+		if _, ok := liveByName[name]; ok && !desiredNames.Has(name) {
+			changes = append(changes, Change{Kind: KindPodIdentityAssociation, Name: name, Action: ActionDelete})
+		}
+	}
+	return changes
+}
+
+func diffFargateProfiles(cfg *api.ClusterConfig, live *LiveState) []Change {
+	var changes []Change
+	liveByName := map[string]*api.FargateProfile{}
+	for _, fp := range live.FargateProfiles {
+		liveByName[fp.Name] = fp
+	}
+
+	desiredNames := sets.New[string]()
+	for _, fp := range cfg.FargateProfiles {
+		desiredNames.Insert(fp.Name)
+		current, ok := liveByName[fp.Name]
+		if !ok {
+			changes = append(changes, Change{Kind: KindFargateProfile, Name: fp.Name, Action: ActionCreate})
+			continue
+		}
+		currentSelectors, desiredSelectors := fargateSelectorKeys(current.Selectors), fargateSelectorKeys(fp.Selectors)
+		if !slices.Equal(currentSelectors, desiredSelectors) {
+			changes = append(changes, Change{
+				Kind:   KindFargateProfile,
+				Name:   fp.Name,
+				Action: ActionUpdate,
+				Fields: []FieldChange{{
+					Field:     "selectors",
+					Current:   strings.Join(currentSelectors, ","),
+					Desired:   strings.Join(desiredSelectors, ","),
+					Immutable: true,
+				}},
+			})
+		}
+	}
+
+	for _, fp := range live.FargateProfiles {
+		if !desiredNames.Has(fp.Name) {
+			changes = append(changes, Change{Kind: KindFargateProfile, Name: fp.Name, Action: ActionDelete})
+		}
+	}
+	return changes
+}
+
+func fargateSelectorKeys(selectors []api.FargateProfileSelector) []string {
+	var keys []string
+	for _, s := range selectors {
+		var labels []string
+		for k, v := range s.Labels {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+		keys = append(keys, fmt.Sprintf("%s{%s}", s.Namespace, strings.Join(labels, ";")))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func boolFieldChange(field string, current, desired bool) FieldChange {
+	return FieldChange{
+		Field:   field,
+		Current: strconv.FormatBool(current),
+		Desired: strconv.FormatBool(desired),
+	}
+}
+
+func appendIntFieldChange(fields []FieldChange, field string, current int, desired *int) []FieldChange {
+	if desired == nil || *desired == current {
+		return fields
+	}
+	return append(fields, FieldChange{
+		Field:   field,
+		Current: strconv.Itoa(current),
+		Desired: strconv.Itoa(*desired),
+	})
+}
@@ -0,0 +1,149 @@
+package apply_test
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/apply"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("ComputePlan", func() {
+	var (
+		cfg  *api.ClusterConfig
+		live *apply.LiveState
+	)
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "cluster"
+		live = &apply.LiveState{}
+	})
+
+	It("returns no changes when the cluster matches the config", func() {
+		ng := api.NewManagedNodeGroup()
+		ng.Name = "mng"
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{ng}
+		cfg.Addons = []*api.Addon{{Name: "vpc-cni", Version: "1.18.0"}}
+		live.NodeGroups = []*nodegroup.Summary{{Name: "mng", NodeGroupType: api.NodeGroupTypeManaged}}
+		live.Addons = []addon.Summary{{Name: "vpc-cni", Version: "v1.18.0-eksbuild.1"}}
+
+		Expect(apply.ComputePlan(cfg, live).HasChanges()).To(BeFalse())
+	})
+
+	It("reports nodegroups to create, scale and delete", func() {
+		existing := api.NewManagedNodeGroup()
+		existing.Name = "existing"
+		existing.ScalingConfig = &api.ScalingConfig{DesiredCapacity: aws.Int(3)}
+		existing.InstanceType = "m5.xlarge"
+		added := api.NewManagedNodeGroup()
+		added.Name = "added"
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{existing, added}
+		live.NodeGroups = []*nodegroup.Summary{
+			{Name: "existing", DesiredCapacity: 2, InstanceType: "m5.large", NodeGroupType: api.NodeGroupTypeManaged},
+			{Name: "removed", NodeGroupType: api.NodeGroupTypeUnmanaged},
+		}
+
+		plan := apply.ComputePlan(cfg, live)
+		Expect(plan.Changes).To(ConsistOf(
+			apply.Change{
+				Kind:   apply.KindManagedNodeGroup,
+				Name:   "existing",
+				Action: apply.ActionUpdate,
+				Fields: []apply.FieldChange{
+					{Field: "desiredCapacity", Current: "2", Desired: "3"},
+					{Field: "instanceType", Current: "m5.large", Desired: "m5.xlarge", Immutable: true},
+				},
+			},
+			apply.Change{Kind: apply.KindManagedNodeGroup, Name: "added", Action: apply.ActionCreate},
+			apply.Change{Kind: apply.KindNodeGroup, Name: "removed", Action: apply.ActionDelete},
+		))
+	})
+
+	It("compares addon configuration values semantically", func() {
+		cfg.Addons = []*api.Addon{{Name: "coredns", ConfigurationValues: "replicaCount: 3"}}
+		live.Addons = []addon.Summary{{Name: "coredns", ConfigurationValues: `{"replicaCount": 3}`}}
+		Expect(apply.ComputePlan(cfg, live).HasChanges()).To(BeFalse())
+
+		live.Addons[0].ConfigurationValues = `{"replicaCount": 2}`
+		changes := apply.ComputePlan(cfg, live).Select(apply.KindAddon, apply.ActionUpdate)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Fields).To(ConsistOf(HaveField("Field", "configurationValues")))
+	})
+
+	It("expects custom networking in the vpc-cni configuration values when vpc.podSubnets is set", func() {
+		cfg.VPC.PodSubnets = &api.PodSubnets{}
+		cfg.Addons = []*api.Addon{{Name: "vpc-cni"}}
+		live.Addons = []addon.Summary{{
+			Name:                "vpc-cni",
+			ConfigurationValues: `{"env":{"AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG":"true","ENI_CONFIG_LABEL_DEF":"topology.kubernetes.io/zone"}}`,
+		}}
+		Expect(apply.ComputePlan(cfg, live).HasChanges()).To(BeFalse())
+	})
+
+	It("reports logging and endpoint changes", func() {
+		cfg.CloudWatch = &api.ClusterCloudWatch{
+			ClusterLogging: &api.ClusterCloudWatchLogging{EnableTypes: []string{"api", "audit"}},
+		}
+		cfg.VPC.ClusterEndpoints = &api.ClusterEndpoints{PublicAccess: api.Enabled(), PrivateAccess: api.Enabled()}
+		live.EnabledLogTypes = []string{"api"}
+		live.EndpointPublicAccess = true
+
+		plan := apply.ComputePlan(cfg, live)
+		Expect(plan.Select(apply.KindLogging, apply.ActionUpdate)).To(ConsistOf(HaveField("Fields", ConsistOf(apply.FieldChange{
+			Field:   "cloudWatch.clusterLogging.enableTypes",
+			Current: "api",
+			Desired: "api,audit",
+		}))))
+		Expect(plan.Select(apply.KindEndpointAccess, apply.ActionUpdate)).To(ConsistOf(HaveField("Fields", ConsistOf(apply.FieldChange{
+			Field:   "vpc.clusterEndpoints.privateAccess",
+			Current: "false",
+			Desired: "true",
+		}))))
+	})
+
+	It("does not delete access entries for nodes, Fargate pods or the caller", func() {
+		live.CallerARN = "arn:aws:sts::111122223333:assumed-role/cluster-creator/session"
+		live.AccessEntries = []accessentry.Summary{
+			{PrincipalARN: "arn:aws:iam::111122223333:role/node-role", Type: "EC2_LINUX"},
+			{PrincipalARN: "arn:aws:iam::111122223333:role/windows-node-role", Type: "EC2_WINDOWS"},
+			{PrincipalARN: "arn:aws:iam::111122223333:role/pod-execution-role", Type: "FARGATE_LINUX"},
+			{PrincipalARN: "arn:aws:iam::111122223333:role/admin/cluster-creator", Type: "STANDARD"},
+			{PrincipalARN: "arn:aws:iam::111122223333:role/admin", Type: "STANDARD", KubernetesGroups: []string{"admins"}},
+			{PrincipalARN: "arn:aws:iam::444455556666:role/cluster-creator", Type: "STANDARD"},
+		}
+
+		Expect(apply.ComputePlan(cfg, live).Changes).To(ConsistOf(
+			apply.Change{
+				Kind:   apply.KindAccessEntry,
+				Name:   "arn:aws:iam::111122223333:role/admin",
+				Action: apply.ActionDelete,
+			},
+			apply.Change{
+				Kind:   apply.KindAccessEntry,
+				Name:   "arn:aws:iam::444455556666:role/cluster-creator",
+				Action: apply.ActionDelete,
+			},
+		))
+	})
+
+	It("does not delete default addons", func() {
+		live.Addons = []addon.Summary{
+			{Name: "vpc-cni"},
+			{Name: "coredns"},
+			{Name: "kube-proxy"},
+			{Name: "metrics-server"},
+			{Name: "aws-ebs-csi-driver"},
+		}
+
+		Expect(apply.ComputePlan(cfg, live).Changes).To(ConsistOf(apply.Change{
+			Kind:   apply.KindAddon,
+			Name:   "aws-ebs-csi-driver",
+			Action: apply.ActionDelete,
+		}))
+	})
+})
@@ -0,0 +1,91 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	"github.com/weaveworks/eksctl/pkg/accessentry"
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// NodeGroupLister lists managed and self-managed nodegroups.
+type NodeGroupLister interface {
+	GetAll(ctx context.Context) ([]*nodegroup.Summary, error)
+}
+
+// AddonLister lists EKS addons.
+type AddonLister interface {
+	GetAll(ctx context.Context) ([]addon.Summary, error)
+}
+
+// PodIdentityAssociationLister lists pod identity associations.
+type PodIdentityAssociationLister interface {
+	GetPodIdentityAssociations(ctx context.Context, namespace, serviceAccountName string) ([]podidentityassociation.Summary, error)
+}
+
+// FargateProfileLister lists Fargate profiles.
+type FargateProfileLister interface {
+	ReadProfiles(ctx context.Context) ([]*api.FargateProfile, error)
+}
+
+// StateCollector fetches the live state of all resources reconciled by apply.
+type StateCollector struct {
+	NodeGroupLister              NodeGroupLister
+	AddonLister                  AddonLister
+	AccessEntryGetter            accessentryactions.GetterInterface
+	PodIdentityAssociationLister PodIdentityAssociationLister
+	FargateProfileLister         FargateProfileLister
+	// CallerARN is the ARN of the IAM identity running apply, its access entry is never pruned
+	CallerARN string
+}
+
+// Collect returns the live state of the cluster; cluster must be the output of a fresh DescribeCluster call.
+func (s *StateCollector) Collect(ctx context.Context, cluster *ekstypes.Cluster) (*LiveState, error) {
+	live := &LiveState{
+		CallerARN: s.CallerARN,
+	}
+
+	if cluster.Logging != nil {
+		for _, logSetup := range cluster.Logging.ClusterLogging {
+			if !api.IsEnabled(logSetup.Enabled) {
+				continue
+			}
+			for _, logType := range logSetup.Types {
+				live.EnabledLogTypes = append(live.EnabledLogTypes, string(logType))
+			}
+		}
+	}
+	if vpcConfig := cluster.ResourcesVpcConfig; vpcConfig != nil {
+		live.EndpointPrivateAccess = vpcConfig.EndpointPrivateAccess
+		live.EndpointPublicAccess = vpcConfig.EndpointPublicAccess
+	}
+	if cluster.ZonalShiftConfig != nil {
+		live.ZonalShiftEnabled = cluster.ZonalShiftConfig.Enabled
+	}
+
+	var err error
+	if live.NodeGroups, err = s.NodeGroupLister.GetAll(ctx); err != nil {
+		return nil, fmt.Errorf("listing nodegroups: %w", err)
+	}
+	if live.Addons, err = s.AddonLister.GetAll(ctx); err != nil {
+		return nil, fmt.Errorf("listing addons: %w", err)
+	}
+	if cluster.AccessConfig != nil && accessentry.IsEnabled(cluster.AccessConfig.AuthenticationMode) {
+		if live.AccessEntries, err = s.AccessEntryGetter.Get(ctx, api.ARN{}); err != nil {
+			return nil, fmt.Errorf("listing access entries: %w", err)
+		}
+	}
+	if live.PodIdentityAssociations, err = s.PodIdentityAssociationLister.GetPodIdentityAssociations(ctx, "", ""); err != nil {
+		return nil, fmt.Errorf("listing pod identity associations: %w", err)
+	}
+	if live.FargateProfiles, err = s.FargateProfileLister.ReadProfiles(ctx); err != nil {
+		return nil, fmt.Errorf("listing Fargate profiles: %w", err)
+	}
+	return live, nil
+}
@@ -12,11 +12,16 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/automode"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 )
 
 type Cluster interface {
 	Upgrade(ctx context.Context, dryRun bool) error
-	Delete(ctx context.Context, waitInterval, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int) error
+	// UpgradeTasks returns the tasks that Upgrade runs, without upgrading anything
+	UpgradeTasks(ctx context.Context) (*tasks.TaskTree, error)
+	Delete(ctx context.Context, waitInterval, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int, journal *tasks.Journal) error
+	// DeleteTasks returns the tasks that Delete runs once all nodegroups have been drained, without deleting anything
+	DeleteTasks(ctx context.Context, waitInterval time.Duration, wait, force bool) (*tasks.TaskTree, error)
 }
 
 func New(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider) (Cluster, error) {
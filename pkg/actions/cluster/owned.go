@@ -75,7 +75,40 @@ func (c *OwnedCluster) Upgrade(ctx context.Context, dryRun bool) error {
 	return nil
 }
 
-func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int) error {
+// UpgradeTasks returns the tasks that Upgrade runs, without upgrading anything.
+func (c *OwnedCluster) UpgradeTasks(ctx context.Context) (*tasks.TaskTree, error) {
+	if err := vpc.UseFromClusterStack(ctx, c.ctl.AWSProvider, c.clusterStack, c.cfg, true); err != nil {
+		return nil, fmt.Errorf("getting VPC configuration for cluster %q: %w", c.cfg.Metadata.Name, err)
+	}
+
+	taskTree := &tasks.TaskTree{Parallel: false}
+	controlPlaneTask, err := upgradeControlPlaneTask(ctx, c.cfg, c.ctl)
+	if err != nil {
+		return nil, err
+	}
+	if controlPlaneTask != nil {
+		taskTree.Append(controlPlaneTask)
+	}
+
+	stackUpdateRequired, err := c.stackManager.AppendNewClusterStackResource(ctx, false, true)
+	if err != nil {
+		return nil, err
+	}
+	if stackUpdateRequired {
+		stackName := c.stackManager.MakeClusterStackName()
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("update cluster stack %q", stackName),
+			Doer: func() error {
+				_, err := c.stackManager.AppendNewClusterStackResource(ctx, false, false)
+				return err
+			},
+			Resource: tasks.ResourceInfo{Kind: tasks.KindCluster, StackName: stackName},
+		})
+	}
+	return taskTree, nil
+}
+
+func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int, journal *tasks.Journal) error {
 	clusterOperable, err := c.ctl.CanOperate(c.cfg)
 	if err != nil {
 		logger.Debug("failed to check if cluster is operable: %v", err)
@@ -119,6 +152,63 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 		}
 	}
 
+	tasks, err := c.newDeleteTasks(ctx, allStacks, clusterOperable, clientSet, wait, force)
+	if err != nil {
+		return err
+	}
+
+	if tasks.Len() == 0 {
+		logger.Warning("no cluster resources were found for %q", c.cfg.Metadata.Name)
+		return nil
+	}
+
+	journal.Track(tasks, "cluster")
+	logger.Info(tasks.Describe())
+	if errs := tasks.DoAllSync(); len(errs) > 0 {
+		return handleErrors(errs, "cluster with nodegroup(s)")
+	}
+
+	if err := c.deleteKarpenterStackIfExists(ctx); err != nil {
+		return err
+	}
+
+	if err := checkForUndeletedStacks(ctx, c.stackManager); err != nil {
+		return err
+	}
+	if err := c.autoModeDeleter.DeleteIfRequired(ctx); err != nil {
+		return err
+	}
+
+	if err := journal.Remove(); err != nil {
+		logger.Warning(err.Error())
+	}
+	logger.Success("all cluster resources were deleted")
+
+	return nil
+}
+
+// DeleteTasks returns the tasks that Delete runs once all nodegroups have been drained, without deleting anything.
+func (c *OwnedCluster) DeleteTasks(ctx context.Context, _ time.Duration, wait, force bool) (*tasks.TaskTree, error) {
+	clusterOperable, err := c.ctl.CanOperate(c.cfg)
+	if err != nil {
+		logger.Debug("failed to check if cluster is operable: %v", err)
+	}
+
+	allStacks, err := c.stackManager.ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var clientSet kubernetes.Interface
+	if clusterOperable {
+		if clientSet, err = c.newClientSet(); err != nil && !force {
+			return nil, err
+		}
+	}
+	return c.newDeleteTasks(ctx, allStacks, clusterOperable, clientSet, wait, force)
+}
+
+func (c *OwnedCluster) newDeleteTasks(ctx context.Context, allStacks []manager.NodeGroupStack, clusterOperable bool, clientSet kubernetes.Interface, wait, force bool) (*tasks.TaskTree, error) {
 	newOIDCManager := func() (*iamoidc.OpenIDConnectManager, error) {
 		return c.ctl.NewOpenIDConnectManager(ctx, c.cfg)
 	}
@@ -127,7 +217,7 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 		if !clusterOperable {
 			return &tasks.TaskTree{}, nil
 		}
-		clientSet, err = c.newClientSet()
+		clientSet, err := c.newClientSet()
 		if err != nil {
 			if force {
 				logger.Warning("error occurred while deleting IAM Role stacks for pod identity associations: %v; force=true so proceeding with cluster deletion", err)
@@ -139,7 +229,7 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 			DeleteTasks(ctx, []podidentityassociation.Identifier{})
 	}
 
-	tasks, err := c.stackManager.NewTasksToDeleteClusterWithNodeGroups(ctx, c.clusterStack, allStacks, clusterOperable, newOIDCManager, newTasksToDeleteAddonIAM, newTasksToDeletePodIdentityRoles, c.ctl.Status.ClusterInfo.Cluster, kubernetes.NewCachedClientSet(clientSet), wait, force, func(errs chan error, _ string) error {
+	return c.stackManager.NewTasksToDeleteClusterWithNodeGroups(ctx, c.clusterStack, allStacks, clusterOperable, newOIDCManager, newTasksToDeleteAddonIAM, newTasksToDeletePodIdentityRoles, c.ctl.Status.ClusterInfo.Cluster, kubernetes.NewCachedClientSet(clientSet), wait, force, func(errs chan error, _ string) error {
 		logger.Info("trying to cleanup dangling network interfaces")
 		stack, err := c.stackManager.DescribeClusterStack(ctx)
 		if err != nil {
@@ -155,35 +245,6 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 		}()
 		return nil
 	})
-
-	if err != nil {
-		return err
-	}
-
-	if tasks.Len() == 0 {
-		logger.Warning("no cluster resources were found for %q", c.cfg.Metadata.Name)
-		return nil
-	}
-
-	logger.Info(tasks.Describe())
-	if errs := tasks.DoAllSync(); len(errs) > 0 {
-		return handleErrors(errs, "cluster with nodegroup(s)")
-	}
-
-	if err := c.deleteKarpenterStackIfExists(ctx); err != nil {
-		return err
-	}
-
-	if err := checkForUndeletedStacks(ctx, c.stackManager); err != nil {
-		return err
-	}
-	if err := c.autoModeDeleter.DeleteIfRequired(ctx); err != nil {
-		return err
-	}
-
-	logger.Success("all cluster resources were deleted")
-
-	return nil
 }
 
 func (c *OwnedCluster) deleteKarpenterStackIfExists(ctx context.Context) error {
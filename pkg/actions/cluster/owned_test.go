@@ -128,7 +128,7 @@ var _ = Describe("Delete", func() {
 				return mockedDrainer
 			})
 
-			err := c.Delete(context.Background(), time.Microsecond, 0, false, false, false, 1)
+			err := c.Delete(context.Background(), time.Microsecond, 0, false, false, false, 1, nil)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeStackManager.DeleteTasksForDeprecatedStacksCallCount()).To(Equal(1))
 			Expect(ranDeleteDeprecatedTasks).To(BeTrue())
@@ -203,7 +203,7 @@ var _ = Describe("Delete", func() {
 					return mockedDrainer
 				})
 
-				err := c.Delete(context.Background(), time.Microsecond, 0, false, true, false, 1)
+				err := c.Delete(context.Background(), time.Microsecond, 0, false, true, false, 1, nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeStackManager.DeleteTasksForDeprecatedStacksCallCount()).To(Equal(1))
 				Expect(ranDeleteDeprecatedTasks).To(BeFalse())
@@ -276,7 +276,7 @@ var _ = Describe("Delete", func() {
 					return mockedDrainer
 				})
 
-				err := c.Delete(context.Background(), time.Microsecond, time.Second*0, false, false, false, 1)
+				err := c.Delete(context.Background(), time.Microsecond, time.Second*0, false, false, false, 1, nil)
 				Expect(err).To(MatchError(errorMessage))
 				Expect(fakeStackManager.DeleteTasksForDeprecatedStacksCallCount()).To(Equal(0))
 				Expect(ranDeleteDeprecatedTasks).To(BeFalse())
@@ -329,7 +329,7 @@ var _ = Describe("Delete", func() {
 				return fake.NewSimpleClientset(), nil
 			})
 
-			err := c.Delete(context.Background(), time.Microsecond, time.Second*0, false, false, false, 1)
+			err := c.Delete(context.Background(), time.Microsecond, time.Second*0, false, false, false, 1, nil)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeStackManager.DeleteTasksForDeprecatedStacksCallCount()).To(Equal(1))
 			Expect(ranDeleteDeprecatedTasks).To(BeTrue())
@@ -337,4 +337,35 @@ var _ = Describe("Delete", func() {
 			Expect(ranDeleteClusterTasks).To(BeTrue())
 		})
 	})
+
+	Context("when only the tasks are requested", func() {
+		It("returns the tasks without draining or deleting anything", func() {
+			fakeStackManager.NewTasksToDeleteClusterWithNodeGroupsReturns(&tasks.TaskTree{
+				Tasks: []tasks.Task{&tasks.GenericTask{
+					Description: "delete cluster control plane",
+					Doer: func() error {
+						ranDeleteClusterTasks = true
+						return nil
+					},
+				}},
+			}, nil)
+
+			c := cluster.NewOwnedCluster(cfg, ctl, nil, fakeStackManager, autoModeDeleter)
+			drainer := &drainerMockOwned{}
+			c.SetNewNodeGroupDrainer(func(clientSet kubernetes.Interface) cluster.NodeGroupDrainer {
+				return drainer
+			})
+			c.SetNewClientSet(func() (kubernetes.Interface, error) {
+				return fake.NewSimpleClientset(), nil
+			})
+
+			taskTree, err := c.DeleteTasks(context.Background(), time.Microsecond, true, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(taskTree.Describe()).To(ContainSubstring("delete cluster control plane"))
+			Expect(fakeStackManager.NewTasksToDeleteClusterWithNodeGroupsCallCount()).To(Equal(1))
+			Expect(ranDeleteClusterTasks).To(BeFalse())
+			Expect(fakeStackManager.DeleteTasksForDeprecatedStacksCallCount()).To(BeZero())
+			drainer.AssertNotCalled(GinkgoT(), "Drain", mock.Anything)
+		})
+	})
 })
@@ -58,7 +58,20 @@ func (c *UnownedCluster) Upgrade(ctx context.Context, dryRun bool) error {
 	return nil
 }
 
-func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int) error {
+// UpgradeTasks returns the tasks that Upgrade runs, without upgrading anything.
+func (c *UnownedCluster) UpgradeTasks(ctx context.Context) (*tasks.TaskTree, error) {
+	taskTree := &tasks.TaskTree{Parallel: false}
+	controlPlaneTask, err := upgradeControlPlaneTask(ctx, c.cfg, c.ctl)
+	if err != nil {
+		return nil, err
+	}
+	if controlPlaneTask != nil {
+		taskTree.Append(controlPlaneTask)
+	}
+	return taskTree, nil
+}
+
+func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int, journal *tasks.Journal) error {
 	clusterName := c.cfg.Metadata.Name
 
 	if err := c.checkClusterExists(ctx, clusterName); err != nil {
@@ -108,11 +121,11 @@ func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWa
 
 	// we have to wait for nodegroups to delete before deleting the cluster
 	// so the `wait` value is ignored here
-	if err := c.deleteAndWaitForNodegroupsDeletion(ctx, waitInterval, allStacks); err != nil {
+	if err := c.deleteAndWaitForNodegroupsDeletion(ctx, waitInterval, allStacks, journal); err != nil {
 		return err
 	}
 
-	if err := c.deleteIAMAndOIDC(ctx, wait, clusterOperable, clientSet, force); err != nil {
+	if err := c.deleteIAMAndOIDC(ctx, wait, clusterOperable, clientSet, force, journal); err != nil {
 		if force {
 			logger.Warning("error occurred during deletion: %v", err)
 		} else {
@@ -131,10 +144,81 @@ func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWa
 		return err
 	}
 
+	if err := journal.Remove(); err != nil {
+		logger.Warning(err.Error())
+	}
 	logger.Success("all cluster resources were deleted")
 	return nil
 }
 
+// DeleteTasks returns the tasks that Delete runs once all nodegroups have been drained, without deleting anything.
+func (c *UnownedCluster) DeleteTasks(ctx context.Context, waitInterval time.Duration, wait, force bool) (*tasks.TaskTree, error) {
+	clusterName := c.cfg.Metadata.Name
+
+	if err := c.checkClusterExists(ctx, clusterName); err != nil {
+		return nil, err
+	}
+
+	clusterOperable, err := c.ctl.CanOperate(c.cfg)
+	if err != nil {
+		logger.Debug("failed to check if cluster is operable: %v", err)
+	}
+
+	allStacks, err := c.stackManager.ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var clientSet kubernetes.Interface
+	if clusterOperable {
+		if clientSet, err = c.newClientSet(); err != nil {
+			return nil, err
+		}
+	}
+
+	taskTree := &tasks.TaskTree{Parallel: false}
+	fargateStack, err := c.stackManager.GetFargateStack(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if fargateStack != nil {
+		taskTree.Append(&tasks.GenericTask{
+			Description: "delete fargate role",
+			Doer: func() error {
+				return c.deleteFargateRoleIfExists(ctx)
+			},
+			Resource: tasks.ResourceInfo{Kind: tasks.KindFargateProfile, StackName: *fargateStack.StackName},
+		})
+	}
+
+	nodeGroupTasks, err := c.nodeGroupDeletionTasks(ctx, waitInterval, allStacks)
+	if err != nil {
+		return nil, err
+	}
+	if nodeGroupTasks.Len() > 0 {
+		nodeGroupTasks.IsSubTask = true
+		taskTree.Append(nodeGroupTasks)
+	}
+
+	iamAndOIDCTasks, err := c.iamAndOIDCDeletionTasks(ctx, wait, clusterOperable, clientSet, force)
+	if err != nil {
+		return nil, err
+	}
+	if iamAndOIDCTasks.Len() > 0 {
+		iamAndOIDCTasks.IsSubTask = true
+		taskTree.Append(iamAndOIDCTasks)
+	}
+
+	taskTree.Append(&tasks.GenericTask{
+		Description: fmt.Sprintf("delete cluster control plane %q", clusterName),
+		Doer: func() error {
+			return c.deleteCluster(ctx, wait)
+		},
+		Resource: tasks.ResourceInfo{Kind: tasks.KindCluster},
+	})
+	return taskTree, nil
+}
+
 func (c *UnownedCluster) deleteFargateRoleIfExists(ctx context.Context) error {
 	stack, err := c.stackManager.GetFargateStack(ctx)
 	if err != nil {
@@ -164,7 +248,28 @@ func (c *UnownedCluster) checkClusterExists(ctx context.Context, clusterName str
 	return nil
 }
 
-func (c *UnownedCluster) deleteIAMAndOIDC(ctx context.Context, wait bool, clusterOperable bool, clientSet kubernetes.Interface, force bool) error {
+func (c *UnownedCluster) deleteIAMAndOIDC(ctx context.Context, wait bool, clusterOperable bool, clientSet kubernetes.Interface, force bool, journal *tasks.Journal) error {
+	tasksTree, err := c.iamAndOIDCDeletionTasks(ctx, wait, clusterOperable, clientSet, force)
+	if err != nil {
+		return err
+	}
+
+	if tasksTree.Len() == 0 {
+		logger.Warning("no IAM and OIDC resources were found for %q", c.cfg.Metadata.Name)
+		return nil
+	}
+
+	journal.Track(tasksTree, "iam")
+	logger.Info(tasksTree.Describe())
+	if errs := tasksTree.DoAllSync(); len(errs) > 0 {
+		return handleErrors(errs, "cluster IAM and OIDC")
+	}
+
+	logger.Info("all IAM and OIDC resources were deleted")
+	return nil
+}
+
+func (c *UnownedCluster) iamAndOIDCDeletionTasks(ctx context.Context, wait bool, clusterOperable bool, clientSet kubernetes.Interface, force bool) (*tasks.TaskTree, error) {
 	tasksTree := &tasks.TaskTree{Parallel: false}
 
 	if clusterOperable {
@@ -174,7 +279,7 @@ func (c *UnownedCluster) deleteIAMAndOIDC(ctx context.Context, wait bool, cluste
 		}
 		serviceAccountAndOIDCTasks, err := c.stackManager.NewTasksToDeleteOIDCProviderWithIAMServiceAccounts(ctx, newOIDCManager, c.ctl.Status.ClusterInfo.Cluster, clientSetGetter, force)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if serviceAccountAndOIDCTasks.Len() > 0 {
@@ -185,26 +290,14 @@ func (c *UnownedCluster) deleteIAMAndOIDC(ctx context.Context, wait bool, cluste
 
 	deleteAddonIAMTasks, err := addon.NewRemover(c.stackManager).DeleteAddonIAMTasks(ctx, wait)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if deleteAddonIAMTasks.Len() > 0 {
 		deleteAddonIAMTasks.IsSubTask = true
 		tasksTree.Append(deleteAddonIAMTasks)
 	}
-
-	if tasksTree.Len() == 0 {
-		logger.Warning("no IAM and OIDC resources were found for %q", c.cfg.Metadata.Name)
-		return nil
-	}
-
-	logger.Info(tasksTree.Describe())
-	if errs := tasksTree.DoAllSync(); len(errs) > 0 {
-		return handleErrors(errs, "cluster IAM and OIDC")
-	}
-
-	logger.Info("all IAM and OIDC resources were deleted")
-	return nil
+	return tasksTree, nil
 }
 
 func (c *UnownedCluster) deleteCluster(ctx context.Context, wait bool) error {
@@ -235,7 +328,27 @@ func (c *UnownedCluster) deleteCluster(ctx context.Context, wait bool) error {
 	}, c.ctl.AWSProvider.WaitTimeout())
 }
 
-func (c *UnownedCluster) deleteAndWaitForNodegroupsDeletion(ctx context.Context, waitInterval time.Duration, allStacks []manager.NodeGroupStack) error {
+func (c *UnownedCluster) deleteAndWaitForNodegroupsDeletion(ctx context.Context, waitInterval time.Duration, allStacks []manager.NodeGroupStack, journal *tasks.Journal) error {
+	tasks, err := c.nodeGroupDeletionTasks(ctx, waitInterval, allStacks)
+	if err != nil {
+		return err
+	}
+	if tasks.Len() == 0 {
+		logger.Warning("no nodegroups found for %s", c.cfg.Metadata.Name)
+		return nil
+	}
+
+	// TODO what dis?
+	tasks.PlanMode = false
+	journal.Track(tasks, "nodegroups")
+	logger.Info(tasks.Describe())
+	if errs := tasks.DoAllSync(); len(errs) > 0 {
+		return handleErrors(errs, "nodegroup(s)")
+	}
+	return nil
+}
+
+func (c *UnownedCluster) nodeGroupDeletionTasks(ctx context.Context, waitInterval time.Duration, allStacks []manager.NodeGroupStack) (*tasks.TaskTree, error) {
 	clusterName := c.cfg.Metadata.Name
 	eksAPI := c.ctl.AWSProvider.EKS()
 
@@ -244,18 +357,17 @@ func (c *UnownedCluster) deleteAndWaitForNodegroupsDeletion(ctx context.Context,
 		ClusterName: &clusterName,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(allStacks) == 0 && len(nodeGroups.Nodegroups) == 0 {
-		logger.Warning("no nodegroups found for %s", clusterName)
-		return nil
+		return &tasks.TaskTree{}, nil
 	}
 
 	// we kill every nodegroup with a stack the standard way. wait is always true
 	tasks, err := c.stackManager.NewTasksToDeleteNodeGroups(allStacks, func(_ string) bool { return true }, true, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, n := range nodeGroups.Nodegroups {
@@ -273,14 +385,7 @@ func (c *UnownedCluster) deleteAndWaitForNodegroupsDeletion(ctx context.Context,
 			tasks.Append(c.stackManager.NewTaskToDeleteUnownedNodeGroup(ctx, clusterName, n, eksAPI, c.waitForUnownedNgsDeletion(ctx, waitInterval)))
 		}
 	}
-
-	// TODO what dis?
-	tasks.PlanMode = false
-	logger.Info(tasks.Describe())
-	if errs := tasks.DoAllSync(); len(errs) > 0 {
-		return handleErrors(errs, "nodegroup(s)")
-	}
-	return nil
+	return tasks, nil
 }
 
 func isNotFound(err error) bool {
@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/weaveworks/eksctl/pkg/printers"
 
@@ -10,17 +11,11 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 )
 
 func upgrade(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider, dryRun bool) (bool, error) {
-	cvm, err := eks.NewClusterVersionsManager(ctl.AWSProvider.EKS())
-	if err != nil {
-		return false, err
-	}
-
-	upgradeVersion, err := cvm.ResolveUpgradeVersion(
-		/* desiredVersion */ cfg.Metadata.Version,
-		/* currentVersion */ ctl.ControlPlaneVersion())
+	upgradeVersion, err := resolveUpgradeVersion(cfg, ctl)
 	if err != nil {
 		return false, err
 	}
@@ -46,3 +41,32 @@ func upgrade(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvid
 	}
 	return upgradeVersion != "", nil
 }
+
+func resolveUpgradeVersion(cfg *api.ClusterConfig, ctl *eks.ClusterProvider) (string, error) {
+	cvm, err := eks.NewClusterVersionsManager(ctl.AWSProvider.EKS())
+	if err != nil {
+		return "", err
+	}
+	return cvm.ResolveUpgradeVersion(
+		/* desiredVersion */ cfg.Metadata.Version,
+		/* currentVersion */ ctl.ControlPlaneVersion())
+}
+
+// upgradeControlPlaneTask returns a task that upgrades the control plane, or nil if no upgrade is required.
+func upgradeControlPlaneTask(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider) (tasks.Task, error) {
+	upgradeVersion, err := resolveUpgradeVersion(cfg, ctl)
+	if err != nil {
+		return nil, err
+	}
+	if upgradeVersion == "" {
+		return nil, nil
+	}
+	return &tasks.GenericTask{
+		Description: fmt.Sprintf("upgrade cluster %q control plane from current version %q to %q", cfg.Metadata.Name, ctl.ControlPlaneVersion(), upgradeVersion),
+		Doer: func() error {
+			_, err := upgrade(ctx, cfg, ctl, false)
+			return err
+		},
+		Resource: tasks.ResourceInfo{Kind: tasks.KindCluster},
+	}, nil
+}
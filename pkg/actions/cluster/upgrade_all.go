@@ -0,0 +1,131 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/utils"
+)
+
+// defaultAddonNames are the addons that are kept compatible with the control plane when upgrading all components
+var defaultAddonNames = []string{api.VPCCNIAddon, api.CoreDNSAddon, api.KubeProxyAddon, api.PodIdentityAgentAddon}
+
+// AddonsUpdater updates addons to versions compatible with a Kubernetes version.
+type AddonsUpdater interface {
+	UpdateAddons(ctx context.Context, kubernetesVersion string) error
+}
+
+// NodeGroupsUpgrader upgrades nodegroups to a Kubernetes version.
+type NodeGroupsUpgrader interface {
+	// ValidateNodeGroups checks that every nodegroup can be upgraded, before anything is upgraded
+	ValidateNodeGroups(ctx context.Context) error
+	UpgradeNodeGroups(ctx context.Context, kubernetesVersion string) error
+}
+
+// HealthChecker checks that a cluster is healthy.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// An AllUpgrader upgrades the control plane, the default addons and the nodegroups of a cluster, one minor
+// version at a time.
+type AllUpgrader struct {
+	ClusterName string
+	// UpgradeControlPlane upgrades the control plane to the next minor version
+	UpgradeControlPlane func(ctx context.Context, version string) error
+	Addons              AddonsUpdater
+	NodeGroups          NodeGroupsUpgrader
+	Health              HealthChecker
+}
+
+// UpgradeSteps returns the versions the control plane goes through, one minor version at a time, to be upgraded
+// from currentVersion to targetVersion.
+func UpgradeSteps(cvm eks.ClusterVersionsManagerInterface, currentVersion, targetVersion string) ([]string, error) {
+	if err := cvm.ValidateVersion(targetVersion); err != nil {
+		return nil, err
+	}
+	if c, err := utils.CompareVersions(targetVersion, currentVersion); err != nil {
+		return nil, fmt.Errorf("couldn't compare versions for upgrade: %w", err)
+	} else if c < 0 {
+		return nil, fmt.Errorf("cannot upgrade to a lower version. Found given target version %q, current cluster version %q", targetVersion, currentVersion)
+	}
+
+	var steps []string
+	for version := currentVersion; version != targetVersion; {
+		next, err := cvm.ResolveUpgradeVersion("", version)
+		if err != nil {
+			return nil, err
+		}
+		if next == "" {
+			return nil, fmt.Errorf("no supported version to upgrade to after %q", version)
+		}
+		steps = append(steps, next)
+		version = next
+	}
+	return steps, nil
+}
+
+// Upgrade upgrades the cluster from currentVersion through each version in steps. The addons and nodegroups are
+// upgraded after every control plane upgrade, and the cluster must be healthy before moving on to the next version.
+// Components that are already on a version are left unchanged, so running Upgrade again resumes a failed upgrade.
+func (u *AllUpgrader) Upgrade(ctx context.Context, currentVersion string, steps []string, dryRun bool) error {
+	if err := u.NodeGroups.ValidateNodeGroups(ctx); err != nil {
+		return err
+	}
+
+	versions := steps
+	if len(versions) == 0 {
+		logger.Info("control plane of cluster %q is already on version %q", u.ClusterName, currentVersion)
+		versions = []string{currentVersion}
+	}
+	from := currentVersion
+	for _, version := range versions {
+		if version != from {
+			cmdutils.LogIntendedAction(dryRun, "upgrade cluster %q control plane from version %q to %q", u.ClusterName, from, version)
+		}
+		cmdutils.LogIntendedAction(dryRun, "update addons %s to their latest versions for Kubernetes %s", strings.Join(defaultAddonNames, ", "), version)
+		cmdutils.LogIntendedAction(dryRun, "upgrade all nodegroups to Kubernetes %s", version)
+		from = version
+	}
+	if dryRun {
+		cmdutils.LogPlanModeWarning(true)
+		return nil
+	}
+
+	if err := u.Health.CheckHealth(ctx); err != nil {
+		return fmt.Errorf("cluster %q must be healthy before it is upgraded: %w", u.ClusterName, err)
+	}
+	from = currentVersion
+	for _, version := range versions {
+		if err := u.upgradeTo(ctx, from, version); err != nil {
+			return fmt.Errorf("upgrading cluster %q to version %q: %w; run the command again to resume the upgrade", u.ClusterName, version, err)
+		}
+		from = version
+	}
+	return nil
+}
+
+func (u *AllUpgrader) upgradeTo(ctx context.Context, from, version string) error {
+	if version != from {
+		if err := u.UpgradeControlPlane(ctx, version); err != nil {
+			return fmt.Errorf("upgrading control plane: %w", err)
+		}
+	}
+	if err := u.Addons.UpdateAddons(ctx, version); err != nil {
+		return fmt.Errorf("updating addons: %w", err)
+	}
+	if err := u.NodeGroups.UpgradeNodeGroups(ctx, version); err != nil {
+		return fmt.Errorf("upgrading nodegroups: %w", err)
+	}
+	if err := u.Health.CheckHealth(ctx); err != nil {
+		return err
+	}
+	logger.Success("control plane, addons and nodegroups of cluster %q are on version %q", u.ClusterName, version)
+	return nil
+}
@@ -0,0 +1,415 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/blang/semver/v4"
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+	"github.com/weaveworks/eksctl/pkg/utils/apierrors"
+)
+
+// AddonManager updates EKS addons.
+type AddonManager interface {
+	Update(ctx context.Context, addon *api.Addon, podIdentityIAMUpdater addon.PodIdentityIAMUpdater, waitTimeout time.Duration) error
+}
+
+// A DefaultAddonsUpdater updates the default addons of a cluster. Addons managed by EKS are updated to their latest
+// version for the Kubernetes version, self-managed addons are updated in place.
+type DefaultAddonsUpdater struct {
+	ClusterConfig         *api.ClusterConfig
+	EKSAPI                awsapi.EKS
+	AddonManager          AddonManager
+	PodIdentityIAMUpdater addon.PodIdentityIAMUpdater
+	WaitTimeout           time.Duration
+	// UpdateSelfManaged updates an addon that is not managed by EKS to match the control plane version
+	UpdateSelfManaged func(ctx context.Context, addonName string) error
+}
+
+// UpdateAddons updates the default addons to versions compatible with kubernetesVersion.
+func (d *DefaultAddonsUpdater) UpdateAddons(ctx context.Context, kubernetesVersion string) error {
+	for _, addonName := range defaultAddonNames {
+		_, err := d.EKSAPI.DescribeAddon(ctx, &awseks.DescribeAddonInput{
+			AddonName:   aws.String(addonName),
+			ClusterName: aws.String(d.ClusterConfig.Metadata.Name),
+		})
+		switch {
+		case err == nil:
+			logger.Info("updating addon %q to its latest version for Kubernetes %s", addonName, kubernetesVersion)
+			if err := d.AddonManager.Update(ctx, d.addonFromConfig(addonName), d.PodIdentityIAMUpdater, d.WaitTimeout); err != nil {
+				return fmt.Errorf("updating addon %q: %w", addonName, err)
+			}
+		case !apierrors.IsNotFoundError(err):
+			return fmt.Errorf("describing addon %q: %w", addonName, err)
+		case addonName == api.PodIdentityAgentAddon:
+			// the pod identity agent is only available as an EKS addon
+			logger.Debug("addon %q is not installed", addonName)
+		default:
+			logger.Info("updating self-managed addon %q for Kubernetes %s", addonName, kubernetesVersion)
+			if err := d.UpdateSelfManaged(ctx, addonName); err != nil {
+				return fmt.Errorf("updating self-managed addon %q: %w", addonName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// addonFromConfig returns the addon as it is set in the config file, if it is, with its version set to latest.
+func (d *DefaultAddonsUpdater) addonFromConfig(addonName string) *api.Addon {
+	for _, a := range d.ClusterConfig.Addons {
+		if a.Name != addonName {
+			continue
+		}
+		addonCopy := a.DeepCopy()
+		if addonCopy.Version != "" && addonCopy.Version != "latest" {
+			logger.Warning("ignoring version %q of addon %q set in the config file, which may not be compatible with the new Kubernetes version", addonCopy.Version, addonName)
+		}
+		addonCopy.Version = "latest"
+		return addonCopy
+	}
+	return &api.Addon{Name: addonName, Version: "latest"}
+}
+
+// ManagedNodeGroupUpgrader upgrades managed nodegroups.
+type ManagedNodeGroupUpgrader interface {
+	Upgrade(ctx context.Context, options nodegroup.UpgradeOptions) error
+}
+
+// NodeGroupReplacer replaces nodegroups.
+type NodeGroupReplacer interface {
+	Replace(ctx context.Context, cfg *api.ClusterConfig, options nodegroup.ReplaceOptions) error
+}
+
+// An AllNodeGroupsUpgrader upgrades every nodegroup of a cluster. Managed nodegroups are upgraded in place; the
+// nodes of self-managed nodegroups cannot be, so self-managed nodegroups are replaced with a copy from the config file.
+type AllNodeGroupsUpgrader struct {
+	ClusterConfig   *api.ClusterConfig
+	EKSAPI          awsapi.EKS
+	StackHelper     nodegroup.StackHelper
+	ClientSet       kubernetes.Interface
+	ManagedUpgrader ManagedNodeGroupUpgrader
+	Replacer        NodeGroupReplacer
+	// ReplaceOptions controls how self-managed nodegroups are replaced, its Name and NewName are ignored
+	ReplaceOptions nodegroup.ReplaceOptions
+
+	managedNodeGroups     []string
+	selfManagedNodeGroups []selfManagedNodeGroup
+}
+
+// A selfManagedNodeGroup is a self-managed nodegroup in the config file and the live nodegroup created from it.
+// Replaced nodegroups are renamed to their next generation, e.g. ng-1-v2 for ng-1, so the live nodegroup may have
+// a different name than the nodegroup in the config file.
+type selfManagedNodeGroup struct {
+	ng *api.NodeGroup
+	// name is the name of the latest generation of the nodegroup
+	name string
+	// replacing is the name of an older generation whose replacement by name was interrupted
+	replacing string
+}
+
+// ValidateNodeGroups checks that managed nodegroups do not use a custom AMI, and that self-managed nodegroups
+// are in the config file and do not set an AMI ID.
+func (u *AllNodeGroupsUpgrader) ValidateNodeGroups(ctx context.Context) error {
+	clusterName := u.ClusterConfig.Metadata.Name
+	u.managedNodeGroups = nil
+	paginator := awseks.NewListNodegroupsPaginator(u.EKSAPI, &awseks.ListNodegroupsInput{
+		ClusterName: aws.String(clusterName),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("listing nodegroups: %w", err)
+		}
+		u.managedNodeGroups = append(u.managedNodeGroups, output.Nodegroups...)
+	}
+
+	var problems []string
+	for _, name := range u.managedNodeGroups {
+		ng, err := u.describeNodeGroup(ctx, name)
+		if err != nil {
+			return err
+		}
+		if ng.AmiType == ekstypes.AMITypesCustom {
+			problems = append(problems, fmt.Sprintf("managed nodegroup %q uses a custom AMI, upgrade it with 'eksctl replace nodegroup --node-ami'", name))
+		}
+	}
+
+	stacks, err := u.StackHelper.ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		return err
+	}
+	u.selfManagedNodeGroups = nil
+	generations := map[*api.NodeGroup][]string{}
+	for _, stack := range stacks {
+		if stack.Type != api.NodeGroupTypeUnmanaged {
+			continue
+		}
+		ng := u.findNodeGroup(stack.NodeGroupName)
+		if ng == nil {
+			problems = append(problems, fmt.Sprintf("self-managed nodegroup %q must be in the config file to be replaced", stack.NodeGroupName))
+			continue
+		}
+		if _, found := generations[ng]; !found {
+			if strings.HasPrefix(ng.AMI, "ami-") {
+				problems = append(problems, fmt.Sprintf("self-managed nodegroup %q sets AMI %q, remove it from the config file to use the EKS optimized AMI for each version", ng.Name, ng.AMI))
+			}
+			u.selfManagedNodeGroups = append(u.selfManagedNodeGroups, selfManagedNodeGroup{ng: ng})
+		}
+		generations[ng] = append(generations[ng], stack.NodeGroupName)
+	}
+	for i := range u.selfManagedNodeGroups {
+		smng := &u.selfManagedNodeGroups[i]
+		names := generations[smng.ng]
+		slices.SortFunc(names, func(a, b string) int {
+			_, generationA := nodegroup.NodeGroupGeneration(a)
+			_, generationB := nodegroup.NodeGroupGeneration(b)
+			return generationA - generationB
+		})
+		switch len(names) {
+		case 1:
+			smng.name = names[0]
+		case 2:
+			// a previous upgrade was interrupted after the replacement was created
+			smng.name, smng.replacing = names[1], names[0]
+		default:
+			problems = append(problems, fmt.Sprintf("nodegroups %s were all created from self-managed nodegroup %q, delete all but one of them", strings.Join(names, ", "), smng.ng.Name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("cannot upgrade nodegroups:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// UpgradeNodeGroups upgrades the nodegroups found by ValidateNodeGroups to kubernetesVersion, skipping those that
+// are already on it. Self-managed nodegroups are renamed in the config as they are replaced, and interrupted
+// replacements are completed before the nodegroups are upgraded.
+func (u *AllNodeGroupsUpgrader) UpgradeNodeGroups(ctx context.Context, kubernetesVersion string) error {
+	for _, name := range u.managedNodeGroups {
+		ng, err := u.describeNodeGroup(ctx, name)
+		if err != nil {
+			return err
+		}
+		if aws.ToString(ng.Version) == kubernetesVersion {
+			logger.Info("managed nodegroup %q is already on Kubernetes %s", name, kubernetesVersion)
+			continue
+		}
+		if err := u.ManagedUpgrader.Upgrade(ctx, nodegroup.UpgradeOptions{
+			NodegroupName:     name,
+			KubernetesVersion: kubernetesVersion,
+			Wait:              true,
+		}); err != nil {
+			return fmt.Errorf("upgrading nodegroup %q: %w", name, err)
+		}
+	}
+
+	for i := range u.selfManagedNodeGroups {
+		smng := &u.selfManagedNodeGroups[i]
+		if smng.replacing != "" {
+			if err := u.replace(ctx, smng.ng, smng.replacing, smng.name, true); err != nil {
+				return err
+			}
+			smng.replacing = ""
+		}
+		smng.ng.Name = smng.name
+		upToDate, err := u.nodesAreOnVersion(ctx, smng.ng, kubernetesVersion)
+		if err != nil {
+			return err
+		}
+		if upToDate {
+			logger.Info("nodes of nodegroup %q are already on Kubernetes %s", smng.name, kubernetesVersion)
+			continue
+		}
+		newName := nodegroup.NextNodeGroupName(smng.name)
+		if err := u.replace(ctx, smng.ng, smng.name, newName, false); err != nil {
+			return err
+		}
+		smng.name = newName
+	}
+	return nil
+}
+
+// replace replaces nodegroup name, created from ng, with nodegroup newName, and renames ng to newName.
+func (u *AllNodeGroupsUpgrader) replace(ctx context.Context, ng *api.NodeGroup, name, newName string, resume bool) error {
+	ng.Name = name
+	options := u.ReplaceOptions
+	options.Name = name
+	options.NewName = newName
+	options.Resume = resume
+	if err := u.Replacer.Replace(ctx, u.ClusterConfig, options); err != nil {
+		return err
+	}
+	ng.Name = newName
+	logger.Warning("nodegroup %q has been replaced by nodegroup %q, rename it in the config file", name, newName)
+	return nil
+}
+
+func (u *AllNodeGroupsUpgrader) describeNodeGroup(ctx context.Context, name string) (*ekstypes.Nodegroup, error) {
+	output, err := u.EKSAPI.DescribeNodegroup(ctx, &awseks.DescribeNodegroupInput{
+		ClusterName:   aws.String(u.ClusterConfig.Metadata.Name),
+		NodegroupName: aws.String(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing nodegroup %q: %w", name, err)
+	}
+	return output.Nodegroup, nil
+}
+
+// findNodeGroup returns the nodegroup in the config file that nodegroup name was created from, either the
+// nodegroup with that name or, if there is none, a nodegroup of another generation of it.
+func (u *AllNodeGroupsUpgrader) findNodeGroup(name string) *api.NodeGroup {
+	for _, ng := range u.ClusterConfig.NodeGroups {
+		if ng.Name == name {
+			return ng
+		}
+	}
+	baseName, _ := nodegroup.NodeGroupGeneration(name)
+	for _, ng := range u.ClusterConfig.NodeGroups {
+		if ngBaseName, _ := nodegroup.NodeGroupGeneration(ng.Name); ngBaseName == baseName {
+			return ng
+		}
+	}
+	return nil
+}
+
+// nodesAreOnVersion reports whether ng has nodes and all of them run kubernetesVersion.
+func (u *AllNodeGroupsUpgrader) nodesAreOnVersion(ctx context.Context, ng *api.NodeGroup, kubernetesVersion string) (bool, error) {
+	nodes, err := u.ClientSet.CoreV1().Nodes().List(ctx, ng.ListOptions())
+	if err != nil {
+		return false, fmt.Errorf("listing nodes of nodegroup %q: %w", ng.Name, err)
+	}
+	if len(nodes.Items) == 0 {
+		return false, nil
+	}
+	for _, node := range nodes.Items {
+		if minorVersion(node.Status.NodeInfo.KubeletVersion) != kubernetesVersion {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// minorVersion returns the major and minor components of a Kubernetes version, e.g. 1.30 for v1.30.4-eks-a737599.
+func minorVersion(kubeletVersion string) string {
+	v, err := semver.ParseTolerant(kubeletVersion)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// A ClusterHealthChecker waits for the nodes, the kube-system workloads and the EKS addons of a cluster to be healthy.
+type ClusterHealthChecker struct {
+	ClusterName  string
+	ClientSet    kubernetes.Interface
+	EKSAPI       awsapi.EKS
+	Timeout      time.Duration
+	PollInterval time.Duration
+}
+
+// CheckHealth waits until the cluster is healthy, and returns the remaining problems if it is not within the timeout.
+func (h *ClusterHealthChecker) CheckHealth(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, h.Timeout)
+	defer cancel()
+	for {
+		problems, err := h.problems(ctx)
+		if err != nil {
+			return err
+		}
+		if len(problems) == 0 {
+			logger.Info("cluster %q is healthy", h.ClusterName)
+			return nil
+		}
+		logger.Info("waiting for cluster %q to be healthy: %s", h.ClusterName, strings.Join(problems, "; "))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for cluster %q to be healthy: %s", h.ClusterName, strings.Join(problems, "; "))
+		case <-time.After(h.PollInterval):
+		}
+	}
+}
+
+func (h *ClusterHealthChecker) problems(ctx context.Context) ([]string, error) {
+	var problems []string
+	nodes, err := h.ClientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing nodes: %w", err)
+	}
+	for _, node := range nodes.Items {
+		if !isNodeReady(&node) {
+			problems = append(problems, fmt.Sprintf("node %s is not ready", node.Name))
+		}
+	}
+
+	deployments, err := h.ClientSet.AppsV1().Deployments(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		replicas := int32(1)
+		if d.Spec.Replicas != nil {
+			replicas = *d.Spec.Replicas
+		}
+		if d.Status.UpdatedReplicas < replicas || d.Status.AvailableReplicas < replicas {
+			problems = append(problems, fmt.Sprintf("deployment %s/%s has %d of %d updated replicas available", d.Namespace, d.Name, min(d.Status.UpdatedReplicas, d.Status.AvailableReplicas), replicas))
+		}
+	}
+
+	daemonSets, err := h.ClientSet.AppsV1().DaemonSets(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing daemonsets: %w", err)
+	}
+	for _, ds := range daemonSets.Items {
+		desired := ds.Status.DesiredNumberScheduled
+		if ds.Status.UpdatedNumberScheduled < desired || ds.Status.NumberAvailable < desired {
+			problems = append(problems, fmt.Sprintf("daemonset %s/%s has %d of %d updated pods available", ds.Namespace, ds.Name, min(ds.Status.UpdatedNumberScheduled, ds.Status.NumberAvailable), desired))
+		}
+	}
+
+	paginator := awseks.NewListAddonsPaginator(h.EKSAPI, &awseks.ListAddonsInput{
+		ClusterName: aws.String(h.ClusterName),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing addons: %w", err)
+		}
+		for _, addonName := range output.Addons {
+			addonOutput, err := h.EKSAPI.DescribeAddon(ctx, &awseks.DescribeAddonInput{
+				AddonName:   aws.String(addonName),
+				ClusterName: aws.String(h.ClusterName),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("describing addon %q: %w", addonName, err)
+			}
+			if status := addonOutput.Addon.Status; status != ekstypes.AddonStatusActive {
+				problems = append(problems, fmt.Sprintf("addon %s is %s", addonName, status))
+			}
+		}
+	}
+	return problems, nil
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
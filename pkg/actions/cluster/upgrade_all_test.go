@@ -0,0 +1,380 @@
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	eksfakes "github.com/weaveworks/eksctl/pkg/eks/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks/mocksv2"
+)
+
+type recordingUpgrader struct {
+	calls         *[]string
+	validateErr   error
+	nodeGroupsErr error
+	healthErr     error
+}
+
+func (r *recordingUpgrader) UpdateAddons(_ context.Context, version string) error {
+	*r.calls = append(*r.calls, "addons "+version)
+	return nil
+}
+
+func (r *recordingUpgrader) ValidateNodeGroups(_ context.Context) error {
+	*r.calls = append(*r.calls, "validate")
+	return r.validateErr
+}
+
+func (r *recordingUpgrader) UpgradeNodeGroups(_ context.Context, version string) error {
+	*r.calls = append(*r.calls, "nodegroups "+version)
+	return r.nodeGroupsErr
+}
+
+func (r *recordingUpgrader) CheckHealth(_ context.Context) error {
+	*r.calls = append(*r.calls, "health")
+	return r.healthErr
+}
+
+type recordingAddonManager struct {
+	addons []*api.Addon
+}
+
+func (m *recordingAddonManager) Update(_ context.Context, a *api.Addon, _ addon.PodIdentityIAMUpdater, _ time.Duration) error {
+	m.addons = append(m.addons, a)
+	return nil
+}
+
+type recordingNodeGroupUpgrader struct {
+	calls *[]string
+}
+
+func (u *recordingNodeGroupUpgrader) Upgrade(_ context.Context, options nodegroup.UpgradeOptions) error {
+	*u.calls = append(*u.calls, fmt.Sprintf("upgrade %s to %s", options.NodegroupName, options.KubernetesVersion))
+	return nil
+}
+
+func (u *recordingNodeGroupUpgrader) Replace(_ context.Context, _ *api.ClusterConfig, options nodegroup.ReplaceOptions) error {
+	call := fmt.Sprintf("replace %s with %s", options.Name, options.NewName)
+	if options.Resume {
+		call = fmt.Sprintf("resume replacing %s with %s", options.Name, options.NewName)
+	}
+	*u.calls = append(*u.calls, call)
+	return nil
+}
+
+var _ = Describe("Upgrading all components", func() {
+	Describe("UpgradeSteps", func() {
+		var cvm *eksfakes.FakeClusterVersionsManagerInterface
+
+		BeforeEach(func() {
+			cvm = &eksfakes.FakeClusterVersionsManagerInterface{}
+			cvm.ResolveUpgradeVersionStub = func(_, current string) (string, error) {
+				var minor int
+				_, err := fmt.Sscanf(current, "1.%d", &minor)
+				return fmt.Sprintf("1.%d", minor+1), err
+			}
+		})
+
+		It("steps through every minor version", func() {
+			steps, err := cluster.UpgradeSteps(cvm, "1.28", "1.31")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(steps).To(Equal([]string{"1.29", "1.30", "1.31"}))
+		})
+
+		It("has no steps when the control plane is on the target version", func() {
+			steps, err := cluster.UpgradeSteps(cvm, "1.31", "1.31")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(steps).To(BeEmpty())
+		})
+
+		It("rejects a lower version", func() {
+			_, err := cluster.UpgradeSteps(cvm, "1.31", "1.30")
+			Expect(err).To(MatchError(ContainSubstring("cannot upgrade to a lower version")))
+		})
+
+		It("rejects an unsupported version", func() {
+			cvm.ValidateVersionReturns(errors.New("invalid version, supported values: 1.30, 1.31"))
+			_, err := cluster.UpgradeSteps(cvm, "1.30", "1.40")
+			Expect(err).To(MatchError("invalid version, supported values: 1.30, 1.31"))
+		})
+	})
+
+	Describe("AllUpgrader", func() {
+		var (
+			calls    []string
+			recorder *recordingUpgrader
+			upgrader *cluster.AllUpgrader
+		)
+
+		BeforeEach(func() {
+			calls = nil
+			recorder = &recordingUpgrader{calls: &calls}
+			upgrader = &cluster.AllUpgrader{
+				ClusterName: "cluster",
+				UpgradeControlPlane: func(_ context.Context, version string) error {
+					calls = append(calls, "control plane "+version)
+					return nil
+				},
+				Addons:     recorder,
+				NodeGroups: recorder,
+				Health:     recorder,
+			}
+		})
+
+		It("upgrades the control plane, addons and nodegroups one version at a time", func() {
+			Expect(upgrader.Upgrade(context.Background(), "1.29", []string{"1.30", "1.31"}, false)).To(Succeed())
+			Expect(calls).To(Equal([]string{
+				"validate",
+				"health",
+				"control plane 1.30", "addons 1.30", "nodegroups 1.30", "health",
+				"control plane 1.31", "addons 1.31", "nodegroups 1.31", "health",
+			}))
+		})
+
+		It("upgrades addons and nodegroups when the control plane is on the target version", func() {
+			Expect(upgrader.Upgrade(context.Background(), "1.31", nil, false)).To(Succeed())
+			Expect(calls).To(Equal([]string{"validate", "health", "addons 1.31", "nodegroups 1.31", "health"}))
+		})
+
+		It("only validates in plan mode", func() {
+			Expect(upgrader.Upgrade(context.Background(), "1.29", []string{"1.30", "1.31"}, true)).To(Succeed())
+			Expect(calls).To(Equal([]string{"validate"}))
+		})
+
+		It("does not upgrade anything when the nodegroups cannot be upgraded", func() {
+			recorder.validateErr = errors.New("cannot upgrade nodegroups")
+			Expect(upgrader.Upgrade(context.Background(), "1.29", []string{"1.30"}, false)).To(MatchError("cannot upgrade nodegroups"))
+			Expect(calls).To(Equal([]string{"validate"}))
+		})
+
+		It("does not upgrade an unhealthy cluster", func() {
+			recorder.healthErr = errors.New("node node-1 is not ready")
+			err := upgrader.Upgrade(context.Background(), "1.29", []string{"1.30"}, false)
+			Expect(err).To(MatchError(`cluster "cluster" must be healthy before it is upgraded: node node-1 is not ready`))
+			Expect(calls).To(Equal([]string{"validate", "health"}))
+		})
+
+		It("stops at the version that failed", func() {
+			recorder.nodeGroupsErr = errors.New("nodegroup is currently being updated")
+			err := upgrader.Upgrade(context.Background(), "1.29", []string{"1.30", "1.31"}, false)
+			Expect(err).To(MatchError(`upgrading cluster "cluster" to version "1.30": upgrading nodegroups: nodegroup is currently being updated; run the command again to resume the upgrade`))
+			Expect(calls).To(Equal([]string{"validate", "health", "control plane 1.30", "addons 1.30", "nodegroups 1.30"}))
+		})
+	})
+
+	Describe("DefaultAddonsUpdater", func() {
+		It("updates EKS addons to their latest version and self-managed addons in place", func() {
+			cfg := api.NewClusterConfig()
+			cfg.Metadata.Name = "cluster"
+			cfg.Addons = []*api.Addon{{Name: api.VPCCNIAddon, Version: "1.18.0", ConfigurationValues: `{"env":{}}`}}
+
+			eksAPI := &mocksv2.EKS{}
+			eksAPI.On("DescribeAddon", mock.Anything, mock.MatchedBy(func(input *awseks.DescribeAddonInput) bool {
+				return *input.AddonName == api.VPCCNIAddon || *input.AddonName == api.PodIdentityAgentAddon
+			})).Return(&awseks.DescribeAddonOutput{}, nil)
+			eksAPI.On("DescribeAddon", mock.Anything, mock.Anything).Return(nil, &ekstypes.ResourceNotFoundException{})
+
+			addonManager := &recordingAddonManager{}
+			var selfManaged []string
+			updater := &cluster.DefaultAddonsUpdater{
+				ClusterConfig: cfg,
+				EKSAPI:        eksAPI,
+				AddonManager:  addonManager,
+				UpdateSelfManaged: func(_ context.Context, addonName string) error {
+					selfManaged = append(selfManaged, addonName)
+					return nil
+				},
+			}
+			Expect(updater.UpdateAddons(context.Background(), "1.31")).To(Succeed())
+
+			Expect(addonManager.addons).To(Equal([]*api.Addon{
+				{Name: api.VPCCNIAddon, Version: "latest", ConfigurationValues: `{"env":{}}`},
+				{Name: api.PodIdentityAgentAddon, Version: "latest"},
+			}))
+			Expect(cfg.Addons[0].Version).To(Equal("1.18.0"))
+			Expect(selfManaged).To(Equal([]string{api.CoreDNSAddon, api.KubeProxyAddon}))
+		})
+	})
+
+	Describe("AllNodeGroupsUpgrader", func() {
+		var (
+			cfg           *api.ClusterConfig
+			calls         []string
+			nodeGroups    map[string]*ekstypes.Nodegroup
+			stackManager  *fakes.FakeStackManager
+			fakeClientSet *fake.Clientset
+			upgrader      *cluster.AllNodeGroupsUpgrader
+		)
+
+		newNode := func(name, nodeGroup, kubeletVersion string) *corev1.Node {
+			return &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{api.NodeGroupNameLabel: nodeGroup}},
+				Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{KubeletVersion: kubeletVersion}},
+			}
+		}
+
+		BeforeEach(func() {
+			cfg = api.NewClusterConfig()
+			cfg.Metadata.Name = "cluster"
+			ng := cfg.NewNodeGroup()
+			ng.Name = "ng-1"
+			ng.AMI = api.NodeImageResolverAutoSSM
+
+			calls = nil
+			nodeGroups = map[string]*ekstypes.Nodegroup{
+				"mng-1": {Version: aws.String("1.30"), AmiType: ekstypes.AMITypesAl2023X8664Standard},
+				"mng-2": {Version: aws.String("1.31"), AmiType: ekstypes.AMITypesAl2023X8664Standard},
+			}
+			eksAPI := &mocksv2.EKS{}
+			eksAPI.On("ListNodegroups", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListNodegroupsOutput{
+				Nodegroups: []string{"mng-1", "mng-2"},
+			}, nil)
+			eksAPI.On("DescribeNodegroup", mock.Anything, mock.Anything).Return(
+				func(_ context.Context, input *awseks.DescribeNodegroupInput, _ ...func(*awseks.Options)) (*awseks.DescribeNodegroupOutput, error) {
+					return &awseks.DescribeNodegroupOutput{Nodegroup: nodeGroups[*input.NodegroupName]}, nil
+				}, nil)
+
+			stackManager = &fakes.FakeStackManager{}
+			stackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+				{NodeGroupName: "mng-1", Type: api.NodeGroupTypeManaged},
+				{NodeGroupName: "ng-1", Type: api.NodeGroupTypeUnmanaged},
+			}, nil)
+			fakeClientSet = fake.NewSimpleClientset(newNode("node-1", "ng-1", "v1.30.4-eks-a737599"))
+
+			recorder := &recordingNodeGroupUpgrader{calls: &calls}
+			upgrader = &cluster.AllNodeGroupsUpgrader{
+				ClusterConfig:   cfg,
+				EKSAPI:          eksAPI,
+				StackHelper:     stackManager,
+				ClientSet:       fakeClientSet,
+				ManagedUpgrader: recorder,
+				Replacer:        recorder,
+			}
+		})
+
+		It("upgrades managed nodegroups and replaces self-managed nodegroups that are not on the version", func() {
+			Expect(upgrader.ValidateNodeGroups(context.Background())).To(Succeed())
+			Expect(upgrader.UpgradeNodeGroups(context.Background(), "1.31")).To(Succeed())
+			Expect(calls).To(Equal([]string{"upgrade mng-1 to 1.31", "replace ng-1 with ng-1-v2"}))
+			Expect(cfg.NodeGroups[0].Name).To(Equal("ng-1-v2"))
+		})
+
+		It("leaves self-managed nodegroups whose nodes are on the version", func() {
+			Expect(upgrader.ValidateNodeGroups(context.Background())).To(Succeed())
+			Expect(upgrader.UpgradeNodeGroups(context.Background(), "1.30")).To(Succeed())
+			Expect(calls).To(Equal([]string{"upgrade mng-2 to 1.30"}))
+		})
+
+		When("self-managed nodegroups were replaced by an earlier run", func() {
+			It("upgrades the live generation of the nodegroup in the config file", func() {
+				stackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+					{NodeGroupName: "ng-1-v2", Type: api.NodeGroupTypeUnmanaged},
+				}, nil)
+				Expect(fakeClientSet.CoreV1().Nodes().Delete(context.Background(), "node-1", metav1.DeleteOptions{})).To(Succeed())
+				_, err := fakeClientSet.CoreV1().Nodes().Create(context.Background(), newNode("node-2", "ng-1-v2", "v1.31.2-eks-a737599"), metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(upgrader.ValidateNodeGroups(context.Background())).To(Succeed())
+				Expect(upgrader.UpgradeNodeGroups(context.Background(), "1.31")).To(Succeed())
+				Expect(upgrader.UpgradeNodeGroups(context.Background(), "1.32")).To(Succeed())
+				Expect(calls).To(Equal([]string{"upgrade mng-1 to 1.31", "upgrade mng-1 to 1.32", "upgrade mng-2 to 1.32", "replace ng-1-v2 with ng-1-v3"}))
+				Expect(cfg.NodeGroups[0].Name).To(Equal("ng-1-v3"))
+			})
+
+			It("completes an interrupted replacement", func() {
+				stackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+					{NodeGroupName: "ng-1-v2", Type: api.NodeGroupTypeUnmanaged},
+					{NodeGroupName: "ng-1", Type: api.NodeGroupTypeUnmanaged},
+				}, nil)
+				_, err := fakeClientSet.CoreV1().Nodes().Create(context.Background(), newNode("node-2", "ng-1-v2", "v1.30.4-eks-a737599"), metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(upgrader.ValidateNodeGroups(context.Background())).To(Succeed())
+				Expect(upgrader.UpgradeNodeGroups(context.Background(), "1.30")).To(Succeed())
+				Expect(calls).To(Equal([]string{"upgrade mng-2 to 1.30", "resume replacing ng-1 with ng-1-v2"}))
+				Expect(cfg.NodeGroups[0].Name).To(Equal("ng-1-v2"))
+			})
+
+			It("rejects more than two generations of a nodegroup", func() {
+				stackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+					{NodeGroupName: "ng-1", Type: api.NodeGroupTypeUnmanaged},
+					{NodeGroupName: "ng-1-v2", Type: api.NodeGroupTypeUnmanaged},
+					{NodeGroupName: "ng-1-v3", Type: api.NodeGroupTypeUnmanaged},
+				}, nil)
+				err := upgrader.ValidateNodeGroups(context.Background())
+				Expect(err).To(MatchError(ContainSubstring(`nodegroups ng-1, ng-1-v2, ng-1-v3 were all created from self-managed nodegroup "ng-1"`)))
+			})
+		})
+
+		It("rejects nodegroups that cannot be upgraded", func() {
+			nodeGroups["mng-2"].AmiType = ekstypes.AMITypesCustom
+			cfg.NodeGroups = nil
+
+			err := upgrader.ValidateNodeGroups(context.Background())
+			Expect(err).To(MatchError(ContainSubstring(`managed nodegroup "mng-2" uses a custom AMI`)))
+			Expect(err).To(MatchError(ContainSubstring(`self-managed nodegroup "ng-1" must be in the config file to be replaced`)))
+		})
+
+		It("rejects self-managed nodegroups with an AMI ID", func() {
+			cfg.NodeGroups[0].AMI = "ami-123"
+			err := upgrader.ValidateNodeGroups(context.Background())
+			Expect(err).To(MatchError(ContainSubstring(`self-managed nodegroup "ng-1" sets AMI "ami-123"`)))
+		})
+	})
+
+	Describe("ClusterHealthChecker", func() {
+		It("reports what is unhealthy when the cluster does not become healthy", func() {
+			eksAPI := &mocksv2.EKS{}
+			eksAPI.On("ListAddons", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListAddonsOutput{
+				Addons: []string{api.CoreDNSAddon},
+			}, nil)
+			eksAPI.On("DescribeAddon", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonOutput{
+				Addon: &ekstypes.Addon{Status: ekstypes.AddonStatusDegraded},
+			}, nil)
+			checker := &cluster.ClusterHealthChecker{
+				ClusterName: "cluster",
+				ClientSet: fake.NewSimpleClientset(&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+					Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+					}},
+				}),
+				EKSAPI:       eksAPI,
+				Timeout:      50 * time.Millisecond,
+				PollInterval: 10 * time.Millisecond,
+			}
+			err := checker.CheckHealth(context.Background())
+			Expect(err).To(MatchError(`timed out waiting for cluster "cluster" to be healthy: node node-1 is not ready; addon coredns is DEGRADED`))
+		})
+
+		It("succeeds when the cluster is healthy", func() {
+			eksAPI := &mocksv2.EKS{}
+			eksAPI.On("ListAddons", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListAddonsOutput{}, nil)
+			checker := &cluster.ClusterHealthChecker{
+				ClusterName: "cluster",
+				ClientSet:   fake.NewSimpleClientset(),
+				EKSAPI:      eksAPI,
+				Timeout:     time.Second,
+			}
+			Expect(checker.CheckHealth(context.Background())).To(Succeed())
+		})
+	})
+})
@@ -7,8 +7,6 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/kris-nova/logger"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/authconfigmap"
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
@@ -29,10 +27,7 @@ func (i *Installer) Create(ctx context.Context) error {
 			return i.ClientSet, nil
 		},
 	}
-	instanceProfileName := fmt.Sprintf("eksctl-%s-%s", builder.KarpenterNodeInstanceProfile, i.Config.Metadata.Name)
-	if i.Config.Karpenter.DefaultInstanceProfile != nil {
-		instanceProfileName = aws.ToString(i.Config.Karpenter.DefaultInstanceProfile)
-	}
+	instanceProfileName := i.instanceProfileName()
 
 	// Create IAM roles
 	taskTree := newTasksToInstallKarpenterIAMRoles(ctx, i.Config, i.StackManager, i.CTL.AWSProvider.EC2(), instanceProfileName)
@@ -43,7 +38,7 @@ func (i *Installer) Create(ctx context.Context) error {
 	// Set up service account
 	// Because we prefix with eksctl and to avoid having to get the name again,
 	// we always pass in the name and overwrite with the service account label.
-	roleName := fmt.Sprintf("eksctl-%s-iamservice-role", i.Config.Metadata.Name)
+	roleName := serviceAccountRoleName(i.Config.Metadata.Name)
 	roleARN := fmt.Sprintf("arn:%s:iam::%s:role/%s", parsedARN.Partition, parsedARN.AccountID, roleName)
 	policyArn := fmt.Sprintf("arn:%s:iam::%s:policy/eksctl-%s-%s", parsedARN.Partition, parsedARN.AccountID, builder.KarpenterManagedPolicy, i.Config.Metadata.Name)
 	iamServiceAccount := &api.ClusterIAMServiceAccount{
@@ -69,8 +64,7 @@ func (i *Installer) Create(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create client for auth config: %w", err)
 	}
-	identityArn := fmt.Sprintf("arn:%s:iam::%s:role/eksctl-%s-%s", parsedARN.Partition, parsedARN.AccountID, builder.KarpenterNodeRoleName, i.Config.Metadata.Name)
-	id, err := iam.NewIdentity(identityArn, authconfigmap.RoleNodeGroupUsername, authconfigmap.RoleNodeGroupGroups)
+	id, err := iam.NewIdentity(nodeRoleARN(parsedARN, i.Config.Metadata.Name), authconfigmap.RoleNodeGroupUsername, authconfigmap.RoleNodeGroupGroups)
 	if err != nil {
 		return fmt.Errorf("failed to create new identity: %w", err)
 	}
@@ -82,5 +76,8 @@ func (i *Installer) Create(ctx context.Context) error {
 	}
 
 	// Install Karpenter
-	return i.KarpenterInstaller.Install(context.Background(), roleARN, instanceProfileName)
+	if err := i.KarpenterInstaller.Install(context.Background(), roleARN, instanceProfileName); err != nil {
+		return err
+	}
+	return i.applyNodePools(ctx)
 }
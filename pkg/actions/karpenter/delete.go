@@ -0,0 +1,95 @@
+package karpenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/iam"
+	"github.com/weaveworks/eksctl/pkg/karpenter"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+// Delete removes the NodePools and EC2NodeClasses from the cluster, uninstalls Karpenter and deletes the
+// IAM resources and the aws-auth identity created for it.
+func (i *Installer) Delete(ctx context.Context) error {
+	parsedARN, err := arn.Parse(i.Config.Status.ARN)
+	if err != nil {
+		return fmt.Errorf("unexpected or invalid ARN: %q, %w", i.Config.Status.ARN, err)
+	}
+	stack, err := i.StackManager.GetKarpenterStack(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get Karpenter stack: %w", err)
+	}
+
+	version := i.Config.Karpenter.Version
+	if stack != nil {
+		if installedVersion := getKarpenterTagValue(stack.Tags, api.KarpenterVersionTag); installedVersion != "" {
+			version = installedVersion
+		}
+	}
+	if _, err := karpenter.APIVersion(version); err != nil {
+		logger.Warning("not deleting NodePools and EC2NodeClasses: %v", err)
+	} else {
+		nodePoolManager := &karpenter.NodePoolManager{
+			Client:  i.DynamicClient,
+			Version: version,
+		}
+		if err := nodePoolManager.DeleteAll(ctx, i.CTL.AWSProvider.WaitTimeout()); err != nil {
+			return fmt.Errorf("failed to delete Karpenter NodePools and EC2NodeClasses: %w", err)
+		}
+	}
+
+	if err := i.KarpenterInstaller.Uninstall(ctx); err != nil {
+		return err
+	}
+
+	clientSetGetter := &kubernetes.CallbackClientSet{
+		Callback: func() (kubernetes.Interface, error) {
+			return i.ClientSet, nil
+		},
+	}
+	serviceAccountName := fmt.Sprintf("%s/%s", karpenter.DefaultNamespace, karpenter.DefaultServiceAccountName)
+	serviceAccountTaskTree, err := i.StackManager.NewTasksToDeleteIAMServiceAccounts(ctx, []string{serviceAccountName}, clientSetGetter, true)
+	if err != nil {
+		return fmt.Errorf("failed to create tasks to delete the Karpenter service account: %w", err)
+	}
+	logger.Info(serviceAccountTaskTree.Describe())
+	if errs := serviceAccountTaskTree.DoAllSync(); len(errs) > 0 {
+		return fmt.Errorf("failed to delete the Karpenter service account: %w", errors.Join(errs...))
+	}
+
+	acm, err := authconfigmap.NewFromClientSet(i.ClientSet)
+	if err != nil {
+		return fmt.Errorf("failed to create client for auth config: %w", err)
+	}
+	identities, err := acm.GetIdentities()
+	if err != nil {
+		return fmt.Errorf("failed to get identities from auth config: %w", err)
+	}
+	identityARN := nodeRoleARN(parsedARN, i.Config.Metadata.Name)
+	if slices.ContainsFunc(identities, func(id iam.Identity) bool { return id.ARN() == identityARN }) {
+		if err := acm.RemoveIdentity(identityARN, true); err != nil {
+			return fmt.Errorf("failed to remove identity: %w", err)
+		}
+		if err := acm.Save(); err != nil {
+			return fmt.Errorf("failed to save the identity config: %w", err)
+		}
+	}
+
+	if stack != nil {
+		logger.Info("deleting Karpenter stack %q", aws.ToString(stack.StackName))
+		if err := i.StackManager.DeleteStackSync(ctx, stack); err != nil {
+			return fmt.Errorf("failed to delete Karpenter stack: %w", err)
+		}
+	}
+	logger.Success("deleted Karpenter from cluster %q", i.Config.Metadata.Name)
+	return nil
+}
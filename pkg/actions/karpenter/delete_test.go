@@ -0,0 +1,138 @@
+package karpenter_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	karpenteractions "github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	managerfakes "github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	karpenterfakes "github.com/weaveworks/eksctl/pkg/karpenter/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+var _ = Describe("Delete", func() {
+	var (
+		cfg                    *api.ClusterConfig
+		fakeStackManager       *managerfakes.FakeStackManager
+		fakeKarpenterInstaller *karpenterfakes.FakeChartInstaller
+		fakeClientSet          *fake.Clientset
+		dynamicClient          *dynamicfake.FakeDynamicClient
+		installer              *karpenteractions.Installer
+		karpenterStack         *manager.Stack
+		nodePoolGVR            = schema.GroupVersionResource{Group: "karpenter.sh", Version: "v1beta1", Resource: "nodepools"}
+		ec2NodeClassGVR        = schema.GroupVersionResource{Group: "karpenter.k8s.aws", Version: "v1beta1", Resource: "ec2nodeclasses"}
+	)
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Status = &api.ClusterStatus{
+			ARN: "arn:aws:eks:us-west-2:123456789012:cluster/my-cluster",
+		}
+		cfg.Karpenter = &api.Karpenter{
+			Version: "1.1.0",
+		}
+		karpenterStack = &manager.Stack{
+			StackName: aws.String("eksctl-my-cluster-karpenter"),
+			Tags: []cfntypes.Tag{
+				{Key: aws.String(api.KarpenterVersionTag), Value: aws.String("0.37.0")},
+			},
+		}
+		fakeStackManager = &managerfakes.FakeStackManager{}
+		fakeStackManager.GetKarpenterStackReturns(karpenterStack, nil)
+		fakeStackManager.NewTasksToDeleteIAMServiceAccountsReturns(&tasks.TaskTree{}, nil)
+		fakeKarpenterInstaller = &karpenterfakes.FakeChartInstaller{}
+		fakeClientSet = fake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "aws-auth",
+				Namespace: "kube-system",
+				UID:       "uid",
+			},
+			Data: map[string]string{
+				"mapRoles": `- rolearn: arn:aws:iam::123456789012:role/eksctl-KarpenterNodeRole-my-cluster
+  username: system:node:{{EC2PrivateDNSName}}
+  groups:
+  - system:bootstrappers
+  - system:nodes
+- rolearn: arn:aws:iam::123456789012:role/other
+  username: other
+`,
+			},
+		})
+		dynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			nodePoolGVR:     "NodePoolList",
+			ec2NodeClassGVR: "EC2NodeClassList",
+		}, &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "karpenter.sh/v1beta1",
+			"kind":       "NodePool",
+			"metadata":   map[string]interface{}{"name": "default"},
+		}})
+		installer = &karpenteractions.Installer{
+			StackManager:       fakeStackManager,
+			CTL:                &eks.ClusterProvider{AWSProvider: mockprovider.NewMockProvider()},
+			Config:             cfg,
+			KarpenterInstaller: fakeKarpenterInstaller,
+			ClientSet:          fakeClientSet,
+			DynamicClient:      dynamicClient,
+		}
+	})
+
+	It("deletes the NodePools, the release, the service account, the identity and the Karpenter stack", func() {
+		Expect(installer.Delete(context.Background())).To(Succeed())
+
+		nodePools, err := dynamicClient.Resource(nodePoolGVR).List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodePools.Items).To(BeEmpty())
+
+		Expect(fakeKarpenterInstaller.UninstallCallCount()).To(Equal(1))
+
+		Expect(fakeStackManager.NewTasksToDeleteIAMServiceAccountsCallCount()).To(Equal(1))
+		_, serviceAccounts, _, wait := fakeStackManager.NewTasksToDeleteIAMServiceAccountsArgsForCall(0)
+		Expect(serviceAccounts).To(Equal([]string{"karpenter/karpenter"}))
+		Expect(wait).To(BeTrue())
+
+		cm, err := fakeClientSet.CoreV1().ConfigMaps("kube-system").Get(context.Background(), "aws-auth", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cm.Data["mapRoles"]).NotTo(ContainSubstring("KarpenterNodeRole"))
+		Expect(cm.Data["mapRoles"]).To(ContainSubstring("arn:aws:iam::123456789012:role/other"))
+
+		Expect(fakeStackManager.DeleteStackSyncCallCount()).To(Equal(1))
+		_, stack := fakeStackManager.DeleteStackSyncArgsForCall(0)
+		Expect(stack).To(Equal(karpenterStack))
+	})
+
+	When("uninstalling the release fails", func() {
+		It("does not delete the IAM resources", func() {
+			fakeKarpenterInstaller.UninstallReturns(errors.New("nope"))
+			Expect(installer.Delete(context.Background())).To(MatchError("nope"))
+			Expect(fakeStackManager.NewTasksToDeleteIAMServiceAccountsCallCount()).To(Equal(0))
+			Expect(fakeStackManager.DeleteStackSyncCallCount()).To(Equal(0))
+		})
+	})
+
+	When("the Karpenter stack does not exist", func() {
+		It("uninstalls Karpenter without deleting a stack", func() {
+			fakeStackManager.GetKarpenterStackReturns(nil, nil)
+			cfg.Karpenter.Version = "0.37.0"
+			Expect(installer.Delete(context.Background())).To(Succeed())
+			Expect(fakeKarpenterInstaller.UninstallCallCount()).To(Equal(1))
+			Expect(fakeStackManager.DeleteStackSyncCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -5,17 +5,24 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/kris-nova/logger"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
 	kubeclient "k8s.io/client-go/kubernetes"
+	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/eks"
 	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
 	"github.com/weaveworks/eksctl/pkg/karpenter"
 	"github.com/weaveworks/eksctl/pkg/karpenter/providers/helm"
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
 	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 	"github.com/weaveworks/eksctl/pkg/utils/waiters"
 )
@@ -26,6 +33,8 @@ import (
 //counterfeiter:generate -o fakes/fake_karpenter_installer.go . InstallerTaskCreator
 type InstallerTaskCreator interface {
 	Create(ctx context.Context) error
+	Upgrade(ctx context.Context) error
+	Delete(ctx context.Context) error
 }
 
 // Installer contains all necessary dependencies for the Karpenter Install tasks and others.
@@ -36,6 +45,7 @@ type Installer struct {
 	Wait               WaitFunc
 	KarpenterInstaller karpenter.ChartInstaller
 	ClientSet          kubernetes.Interface
+	DynamicClient      dynamic.Interface
 	OIDC               *iamoidc.OpenIDConnectManager
 }
 
@@ -55,6 +65,14 @@ func NewInstaller(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterP
 		Namespace:     karpenter.DefaultNamespace,
 		ClusterConfig: cfg,
 	})
+	restConfig, err := restClientGetter.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
 	oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -76,6 +94,7 @@ func NewInstaller(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterP
 		Wait:               waiters.Wait,
 		KarpenterInstaller: karpenterInstaller,
 		ClientSet:          clientSet,
+		DynamicClient:      dynamicClient,
 		OIDC:               oidc,
 	}, nil
 }
@@ -91,3 +110,50 @@ func doTasks(taskTree *tasks.TaskTree) error {
 	}
 	return nil
 }
+
+// instanceProfileName returns the name of the instance profile used by Karpenter nodes.
+func (i *Installer) instanceProfileName() string {
+	if i.Config.Karpenter.DefaultInstanceProfile != nil {
+		return aws.ToString(i.Config.Karpenter.DefaultInstanceProfile)
+	}
+	return fmt.Sprintf("eksctl-%s-%s", builder.KarpenterNodeInstanceProfile, i.Config.Metadata.Name)
+}
+
+// applyNodePools applies the NodePools and EC2NodeClasses in the Karpenter config.
+func (i *Installer) applyNodePools(ctx context.Context) error {
+	if len(i.Config.Karpenter.NodePools) == 0 && len(i.Config.Karpenter.EC2NodeClasses) == 0 {
+		return nil
+	}
+	nodePoolManager := &karpenter.NodePoolManager{
+		Client:  i.DynamicClient,
+		Version: i.Config.Karpenter.Version,
+	}
+	if err := nodePoolManager.Apply(ctx, i.Config.Karpenter); err != nil {
+		return fmt.Errorf("failed to apply Karpenter NodePools and EC2NodeClasses: %w", err)
+	}
+	return nil
+}
+
+// serviceAccountRoleName returns the name of the IAM role of the Karpenter service account.
+func serviceAccountRoleName(clusterName string) string {
+	return fmt.Sprintf("eksctl-%s-iamservice-role", clusterName)
+}
+
+// nodeRoleARN returns the ARN of the IAM role of the nodes launched by Karpenter.
+func nodeRoleARN(clusterARN arn.ARN, clusterName string) string {
+	return fmt.Sprintf("arn:%s:iam::%s:role/eksctl-%s-%s", clusterARN.Partition, clusterARN.AccountID, builder.KarpenterNodeRoleName, clusterName)
+}
+
+// NewInstallerForExistingCluster creates a new Karpenter installer for a cluster whose status has been refreshed.
+func NewInstallerForExistingCluster(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider) (InstallerTaskCreator, error) {
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return nil, err
+	}
+	config := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), "", ctl.AWSProvider.Profile().Name)
+	kubeConfigBytes, err := runtime.Encode(clientcmdlatest.Codec, config)
+	if err != nil {
+		return nil, fmt.Errorf("generating kubeconfig: %w", err)
+	}
+	return NewInstaller(ctx, cfg, ctl, ctl.NewStackManager(cfg), clientSet, kubernetes.NewRESTClientGetter("karpenter", string(kubeConfigBytes)))
+}
@@ -108,9 +108,14 @@ func (k *karpenterIAMRolesTask) GetKarpenterName(s *manager.Stack) string {
 
 // getKarpenterTagName returns the Karpenter name of a stack based on its tags.
 func getKarpenterTagName(tags []cfntypes.Tag) string {
+	return getKarpenterTagValue(tags, api.KarpenterNameTag)
+}
+
+// getKarpenterTagValue returns the value of a tag of the Karpenter stack.
+func getKarpenterTagValue(tags []cfntypes.Tag, key string) string {
 	for _, tag := range tags {
-		if *tag.Key == api.KarpenterNameTag {
-			return *tag.Value
+		if aws.ToString(tag.Key) == key {
+			return aws.ToString(tag.Value)
 		}
 	}
 	return ""
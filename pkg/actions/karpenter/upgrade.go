@@ -0,0 +1,88 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/karpenter"
+)
+
+// Upgrade checks that Karpenter can be upgraded from the installed version, updates the IAM policies in the Karpenter
+// stack for the configured version, upgrades the Karpenter CRDs and release and applies the NodePools and
+// EC2NodeClasses in the config.
+func (i *Installer) Upgrade(ctx context.Context) error {
+	parsedARN, err := arn.Parse(i.Config.Status.ARN)
+	if err != nil {
+		return fmt.Errorf("unexpected or invalid ARN: %q, %w", i.Config.Status.ARN, err)
+	}
+	stack, err := i.StackManager.GetKarpenterStack(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get Karpenter stack: %w", err)
+	}
+	if stack == nil {
+		return fmt.Errorf("no Karpenter stack found for cluster %q, Karpenter must be installed by eksctl to be upgraded", i.Config.Metadata.Name)
+	}
+
+	if installedVersion := getKarpenterTagValue(stack.Tags, api.KarpenterVersionTag); installedVersion != "" {
+		if err := karpenter.ValidateUpgrade(installedVersion, i.Config.Karpenter.Version); err != nil {
+			return err
+		}
+	} else {
+		logger.Warning("the installed version of Karpenter is not known, not checking that it can be upgraded to version %s", i.Config.Karpenter.Version)
+	}
+
+	instanceProfileName := i.instanceProfileName()
+	resourceSet := builder.NewKarpenterResourceSet(i.Config, instanceProfileName)
+	if err := resourceSet.AddAllResources(); err != nil {
+		return err
+	}
+	template, err := resourceSet.RenderJSON()
+	if err != nil {
+		return fmt.Errorf("failed to render Karpenter stack template: %w", err)
+	}
+	stack.Tags = withKarpenterVersionTag(stack.Tags, i.Config.Karpenter.Version)
+	if err := i.StackManager.UpdateStack(ctx, manager.UpdateStackOptions{
+		Stack:         stack,
+		ChangeSetName: i.StackManager.MakeChangeSetName("update-karpenter"),
+		Description:   fmt.Sprintf("updating Karpenter stack %q for version %s", aws.ToString(stack.StackName), i.Config.Karpenter.Version),
+		TemplateData:  manager.TemplateBody(template),
+		Wait:          true,
+	}); err != nil {
+		return fmt.Errorf("failed to update Karpenter stack: %w", err)
+	}
+
+	roleARN := fmt.Sprintf("arn:%s:iam::%s:role/%s", parsedARN.Partition, parsedARN.AccountID, serviceAccountRoleName(i.Config.Metadata.Name))
+	if err := karpenter.AdoptCRDs(ctx, i.DynamicClient); err != nil {
+		return err
+	}
+	if err := i.KarpenterInstaller.Upgrade(ctx, roleARN, instanceProfileName); err != nil {
+		return err
+	}
+	if err := i.applyNodePools(ctx); err != nil {
+		return err
+	}
+	logger.Success("upgraded Karpenter on cluster %q to version %s", i.Config.Metadata.Name, i.Config.Karpenter.Version)
+	return nil
+}
+
+// withKarpenterVersionTag returns a copy of the stack tags with the Karpenter version tag set to version.
+func withKarpenterVersionTag(tags []cfntypes.Tag, version string) []cfntypes.Tag {
+	updated := make([]cfntypes.Tag, 0, len(tags)+1)
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) != api.KarpenterVersionTag {
+			updated = append(updated, tag)
+		}
+	}
+	return append(updated, cfntypes.Tag{
+		Key:   aws.String(api.KarpenterVersionTag),
+		Value: aws.String(version),
+	})
+}
@@ -0,0 +1,133 @@
+package karpenter_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	core "k8s.io/client-go/testing"
+
+	karpenteractions "github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	managerfakes "github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	karpenterfakes "github.com/weaveworks/eksctl/pkg/karpenter/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Upgrade", func() {
+	var (
+		cfg                    *api.ClusterConfig
+		fakeStackManager       *managerfakes.FakeStackManager
+		fakeKarpenterInstaller *karpenterfakes.FakeChartInstaller
+		dynamicClient          *dynamicfake.FakeDynamicClient
+		installer              *karpenteractions.Installer
+		appliedResources       []schema.GroupVersionResource
+	)
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Status = &api.ClusterStatus{
+			ARN: "arn:aws:eks:us-west-2:123456789012:cluster/my-cluster",
+		}
+		cfg.Karpenter = &api.Karpenter{
+			Version: "1.1.0",
+			NodePools: []api.KarpenterNodePool{
+				{Name: "default", Spec: api.InlineDocument{"weight": "10"}},
+			},
+		}
+		fakeStackManager = &managerfakes.FakeStackManager{}
+		fakeStackManager.GetKarpenterStackReturns(&manager.Stack{
+			StackName: aws.String("eksctl-my-cluster-karpenter"),
+			Tags: []cfntypes.Tag{
+				{Key: aws.String(api.KarpenterNameTag), Value: aws.String("eksctl-my-cluster-karpenter")},
+				{Key: aws.String(api.KarpenterVersionTag), Value: aws.String("0.37.0")},
+			},
+		}, nil)
+		fakeStackManager.MakeChangeSetNameReturns("eksctl-update-karpenter-1")
+		fakeKarpenterInstaller = &karpenterfakes.FakeChartInstaller{}
+		dynamicClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+		appliedResources = nil
+		dynamicClient.PrependReactor("patch", "*", func(action core.Action) (bool, runtime.Object, error) {
+			appliedResources = append(appliedResources, action.GetResource())
+			return true, nil, nil
+		})
+		installer = &karpenteractions.Installer{
+			StackManager:       fakeStackManager,
+			CTL:                &eks.ClusterProvider{AWSProvider: mockprovider.NewMockProvider()},
+			Config:             cfg,
+			KarpenterInstaller: fakeKarpenterInstaller,
+			DynamicClient:      dynamicClient,
+		}
+	})
+
+	It("updates the Karpenter stack, upgrades the release and applies the NodePools", func() {
+		Expect(installer.Upgrade(context.Background())).To(Succeed())
+
+		Expect(fakeStackManager.UpdateStackCallCount()).To(Equal(1))
+		_, options := fakeStackManager.UpdateStackArgsForCall(0)
+		Expect(options.ChangeSetName).To(Equal("eksctl-update-karpenter-1"))
+		Expect(options.Wait).To(BeTrue())
+		Expect(string(options.TemplateData.(manager.TemplateBody))).To(ContainSubstring(`"iam:ListInstanceProfiles"`))
+		Expect(options.Stack.Tags).To(ConsistOf(
+			cfntypes.Tag{Key: aws.String(api.KarpenterNameTag), Value: aws.String("eksctl-my-cluster-karpenter")},
+			cfntypes.Tag{Key: aws.String(api.KarpenterVersionTag), Value: aws.String("1.1.0")},
+		))
+
+		Expect(fakeKarpenterInstaller.UpgradeCallCount()).To(Equal(1))
+		_, roleARN, instanceProfile := fakeKarpenterInstaller.UpgradeArgsForCall(0)
+		Expect(roleARN).To(Equal("arn:aws:iam::123456789012:role/eksctl-my-cluster-iamservice-role"))
+		Expect(instanceProfile).To(Equal(fmt.Sprintf("eksctl-%s-my-cluster", builder.KarpenterNodeInstanceProfile)))
+
+		crd := schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+		Expect(appliedResources).To(Equal([]schema.GroupVersionResource{
+			crd, crd, crd, crd, crd, crd,
+			{Group: "karpenter.sh", Version: "v1", Resource: "nodepools"},
+		}))
+	})
+
+	When("the installed version cannot be upgraded to the configured version", func() {
+		It("errors without updating the stack", func() {
+			cfg.Karpenter.Version = "0.36.0"
+			Expect(installer.Upgrade(context.Background())).To(MatchError("cannot downgrade Karpenter from version 0.37.0 to 0.36.0"))
+			Expect(fakeStackManager.UpdateStackCallCount()).To(Equal(0))
+			Expect(fakeKarpenterInstaller.UpgradeCallCount()).To(Equal(0))
+		})
+	})
+
+	When("the installed version is not known", func() {
+		It("upgrades Karpenter", func() {
+			fakeStackManager.GetKarpenterStackReturns(&manager.Stack{
+				StackName: aws.String("eksctl-my-cluster-karpenter"),
+			}, nil)
+			Expect(installer.Upgrade(context.Background())).To(Succeed())
+			Expect(fakeKarpenterInstaller.UpgradeCallCount()).To(Equal(1))
+		})
+	})
+
+	When("Karpenter was not installed by eksctl", func() {
+		It("errors", func() {
+			fakeStackManager.GetKarpenterStackReturns(nil, nil)
+			Expect(installer.Upgrade(context.Background())).To(MatchError(`no Karpenter stack found for cluster "my-cluster", Karpenter must be installed by eksctl to be upgraded`))
+			Expect(fakeKarpenterInstaller.UpgradeCallCount()).To(Equal(0))
+		})
+	})
+
+	When("updating the stack fails", func() {
+		It("does not upgrade the release", func() {
+			fakeStackManager.UpdateStackReturns(errors.New("nope"))
+			Expect(installer.Upgrade(context.Background())).To(MatchError("failed to update Karpenter stack: nope"))
+			Expect(fakeKarpenterInstaller.UpgradeCallCount()).To(Equal(0))
+		})
+	})
+})
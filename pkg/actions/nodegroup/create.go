@@ -37,6 +37,7 @@ type CreateOpts struct {
 	InstallNeuronDevicePlugin bool
 	InstallNvidiaDevicePlugin bool
 	DryRunSettings            DryRunSettings
+	PlanSettings              PlanSettings
 	SkipOutdatedAddonsCheck   bool
 	ConfigFileProvided        bool
 	Parallelism               int
@@ -47,6 +48,12 @@ type DryRunSettings struct {
 	OutStream io.Writer
 }
 
+// PlanSettings controls printing the tasks that would create the nodegroups instead of executing them.
+type PlanSettings struct {
+	Output    printers.Type
+	OutStream io.Writer
+}
+
 // Create creates a new nodegroup with the given options.
 func (m *Manager) Create(ctx context.Context, options CreateOpts, nodegroupFilter filter.NodegroupFilter) error {
 	cfg := m.cfg
@@ -130,7 +137,8 @@ func (m *Manager) Create(ctx context.Context, options CreateOpts, nodegroupFilte
 		return err
 	}
 
-	if !options.DryRunSettings.DryRun {
+	// normalizing imports SSH keys, which must not happen when only printing a plan
+	if !options.DryRunSettings.DryRun && options.PlanSettings.Output == "" {
 		if err := nodeGroupService.Normalize(ctx, nodePools, cfg); err != nil {
 			return err
 		}
@@ -183,7 +191,18 @@ func (m *Manager) Create(ctx context.Context, options CreateOpts, nodegroupFilte
 		return cmdutils.PrintNodeGroupDryRunConfig(clusterConfigCopy, options.DryRunSettings.OutStream)
 	}
 
-	if err := m.nodeCreationTasks(ctx, isOwnedCluster, skipEgressRules, options.UpdateAuthConfigMap, options.Parallelism); err != nil {
+	taskTree, err := m.nodeCreationTasks(ctx, isOwnedCluster, skipEgressRules, options.UpdateAuthConfigMap, options.Parallelism)
+	if err != nil {
+		return err
+	}
+
+	if options.PlanSettings.Output != "" {
+		plan := &tasks.TaskTree{Parallel: false}
+		plan.Append(taskTree, m.ctl.ClusterTasksForNodeGroups(cfg, options.InstallNeuronDevicePlugin, options.InstallNvidiaDevicePlugin))
+		return cmdutils.PrintTaskPlan(plan, options.PlanSettings.Output, options.PlanSettings.OutStream)
+	}
+
+	if err := eks.DoAllNodegroupStackTasks(taskTree, meta.Region, meta.Name); err != nil {
 		return err
 	}
 
@@ -215,9 +234,8 @@ func makeOutpostsService(clusterConfig *api.ClusterConfig, provider api.ClusterP
 	}
 }
 
-func (m *Manager) nodeCreationTasks(ctx context.Context, isOwnedCluster, skipEgressRules bool, updateAuthConfigMap *bool, parallelism int) error {
+func (m *Manager) nodeCreationTasks(ctx context.Context, isOwnedCluster, skipEgressRules bool, updateAuthConfigMap *bool, parallelism int) (*tasks.TaskTree, error) {
 	cfg := m.cfg
-	meta := cfg.Metadata
 
 	taskTree := &tasks.TaskTree{
 		Parallel: false,
@@ -247,12 +265,16 @@ func (m *Manager) nodeCreationTasks(ctx context.Context, isOwnedCluster, skipEgr
 				return nil
 			},
 			Description: "fix cluster compatibility",
+			Resource: tasks.ResourceInfo{
+				Kind:      tasks.KindCluster,
+				StackName: m.stackManager.MakeClusterStackName(),
+			},
 		})
 	}
 
 	awsNodeUsesIRSA, err := eks.DoesAWSNodeUseIRSA(ctx, m.ctl.AWSProvider, m.clientSet)
 	if err != nil {
-		return fmt.Errorf("couldn't check aws-node for annotation: %w", err)
+		return nil, fmt.Errorf("couldn't check aws-node for annotation: %w", err)
 	}
 
 	if !awsNodeUsesIRSA && api.IsEnabled(cfg.IAM.WithOIDC) {
@@ -280,7 +302,7 @@ func (m *Manager) nodeCreationTasks(ctx context.Context, isOwnedCluster, skipEgr
 	}
 
 	taskTree.Append(allNodeGroupTasks)
-	return eks.DoAllNodegroupStackTasks(taskTree, meta.Region, meta.Name)
+	return taskTree, nil
 }
 
 func (m *Manager) postNodeCreationTasks(ctx context.Context, clientSet kubernetes.Interface, options CreateOpts) error {
@@ -2,9 +2,13 @@ package nodegroup
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/kris-nova/logger"
@@ -24,6 +28,12 @@ type DrainInput struct {
 	Undo                  bool
 	DisableEviction       bool
 	Parallel              int
+	// PDBAware orders and throttles the nodes being drained based on the PodDisruptionBudgets in the cluster
+	PDBAware bool
+	// PDBBlockTimeout is how long an eviction may be blocked by a PodDisruptionBudget when PDBAware is set
+	PDBBlockTimeout time.Duration
+	// CheckCapacity refuses to drain when the evicted pods would not fit on the remaining nodes
+	CheckCapacity bool
 }
 
 // A Drainer drains nodegroups.
@@ -41,11 +51,36 @@ func (d *Drainer) Drain(ctx context.Context, input *DrainInput) error {
 		return nil
 	}
 
-	g, ctx := errgroup.WithContext(ctx)
+	var budgets *drain.BudgetScheduler
+	if input.PDBAware && !input.Undo {
+		if input.DisableEviction {
+			return errors.New("PodDisruptionBudgets are not checked when eviction is disabled, a PodDisruptionBudget-aware drain cannot be used with --disable-eviction")
+		}
+		var err error
+		if budgets, err = drain.NewBudgetScheduler(ctx, d.ClientSet, input.PDBBlockTimeout); err != nil {
+			return err
+		}
+	}
+
+	var nodeGroupDrainers []*drain.NodeGroupDrainer
 	for _, nodegroup := range input.NodeGroups {
-		nodegroup := nodegroup
+		nodeGroupDrainer := drain.NewNodeGroupDrainer(d.ClientSet, nodegroup, input.MaxGracePeriod, input.NodeDrainWaitPeriod, input.PodEvictionWaitPeriod, input.Undo, input.DisableEviction, input.Parallel)
+		if budgets != nil {
+			nodeGroupDrainer.UseBudgetScheduler(budgets)
+		}
+		nodeGroupDrainers = append(nodeGroupDrainers, &nodeGroupDrainer)
+	}
+
+	if input.CheckCapacity && !input.Undo {
+		if err := checkCapacity(ctx, d.ClientSet, nodeGroupDrainers); err != nil {
+			return err
+		}
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	for _, nodeGroupDrainer := range nodeGroupDrainers {
+		nodeGroupDrainer := nodeGroupDrainer
 		g.Go(func() error {
-			nodeGroupDrainer := drain.NewNodeGroupDrainer(d.ClientSet, nodegroup, input.MaxGracePeriod, input.NodeDrainWaitPeriod, input.PodEvictionWaitPeriod, input.Undo, input.DisableEviction, input.Parallel)
 			return nodeGroupDrainer.Drain(ctx, sem)
 		})
 	}
@@ -54,3 +89,32 @@ func (d *Drainer) Drain(ctx context.Context, input *DrainInput) error {
 	}
 	return nil
 }
+
+// checkCapacity makes sure that the pods evicted from the nodegroups being drained fit on the remaining nodes.
+func checkCapacity(ctx context.Context, clientSet kubernetes.Interface, nodeGroupDrainers []*drain.NodeGroupDrainer) error {
+	drainingNodes := sets.New[string]()
+	var pods []corev1.Pod
+	for _, nodeGroupDrainer := range nodeGroupDrainers {
+		nodes, nodePods, err := nodeGroupDrainer.PodsForEviction(ctx)
+		if err != nil {
+			return fmt.Errorf("listing pods to evict: %w", err)
+		}
+		drainingNodes.Insert(nodes...)
+		pods = append(pods, nodePods...)
+	}
+
+	unschedulable, err := drain.CheckCapacity(ctx, clientSet, drainingNodes, pods)
+	if err != nil {
+		return fmt.Errorf("checking capacity of the remaining nodes: %w", err)
+	}
+	if len(unschedulable) == 0 {
+		logger.Info("the %d pod(s) to evict from %d node(s) fit on the remaining nodes", len(pods), drainingNodes.Len())
+		return nil
+	}
+	var msgs []string
+	for _, pod := range unschedulable {
+		msgs = append(msgs, pod.String())
+	}
+	return fmt.Errorf("%d pod(s) would not fit on the remaining nodes after draining, add capacity or use --skip-capacity-check to drain anyway:\n%s",
+		len(unschedulable), strings.Join(msgs, "\n"))
+}
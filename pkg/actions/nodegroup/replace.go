@@ -0,0 +1,263 @@
+package nodegroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+// NodeGroupsDrainer drains nodegroups.
+type NodeGroupsDrainer interface {
+	Drain(ctx context.Context, input *DrainInput) error
+}
+
+// NodeGroupsDeleter deletes nodegroups.
+type NodeGroupsDeleter interface {
+	Delete(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup, options DeleteOptions) error
+}
+
+// ReplaceOptions controls how a nodegroup is replaced.
+type ReplaceOptions struct {
+	// Name is the name of the nodegroup to replace
+	Name string
+	// NewName is the name of the replacement nodegroup, it is derived from Name if not set
+	NewName string
+	// AMI, InstanceTypes and ReleaseVersion override the spec of the nodegroup being replaced
+	AMI            string
+	InstanceTypes  []string
+	ReleaseVersion string
+	// Drain controls how the nodegroup being replaced is drained, its NodeGroups are ignored
+	Drain DrainInput
+	// DrainTimeout limits how long draining the nodegroup being replaced may take
+	DrainTimeout        time.Duration
+	UpdateAuthConfigMap bool
+	// Resume completes an interrupted replacement: if the replacement nodegroup already exists, it is not created
+	// again and the nodegroup being replaced is drained and deleted
+	Resume bool
+}
+
+// A Replacer replaces a nodegroup with a copy that has a new name; the copy is created and its nodes are ready
+// before the original nodegroup is drained and deleted.
+type Replacer struct {
+	StackHelper StackHelper
+	// Create creates the nodegroups in the given config and waits for their nodes to become ready
+	Create  func(ctx context.Context, cfg *api.ClusterConfig) error
+	Drainer NodeGroupsDrainer
+	Deleter NodeGroupsDeleter
+	// GetNodeGroupIAM looks up the instance role of a nodegroup so that it can be removed from the aws-auth ConfigMap
+	GetNodeGroupIAM func(ctx context.Context, ng *api.NodeGroup) error
+}
+
+// Replace creates the replacement nodegroup, drains the original nodegroup and deletes it. If the replacement
+// fails to become ready, it is deleted and the original nodegroup is left unchanged.
+func (r *Replacer) Replace(ctx context.Context, cfg *api.ClusterConfig, options ReplaceOptions) error {
+	replacementCfg, err := NewReplacementConfig(cfg, options)
+	if err != nil {
+		return err
+	}
+	oldNodeGroups, oldManagedNodeGroups := findNodeGroup(cfg, options.Name)
+	newName := replacementCfg.GetAllNodeGroupNames()[0]
+	// a role set in the config file is shared by both nodegroups and must stay in the aws-auth ConfigMap
+	updateAuthConfigMap := options.UpdateAuthConfigMap && !hasInstanceRoleARN(oldNodeGroups)
+
+	stacks, err := r.StackHelper.ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		return err
+	}
+	if findStack(stacks, options.Name) == nil {
+		return fmt.Errorf("nodegroup %q was not created by eksctl and cannot be replaced", options.Name)
+	}
+	switch replacementStack := findStack(stacks, newName); {
+	case replacementStack == nil:
+		logger.Info("creating nodegroup %q to replace nodegroup %q", newName, options.Name)
+		if err := r.Create(ctx, replacementCfg); err != nil {
+			logger.Warning("nodegroup %q did not become ready, rolling back: %v", newName, err)
+			if deleteErr := r.delete(ctx, replacementCfg.NodeGroups, replacementCfg.ManagedNodeGroups, updateAuthConfigMap); deleteErr != nil {
+				return fmt.Errorf("creating nodegroup %q: %w; rolling back also failed, delete it with 'eksctl delete nodegroup': %v", newName, err, deleteErr)
+			}
+			return fmt.Errorf("creating nodegroup %q: %w; nodegroup %q was left unchanged", newName, err, options.Name)
+		}
+	case !options.Resume:
+		return fmt.Errorf("nodegroup %q already exists, use --new-name to choose another name for the replacement", newName)
+	case !isStackComplete(replacementStack):
+		return fmt.Errorf("cannot resume the replacement of nodegroup %q, the stack of nodegroup %q is not complete; delete nodegroup %q and retry", options.Name, newName, newName)
+	default:
+		logger.Info("nodegroup %q already exists, resuming the replacement of nodegroup %q", newName, options.Name)
+	}
+
+	drainInput := options.Drain
+	drainInput.NodeGroups = cmdutils.ToKubeNodeGroups(oldNodeGroups, oldManagedNodeGroups)
+	drainCtx := ctx
+	if options.DrainTimeout > 0 {
+		var cancel context.CancelFunc
+		drainCtx, cancel = context.WithTimeout(ctx, options.DrainTimeout)
+		defer cancel()
+	}
+	if err := r.Drainer.Drain(drainCtx, &drainInput); err != nil {
+		return fmt.Errorf("draining nodegroup %q: %w; nodegroup %q is ready, drain and delete nodegroup %q to complete the replacement", options.Name, err, newName, options.Name)
+	}
+
+	if err := r.delete(ctx, oldNodeGroups, oldManagedNodeGroups, updateAuthConfigMap); err != nil {
+		return fmt.Errorf("deleting nodegroup %q: %w", options.Name, err)
+	}
+
+	logger.Success("replaced nodegroup %q with nodegroup %q", options.Name, newName)
+	return nil
+}
+
+func (r *Replacer) delete(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup, updateAuthConfigMap bool) error {
+	if updateAuthConfigMap && r.GetNodeGroupIAM != nil {
+		for _, ng := range nodeGroups {
+			if ng.IAM == nil || ng.IAM.InstanceRoleARN == "" {
+				if err := r.GetNodeGroupIAM(ctx, ng); err != nil {
+					logger.Warning("error getting instance role ARN for nodegroup %q: %v", ng.Name, err)
+				}
+			}
+		}
+	}
+	return r.Deleter.Delete(ctx, nodeGroups, managedNodeGroups, DeleteOptions{
+		Wait:                true,
+		UpdateAuthConfigMap: updateAuthConfigMap,
+	})
+}
+
+func hasInstanceRoleARN(nodeGroups []*api.NodeGroup) bool {
+	for _, ng := range nodeGroups {
+		if ng.IAM != nil && ng.IAM.InstanceRoleARN != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// NewReplacementConfig returns a copy of cfg that only holds a copy of the nodegroup being replaced,
+// renamed and with the overrides in options applied.
+func NewReplacementConfig(cfg *api.ClusterConfig, options ReplaceOptions) (*api.ClusterConfig, error) {
+	nodeGroups, managedNodeGroups := findNodeGroup(cfg, options.Name)
+	if len(nodeGroups) == 0 && len(managedNodeGroups) == 0 {
+		return nil, fmt.Errorf("nodegroup %q not found in config file", options.Name)
+	}
+
+	newName := options.NewName
+	if newName == "" {
+		newName = NextNodeGroupName(options.Name)
+	}
+	if newName == options.Name {
+		return nil, errors.New("the replacement nodegroup must have a different name")
+	}
+	if api.IsInvalidNameArg(newName) {
+		return nil, api.ErrInvalidName(newName)
+	}
+
+	var nodePool api.NodePool
+	if len(nodeGroups) > 0 {
+		nodePool = nodeGroups[0]
+	} else {
+		nodePool = managedNodeGroups[0]
+	}
+	if iam := nodePool.BaseNodeGroup().IAM; iam != nil && iam.InstanceRoleName != "" && iam.InstanceRoleARN == "" {
+		return nil, fmt.Errorf("nodegroup %q sets iam.instanceRoleName, which cannot be used by two nodegroups at the same time; set iam.instanceRoleARN instead", options.Name)
+	}
+
+	replacementCfg := cfg.DeepCopy()
+	replacementCfg.NodeGroups = nil
+	replacementCfg.ManagedNodeGroups = nil
+
+	if len(nodeGroups) > 0 {
+		ng := nodeGroups[0].DeepCopy()
+		ng.Name = newName
+		if options.ReleaseVersion != "" {
+			return nil, errors.New("--release-version is only supported for managed nodegroups")
+		}
+		if options.AMI != "" {
+			ng.AMI = options.AMI
+		}
+		if len(options.InstanceTypes) > 0 {
+			switch {
+			case ng.InstancesDistribution != nil:
+				ng.InstancesDistribution.InstanceTypes = options.InstanceTypes
+			case len(options.InstanceTypes) == 1:
+				ng.InstanceType = options.InstanceTypes[0]
+			default:
+				return nil, fmt.Errorf("nodegroup %q has a single instance type, set instancesDistribution to use multiple instance types", options.Name)
+			}
+		}
+		replacementCfg.NodeGroups = []*api.NodeGroup{ng}
+		if err := api.ValidateNodeGroup(0, ng, replacementCfg); err != nil {
+			return nil, err
+		}
+		return replacementCfg, nil
+	}
+
+	ng := managedNodeGroups[0].DeepCopy()
+	ng.Name = newName
+	if options.AMI != "" {
+		ng.AMI = options.AMI
+	}
+	if len(options.InstanceTypes) > 0 {
+		ng.InstanceType = ""
+		ng.InstanceTypes = options.InstanceTypes
+	}
+	if options.ReleaseVersion != "" {
+		ng.ReleaseVersion = options.ReleaseVersion
+	}
+	replacementCfg.ManagedNodeGroups = []*api.ManagedNodeGroup{ng}
+	if err := api.ValidateManagedNodeGroup(0, ng); err != nil {
+		return nil, err
+	}
+	return replacementCfg, nil
+}
+
+func findNodeGroup(cfg *api.ClusterConfig, name string) ([]*api.NodeGroup, []*api.ManagedNodeGroup) {
+	for _, ng := range cfg.NodeGroups {
+		if ng.Name == name {
+			return []*api.NodeGroup{ng}, nil
+		}
+	}
+	for _, ng := range cfg.ManagedNodeGroups {
+		if ng.Name == name {
+			return nil, []*api.ManagedNodeGroup{ng}
+		}
+	}
+	return nil, nil
+}
+
+var nodeGroupGenerationSuffix = regexp.MustCompile(`^(.*)-v(\d+)$`)
+
+// NextNodeGroupName returns the name of the next generation of a nodegroup, e.g. ng-1-v2 for ng-1 and ng-1-v3 for ng-1-v2
+func NextNodeGroupName(name string) string {
+	baseName, generation := NodeGroupGeneration(name)
+	return fmt.Sprintf("%s-v%d", baseName, generation+1)
+}
+
+// NodeGroupGeneration returns the name of the first generation of a nodegroup and the generation of name,
+// e.g. ng-1 and 3 for ng-1-v3, and ng-1 and 1 for ng-1
+func NodeGroupGeneration(name string) (string, int) {
+	if m := nodeGroupGenerationSuffix.FindStringSubmatch(name); m != nil {
+		if generation, err := strconv.Atoi(m[2]); err == nil {
+			return m[1], generation
+		}
+	}
+	return name, 1
+}
+
+func isStackComplete(stack *manager.NodeGroupStack) bool {
+	if stack.Stack == nil {
+		return false
+	}
+	switch stack.Stack.StackStatus {
+	case cfntypes.StackStatusCreateComplete, cfntypes.StackStatusUpdateComplete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+package nodegroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup/fakes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+)
+
+type fakeReplaceDrainer struct {
+	calls *[]string
+	input *nodegroup.DrainInput
+	err   error
+}
+
+func (d *fakeReplaceDrainer) Drain(_ context.Context, input *nodegroup.DrainInput) error {
+	*d.calls = append(*d.calls, "drain")
+	d.input = input
+	return d.err
+}
+
+type fakeReplaceDeleter struct {
+	calls   *[]string
+	options []nodegroup.DeleteOptions
+	err     error
+}
+
+func (d *fakeReplaceDeleter) Delete(_ context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup, options nodegroup.DeleteOptions) error {
+	for _, ng := range nodeGroups {
+		*d.calls = append(*d.calls, "delete "+ng.Name)
+	}
+	for _, ng := range managedNodeGroups {
+		*d.calls = append(*d.calls, "delete "+ng.Name)
+	}
+	d.options = append(d.options, options)
+	return d.err
+}
+
+var _ = Describe("Replace", func() {
+	var (
+		cfg         *api.ClusterConfig
+		calls       []string
+		created     *api.ClusterConfig
+		createErr   error
+		stackHelper *fakes.FakeStackHelper
+		drainer     *fakeReplaceDrainer
+		deleter     *fakeReplaceDeleter
+		replacer    *nodegroup.Replacer
+	)
+
+	newNodeGroup := func(name string) *api.NodeGroup {
+		ng := cfg.NewNodeGroup()
+		ng.Name = name
+		ng.InstanceType = "m5.large"
+		api.SetNodeGroupDefaults(ng, cfg.Metadata, false)
+		return ng
+	}
+
+	newManagedNodeGroup := func(name string) *api.ManagedNodeGroup {
+		ng := api.NewManagedNodeGroup()
+		ng.Name = name
+		ng.InstanceType = "m5.large"
+		api.SetManagedNodeGroupDefaults(ng, cfg.Metadata, false)
+		cfg.ManagedNodeGroups = append(cfg.ManagedNodeGroups, ng)
+		return ng
+	}
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "cluster"
+		cfg.Metadata.Region = "us-west-2"
+		newNodeGroup("ng-1")
+		newManagedNodeGroup("mng-1-v2")
+
+		calls = nil
+		created = nil
+		createErr = nil
+		stackHelper = &fakes.FakeStackHelper{}
+		stackHelper.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+			{NodeGroupName: "ng-1"},
+			{NodeGroupName: "mng-1-v2"},
+		}, nil)
+		drainer = &fakeReplaceDrainer{calls: &calls}
+		deleter = &fakeReplaceDeleter{calls: &calls}
+		replacer = &nodegroup.Replacer{
+			StackHelper: stackHelper,
+			Create: func(_ context.Context, cfg *api.ClusterConfig) error {
+				created = cfg
+				calls = append(calls, "create "+cfg.GetAllNodeGroupNames()[0])
+				return createErr
+			},
+			Drainer: drainer,
+			Deleter: deleter,
+		}
+	})
+
+	It("creates the replacement before draining and deleting the nodegroup", func() {
+		err := replacer.Replace(context.Background(), cfg, nodegroup.ReplaceOptions{
+			Name:                "ng-1",
+			Drain:               nodegroup.DrainInput{Parallel: 2},
+			UpdateAuthConfigMap: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal([]string{"create ng-1-v2", "drain", "delete ng-1"}))
+		Expect(created.NodeGroups).To(HaveLen(1))
+		Expect(created.ManagedNodeGroups).To(BeEmpty())
+
+		Expect(drainer.input.Parallel).To(Equal(2))
+		Expect(drainer.input.NodeGroups).To(HaveLen(1))
+		Expect(drainer.input.NodeGroups[0].NameString()).To(Equal("ng-1"))
+		Expect(deleter.options).To(ConsistOf(nodegroup.DeleteOptions{Wait: true, UpdateAuthConfigMap: true}))
+		Expect(cfg.GetAllNodeGroupNames()).To(ConsistOf("ng-1", "mng-1-v2"))
+	})
+
+	It("deletes the replacement and leaves the nodegroup unchanged when it does not become ready", func() {
+		createErr = errors.New("timed out waiting for nodes")
+		err := replacer.Replace(context.Background(), cfg, nodegroup.ReplaceOptions{Name: "mng-1-v2"})
+		Expect(err).To(MatchError(`creating nodegroup "mng-1-v3": timed out waiting for nodes; nodegroup "mng-1-v2" was left unchanged`))
+		Expect(calls).To(Equal([]string{"create mng-1-v3", "delete mng-1-v3"}))
+	})
+
+	It("reports a failed rollback", func() {
+		createErr = errors.New("timed out waiting for nodes")
+		deleter.err = errors.New("stack is in state DELETE_FAILED")
+		err := replacer.Replace(context.Background(), cfg, nodegroup.ReplaceOptions{Name: "ng-1"})
+		Expect(err).To(MatchError(ContainSubstring("rolling back also failed")))
+	})
+
+	It("does not delete the nodegroup when draining fails", func() {
+		drainer.err = errors.New("cannot evict pod")
+		err := replacer.Replace(context.Background(), cfg, nodegroup.ReplaceOptions{Name: "ng-1"})
+		Expect(err).To(MatchError(ContainSubstring(`draining nodegroup "ng-1": cannot evict pod`)))
+		Expect(calls).To(Equal([]string{"create ng-1-v2", "drain"}))
+	})
+
+	It("refuses to replace a nodegroup with an existing one", func() {
+		err := replacer.Replace(context.Background(), cfg, nodegroup.ReplaceOptions{Name: "ng-1", NewName: "mng-1-v2"})
+		Expect(err).To(MatchError(ContainSubstring(`nodegroup "mng-1-v2" already exists`)))
+		Expect(calls).To(BeEmpty())
+	})
+
+	When("resuming a replacement", func() {
+		BeforeEach(func() {
+			stackHelper.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+				{NodeGroupName: "ng-1", Stack: &manager.Stack{StackStatus: cftypes.StackStatusCreateComplete}},
+				{NodeGroupName: "ng-1-v2", Stack: &manager.Stack{StackStatus: cftypes.StackStatusCreateComplete}},
+			}, nil)
+		})
+
+		It("drains and deletes the nodegroup without creating the existing replacement", func() {
+			err := replacer.Replace(context.Background(), cfg, nodegroup.ReplaceOptions{Name: "ng-1", Resume: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(calls).To(Equal([]string{"drain", "delete ng-1"}))
+		})
+
+		It("refuses to use a replacement whose stack is not complete", func() {
+			stackHelper.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+				{NodeGroupName: "ng-1", Stack: &manager.Stack{StackStatus: cftypes.StackStatusCreateComplete}},
+				{NodeGroupName: "ng-1-v2", Stack: &manager.Stack{StackStatus: cftypes.StackStatusRollbackComplete}},
+			}, nil)
+			err := replacer.Replace(context.Background(), cfg, nodegroup.ReplaceOptions{Name: "ng-1", Resume: true})
+			Expect(err).To(MatchError(ContainSubstring(`the stack of nodegroup "ng-1-v2" is not complete`)))
+			Expect(calls).To(BeEmpty())
+		})
+	})
+
+	It("refuses to replace a nodegroup that has no stack", func() {
+		stackHelper.ListNodeGroupStacksWithStatusesReturns(nil, nil)
+		err := replacer.Replace(context.Background(), cfg, nodegroup.ReplaceOptions{Name: "ng-1"})
+		Expect(err).To(MatchError(`nodegroup "ng-1" was not created by eksctl and cannot be replaced`))
+		Expect(calls).To(BeEmpty())
+	})
+
+	It("keeps a shared instance role in the aws-auth ConfigMap", func() {
+		cfg.NodeGroups[0].IAM.InstanceRoleARN = "arn:aws:iam::123456789012:role/nodes"
+		err := replacer.Replace(context.Background(), cfg, nodegroup.ReplaceOptions{Name: "ng-1", UpdateAuthConfigMap: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleter.options).To(ConsistOf(nodegroup.DeleteOptions{Wait: true}))
+	})
+
+	Context("NewReplacementConfig", func() {
+		It("applies the overrides to a copy of a nodegroup", func() {
+			cfg.NodeGroups[0].OverrideBootstrapCommand = aws.String("/etc/eks/bootstrap.sh cluster")
+			cfg.NodeGroups[0].ContainerRuntime = nil
+			replacementCfg, err := nodegroup.NewReplacementConfig(cfg, nodegroup.ReplaceOptions{
+				Name:          "ng-1",
+				NewName:       "ng-blue",
+				AMI:           "ami-123",
+				InstanceTypes: []string{"m6i.large"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replacementCfg.NodeGroups).To(HaveLen(1))
+			ng := replacementCfg.NodeGroups[0]
+			Expect(ng.Name).To(Equal("ng-blue"))
+			Expect(ng.AMI).To(Equal("ami-123"))
+			Expect(ng.InstanceType).To(Equal("m6i.large"))
+			Expect(cfg.NodeGroups[0].InstanceType).To(Equal("m5.large"))
+		})
+
+		It("applies the overrides to a copy of a managed nodegroup", func() {
+			replacementCfg, err := nodegroup.NewReplacementConfig(cfg, nodegroup.ReplaceOptions{
+				Name:           "mng-1-v2",
+				InstanceTypes:  []string{"m6i.large", "m6a.large"},
+				ReleaseVersion: "1.30.0-20240703",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replacementCfg.ManagedNodeGroups).To(HaveLen(1))
+			ng := replacementCfg.ManagedNodeGroups[0]
+			Expect(ng.Name).To(Equal("mng-1-v3"))
+			Expect(ng.InstanceType).To(BeEmpty())
+			Expect(ng.InstanceTypes).To(Equal([]string{"m6i.large", "m6a.large"}))
+			Expect(ng.ReleaseVersion).To(Equal("1.30.0-20240703"))
+		})
+
+		DescribeTable("rejects invalid replacements", func(options nodegroup.ReplaceOptions, expectedErr string) {
+			_, err := nodegroup.NewReplacementConfig(cfg, options)
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+			Entry("unknown nodegroup", nodegroup.ReplaceOptions{Name: "ng-2"}, `nodegroup "ng-2" not found in config file`),
+			Entry("same name", nodegroup.ReplaceOptions{Name: "ng-1", NewName: "ng-1"}, "must have a different name"),
+			Entry("invalid name", nodegroup.ReplaceOptions{Name: "ng-1", NewName: "ng_1"}, "validation for ng_1 failed"),
+			Entry("release version for an unmanaged nodegroup", nodegroup.ReplaceOptions{Name: "ng-1", ReleaseVersion: "1.30.0-20240703"}, "only supported for managed nodegroups"),
+			Entry("multiple instance types without instancesDistribution", nodegroup.ReplaceOptions{Name: "ng-1", InstanceTypes: []string{"m5.large", "m6i.large"}}, "set instancesDistribution"),
+		)
+	})
+
+	DescribeTable("NodeGroupGeneration", func(name, expectedBaseName string, expectedGeneration int) {
+		baseName, generation := nodegroup.NodeGroupGeneration(name)
+		Expect(baseName).To(Equal(expectedBaseName))
+		Expect(generation).To(Equal(expectedGeneration))
+		Expect(nodegroup.NextNodeGroupName(name)).To(Equal(fmt.Sprintf("%s-v%d", expectedBaseName, expectedGeneration+1)))
+	},
+		Entry("first generation", "ng-1", "ng-1", 1),
+		Entry("later generation", "ng-1-v3", "ng-1", 3),
+		Entry("suffix without a number", "ng-vx", "ng-vx", 1),
+	)
+})
@@ -112,6 +112,7 @@ func (d *Deleter) DeleteTasks(ctx context.Context, podIDs []Identifier) (*tasks.
 				Doer: func() error {
 					return d.deleteRoleStack(ctx, name)
 				},
+				Resource: tasks.ResourceInfo{Kind: tasks.KindPodIdentityAssociation, StackName: name},
 			})
 		}
 		return taskTree, nil
@@ -170,6 +171,7 @@ func (d *Deleter) makeDeleteTask(ctx context.Context, p Identifier, roleStackNam
 	}
 	return &tasks.GenericTask{
 		Description: fmt.Sprintf("delete pod identity association %q", podIdentityAssociationID),
+		Resource:    tasks.ResourceInfo{Kind: tasks.KindPodIdentityAssociation},
 		Doer: func() error {
 			if len(output.Associations) == 1 {
 				if _, err := d.APIDeleter.DeletePodIdentityAssociation(ctx, &eks.DeletePodIdentityAssociationInput{
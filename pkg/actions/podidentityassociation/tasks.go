@@ -40,6 +40,10 @@ func (t *createPodIdentityAssociationTask) Describe() string {
 	return t.info
 }
 
+func (t *createPodIdentityAssociationTask) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: tasks.KindPodIdentityAssociation}
+}
+
 func (t *createPodIdentityAssociationTask) Do(errorCh chan error) error {
 	defer close(errorCh)
 
@@ -0,0 +1,393 @@
+package workload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kris-nova/logger"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/fargate/coredns"
+	"github.com/weaveworks/eksctl/pkg/utils/retry"
+)
+
+// Target is the compute workloads are moved to.
+type Target string
+
+const (
+	// TargetNodeGroup moves workloads to the EC2 nodes of nodegroups.
+	TargetNodeGroup Target = "nodegroup"
+	// TargetFargate moves workloads to Fargate.
+	TargetFargate Target = "fargate"
+)
+
+// Targets are the valid targets of a move.
+var Targets = []Target{TargetNodeGroup, TargetFargate}
+
+func (t Target) computeType() string {
+	if t == TargetFargate {
+		return coredns.ComputeTypeFargate
+	}
+	return coredns.ComputeTypeEC2
+}
+
+// Description is the name of the compute of the target in log and error messages.
+func (t Target) Description() string {
+	if t == TargetFargate {
+		return "Fargate"
+	}
+	return "nodegroups"
+}
+
+// Placement is the node a pod of a moved workload runs on.
+type Placement struct {
+	Workload string `json:"workload"`
+	Pod      string `json:"pod"`
+	Node     string `json:"node"`
+}
+
+// Mover moves the Deployments and StatefulSets of a namespace between nodegroups and Fargate.
+type Mover struct {
+	clientSet   kubernetes.Interface
+	retryPolicy retry.Policy
+}
+
+// NewMover returns a new Mover. retryPolicy controls how long Move waits for pods to be rescheduled.
+func NewMover(clientSet kubernetes.Interface, retryPolicy retry.Policy) *Mover {
+	return &Mover{
+		clientSet:   clientSet,
+		retryPolicy: retryPolicy,
+	}
+}
+
+// workload is a Deployment or a StatefulSet.
+type workload struct {
+	kind     string
+	name     string
+	template metav1.ObjectMeta
+	selector *metav1.LabelSelector
+	patch    func(ctx context.Context, data []byte) error
+	// rolledOut reports whether all replicas of the workload have been updated and are ready.
+	rolledOut func(ctx context.Context) (bool, error)
+}
+
+func (w workload) String() string {
+	return fmt.Sprintf("%s/%s", w.kind, w.name)
+}
+
+// Move sets the compute type annotation on the pod templates of the Deployments and StatefulSets in namespace,
+// waits for their pods to be rescheduled, and returns where the pods run.
+// When moving to Fargate, the pod templates are also given the labels of a Fargate profile selecting namespace.
+func (m *Mover) Move(ctx context.Context, namespace string, target Target, fargateProfiles []*api.FargateProfile) ([]Placement, error) {
+	var labels map[string]string
+	switch target {
+	case TargetFargate:
+		selector, err := findSelector(fargateProfiles, namespace)
+		if err != nil {
+			return nil, err
+		}
+		labels = selector.Labels
+	case TargetNodeGroup:
+		if err := m.checkEC2NodesExist(ctx); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid target %q, valid targets are %v", target, Targets)
+	}
+
+	workloads, err := m.listWorkloads(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(workloads) == 0 {
+		logger.Info("no Deployments or StatefulSets found in namespace %q", namespace)
+		return nil, nil
+	}
+
+	// CoreDNS is moved the same way eksctl schedules it onto Fargate when creating a Fargate profile, unless the
+	// selecting Fargate profile requires labels on its pods.
+	movesCoreDNS := false
+	for _, w := range workloads {
+		if !needsPatch(w.template, target, labels) {
+			logger.Info("%s is already scheduled onto %s", w, target.Description())
+			continue
+		}
+		if isCoreDNS(namespace, w) && len(labels) == 0 {
+			if err := scheduleCoreDNS(m.clientSet, target); err != nil {
+				return nil, err
+			}
+			movesCoreDNS = true
+			continue
+		}
+		patch, err := makePatch(target, labels)
+		if err != nil {
+			return nil, err
+		}
+		if err := w.patch(ctx, patch); err != nil {
+			return nil, fmt.Errorf("patching %s in namespace %q: %w", w, namespace, err)
+		}
+		logger.Info("%s is now schedulable onto %s", w, target.Description())
+	}
+
+	logger.Info("waiting for %d workload(s) in namespace %q to be scheduled onto %s", len(workloads), namespace, target.Description())
+	if movesCoreDNS {
+		if err := waitForCoreDNS(m.clientSet, m.retryPolicy, target); err != nil {
+			return nil, err
+		}
+	}
+	return m.waitForPlacement(ctx, namespace, workloads, target)
+}
+
+func isCoreDNS(namespace string, w workload) bool {
+	return namespace == coredns.Namespace && w.kind == "deployment" && w.name == coredns.Name
+}
+
+func scheduleCoreDNS(clientSet kubernetes.Interface, target Target) error {
+	if target == TargetFargate {
+		return coredns.ScheduleOnFargate(clientSet)
+	}
+	return coredns.ScheduleOnEC2(clientSet)
+}
+
+func waitForCoreDNS(clientSet kubernetes.Interface, retryPolicy retry.Policy, target Target) error {
+	if target == TargetFargate {
+		return coredns.WaitForScheduleOnFargate(clientSet, retryPolicy)
+	}
+	return coredns.WaitForScheduleOnEC2(clientSet, retryPolicy)
+}
+
+func (m *Mover) waitForPlacement(ctx context.Context, namespace string, workloads []workload, target Target) ([]Placement, error) {
+	// Clone the retry policy to ensure this method is re-entrant/thread-safe:
+	retryPolicy := m.retryPolicy.Clone()
+	var pending []string
+	for !retryPolicy.Done() {
+		var (
+			placements []Placement
+			err        error
+		)
+		placements, pending, err = m.placements(ctx, namespace, workloads, target)
+		if err != nil {
+			return nil, err
+		}
+		if len(pending) == 0 {
+			return placements, nil
+		}
+		logger.Debug("waiting for %s to be scheduled onto %s", strings.Join(pending, ", "), target.Description())
+		time.Sleep(retryPolicy.Duration())
+	}
+	return nil, fmt.Errorf("timed out while waiting for %s in namespace %q to be scheduled onto %s", strings.Join(pending, ", "), namespace, target.Description())
+}
+
+// placements returns where the pods of workloads run, and the workloads that are not fully scheduled onto target yet.
+func (m *Mover) placements(ctx context.Context, namespace string, workloads []workload, target Target) ([]Placement, []string, error) {
+	var (
+		placements []Placement
+		pending    []string
+	)
+	for _, w := range workloads {
+		rolledOut, err := w.rolledOut(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !rolledOut {
+			pending = append(pending, w.String())
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(w.selector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing selector of %s: %w", w, err)
+		}
+		pods, err := m.clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing pods of %s: %w", w, err)
+		}
+		onTarget := true
+		for _, pod := range pods.Items {
+			if pod.DeletionTimestamp != nil {
+				continue
+			}
+			if !isRunningOn(&pod, target) {
+				onTarget = false
+				break
+			}
+			placements = append(placements, Placement{
+				Workload: w.String(),
+				Pod:      pod.Name,
+				Node:     pod.Spec.NodeName,
+			})
+		}
+		if !onTarget {
+			pending = append(pending, w.String())
+		}
+	}
+	return placements, pending, nil
+}
+
+func isRunningOn(pod *corev1.Pod, target Target) bool {
+	logger.Debug("pod %q with status %q is scheduled on %q", pod.Name, pod.Status.Phase, pod.Spec.NodeName)
+	return pod.Status.Phase == corev1.PodRunning &&
+		pod.Spec.NodeName != "" &&
+		coredns.IsFargateNode(pod.Spec.NodeName) == (target == TargetFargate)
+}
+
+func (m *Mover) checkEC2NodesExist(ctx context.Context) error {
+	nodes, err := m.clientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing nodes: %w", err)
+	}
+	for _, node := range nodes.Items {
+		if !coredns.IsFargateNode(node.Name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("no EC2 nodes found in the cluster; create a nodegroup before moving workloads off Fargate")
+}
+
+func (m *Mover) listWorkloads(ctx context.Context, namespace string) ([]workload, error) {
+	var workloads []workload
+	deployments := m.clientSet.AppsV1().Deployments(namespace)
+	deploymentList, err := deployments.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing Deployments in namespace %q: %w", namespace, err)
+	}
+	for _, d := range deploymentList.Items {
+		name := d.Name
+		workloads = append(workloads, workload{
+			kind:     "deployment",
+			name:     name,
+			template: d.Spec.Template.ObjectMeta,
+			selector: d.Spec.Selector,
+			patch: func(ctx context.Context, data []byte) error {
+				_, err := deployments.Patch(ctx, name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+				return err
+			},
+			rolledOut: func(ctx context.Context) (bool, error) {
+				d, err := deployments.Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					return false, err
+				}
+				return isDeploymentRolledOut(d), nil
+			},
+		})
+	}
+
+	statefulSets := m.clientSet.AppsV1().StatefulSets(namespace)
+	statefulSetList, err := statefulSets.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing StatefulSets in namespace %q: %w", namespace, err)
+	}
+	for _, s := range statefulSetList.Items {
+		name := s.Name
+		workloads = append(workloads, workload{
+			kind:     "statefulset",
+			name:     name,
+			template: s.Spec.Template.ObjectMeta,
+			selector: s.Spec.Selector,
+			patch: func(ctx context.Context, data []byte) error {
+				_, err := statefulSets.Patch(ctx, name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+				return err
+			},
+			rolledOut: func(ctx context.Context) (bool, error) {
+				s, err := statefulSets.Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					return false, err
+				}
+				return isStatefulSetRolledOut(s), nil
+			},
+		})
+	}
+	return workloads, nil
+}
+
+func isDeploymentRolledOut(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.Replicas == replicas &&
+		d.Status.UpdatedReplicas == replicas &&
+		d.Status.ReadyReplicas == replicas
+}
+
+func isStatefulSetRolledOut(s *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+	return s.Status.ObservedGeneration >= s.Generation &&
+		s.Status.CurrentRevision == s.Status.UpdateRevision &&
+		s.Status.UpdatedReplicas == replicas &&
+		s.Status.ReadyReplicas == replicas
+}
+
+func needsPatch(template metav1.ObjectMeta, target Target, labels map[string]string) bool {
+	if template.Annotations[coredns.ComputeTypeAnnotationKey] != target.computeType() {
+		return true
+	}
+	for k, v := range labels {
+		if template.Labels[k] != v {
+			return true
+		}
+	}
+	return false
+}
+
+func makePatch(target Target, labels map[string]string) ([]byte, error) {
+	metadata := map[string]interface{}{
+		"annotations": map[string]string{
+			coredns.ComputeTypeAnnotationKey: target.computeType(),
+		},
+	}
+	if len(labels) > 0 {
+		metadata["labels"] = labels
+	}
+	return json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": metadata,
+			},
+		},
+	})
+}
+
+// findSelector returns the Fargate profile selector with the fewest labels that selects namespace.
+// Selectors with wildcards in label values are skipped, as they do not determine the labels pods need.
+func findSelector(profiles []*api.FargateProfile, namespace string) (*api.FargateProfileSelector, error) {
+	var selectors []api.FargateProfileSelector
+	for _, profile := range profiles {
+		for _, selector := range profile.Selectors {
+			if matched, _ := path.Match(selector.Namespace, namespace); matched && !hasWildcardValues(selector.Labels) {
+				selectors = append(selectors, selector)
+			}
+		}
+	}
+	if len(selectors) == 0 {
+		return nil, fmt.Errorf("no Fargate profile selects namespace %q; create one with `eksctl create fargateprofile --namespace %s`", namespace, namespace)
+	}
+	sort.SliceStable(selectors, func(i, j int) bool {
+		return len(selectors[i].Labels) < len(selectors[j].Labels)
+	})
+	return &selectors[0], nil
+}
+
+func hasWildcardValues(labels map[string]string) bool {
+	for _, v := range labels {
+		if strings.ContainsAny(v, "*?") {
+			return true
+		}
+	}
+	return false
+}
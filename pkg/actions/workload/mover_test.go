@@ -0,0 +1,205 @@
+package workload_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/workload"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/fargate/coredns"
+	"github.com/weaveworks/eksctl/pkg/utils/retry"
+)
+
+const (
+	namespace   = "apps"
+	fargateNode = "fargate-ip-192-168-1-1.us-west-2.compute.internal"
+	ec2Node     = "ip-192-168-2-2.us-west-2.compute.internal"
+)
+
+var _ = Describe("Mover", func() {
+	var (
+		retryPolicy = &retry.ConstantBackoff{
+			Time: 0, TimeUnit: time.Second, MaxRetries: 1,
+		}
+		profiles = []*api.FargateProfile{
+			{
+				Name: "labelled",
+				Selectors: []api.FargateProfileSelector{
+					{Namespace: "app*", Labels: map[string]string{"compute": "fargate"}},
+				},
+			},
+		}
+	)
+
+	move := func(target workload.Target, objects ...runtime.Object) (*fake.Clientset, []workload.Placement, error) {
+		clientSet := fake.NewSimpleClientset(objects...)
+		placements, err := workload.NewMover(clientSet, retryPolicy).Move(context.Background(), namespace, target, profiles)
+		return clientSet, placements, err
+	}
+
+	It("moves workloads to Fargate and adds the labels of the selecting Fargate profile", func() {
+		clientSet, placements, err := move(workload.TargetFargate,
+			deployment("web", "ec2"), pod("web-1", "web", fargateNode),
+			statefulSet("db"), pod("db-0", "db", fargateNode),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(placements).To(ConsistOf(
+			workload.Placement{Workload: "deployment/web", Pod: "web-1", Node: fargateNode},
+			workload.Placement{Workload: "statefulset/db", Pod: "db-0", Node: fargateNode},
+		))
+
+		d, err := clientSet.AppsV1().Deployments(namespace).Get(context.Background(), "web", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.Spec.Template.Annotations).To(HaveKeyWithValue(coredns.ComputeTypeAnnotationKey, "fargate"))
+		Expect(d.Spec.Template.Labels).To(HaveKeyWithValue("compute", "fargate"))
+		Expect(d.Spec.Template.Labels).To(HaveKeyWithValue("app", "web"))
+
+		s, err := clientSet.AppsV1().StatefulSets(namespace).Get(context.Background(), "db", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Spec.Template.Annotations).To(HaveKeyWithValue(coredns.ComputeTypeAnnotationKey, "fargate"))
+	})
+
+	It("moves workloads to nodegroups", func() {
+		clientSet, placements, err := move(workload.TargetNodeGroup,
+			node(ec2Node), deployment("web", "fargate"), pod("web-1", "web", ec2Node),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(placements).To(ConsistOf(workload.Placement{Workload: "deployment/web", Pod: "web-1", Node: ec2Node}))
+
+		d, err := clientSet.AppsV1().Deployments(namespace).Get(context.Background(), "web", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.Spec.Template.Annotations).To(HaveKeyWithValue(coredns.ComputeTypeAnnotationKey, "ec2"))
+	})
+
+	It("moves CoreDNS to nodegroups", func() {
+		clientSet := fake.NewSimpleClientset(node(ec2Node), coreDNSDeployment("fargate"), coreDNSPod("ec2", ec2Node))
+		placements, err := workload.NewMover(clientSet, retryPolicy).Move(context.Background(), coredns.Namespace, workload.TargetNodeGroup, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(placements).To(ConsistOf(workload.Placement{Workload: "deployment/coredns", Pod: "coredns-1", Node: ec2Node}))
+
+		d, err := clientSet.AppsV1().Deployments(coredns.Namespace).Get(context.Background(), coredns.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.Spec.Template.Annotations).To(HaveKeyWithValue(coredns.ComputeTypeAnnotationKey, "ec2"))
+	})
+
+	It("moves CoreDNS to Fargate", func() {
+		clientSet := fake.NewSimpleClientset(coreDNSDeployment("ec2"), coreDNSPod("fargate", fargateNode))
+		placements, err := workload.NewMover(clientSet, retryPolicy).Move(context.Background(), coredns.Namespace, workload.TargetFargate, []*api.FargateProfile{
+			{
+				Name:      "default",
+				Selectors: []api.FargateProfileSelector{{Namespace: coredns.Namespace}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(placements).To(ConsistOf(workload.Placement{Workload: "deployment/coredns", Pod: "coredns-1", Node: fargateNode}))
+
+		d, err := clientSet.AppsV1().Deployments(coredns.Namespace).Get(context.Background(), coredns.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.Spec.Template.Annotations).To(HaveKeyWithValue(coredns.ComputeTypeAnnotationKey, "fargate"))
+	})
+
+	It("times out when CoreDNS pods are not rescheduled", func() {
+		clientSet := fake.NewSimpleClientset(node(ec2Node), coreDNSDeployment("fargate"), coreDNSPod("fargate", fargateNode))
+		_, err := workload.NewMover(clientSet, retryPolicy).Move(context.Background(), coredns.Namespace, workload.TargetNodeGroup, nil)
+		Expect(err).To(MatchError(`timed out while waiting for "coredns" to be scheduled on EC2 nodes`))
+	})
+
+	It("errors when no Fargate profile selects the namespace", func() {
+		clientSet := fake.NewSimpleClientset(deployment("web", "ec2"))
+		_, err := workload.NewMover(clientSet, retryPolicy).Move(context.Background(), "other", workload.TargetFargate, profiles)
+		Expect(err).To(MatchError(ContainSubstring(`no Fargate profile selects namespace "other"`)))
+	})
+
+	It("errors when the cluster has no EC2 nodes", func() {
+		_, _, err := move(workload.TargetNodeGroup, node(fargateNode), deployment("web", "fargate"))
+		Expect(err).To(MatchError(ContainSubstring("no EC2 nodes found")))
+	})
+
+	It("times out when pods are not rescheduled", func() {
+		_, _, err := move(workload.TargetNodeGroup,
+			node(ec2Node), deployment("web", "fargate"), pod("web-1", "web", fargateNode),
+		)
+		Expect(err).To(MatchError(`timed out while waiting for deployment/web in namespace "apps" to be scheduled onto nodegroups`))
+	})
+
+	It("ignores terminating pods", func() {
+		terminating := pod("web-0", "web", fargateNode)
+		terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		_, placements, err := move(workload.TargetNodeGroup,
+			node(ec2Node), deployment("web", "fargate"), terminating, pod("web-1", "web", ec2Node),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(placements).To(HaveLen(1))
+	})
+})
+
+func deployment(name, computeType string) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": name},
+					Annotations: map[string]string{coredns.ComputeTypeAnnotationKey: computeType},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1},
+	}
+}
+
+func statefulSet(name string) *appsv1.StatefulSet {
+	replicas := int32(1)
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{UpdatedReplicas: 1, ReadyReplicas: 1},
+	}
+}
+
+func pod(name, app, nodeName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{"app": app},
+		},
+		Spec:   corev1.PodSpec{NodeName: nodeName},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func coreDNSDeployment(computeType string) *appsv1.Deployment {
+	d := deployment(coredns.Name, computeType)
+	d.Namespace = coredns.Namespace
+	return d
+}
+
+func coreDNSPod(computeType, nodeName string) *corev1.Pod {
+	p := pod("coredns-1", coredns.Name, nodeName)
+	p.Namespace = coredns.Namespace
+	p.Labels["eks.amazonaws.com/component"] = coredns.Name
+	p.Annotations = map[string]string{coredns.ComputeTypeAnnotationKey: computeType}
+	return p
+}
+
+func node(name string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
@@ -0,0 +1,286 @@
+package v1alpha5
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/weaveworks/eksctl/pkg/utils"
+)
+
+// KubeletImageMaximumGCAgeMinVersion is the minimum Kubernetes version that supports kubelet.imageMaximumGCAge.
+const KubeletImageMaximumGCAgeMinVersion = Version1_30
+
+var (
+	kubeletEvictionSignals = []string{
+		"memory.available",
+		"nodefs.available",
+		"nodefs.inodesFree",
+		"imagefs.available",
+		"imagefs.inodesFree",
+		"pid.available",
+	}
+
+	kubeletReservedResources = []string{
+		"cpu",
+		"memory",
+		"ephemeral-storage",
+		"pid",
+	}
+)
+
+// FieldNames returns the names of the fields that are set in k, sorted alphabetically.
+func (k *NodeGroupKubelet) FieldNames() []string {
+	doc, err := k.toInlineDocument()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for name := range doc {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ToKubeletConfig returns the fields that are set in k, except maxPods, as KubeletConfiguration fields.
+// maxPods is excluded as it is set the same way as maxPodsPerNode by each AMI family.
+func (k *NodeGroupKubelet) ToKubeletConfig() (InlineDocument, error) {
+	doc, err := k.toInlineDocument()
+	if err != nil {
+		return nil, err
+	}
+	delete(doc, "maxPods")
+	return doc, nil
+}
+
+func (k *NodeGroupKubelet) toInlineDocument() (InlineDocument, error) {
+	data, err := json.Marshal(k)
+	if err != nil {
+		return nil, err
+	}
+	var doc InlineDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// GetMaxPodsPerNode returns the maximum number of pods per node set in either maxPodsPerNode or kubelet.maxPods.
+func (n *NodeGroupBase) GetMaxPodsPerNode() int {
+	if n.Kubelet != nil && n.Kubelet.MaxPods != nil {
+		return *n.Kubelet.MaxPods
+	}
+	return n.MaxPodsPerNode
+}
+
+func validateNodeGroupKubelet(np NodePool, path string) error {
+	ng := np.BaseNodeGroup()
+	kubelet := ng.Kubelet
+	kubeletPath := path + ".kubelet"
+
+	if kubelet.MaxPods != nil {
+		if *kubelet.MaxPods <= 0 {
+			return fmt.Errorf("%s.maxPods must be greater than 0", kubeletPath)
+		}
+		if ng.MaxPodsPerNode != 0 {
+			return fmt.Errorf("only one of %s.maxPodsPerNode or %s.maxPods can be set", path, kubeletPath)
+		}
+	}
+
+	if err := validateEvictionThresholds(kubelet.EvictionHard, kubeletPath+".evictionHard"); err != nil {
+		return err
+	}
+	if err := validateEvictionThresholds(kubelet.EvictionSoft, kubeletPath+".evictionSoft"); err != nil {
+		return err
+	}
+	for signal := range kubelet.EvictionSoft {
+		if _, ok := kubelet.EvictionSoftGracePeriod[signal]; !ok {
+			return fmt.Errorf("%s.evictionSoftGracePeriod must be set for eviction signal %q in %s.evictionSoft", kubeletPath, signal, kubeletPath)
+		}
+	}
+	for signal, gracePeriod := range kubelet.EvictionSoftGracePeriod {
+		if _, ok := kubelet.EvictionSoft[signal]; !ok {
+			return fmt.Errorf("%s.evictionSoft must be set for eviction signal %q in %s.evictionSoftGracePeriod", kubeletPath, signal, kubeletPath)
+		}
+		if err := validatePositiveDuration(gracePeriod, fmt.Sprintf("%s.evictionSoftGracePeriod[%q]", kubeletPath, signal)); err != nil {
+			return err
+		}
+	}
+
+	if err := validateReservedResources(kubelet.KubeReserved, kubeletPath+".kubeReserved"); err != nil {
+		return err
+	}
+	if err := validateReservedResources(kubelet.SystemReserved, kubeletPath+".systemReserved"); err != nil {
+		return err
+	}
+
+	if err := validatePercent(kubelet.ImageGCHighThresholdPercent, kubeletPath+".imageGCHighThresholdPercent"); err != nil {
+		return err
+	}
+	if err := validatePercent(kubelet.ImageGCLowThresholdPercent, kubeletPath+".imageGCLowThresholdPercent"); err != nil {
+		return err
+	}
+	if kubelet.ImageGCHighThresholdPercent != nil && kubelet.ImageGCLowThresholdPercent != nil &&
+		*kubelet.ImageGCLowThresholdPercent >= *kubelet.ImageGCHighThresholdPercent {
+		return fmt.Errorf("%[1]s.imageGCLowThresholdPercent must be less than %[1]s.imageGCHighThresholdPercent", kubeletPath)
+	}
+	if kubelet.ImageMaximumGCAge != "" {
+		if err := validatePositiveDuration(kubelet.ImageMaximumGCAge, kubeletPath+".imageMaximumGCAge"); err != nil {
+			return err
+		}
+	}
+
+	if err := validateOneOf(kubelet.CPUManagerPolicy, kubeletPath+".cpuManagerPolicy",
+		KubeletCPUManagerPolicyNone, KubeletCPUManagerPolicyStatic); err != nil {
+		return err
+	}
+	if err := validateOneOf(kubelet.TopologyManagerPolicy, kubeletPath+".topologyManagerPolicy",
+		KubeletTopologyManagerPolicyNone, KubeletTopologyManagerPolicyBestEffort, KubeletTopologyManagerPolicyRestricted, KubeletTopologyManagerPolicySingleNUMANode); err != nil {
+		return err
+	}
+	if err := validateOneOf(kubelet.TopologyManagerScope, kubeletPath+".topologyManagerScope",
+		KubeletTopologyManagerScopeContainer, KubeletTopologyManagerScopePod); err != nil {
+		return err
+	}
+
+	return validateNodeGroupKubeletAMIFamily(np, path)
+}
+
+// validateNodeGroupKubeletAMIFamily checks that the kubelet settings can be applied to nodes of the AMI family of np.
+func validateNodeGroupKubeletAMIFamily(np NodePool, path string) error {
+	ng := np.BaseNodeGroup()
+	fieldNotSupported := func(field string) error {
+		return &unsupportedFieldError{
+			ng:    ng,
+			path:  path,
+			field: "kubelet." + field,
+		}
+	}
+
+	if mng, ok := np.(*ManagedNodeGroup); ok {
+		if IsWindowsImage(ng.AMIFamily) {
+			return fmt.Errorf("%s.kubelet is not supported for managed Windows nodegroups", path)
+		}
+		if mng.AMI != "" && ng.AMIFamily != NodeImageFamilyAmazonLinux2023 {
+			return fmt.Errorf("%s.kubelet is not supported when using a custom AMI based on %s (%s.ami)", path, ng.AMIFamily, path)
+		}
+	}
+
+	switch {
+	case IsWindowsImage(ng.AMIFamily):
+		// the CPU and topology managers are not supported by the kubelet on Windows
+		if ng.Kubelet.CPUManagerPolicy != "" {
+			return fieldNotSupported("cpuManagerPolicy")
+		}
+		if ng.Kubelet.TopologyManagerPolicy != "" {
+			return fieldNotSupported("topologyManagerPolicy")
+		}
+		if ng.Kubelet.TopologyManagerScope != "" {
+			return fieldNotSupported("topologyManagerScope")
+		}
+	case ng.AMIFamily == NodeImageFamilyBottlerocket:
+		if ng.Kubelet.ImageMaximumGCAge != "" {
+			return fieldNotSupported("imageMaximumGCAge")
+		}
+	}
+	return nil
+}
+
+// validateNodeGroupKubeletVersion checks that the kubelet settings of ng are supported by nodes running
+// Kubernetes version kubernetesVersion, which is set in versionPath.
+func validateNodeGroupKubeletVersion(ng *NodeGroupBase, path, kubernetesVersion, versionPath string) error {
+	if ng.Kubelet == nil || kubernetesVersion == "" {
+		return nil
+	}
+	// release versions of EKS optimized AMIs have the form 1.29.3-20240531, only the minor version matters
+	version, err := semver.ParseTolerant(kubernetesVersion)
+	if err != nil {
+		return fmt.Errorf("invalid Kubernetes version %q in %s: %w", kubernetesVersion, versionPath, err)
+	}
+	kubernetesVersion = fmt.Sprintf("%d.%d", version.Major, version.Minor)
+	if ng.Kubelet.ImageMaximumGCAge != "" {
+		supported, err := utils.IsMinVersion(KubeletImageMaximumGCAgeMinVersion, kubernetesVersion)
+		if err != nil {
+			return err
+		}
+		if !supported {
+			return fmt.Errorf("%s.kubelet.imageMaximumGCAge requires Kubernetes version %s or later; got %s", path, KubeletImageMaximumGCAgeMinVersion, kubernetesVersion)
+		}
+	}
+	return nil
+}
+
+// validateKubeletExtraConfigConflicts checks that no kubelet setting is set in both ng.Kubelet and ng.KubeletExtraConfig.
+func validateKubeletExtraConfigConflicts(ng *NodeGroup, path string) error {
+	if ng.Kubelet == nil || ng.KubeletExtraConfig == nil {
+		return nil
+	}
+	for _, field := range ng.Kubelet.FieldNames() {
+		if _, ok := (*ng.KubeletExtraConfig)[field]; ok {
+			return fmt.Errorf("only one of %[1]s.kubelet.%[2]s or %[1]s.kubeletExtraConfig.%[2]s can be set", path, field)
+		}
+	}
+	return nil
+}
+
+func validateEvictionThresholds(thresholds map[string]string, path string) error {
+	for signal, threshold := range thresholds {
+		if !slices.Contains(kubeletEvictionSignals, signal) {
+			return fmt.Errorf("invalid eviction signal %q in %s; must be one of %s", signal, path, strings.Join(kubeletEvictionSignals, ", "))
+		}
+		if percent, ok := strings.CutSuffix(threshold, "%"); ok {
+			if _, err := resource.ParseQuantity(percent); err != nil {
+				return fmt.Errorf("invalid percentage %q for eviction signal %q in %s", threshold, signal, path)
+			}
+			continue
+		}
+		if _, err := resource.ParseQuantity(threshold); err != nil {
+			return fmt.Errorf("invalid quantity %q for eviction signal %q in %s: %w", threshold, signal, path, err)
+		}
+	}
+	return nil
+}
+
+func validateReservedResources(reserved map[string]string, path string) error {
+	for name, quantity := range reserved {
+		if !slices.Contains(kubeletReservedResources, name) {
+			return fmt.Errorf("invalid resource %q in %s; must be one of %s", name, path, strings.Join(kubeletReservedResources, ", "))
+		}
+		if _, err := resource.ParseQuantity(quantity); err != nil {
+			return fmt.Errorf("invalid quantity %q for resource %q in %s: %w", quantity, name, path, err)
+		}
+	}
+	return nil
+}
+
+func validatePercent(value *int, path string) error {
+	if value != nil && (*value < 0 || *value > 100) {
+		return fmt.Errorf("%s must be between 0 and 100", path)
+	}
+	return nil
+}
+
+func validatePositiveDuration(value, path string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q in %s: %w", value, path, err)
+	}
+	if d <= 0 {
+		return fmt.Errorf("%s must be a positive duration", path)
+	}
+	return nil
+}
+
+func validateOneOf(value, path string, validValues ...string) error {
+	if value != "" && !slices.Contains(validValues, value) {
+		return fmt.Errorf("invalid value %q for %s; must be one of %s", value, path, strings.Join(validValues, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package v1alpha5_test
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("Kubelet validation", func() {
+	type kubeletEntry struct {
+		amiFamily          string
+		kubelet            *api.NodeGroupKubelet
+		updateNodeGroup    func(*api.NodeGroup)
+		kubernetesVersion  string
+		expectedErr        string
+		expectedManagedErr string
+	}
+
+	validKubelet := func() *api.NodeGroupKubelet {
+		return &api.NodeGroupKubelet{
+			MaxPods: aws.Int(50),
+			EvictionHard: map[string]string{
+				"memory.available": "200Mi",
+				"nodefs.available": "10%",
+			},
+			EvictionSoft: map[string]string{
+				"memory.available": "500Mi",
+			},
+			EvictionSoftGracePeriod: map[string]string{
+				"memory.available": "1m30s",
+			},
+			KubeReserved: map[string]string{
+				"cpu":    "300m",
+				"memory": "300Mi",
+			},
+			SystemReserved: map[string]string{
+				"ephemeral-storage": "1Gi",
+			},
+			ImageGCHighThresholdPercent: aws.Int(85),
+			ImageGCLowThresholdPercent:  aws.Int(80),
+			CPUManagerPolicy:            api.KubeletCPUManagerPolicyStatic,
+			TopologyManagerPolicy:       api.KubeletTopologyManagerPolicySingleNUMANode,
+			TopologyManagerScope:        api.KubeletTopologyManagerScopePod,
+		}
+	}
+
+	DescribeTable("nodegroups", func(e kubeletEntry) {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "cluster"
+		if e.kubernetesVersion != "" {
+			cfg.Metadata.Version = e.kubernetesVersion
+		}
+		ng := cfg.NewNodeGroup()
+		ng.Name = "ng"
+		ng.AMIFamily = e.amiFamily
+		ng.Kubelet = e.kubelet
+		if e.updateNodeGroup != nil {
+			e.updateNodeGroup(ng)
+		}
+		mng := api.NewManagedNodeGroup()
+		mng.Name = "mng"
+		mng.AMIFamily = e.amiFamily
+		mng.Kubelet = e.kubelet
+
+		err := api.ValidateNodeGroup(0, ng, cfg)
+		if err == nil {
+			err = api.ValidateClusterConfig(cfg)
+		}
+		if e.expectedErr != "" {
+			Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		if e.updateNodeGroup != nil {
+			return
+		}
+		err = api.ValidateManagedNodeGroup(0, mng)
+		switch {
+		case e.expectedManagedErr != "":
+			Expect(err).To(MatchError(ContainSubstring(e.expectedManagedErr)))
+		case e.expectedErr != "":
+			Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+		default:
+			Expect(err).NotTo(HaveOccurred())
+		}
+	},
+		Entry("valid settings on AmazonLinux2023", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet:   validKubelet(),
+		}),
+		Entry("valid settings on AmazonLinux2", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2,
+			kubelet:   validKubelet(),
+		}),
+		Entry("valid settings on Bottlerocket", kubeletEntry{
+			amiFamily: api.NodeImageFamilyBottlerocket,
+			kubelet:   validKubelet(),
+		}),
+		Entry("valid settings on Ubuntu", kubeletEntry{
+			amiFamily: api.NodeImageFamilyUbuntu2204,
+			kubelet:   validKubelet(),
+		}),
+		Entry("non-positive maxPods", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet: &api.NodeGroupKubelet{
+				MaxPods: aws.Int(0),
+			},
+			expectedErr: "kubelet.maxPods must be greater than 0",
+		}),
+		Entry("maxPods and maxPodsPerNode", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet: &api.NodeGroupKubelet{
+				MaxPods: aws.Int(20),
+			},
+			updateNodeGroup: func(ng *api.NodeGroup) {
+				ng.MaxPodsPerNode = 30
+			},
+			expectedErr: "only one of nodeGroups[0].maxPodsPerNode or nodeGroups[0].kubelet.maxPods can be set",
+		}),
+		Entry("invalid eviction signal", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet: &api.NodeGroupKubelet{
+				EvictionHard: map[string]string{
+					"memory.free": "100Mi",
+				},
+			},
+			expectedErr: `invalid eviction signal "memory.free" in `,
+		}),
+		Entry("invalid eviction threshold", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet: &api.NodeGroupKubelet{
+				EvictionHard: map[string]string{
+					"nodefs.available": "ten%",
+				},
+			},
+			expectedErr: `invalid percentage "ten%" for eviction signal "nodefs.available"`,
+		}),
+		Entry("soft eviction threshold without a grace period", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet: &api.NodeGroupKubelet{
+				EvictionSoft: map[string]string{
+					"memory.available": "500Mi",
+				},
+			},
+			expectedErr: `kubelet.evictionSoftGracePeriod must be set for eviction signal "memory.available"`,
+		}),
+		Entry("invalid soft eviction grace period", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet: &api.NodeGroupKubelet{
+				EvictionSoft: map[string]string{
+					"memory.available": "500Mi",
+				},
+				EvictionSoftGracePeriod: map[string]string{
+					"memory.available": "90",
+				},
+			},
+			expectedErr: `invalid duration "90"`,
+		}),
+		Entry("invalid reserved resource", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet: &api.NodeGroupKubelet{
+				KubeReserved: map[string]string{
+					"storage": "1Gi",
+				},
+			},
+			expectedErr: `invalid resource "storage" in `,
+		}),
+		Entry("image GC threshold out of range", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet: &api.NodeGroupKubelet{
+				ImageGCHighThresholdPercent: aws.Int(101),
+			},
+			expectedErr: "kubelet.imageGCHighThresholdPercent must be between 0 and 100",
+		}),
+		Entry("image GC low threshold above high threshold", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet: &api.NodeGroupKubelet{
+				ImageGCHighThresholdPercent: aws.Int(70),
+				ImageGCLowThresholdPercent:  aws.Int(80),
+			},
+			expectedErr: "kubelet.imageGCLowThresholdPercent must be less than ",
+		}),
+		Entry("invalid CPU manager policy", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet: &api.NodeGroupKubelet{
+				CPUManagerPolicy: "dynamic",
+			},
+			expectedErr: "kubelet.cpuManagerPolicy; must be one of none, static",
+		}),
+		Entry("invalid topology manager scope", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet: &api.NodeGroupKubelet{
+				TopologyManagerScope: "node",
+			},
+			expectedErr: "kubelet.topologyManagerScope; must be one of container, pod",
+		}),
+		Entry("imageMaximumGCAge on a supported Kubernetes version", kubeletEntry{
+			amiFamily:         api.NodeImageFamilyAmazonLinux2023,
+			kubernetesVersion: api.Version1_30,
+			kubelet: &api.NodeGroupKubelet{
+				ImageMaximumGCAge: "168h",
+			},
+		}),
+		Entry("imageMaximumGCAge on Bottlerocket", kubeletEntry{
+			amiFamily: api.NodeImageFamilyBottlerocket,
+			kubelet: &api.NodeGroupKubelet{
+				ImageMaximumGCAge: "168h",
+			},
+			expectedErr: "kubelet.imageMaximumGCAge is not supported for Bottlerocket nodegroups",
+		}),
+		Entry("CPU manager policy on Windows", kubeletEntry{
+			amiFamily: api.NodeImageFamilyWindowsServer2022CoreContainer,
+			kubelet: &api.NodeGroupKubelet{
+				CPUManagerPolicy: api.KubeletCPUManagerPolicyStatic,
+			},
+			expectedErr:        "kubelet.cpuManagerPolicy is not supported for WindowsServer2022CoreContainer nodegroups",
+			expectedManagedErr: "managedNodeGroups[0].kubelet is not supported for managed Windows nodegroups",
+		}),
+		Entry("conflicting kubeletExtraConfig", kubeletEntry{
+			amiFamily: api.NodeImageFamilyAmazonLinux2023,
+			kubelet: &api.NodeGroupKubelet{
+				KubeReserved: map[string]string{
+					"cpu": "300m",
+				},
+			},
+			updateNodeGroup: func(ng *api.NodeGroup) {
+				ng.KubeletExtraConfig = &api.InlineDocument{
+					"kubeReserved": map[string]interface{}{
+						"memory": "300Mi",
+					},
+				}
+			},
+			expectedErr: "only one of nodeGroups[0].kubelet.kubeReserved or nodeGroups[0].kubeletExtraConfig.kubeReserved can be set",
+		}),
+	)
+
+	It("rejects imageMaximumGCAge on an unsupported Kubernetes version", func() {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Version = api.Version1_29
+		mng := api.NewManagedNodeGroup()
+		mng.Name = "mng"
+		mng.Kubelet = &api.NodeGroupKubelet{
+			ImageMaximumGCAge: "168h",
+		}
+		cfg.ManagedNodeGroups = append(cfg.ManagedNodeGroups, mng)
+		Expect(api.ValidateClusterConfig(cfg)).To(MatchError("managedNodeGroups[0].kubelet.imageMaximumGCAge requires Kubernetes version 1.30 or later; got 1.29"))
+	})
+
+	It("checks imageMaximumGCAge against the release version of a managed nodegroup", func() {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Version = api.Version1_30
+		mng := api.NewManagedNodeGroup()
+		mng.Name = "mng"
+		mng.ReleaseVersion = "1.29.3-20240531"
+		mng.Kubelet = &api.NodeGroupKubelet{
+			ImageMaximumGCAge: "168h",
+		}
+		cfg.ManagedNodeGroups = append(cfg.ManagedNodeGroups, mng)
+		Expect(api.ValidateClusterConfig(cfg)).To(MatchError("managedNodeGroups[0].kubelet.imageMaximumGCAge requires Kubernetes version 1.30 or later; got 1.29"))
+
+		mng.ReleaseVersion = "1.30.0-20240531"
+		Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
+
+		mng.ReleaseVersion = "latest"
+		Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring(`invalid Kubernetes version "latest" in managedNodeGroups[0].releaseVersion`)))
+	})
+
+	It("rejects kubelet for managed nodegroups with a custom AmazonLinux2 AMI", func() {
+		mng := api.NewManagedNodeGroup()
+		mng.Name = "mng"
+		mng.AMI = "ami-1234"
+		mng.AMIFamily = api.NodeImageFamilyAmazonLinux2
+		mng.OverrideBootstrapCommand = aws.String("/etc/eks/bootstrap.sh cluster")
+		mng.Kubelet = &api.NodeGroupKubelet{
+			CPUManagerPolicy: api.KubeletCPUManagerPolicyStatic,
+		}
+		Expect(api.ValidateManagedNodeGroup(0, mng)).To(MatchError("managedNodeGroups[0].kubelet is not supported when using a custom AMI based on AmazonLinux2 (managedNodeGroups[0].ami)"))
+	})
+
+	It("returns the maximum number of pods per node from either field", func() {
+		ng := api.NewNodeGroup()
+		Expect(ng.GetMaxPodsPerNode()).To(Equal(0))
+		ng.MaxPodsPerNode = 20
+		Expect(ng.GetMaxPodsPerNode()).To(Equal(20))
+		ng.MaxPodsPerNode = 0
+		ng.Kubelet = &api.NodeGroupKubelet{MaxPods: aws.Int(30)}
+		Expect(ng.GetMaxPodsPerNode()).To(Equal(30))
+	})
+})
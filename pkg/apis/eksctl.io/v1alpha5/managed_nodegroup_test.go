@@ -173,11 +173,16 @@ var _ = Describe("Managed Nodegroup Validation", func() {
 		err := ValidateManagedNodeGroup(0, mng)
 		Expect(err).To(HaveOccurred())
 		Expect(err.Error()).To(ContainSubstring("cannot set instanceType, ami, ssh.allow, ssh.enableSSM, ssh.sourceSecurityGroupIds, securityGroups, " +
-			"volumeSize, instanceName, instancePrefix, maxPodsPerNode, disableIMDSv1, disablePodIMDS, preBootstrapCommands, overrideBootstrapCommand, placement in managedNodeGroup when a launch template is supplied"))
+			"volumeSize, instanceName, instancePrefix, maxPodsPerNode, kubelet, disableIMDSv1, disablePodIMDS, preBootstrapCommands, overrideBootstrapCommand, placement in managedNodeGroup when a launch template is supplied"))
 	},
 		Entry("instanceType", &NodeGroupBase{
 			InstanceType: "m5.xlarge",
 		}),
+		Entry("kubelet", &NodeGroupBase{
+			Kubelet: &NodeGroupKubelet{
+				CPUManagerPolicy: KubeletCPUManagerPolicyStatic,
+			},
+		}),
 		Entry("AMI", &NodeGroupBase{
 			AMI: "ami-custom",
 		}),
@@ -225,6 +225,26 @@ const (
 	ContainerRuntimeDockerForWindows = "docker"
 )
 
+// Values for `KubeletCPUManagerPolicy`
+const (
+	KubeletCPUManagerPolicyNone   = "none"
+	KubeletCPUManagerPolicyStatic = "static"
+)
+
+// Values for `KubeletTopologyManagerPolicy`
+const (
+	KubeletTopologyManagerPolicyNone           = "none"
+	KubeletTopologyManagerPolicyBestEffort     = "best-effort"
+	KubeletTopologyManagerPolicyRestricted     = "restricted"
+	KubeletTopologyManagerPolicySingleNUMANode = "single-numa-node"
+)
+
+// Values for `KubeletTopologyManagerScope`
+const (
+	KubeletTopologyManagerScopeContainer = "container"
+	KubeletTopologyManagerScopePod       = "pod"
+)
+
 const (
 	// DefaultNodeType is the default instance type to use for nodes
 	DefaultNodeType = "m5.large"
@@ -433,6 +453,8 @@ const (
 // supported version of Karpenter
 const (
 	supportedKarpenterVersion = "v0.20.0"
+	// KarpenterNodePoolsMinimumVersion is the minimum Karpenter version serving the NodePool and EC2NodeClass APIs
+	KarpenterNodePoolsMinimumVersion = "v0.32.0"
 )
 
 // Values for Capacity Reservation Preference
@@ -867,6 +889,7 @@ type ClusterProvider interface {
 	CloudFormation() awsapi.CloudFormation
 	CloudFormationRoleARN() string
 	CloudFormationDisableRollback() bool
+	CloudFormationChangeSetPreview() bool
 	ASG() awsapi.ASG
 	EKS() awsapi.EKS
 	SSM() awsapi.SSM
@@ -898,8 +921,9 @@ type STSPresigner interface {
 
 // ProviderConfig holds global parameters for all interactions with AWS APIs
 type ProviderConfig struct {
-	CloudFormationRoleARN         string
-	CloudFormationDisableRollback bool
+	CloudFormationRoleARN          string
+	CloudFormationDisableRollback  bool
+	CloudFormationChangeSetPreview bool
 
 	Region      string
 	Profile     Profile
@@ -1063,6 +1087,35 @@ type Karpenter struct {
 	// WithSpotInterruptionQueue if true, adds all required policies and rules
 	// for supporting Spot Interruption Queue on Karpenter deployments
 	WithSpotInterruptionQueue *bool `json:"withSpotInterruptionQueue,omitempty"`
+	// Values are Helm values for the Karpenter chart, merged over the values set by eksctl
+	// +optional
+	Values InlineDocument `json:"values,omitempty"`
+	// NodePools holds the Karpenter NodePools to apply after Karpenter is installed or upgraded
+	// +optional
+	NodePools []KarpenterNodePool `json:"nodePools,omitempty"`
+	// EC2NodeClasses holds the Karpenter EC2NodeClasses to apply after Karpenter is installed or upgraded
+	// +optional
+	EC2NodeClasses []KarpenterEC2NodeClass `json:"ec2NodeClasses,omitempty"`
+}
+
+// KarpenterNodePool defines a Karpenter NodePool
+type KarpenterNodePool struct {
+	// Name of the NodePool
+	// +required
+	Name string `json:"name"`
+	// Spec is the spec of the NodePool, as documented by Karpenter
+	// +required
+	Spec InlineDocument `json:"spec"`
+}
+
+// KarpenterEC2NodeClass defines a Karpenter EC2NodeClass
+type KarpenterEC2NodeClass struct {
+	// Name of the EC2NodeClass
+	// +required
+	Name string `json:"name"`
+	// Spec is the spec of the EC2NodeClass, as documented by Karpenter
+	// +required
+	Spec InlineDocument `json:"spec"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -1543,6 +1596,55 @@ type (
 		Settings *InlineDocument `json:"settings,omitempty"`
 	}
 
+	// NodeGroupKubelet holds kubelet settings that are translated for the AMI family of a NodeGroup.
+	// Field names match those of the [kubelet
+	// configuration](https://kubernetes.io/docs/reference/config-api/kubelet-config.v1beta1/)
+	NodeGroupKubelet struct {
+		// MaxPods sets the maximum number of pods per node
+		// +optional
+		MaxPods *int `json:"maxPods,omitempty"`
+		// EvictionHard maps eviction signals, such as `memory.available`, to quantities
+		// or percentages that trigger hard eviction
+		// +optional
+		EvictionHard map[string]string `json:"evictionHard,omitempty"`
+		// EvictionSoft maps eviction signals to quantities or percentages that trigger
+		// soft eviction. Each signal requires a grace period in `evictionSoftGracePeriod`
+		// +optional
+		EvictionSoft map[string]string `json:"evictionSoft,omitempty"`
+		// EvictionSoftGracePeriod maps eviction signals to durations, such as `1m30s`
+		// +optional
+		EvictionSoftGracePeriod map[string]string `json:"evictionSoftGracePeriod,omitempty"`
+		// KubeReserved maps resources (`cpu`, `memory`, `ephemeral-storage` and `pid`)
+		// to quantities reserved for Kubernetes system daemons
+		// +optional
+		KubeReserved map[string]string `json:"kubeReserved,omitempty"`
+		// SystemReserved maps resources (`cpu`, `memory`, `ephemeral-storage` and `pid`)
+		// to quantities reserved for OS system daemons
+		// +optional
+		SystemReserved map[string]string `json:"systemReserved,omitempty"`
+		// ImageGCHighThresholdPercent is the percent of disk usage after which
+		// image garbage collection is always run
+		// +optional
+		ImageGCHighThresholdPercent *int `json:"imageGCHighThresholdPercent,omitempty"`
+		// ImageGCLowThresholdPercent is the percent of disk usage before which
+		// image garbage collection is never run
+		// +optional
+		ImageGCLowThresholdPercent *int `json:"imageGCLowThresholdPercent,omitempty"`
+		// ImageMaximumGCAge is the maximum duration an image can be unused before it is
+		// garbage collected. Requires Kubernetes 1.30 or later
+		// +optional
+		ImageMaximumGCAge string `json:"imageMaximumGCAge,omitempty"`
+		// Valid variants are `KubeletCPUManagerPolicy` constants
+		// +optional
+		CPUManagerPolicy string `json:"cpuManagerPolicy,omitempty"`
+		// Valid variants are `KubeletTopologyManagerPolicy` constants
+		// +optional
+		TopologyManagerPolicy string `json:"topologyManagerPolicy,omitempty"`
+		// Valid variants are `KubeletTopologyManagerScope` constants
+		// +optional
+		TopologyManagerScope string `json:"topologyManagerScope,omitempty"`
+	}
+
 	// NodeGroupUpdateConfig contains the configuration for updating NodeGroups.
 	NodeGroupUpdateConfig struct {
 		// MaxUnavailable sets the max number of nodes that can become unavailable
@@ -1678,6 +1780,11 @@ type NodeGroupBase struct {
 	// +optional
 	MaxPodsPerNode int `json:"maxPodsPerNode,omitempty"`
 
+	// Kubelet holds kubelet settings that are applied consistently across AMI families.
+	// See [customizing the kubelet](/usage/customizing-the-kubelet/)
+	// +optional
+	Kubelet *NodeGroupKubelet `json:"kubelet,omitempty"`
+
 	// See [relevant AWS
 	// docs](https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-attribute-updatepolicy.html#cfn-attributes-updatepolicy-rollingupdate-suspendprocesses)
 	// +optional
@@ -171,7 +171,7 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 
 	// names must be unique across both managed and unmanaged nodegroups
 	ngNames := nameSet{}
-	validateNg := func(ng *NodeGroupBase, path string) error {
+	validateNg := func(ng *NodeGroupBase, path, kubernetesVersion, versionPath string) error {
 		if ng.Name == "" {
 			return fmt.Errorf("%s.name must be set", path)
 		}
@@ -181,7 +181,7 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 		if cfg.PrivateCluster.Enabled && !ng.PrivateNetworking {
 			return fmt.Errorf("%s.privateNetworking must be enabled for a fully-private cluster", path)
 		}
-		return nil
+		return validateNodeGroupKubeletVersion(ng, path, kubernetesVersion, versionPath)
 	}
 
 	if err := validateIdentityProviders(cfg.IdentityProviders); err != nil {
@@ -191,7 +191,7 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 	var ngOutpostARN string
 	for i, ng := range cfg.NodeGroups {
 		path := fmt.Sprintf("nodeGroups[%d]", i)
-		if err := validateNg(ng.NodeGroupBase, path); err != nil {
+		if err := validateNg(ng.NodeGroupBase, path, cfg.Metadata.Version, "metadata.version"); err != nil {
 			return err
 		}
 		if ng.OutpostARN != "" {
@@ -204,7 +204,12 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 
 	for i, ng := range cfg.ManagedNodeGroups {
 		path := fmt.Sprintf("managedNodeGroups[%d]", i)
-		if err := validateNg(ng.NodeGroupBase, path); err != nil {
+		// nodes run the Kubernetes version of the AMI release when it is pinned
+		kubernetesVersion, versionPath := cfg.Metadata.Version, "metadata.version"
+		if ng.ReleaseVersion != "" {
+			kubernetesVersion, versionPath = ng.ReleaseVersion, path+".releaseVersion"
+		}
+		if err := validateNg(ng.NodeGroupBase, path, kubernetesVersion, versionPath); err != nil {
 			return err
 		}
 	}
@@ -303,14 +308,15 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 		}
 	}
 
-	if err := validateKarpenterConfig(cfg); err != nil {
+	if err := ValidateKarpenterConfig(cfg); err != nil {
 		return fmt.Errorf("failed to validate Karpenter config: %w", err)
 	}
 
 	return nil
 }
 
-func validateKarpenterConfig(cfg *ClusterConfig) error {
+// ValidateKarpenterConfig validates the Karpenter config
+func ValidateKarpenterConfig(cfg *ClusterConfig) error {
 	if cfg.Karpenter == nil {
 		return nil
 	}
@@ -335,6 +341,46 @@ func validateKarpenterConfig(cfg *ClusterConfig) error {
 	if IsDisabled(cfg.IAM.WithOIDC) {
 		return errors.New("iam.withOIDC must be enabled with Karpenter")
 	}
+
+	if len(cfg.Karpenter.NodePools) > 0 || len(cfg.Karpenter.EC2NodeClasses) > 0 {
+		nodePoolsVersion, err := version.NewVersion(KarpenterNodePoolsMinimumVersion)
+		if err != nil {
+			return fmt.Errorf("failed to parse Karpenter version %s: %w", KarpenterNodePoolsMinimumVersion, err)
+		}
+		if v.LessThan(nodePoolsVersion) {
+			return fmt.Errorf("nodePools and ec2NodeClasses require Karpenter %s or later", KarpenterNodePoolsMinimumVersion)
+		}
+	}
+
+	nodePoolNames := map[string]struct{}{}
+	for i, np := range cfg.Karpenter.NodePools {
+		path := fmt.Sprintf("karpenter.nodePools[%d]", i)
+		if np.Name == "" {
+			return fmt.Errorf("%s.name must be set", path)
+		}
+		if _, exists := nodePoolNames[np.Name]; exists {
+			return fmt.Errorf("%s.name %q is not unique", path, np.Name)
+		}
+		nodePoolNames[np.Name] = struct{}{}
+		if len(np.Spec) == 0 {
+			return setNonEmpty(path + ".spec")
+		}
+	}
+
+	nodeClassNames := map[string]struct{}{}
+	for i, nc := range cfg.Karpenter.EC2NodeClasses {
+		path := fmt.Sprintf("karpenter.ec2NodeClasses[%d]", i)
+		if nc.Name == "" {
+			return fmt.Errorf("%s.name must be set", path)
+		}
+		if _, exists := nodeClassNames[nc.Name]; exists {
+			return fmt.Errorf("%s.name %q is not unique", path, nc.Name)
+		}
+		nodeClassNames[nc.Name] = struct{}{}
+		if len(nc.Spec) == 0 {
+			return setNonEmpty(path + ".spec")
+		}
+	}
 	return nil
 }
 
@@ -464,6 +510,51 @@ func (c *ClusterConfig) ValidateVPCConfig() error {
 		}
 	}
 
+	return c.validatePodSubnets()
+}
+
+func (c *ClusterConfig) validatePodSubnets() error {
+	podSubnets := c.VPC.PodSubnets
+	if podSubnets == nil {
+		return nil
+	}
+	if c.IPv6Enabled() {
+		return errors.New("vpc.podSubnets is not supported with IPv6")
+	}
+	if c.IsAutoModeEnabled() {
+		return errors.New("vpc.podSubnets is not supported with Auto Mode")
+	}
+	if c.AddonsConfig.DisableDefaultAddons && c.getAddon(VPCCNIAddon) == nil {
+		return fmt.Errorf("the %s addon must be defined in addons when vpc.podSubnets is set and default addons are disabled", VPCCNIAddon)
+	}
+
+	existingVPC := c.VPC.ID != "" || c.HasAnySubnets()
+	switch {
+	case podSubnets.CIDR != nil && len(podSubnets.Subnets) > 0:
+		return errors.New("only one of vpc.podSubnets.cidr and vpc.podSubnets.subnets can be specified")
+	case podSubnets.CIDR != nil:
+		if existingVPC {
+			return errors.New("vpc.podSubnets.cidr is not supported with a pre-existing VPC; specify the pod subnets in vpc.podSubnets.subnets instead")
+		}
+		if podSubnets.CIDR.IP.To4() == nil {
+			return fmt.Errorf("vpc.podSubnets.cidr %s must be an IPv4 CIDR block", podSubnets.CIDR)
+		}
+		if prefix, _ := podSubnets.CIDR.Mask.Size(); prefix < 16 || prefix > 28 {
+			return fmt.Errorf("vpc.podSubnets.cidr %s must have a prefix between /16 and /28", podSubnets.CIDR)
+		}
+	case len(podSubnets.Subnets) > 0:
+		if !existingVPC {
+			return errors.New("vpc.podSubnets.subnets is only supported with a pre-existing VPC; use vpc.podSubnets.cidr to create pod subnets in a VPC created by eksctl")
+		}
+	case existingVPC:
+		return errors.New("vpc.podSubnets.subnets must be specified with a pre-existing VPC")
+	}
+
+	for i, sg := range podSubnets.SecurityGroups {
+		if !strings.HasPrefix(sg, "sg-") {
+			return fmt.Errorf("invalid security group ID %q (vpc.podSubnets.securityGroups[%d])", sg, i)
+		}
+	}
 	return nil
 }
 
@@ -699,6 +790,11 @@ func validateNodeGroupBase(np NodePool, path string, controlPlaneOnOutposts bool
 	if ng.MaxPodsPerNode < 0 {
 		return fmt.Errorf("%s.maxPodsPerNode cannot be negative", path)
 	}
+	if ng.Kubelet != nil {
+		if err := validateNodeGroupKubelet(np, path); err != nil {
+			return err
+		}
+	}
 
 	if IsEnabled(ng.DisablePodIMDS) && ng.IAM != nil {
 		fmtFieldConflictErr := func(_ string) error {
@@ -998,6 +1094,8 @@ func ValidateNodeGroup(i int, ng *NodeGroup, cfg *ClusterConfig) error {
 		}
 	} else if err := validateNodeGroupKubeletExtraConfig(ng.KubeletExtraConfig); err != nil {
 		return err
+	} else if err := validateKubeletExtraConfigConflicts(ng, path); err != nil {
+		return err
 	}
 
 	if err := validateInstanceTypeSupport(ng); err != nil {
@@ -1376,11 +1474,11 @@ func ValidateManagedNodeGroup(index int, ng *ManagedNodeGroup) error {
 		if ng.InstanceType != "" || ng.AMI != "" || IsEnabled(ng.SSH.Allow) || IsEnabled(ng.SSH.EnableSSM) || len(ng.SSH.SourceSecurityGroupIDs) > 0 ||
 			ng.VolumeSize != nil || len(ng.PreBootstrapCommands) > 0 || ng.OverrideBootstrapCommand != nil ||
 			len(ng.SecurityGroups.AttachIDs) > 0 || ng.InstanceName != "" || ng.InstancePrefix != "" || ng.MaxPodsPerNode != 0 ||
-			IsDisabled(ng.DisableIMDSv1) || IsEnabled(ng.DisablePodIMDS) || ng.Placement != nil {
+			ng.Kubelet != nil || IsDisabled(ng.DisableIMDSv1) || IsEnabled(ng.DisablePodIMDS) || ng.Placement != nil {
 
 			incompatibleFields := []string{
 				"instanceType", "ami", "ssh.allow", "ssh.enableSSM", "ssh.sourceSecurityGroupIds", "securityGroups",
-				"volumeSize", "instanceName", "instancePrefix", "maxPodsPerNode", "disableIMDSv1",
+				"volumeSize", "instanceName", "instancePrefix", "maxPodsPerNode", "kubelet", "disableIMDSv1",
 				"disablePodIMDS", "preBootstrapCommands", "overrideBootstrapCommand", "placement",
 			}
 			return fmt.Errorf("cannot set %s in managedNodeGroup when a launch template is supplied", strings.Join(incompatibleFields, ", "))
@@ -1406,6 +1504,9 @@ func ValidateManagedNodeGroup(index int, ng *ManagedNodeGroup) error {
 		if ng.MaxPodsPerNode != 0 {
 			return notSupportedWithCustomAMIErr("maxPodsPerNode")
 		}
+		if ng.Kubelet != nil && ng.Kubelet.MaxPods != nil {
+			return notSupportedWithCustomAMIErr("kubelet.maxPods")
+		}
 		if ng.SSH != nil && IsEnabled(ng.SSH.EnableSSM) {
 			return notSupportedWithCustomAMIErr("enableSSM")
 		}
@@ -12,6 +12,7 @@ import (
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	cft "github.com/weaveworks/eksctl/pkg/cfn/template"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
 )
 
 var _ = Describe("ClusterConfig validation", func() {
@@ -768,6 +769,98 @@ var _ = Describe("ClusterConfig validation", func() {
 		}),
 	)
 
+	type podSubnetsEntry struct {
+		updateConfig func(*api.ClusterConfig)
+		expectedErr  string
+	}
+
+	DescribeTable("vpc.podSubnets", func(e podSubnetsEntry) {
+		clusterConfig := api.NewClusterConfig()
+		e.updateConfig(clusterConfig)
+		err := api.ValidateClusterConfig(clusterConfig)
+		if e.expectedErr != "" {
+			Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+	},
+		Entry("CIDR with a VPC created by eksctl", podSubnetsEntry{
+			updateConfig: func(c *api.ClusterConfig) {
+				c.VPC.PodSubnets = &api.PodSubnets{
+					CIDR:           ipnet.MustParseCIDR("100.64.0.0/16"),
+					SecurityGroups: []string{"sg-1234"},
+				}
+			},
+		}),
+
+		Entry("subnets with a pre-existing VPC", podSubnetsEntry{
+			updateConfig: func(c *api.ClusterConfig) {
+				c.VPC.Subnets = &api.ClusterSubnets{
+					Private: api.AZSubnetMapping{"us-west-2a": {ID: "subnet-1"}},
+				}
+				c.VPC.PodSubnets = &api.PodSubnets{
+					Subnets: api.AZSubnetMapping{"us-west-2a": {ID: "subnet-2"}},
+				}
+			},
+		}),
+
+		Entry("CIDR with a pre-existing VPC", podSubnetsEntry{
+			updateConfig: func(c *api.ClusterConfig) {
+				c.VPC.ID = "vpc-1"
+				c.VPC.PodSubnets = &api.PodSubnets{
+					CIDR: ipnet.MustParseCIDR("100.64.0.0/16"),
+				}
+			},
+			expectedErr: "vpc.podSubnets.cidr is not supported with a pre-existing VPC",
+		}),
+
+		Entry("subnets with a VPC created by eksctl", podSubnetsEntry{
+			updateConfig: func(c *api.ClusterConfig) {
+				c.VPC.PodSubnets = &api.PodSubnets{
+					Subnets: api.AZSubnetMapping{"us-west-2a": {ID: "subnet-2"}},
+				}
+			},
+			expectedErr: "vpc.podSubnets.subnets is only supported with a pre-existing VPC",
+		}),
+
+		Entry("no subnets with a pre-existing VPC", podSubnetsEntry{
+			updateConfig: func(c *api.ClusterConfig) {
+				c.VPC.ID = "vpc-1"
+				c.VPC.PodSubnets = &api.PodSubnets{}
+			},
+			expectedErr: "vpc.podSubnets.subnets must be specified with a pre-existing VPC",
+		}),
+
+		Entry("CIDR prefix is too short", podSubnetsEntry{
+			updateConfig: func(c *api.ClusterConfig) {
+				c.VPC.PodSubnets = &api.PodSubnets{
+					CIDR: ipnet.MustParseCIDR("100.64.0.0/10"),
+				}
+			},
+			expectedErr: "vpc.podSubnets.cidr 100.64.0.0/10 must have a prefix between /16 and /28",
+		}),
+
+		Entry("invalid security group", podSubnetsEntry{
+			updateConfig: func(c *api.ClusterConfig) {
+				c.VPC.PodSubnets = &api.PodSubnets{
+					CIDR:           ipnet.MustParseCIDR("100.64.0.0/16"),
+					SecurityGroups: []string{"pods"},
+				}
+			},
+			expectedErr: `invalid security group ID "pods" (vpc.podSubnets.securityGroups[0])`,
+		}),
+
+		Entry("vpc-cni addon is not created", podSubnetsEntry{
+			updateConfig: func(c *api.ClusterConfig) {
+				c.AddonsConfig.DisableDefaultAddons = true
+				c.VPC.PodSubnets = &api.PodSubnets{
+					CIDR: ipnet.MustParseCIDR("100.64.0.0/16"),
+				}
+			},
+			expectedErr: "the vpc-cni addon must be defined in addons when vpc.podSubnets is set",
+		}),
+	)
+
 	Describe("Cluster Endpoint access", func() {
 		var cfg *api.ClusterConfig
 
@@ -2370,6 +2463,58 @@ var _ = Describe("ClusterConfig validation", func() {
 			}
 			Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring("failed to validate Karpenter config: minimum supported version is v0.20.0")))
 		})
+
+		It("returns an error when nodePools are set for a version without the NodePool API", func() {
+			cfg := api.NewClusterConfig()
+			cfg.IAM.WithOIDC = aws.Bool(true)
+			cfg.Karpenter = &api.Karpenter{
+				Version: "v0.31.0",
+				NodePools: []api.KarpenterNodePool{
+					{Name: "default", Spec: api.InlineDocument{"limits": map[string]interface{}{"cpu": 100}}},
+				},
+			}
+			Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring("nodePools and ec2NodeClasses require Karpenter v0.32.0 or later")))
+		})
+
+		It("returns an error when nodePool names are not unique", func() {
+			cfg := api.NewClusterConfig()
+			cfg.IAM.WithOIDC = aws.Bool(true)
+			cfg.Karpenter = &api.Karpenter{
+				Version: "1.0.6",
+				NodePools: []api.KarpenterNodePool{
+					{Name: "default", Spec: api.InlineDocument{"limits": map[string]interface{}{"cpu": 100}}},
+					{Name: "default", Spec: api.InlineDocument{"limits": map[string]interface{}{"cpu": 10}}},
+				},
+			}
+			Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring(`karpenter.nodePools[1].name "default" is not unique`)))
+		})
+
+		It("returns an error when an ec2NodeClass has no spec", func() {
+			cfg := api.NewClusterConfig()
+			cfg.IAM.WithOIDC = aws.Bool(true)
+			cfg.Karpenter = &api.Karpenter{
+				Version: "1.0.6",
+				EC2NodeClasses: []api.KarpenterEC2NodeClass{
+					{Name: "default"},
+				},
+			}
+			Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring("karpenter.ec2NodeClasses[0].spec must be set and non-empty")))
+		})
+
+		It("accepts valid nodePools and ec2NodeClasses", func() {
+			cfg := api.NewClusterConfig()
+			cfg.IAM.WithOIDC = aws.Bool(true)
+			cfg.Karpenter = &api.Karpenter{
+				Version: "1.0.6",
+				NodePools: []api.KarpenterNodePool{
+					{Name: "default", Spec: api.InlineDocument{"limits": map[string]interface{}{"cpu": 100}}},
+				},
+				EC2NodeClasses: []api.KarpenterEC2NodeClass{
+					{Name: "default", Spec: api.InlineDocument{"amiSelectorTerms": []interface{}{map[string]interface{}{"alias": "al2023@latest"}}}},
+				},
+			}
+			Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
+		})
 	})
 
 	type labelsTaintsEntry struct {
@@ -142,6 +142,16 @@ type (
 		// This field is used internally and is not part of the ClusterConfig schema.
 		LocalZoneSubnets *ClusterSubnets `json:"-"`
 
+		// SecondaryCIDRs are IPv4 CIDR blocks associated with a VPC created by eksctl in addition to its primary CIDR.
+		// This field is used internally and is not part of the ClusterConfig schema.
+		SecondaryCIDRs []*ipnet.IPNet `json:"-"`
+
+		// PodSubnets enables VPC CNI custom networking, with pod ENIs created in these subnets
+		// instead of the subnets of the nodes.
+		// See [custom networking](/usage/vpc-cni-custom-networking/)
+		// +optional
+		PodSubnets *PodSubnets `json:"podSubnets,omitempty"`
+
 		// HostnameType is the type of hostname to use for EC2 instances.
 		HostnameType string `json:"hostnameType,omitempty"`
 
@@ -188,6 +198,23 @@ type (
 		Public  AZSubnetMapping `json:"public,omitempty"`
 	}
 
+	// PodSubnets holds the subnets in which the VPC CNI creates pod ENIs when custom networking is enabled.
+	// An ENIConfig named after each availability zone is created for the pod subnet in that zone.
+	PodSubnets struct {
+		// CIDR is a secondary IPv4 CIDR block associated with a VPC created by eksctl,
+		// which is split into a pod subnet in each availability zone of the cluster
+		// +optional
+		CIDR *ipnet.IPNet `json:"cidr,omitempty"`
+		// Subnets are existing pod subnets, keyed by availability zone or alias; there can be
+		// at most one pod subnet in each availability zone
+		// +optional
+		Subnets AZSubnetMapping `json:"subnets,omitempty"`
+		// SecurityGroups are the IDs of the security groups attached to pod ENIs.
+		// Defaults to the security groups of the primary ENI of each node
+		// +optional
+		SecurityGroups []string `json:"securityGroups,omitempty"`
+	}
+
 	// SubnetTopology can be SubnetTopologyPrivate or SubnetTopologyPublic
 	SubnetTopology string
 	AZSubnetSpec   struct {
@@ -398,6 +425,14 @@ func (v *ClusterVPC) FindOutpostSubnetsARN() (outpostARN string, found bool) {
 
 }
 
+// PodSubnetMapping returns the pod subnets, or nil if VPC CNI custom networking is not enabled.
+func (v *ClusterVPC) PodSubnetMapping() AZSubnetMapping {
+	if v == nil || v.PodSubnets == nil {
+		return nil
+	}
+	return v.PodSubnets.Subnets
+}
+
 // SubnetInfo returns a string containing VPC subnet information
 // Useful for error messages and logs
 func (c *ClusterConfig) SubnetInfo() string {
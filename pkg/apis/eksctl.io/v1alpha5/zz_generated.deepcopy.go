@@ -26,6 +26,7 @@ package v1alpha5
 import (
 	json "encoding/json"
 
+	ipnet "github.com/weaveworks/eksctl/pkg/utils/ipnet"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -906,6 +907,21 @@ func (in *ClusterVPC) DeepCopyInto(out *ClusterVPC) {
 		*out = new(ClusterSubnets)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecondaryCIDRs != nil {
+		in, out := &in.SecondaryCIDRs, &out.SecondaryCIDRs
+		*out = make([]*ipnet.IPNet, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = (*in).DeepCopy()
+			}
+		}
+	}
+	if in.PodSubnets != nil {
+		in, out := &in.PodSubnets, &out.PodSubnets
+		*out = new(PodSubnets)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExtraCIDRs != nil {
 		in, out := &in.ExtraCIDRs, &out.ExtraCIDRs
 		*out = make([]string, len(*in))
@@ -1343,6 +1359,21 @@ func (in *Karpenter) DeepCopyInto(out *Karpenter) {
 		*out = new(bool)
 		**out = **in
 	}
+	in.Values.DeepCopyInto(&out.Values)
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]KarpenterNodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EC2NodeClasses != nil {
+		in, out := &in.EC2NodeClasses, &out.EC2NodeClasses
+		*out = make([]KarpenterEC2NodeClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1356,6 +1387,40 @@ func (in *Karpenter) DeepCopy() *Karpenter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterEC2NodeClass) DeepCopyInto(out *KarpenterEC2NodeClass) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KarpenterEC2NodeClass.
+func (in *KarpenterEC2NodeClass) DeepCopy() *KarpenterEC2NodeClass {
+	if in == nil {
+		return nil
+	}
+	out := new(KarpenterEC2NodeClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterNodePool) DeepCopyInto(out *KarpenterNodePool) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KarpenterNodePool.
+func (in *KarpenterNodePool) DeepCopy() *KarpenterNodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(KarpenterNodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesNetworkConfig) DeepCopyInto(out *KubernetesNetworkConfig) {
 	*out = *in
@@ -1609,6 +1674,11 @@ func (in *NodeGroupBase) DeepCopyInto(out *NodeGroupBase) {
 		*out = new(NodeGroupSGs)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Kubelet != nil {
+		in, out := &in.Kubelet, &out.Kubelet
+		*out = new(NodeGroupKubelet)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ASGSuspendProcesses != nil {
 		in, out := &in.ASGSuspendProcesses, &out.ASGSuspendProcesses
 		*out = make([]string, len(*in))
@@ -1908,6 +1978,72 @@ func (in *NodeGroupInstancesDistribution) DeepCopy() *NodeGroupInstancesDistribu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupKubelet) DeepCopyInto(out *NodeGroupKubelet) {
+	*out = *in
+	if in.MaxPods != nil {
+		in, out := &in.MaxPods, &out.MaxPods
+		*out = new(int)
+		**out = **in
+	}
+	if in.EvictionHard != nil {
+		in, out := &in.EvictionHard, &out.EvictionHard
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionSoft != nil {
+		in, out := &in.EvictionSoft, &out.EvictionSoft
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionSoftGracePeriod != nil {
+		in, out := &in.EvictionSoftGracePeriod, &out.EvictionSoftGracePeriod
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KubeReserved != nil {
+		in, out := &in.KubeReserved, &out.KubeReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SystemReserved != nil {
+		in, out := &in.SystemReserved, &out.SystemReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImageGCHighThresholdPercent != nil {
+		in, out := &in.ImageGCHighThresholdPercent, &out.ImageGCHighThresholdPercent
+		*out = new(int)
+		**out = **in
+	}
+	if in.ImageGCLowThresholdPercent != nil {
+		in, out := &in.ImageGCLowThresholdPercent, &out.ImageGCLowThresholdPercent
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupKubelet.
+func (in *NodeGroupKubelet) DeepCopy() *NodeGroupKubelet {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupKubelet)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeGroupNodeRepairConfig) DeepCopyInto(out *NodeGroupNodeRepairConfig) {
 	*out = *in
@@ -2145,6 +2281,38 @@ func (in *PodIdentityAssociation) DeepCopy() *PodIdentityAssociation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSubnets) DeepCopyInto(out *PodSubnets) {
+	*out = *in
+	if in.CIDR != nil {
+		in, out := &in.CIDR, &out.CIDR
+		*out = (*in).DeepCopy()
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make(AZSubnetMapping, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSubnets.
+func (in *PodSubnets) DeepCopy() *PodSubnets {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSubnets)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateCluster) DeepCopyInto(out *PrivateCluster) {
 	*out = *in
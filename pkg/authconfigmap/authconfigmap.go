@@ -330,3 +330,13 @@ func RemoveNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
 	logger.Debug("updated auth ConfigMap for %s", ng.Name)
 	return nil
 }
+
+// Updater removes nodegroups from the auth ConfigMap of the cluster ClientSet is connected to.
+type Updater struct {
+	ClientSet kubernetes.Interface
+}
+
+// RemoveNodeGroup removes ng from the auth ConfigMap, see RemoveNodeGroup.
+func (u *Updater) RemoveNodeGroup(ng *api.NodeGroup) error {
+	return RemoveNodeGroup(u.ClientSet, ng)
+}
@@ -0,0 +1,31 @@
+package authconfigmap
+
+import (
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/iam"
+)
+
+// IsNodeRole reports whether identity maps the instance role of nodes.
+func IsNodeRole(identity iam.Identity) bool {
+	return identity.Type() == iam.ResourceTypeRole && identity.Username() == RoleNodeGroupUsername
+}
+
+// ToIAMIdentityMappings converts identities to IAM identity mappings that can be loaded from a ClusterConfig file
+// by `eksctl create iamidentitymapping`.
+func ToIAMIdentityMappings(identities []iam.Identity) []*api.IAMIdentityMapping {
+	var mappings []*api.IAMIdentityMapping
+	for _, identity := range identities {
+		if identity.Type() == iam.ResourceTypeAccount {
+			mappings = append(mappings, &api.IAMIdentityMapping{
+				Account: identity.Account(),
+			})
+			continue
+		}
+		mappings = append(mappings, &api.IAMIdentityMapping{
+			ARN:      identity.ARN(),
+			Username: identity.Username(),
+			Groups:   identity.Groups(),
+		})
+	}
+	return mappings
+}
@@ -14,6 +14,7 @@ import (
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	cft "github.com/weaveworks/eksctl/pkg/cfn/template"
+	"github.com/weaveworks/eksctl/pkg/utils"
 )
 
 const (
@@ -57,6 +58,12 @@ const (
 	iamDeleteInstanceProfile    = "iam:DeleteInstanceProfile"
 	iamTagInstanceProfile       = "iam:TagInstanceProfile"
 	iamAddRoleToInstanceProfile = "iam:AddRoleToInstanceProfile"
+	// IAM actions required by Karpenter v0.32.0 and later
+	iamRemoveRoleFromInstanceProfile = "iam:RemoveRoleFromInstanceProfile"
+	// IAM actions required by Karpenter v1.1.0 and later
+	iamListInstanceProfiles = "iam:ListInstanceProfiles"
+	// EKS actions required by Karpenter v0.32.0 and later
+	eksDescribeCluster = "eks:DescribeCluster"
 	// SSM
 	ssmGetParameter = "ssm:GetParameter"
 	// Pricing
@@ -150,7 +157,39 @@ func (k *KarpenterResourceSet) addResourcesForKarpenter() error {
 	k.newResource(KarpenterNodeInstanceProfile, &instanceProfile)
 
 	managedPolicyName := gfnt.NewString(fmt.Sprintf("eksctl-%s-%s", KarpenterManagedPolicy, k.clusterSpec.Metadata.Name))
-	rolePolicyStatements := []cft.MapOfInterfaces{
+	var rolePolicyStatements []cft.MapOfInterfaces
+	if k.isVersionAtLeast("1.0.0") {
+		rolePolicyStatements = k.v1ControllerPolicyStatements()
+	} else {
+		rolePolicyStatements = k.legacyControllerPolicyStatements()
+	}
+
+	if api.IsEnabled(k.clusterSpec.Karpenter.WithSpotInterruptionQueue) {
+		rolePolicyStatements = append(rolePolicyStatements, cft.MapOfInterfaces{
+			"Effect":   effectAllow,
+			"Resource": gfnt.MakeFnGetAtt(KarpenterInterruptionQueue, gfnt.NewString("Arn")),
+			"Action": []string{
+				sqsDeleteMessage,
+				sqsGetQueueAttributes,
+				sqsGetQueueURL,
+				sqsReceiveMessage,
+			},
+		})
+		k.addSpotInterruptionQueueWithRules()
+	}
+
+	managedPolicy := gfniam.ManagedPolicy{
+		ManagedPolicyName: managedPolicyName,
+		PolicyDocument:    cft.MakePolicyDocument(rolePolicyStatements...),
+	}
+	k.newResource(KarpenterManagedPolicy, &managedPolicy)
+
+	return nil
+}
+
+// legacyControllerPolicyStatements returns the controller policy of Karpenter versions before v1.0.0.
+func (k *KarpenterResourceSet) legacyControllerPolicyStatements() []cft.MapOfInterfaces {
+	statements := []cft.MapOfInterfaces{
 		{
 			"Effect":   effectAllow,
 			"Resource": resourceAll,
@@ -182,28 +221,240 @@ func (k *KarpenterResourceSet) addResourcesForKarpenter() error {
 			},
 		},
 	}
-
-	if api.IsEnabled(k.clusterSpec.Karpenter.WithSpotInterruptionQueue) {
-		rolePolicyStatements = append(rolePolicyStatements, cft.MapOfInterfaces{
+	if k.isVersionAtLeast("0.32.0") {
+		statements = append(statements, cft.MapOfInterfaces{
 			"Effect":   effectAllow,
-			"Resource": gfnt.MakeFnGetAtt(KarpenterInterruptionQueue, gfnt.NewString("Arn")),
-			"Action": []string{
-				sqsDeleteMessage,
-				sqsGetQueueAttributes,
-				sqsGetQueueURL,
-				sqsReceiveMessage,
-			},
+			"Resource": resourceAll,
+			"Action":   []string{iamRemoveRoleFromInstanceProfile, eksDescribeCluster},
 		})
-		k.addSpotInterruptionQueueWithRules()
 	}
+	return statements
+}
 
-	managedPolicy := gfniam.ManagedPolicy{
-		ManagedPolicyName: managedPolicyName,
-		PolicyDocument:    cft.MakePolicyDocument(rolePolicyStatements...),
+// v1ControllerPolicyStatements returns the controller policy of Karpenter v1, as published in the Karpenter
+// CloudFormation template: EC2 and IAM write actions are scoped to resources tagged for the cluster.
+func (k *KarpenterResourceSet) v1ControllerPolicyStatements() []cft.MapOfInterfaces {
+	clusterName := k.clusterSpec.Metadata.Name
+	clusterOwnedTag := fmt.Sprintf("kubernetes.io/cluster/%s", clusterName)
+	ec2ARN := func(resource string) *gfnt.Value {
+		return gfnt.MakeFnSubString(fmt.Sprintf("arn:${AWS::Partition}:ec2:${AWS::Region}:*:%s", resource))
+	}
+	ec2ARNs := func(resources ...string) []*gfnt.Value {
+		var arns []*gfnt.Value
+		for _, r := range resources {
+			arns = append(arns, ec2ARN(r))
+		}
+		return arns
+	}
+	instanceProfileARN := gfnt.MakeFnSubString("arn:${AWS::Partition}:iam::${AWS::AccountId}:instance-profile/*")
+	taggedResources := ec2ARNs("fleet/*", "instance/*", "volume/*", "network-interface/*", "launch-template/*", "spot-instances-request/*")
+	clusterRequestTags := cft.MapOfInterfaces{
+		"StringEquals": cft.MapOfInterfaces{
+			"aws:RequestTag/" + clusterOwnedTag:   "owned",
+			"aws:RequestTag/eks:eks-cluster-name": clusterName,
+		},
+		"StringLike": cft.MapOfInterfaces{
+			"aws:RequestTag/karpenter.sh/nodepool": "*",
+		},
+	}
+	clusterResourceTags := cft.MapOfInterfaces{
+		"StringEquals": cft.MapOfInterfaces{
+			"aws:ResourceTag/" + clusterOwnedTag: "owned",
+		},
+		"StringLike": cft.MapOfInterfaces{
+			"aws:ResourceTag/karpenter.sh/nodepool": "*",
+		},
+	}
+	instanceProfileRequestTags := cft.MapOfInterfaces{
+		"StringEquals": cft.MapOfInterfaces{
+			"aws:RequestTag/" + clusterOwnedTag:            "owned",
+			"aws:RequestTag/eks:eks-cluster-name":          clusterName,
+			"aws:RequestTag/topology.kubernetes.io/region": gfnt.RefRegion,
+		},
+		"StringLike": cft.MapOfInterfaces{
+			"aws:RequestTag/karpenter.k8s.aws/ec2nodeclass": "*",
+		},
+	}
+	instanceProfileReadActions := []string{iamGetInstanceProfile}
+	if k.isVersionAtLeast("1.1.0") {
+		instanceProfileReadActions = append(instanceProfileReadActions, iamListInstanceProfiles)
 	}
-	k.newResource(KarpenterManagedPolicy, &managedPolicy)
 
-	return nil
+	return []cft.MapOfInterfaces{
+		{
+			"Sid":      "AllowScopedEC2InstanceAccessActions",
+			"Effect":   effectAllow,
+			"Resource": ec2ARNs("image/*", "snapshot/*", "security-group/*", "subnet/*"),
+			"Action":   []string{ec2RunInstances, ec2CreateFleet},
+		},
+		{
+			"Sid":      "AllowScopedEC2LaunchTemplateAccessActions",
+			"Effect":   effectAllow,
+			"Resource": ec2ARN("launch-template/*"),
+			"Action":   []string{ec2RunInstances, ec2CreateFleet},
+			"Condition": cft.MapOfInterfaces{
+				"StringEquals": cft.MapOfInterfaces{
+					"aws:ResourceTag/" + clusterOwnedTag: "owned",
+				},
+				"StringLike": cft.MapOfInterfaces{
+					"aws:ResourceTag/karpenter.sh/nodepool": "*",
+				},
+			},
+		},
+		{
+			"Sid":       "AllowScopedEC2InstanceActionsWithTags",
+			"Effect":    effectAllow,
+			"Resource":  taggedResources,
+			"Action":    []string{ec2RunInstances, ec2CreateFleet, ec2CreateLaunchTemplate},
+			"Condition": clusterRequestTags,
+		},
+		{
+			"Sid":      "AllowScopedResourceCreationTagging",
+			"Effect":   effectAllow,
+			"Resource": taggedResources,
+			"Action":   []string{ec2CreateTags},
+			"Condition": cft.MapOfInterfaces{
+				"StringEquals": cft.MapOfInterfaces{
+					"aws:RequestTag/" + clusterOwnedTag:   "owned",
+					"aws:RequestTag/eks:eks-cluster-name": clusterName,
+					"ec2:CreateAction":                    []string{"RunInstances", "CreateFleet", "CreateLaunchTemplate"},
+				},
+				"StringLike": cft.MapOfInterfaces{
+					"aws:RequestTag/karpenter.sh/nodepool": "*",
+				},
+			},
+		},
+		{
+			"Sid":      "AllowScopedResourceTagging",
+			"Effect":   effectAllow,
+			"Resource": ec2ARN("instance/*"),
+			"Action":   []string{ec2CreateTags},
+			"Condition": cft.MapOfInterfaces{
+				"StringEquals": cft.MapOfInterfaces{
+					"aws:ResourceTag/" + clusterOwnedTag: "owned",
+				},
+				"StringLike": cft.MapOfInterfaces{
+					"aws:ResourceTag/karpenter.sh/nodepool": "*",
+				},
+				"StringEqualsIfExists": cft.MapOfInterfaces{
+					"aws:RequestTag/eks:eks-cluster-name": clusterName,
+				},
+				"ForAllValues:StringEquals": cft.MapOfInterfaces{
+					"aws:TagKeys": []string{"eks:eks-cluster-name", "karpenter.sh/nodeclaim", "Name"},
+				},
+			},
+		},
+		{
+			"Sid":       "AllowScopedDeletion",
+			"Effect":    effectAllow,
+			"Resource":  ec2ARNs("instance/*", "launch-template/*"),
+			"Action":    []string{ec2TerminateInstances, ec2DeleteLaunchTemplate},
+			"Condition": clusterResourceTags,
+		},
+		{
+			"Sid":      "AllowRegionalReadActions",
+			"Effect":   effectAllow,
+			"Resource": resourceAll,
+			"Action": []string{
+				ec2DescribeAvailabilityZones,
+				ec2DescribeImages,
+				ec2DescribeInstances,
+				ec2DescribeInstanceTypeOfferings,
+				ec2DescribeInstanceTypes,
+				ec2DescribeLaunchTemplates,
+				ec2DescribeSecurityGroups,
+				ec2DescribeSpotPriceHistory,
+				ec2DescribeSubnets,
+			},
+			"Condition": cft.MapOfInterfaces{
+				"StringEquals": cft.MapOfInterfaces{
+					"aws:RequestedRegion": gfnt.RefRegion,
+				},
+			},
+		},
+		{
+			"Sid":      "AllowSSMReadActions",
+			"Effect":   effectAllow,
+			"Resource": gfnt.MakeFnSubString("arn:${AWS::Partition}:ssm:${AWS::Region}::parameter/aws/service/*"),
+			"Action":   []string{ssmGetParameter},
+		},
+		{
+			"Sid":      "AllowPricingReadActions",
+			"Effect":   effectAllow,
+			"Resource": resourceAll,
+			"Action":   []string{pricingGetProducts},
+		},
+		{
+			"Sid":      "AllowPassingInstanceRole",
+			"Effect":   effectAllow,
+			"Resource": gfnt.MakeFnGetAttString(KarpenterNodeRoleName, "Arn"),
+			"Action":   []string{iamPassRole},
+			"Condition": cft.MapOfInterfaces{
+				"StringEquals": cft.MapOfInterfaces{
+					"iam:PassedToService": []string{"ec2.amazonaws.com", "ec2.amazonaws.com.cn"},
+				},
+			},
+		},
+		{
+			"Sid":       "AllowScopedInstanceProfileCreationActions",
+			"Effect":    effectAllow,
+			"Resource":  instanceProfileARN,
+			"Action":    []string{iamCreateInstanceProfile},
+			"Condition": instanceProfileRequestTags,
+		},
+		{
+			"Sid":      "AllowScopedInstanceProfileTagActions",
+			"Effect":   effectAllow,
+			"Resource": instanceProfileARN,
+			"Action":   []string{iamTagInstanceProfile},
+			"Condition": cft.MapOfInterfaces{
+				"StringEquals": cft.MapOfInterfaces{
+					"aws:ResourceTag/" + clusterOwnedTag:            "owned",
+					"aws:ResourceTag/topology.kubernetes.io/region": gfnt.RefRegion,
+					"aws:RequestTag/" + clusterOwnedTag:             "owned",
+					"aws:RequestTag/eks:eks-cluster-name":           clusterName,
+					"aws:RequestTag/topology.kubernetes.io/region":  gfnt.RefRegion,
+				},
+				"StringLike": cft.MapOfInterfaces{
+					"aws:ResourceTag/karpenter.k8s.aws/ec2nodeclass": "*",
+					"aws:RequestTag/karpenter.k8s.aws/ec2nodeclass":  "*",
+				},
+			},
+		},
+		{
+			"Sid":      "AllowScopedInstanceProfileActions",
+			"Effect":   effectAllow,
+			"Resource": instanceProfileARN,
+			"Action":   []string{iamAddRoleToInstanceProfile, iamRemoveRoleFromInstanceProfile, iamDeleteInstanceProfile},
+			"Condition": cft.MapOfInterfaces{
+				"StringEquals": cft.MapOfInterfaces{
+					"aws:ResourceTag/" + clusterOwnedTag:            "owned",
+					"aws:ResourceTag/topology.kubernetes.io/region": gfnt.RefRegion,
+				},
+				"StringLike": cft.MapOfInterfaces{
+					"aws:ResourceTag/karpenter.k8s.aws/ec2nodeclass": "*",
+				},
+			},
+		},
+		{
+			"Sid":      "AllowInstanceProfileReadActions",
+			"Effect":   effectAllow,
+			"Resource": instanceProfileARN,
+			"Action":   instanceProfileReadActions,
+		},
+		{
+			"Sid":      "AllowAPIServerEndpointDiscovery",
+			"Effect":   effectAllow,
+			"Resource": gfnt.MakeFnSubString(fmt.Sprintf("arn:${AWS::Partition}:eks:${AWS::Region}:${AWS::AccountId}:cluster/%s", clusterName)),
+			"Action":   []string{eksDescribeCluster},
+		},
+	}
+}
+
+// isVersionAtLeast reports whether the configured Karpenter version is minVersion or later.
+func (k *KarpenterResourceSet) isVersionAtLeast(minVersion string) bool {
+	compareVersions, err := utils.CompareVersions(k.clusterSpec.Karpenter.Version, minVersion)
+	return err == nil && compareVersions >= 0
 }
 
 func (k *KarpenterResourceSet) addSpotInterruptionQueueWithRules() {
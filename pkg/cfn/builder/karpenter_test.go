@@ -64,6 +64,36 @@ var _ = Describe("karpenter stack", func() {
 				Expect(string(result)).To(Equal(fmt.Sprintf(expectedTemplateWithSpotInterruptionQueue, "eksctl-KarpenterWithSpotInterruptionQueue-test")))
 			})
 		})
+		DescribeTable("version specific permissions", func(version string, expectedActions, unexpectedActions []string) {
+			cfg.Karpenter.Version = version
+			krs := builder.NewKarpenterResourceSet(cfg, "eksctl-KarpenterNodeInstanceProfile-test-karpenter")
+			Expect(krs.AddAllResources()).To(Succeed())
+			result, err := krs.RenderJSON()
+			Expect(err).NotTo(HaveOccurred())
+			for _, action := range expectedActions {
+				Expect(string(result)).To(ContainSubstring(fmt.Sprintf("%q", action)))
+			}
+			for _, action := range unexpectedActions {
+				Expect(string(result)).NotTo(ContainSubstring(fmt.Sprintf("%q", action)))
+			}
+		},
+			Entry("before v0.32.0", "0.31.0", nil, []string{"iam:RemoveRoleFromInstanceProfile", "eks:DescribeCluster", "iam:ListInstanceProfiles"}),
+			Entry("v0.32.0", "v0.32.0", []string{"iam:RemoveRoleFromInstanceProfile", "eks:DescribeCluster"}, []string{"iam:ListInstanceProfiles"}),
+			Entry("v1.0.0", "1.0.0", []string{"AllowScopedEC2InstanceActionsWithTags", "AllowPassingInstanceRole", "iam:RemoveRoleFromInstanceProfile", "eks:DescribeCluster"},
+				[]string{"iam:CreateServiceLinkedRole", "iam:ListInstanceProfiles"}),
+			Entry("v1.1.0", "1.1.0", []string{"iam:RemoveRoleFromInstanceProfile", "eks:DescribeCluster", "iam:ListInstanceProfiles"}, nil),
+		)
+
+		It("scopes the v1 controller policy to the resources of the cluster", func() {
+			cfg.Karpenter.Version = "1.0.0"
+			krs := builder.NewKarpenterResourceSet(cfg, "eksctl-KarpenterNodeInstanceProfile-test-karpenter")
+			Expect(krs.AddAllResources()).To(Succeed())
+			result, err := krs.RenderJSON()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(ContainSubstring(`"aws:RequestTag/kubernetes.io/cluster/test-karpenter": "owned"`))
+			Expect(string(result)).To(ContainSubstring(`"aws:ResourceTag/karpenter.sh/nodepool": "*"`))
+			Expect(string(result)).To(ContainSubstring(`"Fn::Sub": "arn:${AWS::Partition}:eks:${AWS::Region}:${AWS::AccountId}:cluster/test-karpenter"`))
+		})
 	})
 })
 
@@ -15,6 +15,7 @@ import (
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
 	"github.com/weaveworks/eksctl/pkg/vpc"
 )
 
@@ -23,6 +24,9 @@ const (
 	cfnSharedNodeSGResource           = "ClusterSharedNodeSecurityGroup"
 	cfnIngressClusterToNodeSGResource = "IngressDefaultClusterToNodeSG"
 	cfnVPCResource                    = "VPC"
+
+	// subnetTopologyPod is used to name the resources of subnets for pod ENIs
+	subnetTopologyPod api.SubnetTopology = "Pod"
 )
 
 // A IPv4VPCResourceSet builds the resources required for the specified VPC
@@ -41,6 +45,8 @@ type SubnetResource struct {
 	RouteTable       *gfnt.Value
 	AvailabilityZone string
 	onOutpost        bool
+	// secondaryCIDR is the name of the secondary CIDR block that contains the subnet, if any
+	secondaryCIDR string
 }
 
 type SubnetDetails struct {
@@ -48,6 +54,7 @@ type SubnetDetails struct {
 	Public           []SubnetResource
 	PrivateLocalZone []SubnetResource
 	PublicLocalZone  []SubnetResource
+	Pod              []SubnetResource
 
 	controlPlaneOnOutposts bool
 	autoMode               bool
@@ -86,11 +93,22 @@ func (v *IPv4VPCResourceSet) addResources() error {
 		EnableDnsHostnames: gfnt.True(),
 	})
 
+	for _, cidr := range vpc.SecondaryCIDRs {
+		v.rs.newResource(makeSecondaryCIDRResourceName(cidr), &gfnec2.VPCCidrBlock{
+			VpcId:     v.vpcID,
+			CidrBlock: gfnt.NewString(cidr.String()),
+		})
+	}
+
 	// Add base private networking config, which is common to all eksctl created VPCs i.e.
 	// - Private Subnets
 	// - Private Route Tables
 	v.addPrivateRouteTables()
+	if err := v.validateSecondarySubnetRouteTables(); err != nil {
+		return err
+	}
 	v.subnetDetails.Private = v.addSubnets(nil, api.SubnetTopologyPrivate, vpc.Subnets.Private)
+	v.subnetDetails.Pod = v.addSubnets(nil, subnetTopologyPod, vpc.PodSubnetMapping())
 
 	if v.clusterConfig.IsFullyPrivate() {
 		// if the cluster if fully private, we have already added all required resources
@@ -164,11 +182,11 @@ func (s *SubnetDetails) ControlPlaneSubnetRefs() []*gfnt.Value {
 }
 
 func (s *SubnetDetails) PublicSubnetRefs() []*gfnt.Value {
-	return collectSubnetRefs(s.Public)
+	return collectSubnetRefsPredicate(s.Public, isPrimarySubnet)
 }
 
 func (s *SubnetDetails) PrivateSubnetRefs() []*gfnt.Value {
-	return collectSubnetRefs(s.Private)
+	return collectSubnetRefsPredicate(s.Private, isPrimarySubnet)
 }
 
 func (s *SubnetDetails) PublicLocalZoneSubnetRefs() []*gfnt.Value {
@@ -191,6 +209,17 @@ func (s *SubnetDetails) PrivateOutpostSubnetRefs() []*gfnt.Value {
 	})
 }
 
+// SecondarySubnetRefs returns the subnets in subnetResources that belong to the secondary CIDR block named cidrName.
+func (s *SubnetDetails) SecondarySubnetRefs(subnetResources []SubnetResource, cidrName string) []*gfnt.Value {
+	return collectSubnetRefsPredicate(subnetResources, func(sr SubnetResource) bool {
+		return sr.secondaryCIDR == cidrName
+	})
+}
+
+func isPrimarySubnet(sr SubnetResource) bool {
+	return sr.secondaryCIDR == ""
+}
+
 func collectSubnetRefsPredicate(subnetResources []SubnetResource, predicateFn func(SubnetResource) bool) []*gfnt.Value {
 	var subnetRefs []*gfnt.Value
 	for _, subnetAZ := range subnetResources {
@@ -260,6 +289,19 @@ func (v *IPv4VPCResourceSet) addOutputs(ctx context.Context) {
 		}
 	}
 
+	for _, cidr := range clusterVPC.SecondaryCIDRs {
+		cidrName := vpc.MakeSecondaryCIDRName(cidr.IP)
+		if subnetAZs := v.subnetDetails.SecondarySubnetRefs(v.subnetDetails.Private, cidrName); len(subnetAZs) > 0 {
+			addSubnetOutputWithAlias(subnetAZs, clusterVPC.Subnets.Private, outputs.ClusterSubnetsPrivateSecondary+cidrName, vpc.MakeSecondarySubnetAliasFunc())
+		}
+		if subnetAZs := v.subnetDetails.SecondarySubnetRefs(v.subnetDetails.Public, cidrName); len(subnetAZs) > 0 {
+			addSubnetOutputWithAlias(subnetAZs, clusterVPC.Subnets.Public, outputs.ClusterSubnetsPublicSecondary+cidrName, vpc.MakeSecondarySubnetAliasFunc())
+		}
+		if subnetAZs := v.subnetDetails.SecondarySubnetRefs(v.subnetDetails.Pod, cidrName); len(subnetAZs) > 0 {
+			addSubnetOutputWithAlias(subnetAZs, clusterVPC.PodSubnetMapping(), outputs.ClusterSubnetsPodSecondary+cidrName, vpc.MakeSecondarySubnetAliasFunc())
+		}
+	}
+
 	if v.clusterConfig.IsFullyPrivate() {
 		v.rs.defineOutputWithoutCollector(outputs.ClusterFullyPrivate, true, true)
 	}
@@ -291,14 +333,22 @@ func (v *IPv4VPCResourceSet) addSubnets(refRT *gfnt.Value, topology api.SubnetTo
 			subnet.OutpostArn = gfnt.NewString(s.OutpostARN)
 		}
 
+		secondaryCIDR := v.findSecondaryCIDR(s.CIDR)
+		if secondaryCIDR != nil {
+			subnet.AWSCloudFormationDependsOn = []string{makeSecondaryCIDRResourceName(secondaryCIDR)}
+		}
+
 		switch topology {
 		case api.SubnetTopologyPrivate:
 			// Choose the appropriate route table for private subnets.
-			refRT = gfnt.MakeRef("PrivateRouteTable" + nameAlias)
+			refRT = v.privateRouteTableRef(name, s.AZ)
 			subnet.Tags = []gfncfn.Tag{{
 				Key:   gfnt.NewString("kubernetes.io/role/internal-elb"),
 				Value: gfnt.NewString("1"),
 			}}
+		case subnetTopologyPod:
+			// pod subnets are not tagged for load balancers
+			refRT = v.privateRouteTableRef(name, s.AZ)
 		case api.SubnetTopologyPublic:
 			subnet.Tags = []gfncfn.Tag{{
 				Key:   gfnt.NewString("kubernetes.io/role/elb"),
@@ -314,7 +364,7 @@ func (v *IPv4VPCResourceSet) addSubnets(refRT *gfnt.Value, topology api.SubnetTo
 			RouteTableId: refRT,
 		})
 
-		if autoAllocateIPV6 {
+		if autoAllocateIPV6 && secondaryCIDR == nil {
 			refSubnetSlices := getSubnetIPv6CIDRBlock((len(v.clusterConfig.AvailabilityZones) * 2) + 2)
 			v.rs.newResource(subnetAlias+"CIDRv6", &gfnec2.SubnetCidrBlock{
 				SubnetId:      refSubnet,
@@ -322,12 +372,16 @@ func (v *IPv4VPCResourceSet) addSubnets(refRT *gfnt.Value, topology api.SubnetTo
 			})
 		}
 
-		subnetResources = append(subnetResources, SubnetResource{
+		subnetResource := SubnetResource{
 			AvailabilityZone: az,
 			RouteTable:       refRT,
 			Subnet:           refSubnet,
 			onOutpost:        s.OutpostARN != "",
-		})
+		}
+		if secondaryCIDR != nil {
+			subnetResource.secondaryCIDR = vpc.MakeSecondaryCIDRName(secondaryCIDR.IP)
+		}
+		subnetResources = append(subnetResources, subnetResource)
 	}
 	return subnetResources
 }
@@ -336,6 +390,58 @@ func (v *IPv4VPCResourceSet) getPrivateRouteTableRefForAZ(az string) *gfnt.Value
 	return v.azToRTMap[az]
 }
 
+// privateRouteTableRef returns the route table for the private or pod subnet subnetAlias.
+// Subnets in secondary CIDR blocks share the route table of the private subnet created with the VPC in their AZ,
+// so that they use the same NAT gateway and routes to remote networks.
+func (v *IPv4VPCResourceSet) privateRouteTableRef(subnetAlias, az string) *gfnt.Value {
+	if !vpc.IsSecondarySubnetAlias(subnetAlias) {
+		return gfnt.MakeRef("PrivateRouteTable" + makeAZResourceName(subnetAlias))
+	}
+	return gfnt.MakeRef("PrivateRouteTable" + makeAZResourceName(v.primaryPrivateSubnetAliases()[az]))
+}
+
+// primaryPrivateSubnetAliases maps AZs to the alias of the private subnet created with the VPC in each AZ.
+func (v *IPv4VPCResourceSet) primaryPrivateSubnetAliases() map[string]string {
+	aliases := map[string]string{}
+	for alias, s := range v.clusterConfig.VPC.Subnets.Private {
+		if vpc.IsSecondarySubnetAlias(alias) {
+			continue
+		}
+		if existing, ok := aliases[s.AZ]; !ok || alias < existing {
+			aliases[s.AZ] = alias
+		}
+	}
+	return aliases
+}
+
+func (v *IPv4VPCResourceSet) validateSecondarySubnetRouteTables() error {
+	primaryAliases := v.primaryPrivateSubnetAliases()
+	for _, subnets := range []api.AZSubnetMapping{v.clusterConfig.VPC.Subnets.Private, v.clusterConfig.VPC.PodSubnetMapping()} {
+		for alias, s := range subnets {
+			if _, ok := primaryAliases[s.AZ]; vpc.IsSecondarySubnetAlias(alias) && !ok {
+				return fmt.Errorf("subnet %q in secondary CIDR block requires a private subnet created with the VPC in %s", alias, s.AZ)
+			}
+		}
+	}
+	return nil
+}
+
+func (v *IPv4VPCResourceSet) findSecondaryCIDR(subnetCIDR *ipnet.IPNet) *ipnet.IPNet {
+	if subnetCIDR == nil {
+		return nil
+	}
+	for _, cidr := range v.clusterConfig.VPC.SecondaryCIDRs {
+		if cidr.Contains(subnetCIDR.IP) {
+			return cidr
+		}
+	}
+	return nil
+}
+
+func makeSecondaryCIDRResourceName(cidr *ipnet.IPNet) string {
+	return "VPCSecondaryCIDR" + vpc.MakeSecondaryCIDRName(cidr.IP)
+}
+
 func (v *IPv4VPCResourceSet) addPrivateRouteTables() {
 	forEachPrivateSubnet(v.clusterConfig.VPC, func(subnetAlias string) {
 		subnetAZResourceName := makeAZResourceName(subnetAlias)
@@ -404,6 +510,10 @@ func (v *IPv4VPCResourceSet) addHybridNodesNetworking() {
 
 func forEachPrivateSubnet(clusterVPC *api.ClusterVPC, fn func(subnetAlias string)) {
 	for subnetAlias := range clusterVPC.Subnets.Private {
+		// subnets in secondary CIDR blocks share the route table of the private subnet in their AZ
+		if vpc.IsSecondarySubnetAlias(subnetAlias) {
+			continue
+		}
 		fn(subnetAlias)
 	}
 	if clusterVPC.LocalZoneSubnets != nil {
@@ -447,6 +557,10 @@ type clusterSecurityGroup struct {
 
 func (v *IPv4VPCResourceSet) haNAT() {
 	for subnetAlias := range v.clusterConfig.VPC.Subnets.Public {
+		// private subnets in secondary CIDR blocks use the NAT gateway created with the VPC in their AZ
+		if vpc.IsSecondarySubnetAlias(subnetAlias) {
+			continue
+		}
 		subnetAZResourceName := makeAZResourceName(subnetAlias)
 
 		// Allocate an EIP
@@ -17,6 +17,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
 	"github.com/weaveworks/eksctl/pkg/cfn/builder/fakes"
 	"github.com/weaveworks/eksctl/pkg/eks/mocksv2"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
 )
 
 var _ = Describe("VPC Template Builder", func() {
@@ -342,6 +343,50 @@ var _ = Describe("VPC Template Builder", func() {
 				Expect(vpcTemplate.Resources[rtaPrivateB].Properties.RouteTableID).To(Equal(makeRef(privRouteTableB)))
 			})
 		})
+		Context("when the vpc has a secondary CIDR block", func() {
+			BeforeEach(func() {
+				*cfg.VPC.NAT.Gateway = api.ClusterHighlyAvailableNAT
+				addSecondarySubnets(cfg.VPC)
+			})
+
+			It("associates the CIDR block with the VPC", func() {
+				Expect(vpcTemplate.Resources).To(HaveKey(secondaryCIDRKey))
+				Expect(vpcTemplate.Resources[secondaryCIDRKey].Properties.CidrBlock).To(Equal("100.64.0.0/16"))
+				Expect(vpcTemplate.Resources[secondaryCIDRKey].Properties.VpcID).To(Equal(makeRef(vpcResourceKey)))
+			})
+
+			It("adds the subnets with the route table of the private subnet in their AZ", func() {
+				Expect(vpcTemplate.Resources).To(HaveKey(secondaryPrivateSubnetRef))
+				Expect(vpcTemplate.Resources[secondaryPrivateSubnetRef].DependsOn).To(ConsistOf(secondaryCIDRKey))
+				Expect(vpcTemplate.Resources[secondaryPrivateSubnetRef].Properties.CidrBlock).To(Equal("100.64.0.0/19"))
+				rtaSecondaryPrivate := "RouteTableAssociationPrivateSECONDARYUSWEST2A100064000000"
+				Expect(vpcTemplate.Resources[rtaSecondaryPrivate].Properties.RouteTableID).To(Equal(makeRef(privRouteTableA)))
+				Expect(vpcTemplate.Resources).NotTo(HaveKey("PrivateRouteTableSECONDARYUSWEST2A100064000000"))
+
+				Expect(vpcTemplate.Resources).To(HaveKey(secondaryPodSubnetRef))
+				Expect(vpcTemplate.Resources[secondaryPodSubnetRef].DependsOn).To(ConsistOf(secondaryCIDRKey))
+				Expect(vpcTemplate.Resources[secondaryPodSubnetRef].Properties.Tags).To(HaveLen(1))
+				Expect(vpcTemplate.Resources[secondaryPodSubnetRef].Properties.Tags[0].Key).To(Equal("Name"))
+				rtaPod := "RouteTableAssociationPodSECONDARYUSWEST2B100064064000"
+				Expect(vpcTemplate.Resources[rtaPod].Properties.RouteTableID).To(Equal(makeRef(privRouteTableB)))
+
+				rtaSecondaryPublic := "RouteTableAssociationPublicSECONDARYUSWEST2A100064032000"
+				Expect(vpcTemplate.Resources[rtaSecondaryPublic].Properties.RouteTableID).To(Equal(makeRef(pubRouteTable)))
+			})
+
+			It("does not add NAT gateways for public subnets in the secondary CIDR block", func() {
+				Expect(vpcTemplate.Resources).To(HaveKey("NATGatewayUSWEST2A"))
+				Expect(vpcTemplate.Resources).To(HaveKey("NATGatewayUSWEST2B"))
+				Expect(vpcTemplate.Resources).NotTo(HaveKey("NATGatewaySECONDARYUSWEST2A100064032000"))
+			})
+
+			It("does not use the subnets for the control plane", func() {
+				Expect(subnetDetails.Private).To(HaveLen(3))
+				Expect(subnetDetails.PrivateSubnetRefs()).To(HaveLen(2))
+				Expect(subnetDetails.Pod).To(HaveLen(1))
+				Expect(subnetDetails.ControlPlaneSubnetRefs()).To(HaveLen(4))
+			})
+		})
 	})
 
 	Describe("AddOutputs", func() {
@@ -392,6 +437,18 @@ var _ = Describe("VPC Template Builder", func() {
 				Expect(vpcTemplate.Outputs).To(HaveKey("ClusterFullyPrivate"))
 			})
 		})
+
+		Context("the vpc has a secondary CIDR block", func() {
+			BeforeEach(func() {
+				addSecondarySubnets(cfg.VPC)
+			})
+
+			It("adds an output for each type of subnet in the CIDR block", func() {
+				Expect(vpcTemplate.Outputs).To(HaveKey("SubnetsPrivateSecondary100064000000"))
+				Expect(vpcTemplate.Outputs).To(HaveKey("SubnetsPublicSecondary100064000000"))
+				Expect(vpcTemplate.Outputs).To(HaveKey("SubnetsPodSecondary100064000000"))
+			})
+		})
 	})
 
 	Describe("PublicSubnetRefs", func() {
@@ -417,6 +474,37 @@ var _ = Describe("VPC Template Builder", func() {
 	})
 })
 
+const (
+	secondaryCIDRKey          = "VPCSecondaryCIDR100064000000"
+	secondaryPrivateSubnetRef = "SubnetPrivateSECONDARYUSWEST2A100064000000"
+	secondaryPodSubnetRef     = "SubnetPodSECONDARYUSWEST2B100064064000"
+)
+
+func addSecondarySubnets(clusterVPC *api.ClusterVPC) {
+	parseCIDR := func(cidr string) *ipnet.IPNet {
+		ret, err := ipnet.ParseCIDR(cidr)
+		Expect(err).NotTo(HaveOccurred())
+		return ret
+	}
+	clusterVPC.SecondaryCIDRs = []*ipnet.IPNet{parseCIDR("100.64.0.0/16")}
+	clusterVPC.Subnets.Private["secondary-us-west-2a-100064000000"] = api.AZSubnetSpec{
+		AZ:   azA,
+		CIDR: parseCIDR("100.64.0.0/19"),
+	}
+	clusterVPC.Subnets.Public["secondary-us-west-2a-100064032000"] = api.AZSubnetSpec{
+		AZ:   azA,
+		CIDR: parseCIDR("100.64.32.0/19"),
+	}
+	clusterVPC.PodSubnets = &api.PodSubnets{
+		Subnets: api.AZSubnetMapping{
+			"secondary-us-west-2b-100064064000": api.AZSubnetSpec{
+				AZ:   azB,
+				CIDR: parseCIDR("100.64.64.0/19"),
+			},
+		},
+	}
+}
+
 func makePrimitive(primitive string) *gfnt.Value {
 	output, err := gfnt.NewValueFromPrimitive(makeRef(primitive))
 	Expect(err).NotTo(HaveOccurred())
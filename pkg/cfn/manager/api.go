@@ -84,6 +84,8 @@ type StackCollection struct {
 	waitTimeout     time.Duration
 	sharedTags      []types.Tag
 	stsAPI          awsapi.STS
+	// changeSetApprover, when set, is asked to approve each ChangeSet before it is executed
+	changeSetApprover ChangeSetApprover
 }
 
 func newTag(key, value string) types.Tag {
@@ -100,6 +102,10 @@ func NewStackCollection(provider api.ClusterProvider, spec *api.ClusterConfig) S
 	for key, value := range spec.Metadata.Tags {
 		tags = append(tags, newTag(key, value))
 	}
+	var changeSetApprover ChangeSetApprover
+	if provider.CloudFormationChangeSetPreview() {
+		changeSetApprover = stdinChangeSetPrompt()
+	}
 	return &StackCollection{
 		spec:              spec,
 		sharedTags:        tags,
@@ -114,6 +120,7 @@ func NewStackCollection(provider api.ClusterProvider, spec *api.ClusterConfig) S
 		region:            provider.Region(),
 		waitTimeout:       provider.WaitTimeout(),
 		stsAPI:            provider.STS(),
+		changeSetApprover: changeSetApprover,
 	}
 }
 
@@ -365,11 +372,16 @@ func (c *StackCollection) updateStack(ctx context.Context, options UpdateStackOp
 		}
 		return err
 	}
-	changeSet, err := c.DescribeStackChangeSet(ctx, options.Stack, options.ChangeSetName)
+	changeSet, err := c.describeChangeSet(ctx, options.Stack, options.ChangeSetName, c.changeSetApprover != nil)
 	if err != nil {
 		return err
 	}
 	logger.Debug("changes = %#v", changeSet.Changes)
+	if c.changeSetApprover != nil {
+		if err := c.reviewChangeSet(ctx, options.StackName, options.ChangeSetName, changeSet); err != nil {
+			return err
+		}
+	}
 	if err := c.doExecuteChangeSet(ctx, options.StackName, options.ChangeSetName); err != nil {
 		logger.Warning("error executing Cloudformation changeSet %s in stack %s. Check the Cloudformation console for further details", options.ChangeSetName, options.StackName)
 		return err
@@ -779,10 +791,17 @@ func (c *StackCollection) doExecuteChangeSet(ctx context.Context, stackName stri
 
 // DescribeStackChangeSet describes a ChangeSet by name
 func (c *StackCollection) DescribeStackChangeSet(ctx context.Context, i *Stack, changeSetName string) (*ChangeSet, error) {
+	return c.describeChangeSet(ctx, i, changeSetName, false)
+}
+
+func (c *StackCollection) describeChangeSet(ctx context.Context, i *Stack, changeSetName string, includePropertyValues bool) (*ChangeSet, error) {
 	input := &cloudformation.DescribeChangeSetInput{
 		StackName:     i.StackName,
 		ChangeSetName: &changeSetName,
 	}
+	if includePropertyValues {
+		input.IncludePropertyValues = aws.Bool(true)
+	}
 	if api.IsSetAndNonEmptyString(i.StackId) {
 		input.StackName = i.StackId
 	}
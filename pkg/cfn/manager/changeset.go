@@ -0,0 +1,129 @@
+package manager
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/kris-nova/logger"
+)
+
+// ChangeSetApprover is called with a ChangeSet after it has been created; the ChangeSet is only executed
+// if it returns true.
+type ChangeSetApprover func(stackName string, changeSet *ChangeSet) (bool, error)
+
+// NewChangeSetPrompt returns a ChangeSetApprover that prints the changes in a ChangeSet to out and
+// asks for confirmation on in. Stacks may be updated in parallel, so prompts are serialized to keep
+// each answer paired with the changes it was given for.
+func NewChangeSetPrompt(in io.Reader, out io.Writer) ChangeSetApprover {
+	var mu sync.Mutex
+	reader := bufio.NewReader(in)
+	return func(stackName string, changeSet *ChangeSet) (bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if _, err := fmt.Fprintf(out, "\nChanges to stack %q:\n%s\nExecute these changes? [y/N]: ", stackName, FormatChangeSet(changeSet)); err != nil {
+			return false, err
+		}
+		answer, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return false, fmt.Errorf("reading confirmation: %w", err)
+		}
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return true, nil
+		default:
+			return false, nil
+		}
+	}
+}
+
+// stdinChangeSetPrompt is shared by all stack managers, as each prompt buffers what it reads from stdin.
+var stdinChangeSetPrompt = sync.OnceValue(func() ChangeSetApprover {
+	return NewChangeSetPrompt(os.Stdin, os.Stdout)
+})
+
+// FormatChangeSet renders the resource-level changes of a ChangeSet, highlighting replacements.
+func FormatChangeSet(changeSet *ChangeSet) string {
+	var b strings.Builder
+	if len(changeSet.Changes) == 0 {
+		b.WriteString("ChangeSet contains no resource changes\n")
+		return b.String()
+	}
+
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTION\tLOGICAL ID\tTYPE\tREPLACEMENT")
+	for _, change := range changeSet.Changes {
+		rc := change.ResourceChange
+		if rc == nil {
+			continue
+		}
+		replacement := string(rc.Replacement)
+		if replacement == "" {
+			replacement = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rc.Action, aws.ToString(rc.LogicalResourceId), aws.ToString(rc.ResourceType), replacement)
+		for _, detail := range rc.Details {
+			if detail.Target == nil {
+				continue
+			}
+			fmt.Fprintf(w, "\t  %s\t\t\n", formatChangeDetail(detail))
+		}
+	}
+	_ = w.Flush()
+
+	for _, change := range changeSet.Changes {
+		if rc := change.ResourceChange; rc != nil && rc.Replacement == types.ReplacementTrue {
+			fmt.Fprintf(&b, "WARNING: %s %q will be replaced\n", aws.ToString(rc.ResourceType), aws.ToString(rc.LogicalResourceId))
+		}
+	}
+	return b.String()
+}
+
+func formatChangeDetail(detail types.ResourceChangeDetail) string {
+	target := detail.Target
+	path := string(target.Attribute)
+	if name := aws.ToString(target.Name); name != "" {
+		path += "." + name
+	}
+	if target.BeforeValue != nil || target.AfterValue != nil {
+		path += fmt.Sprintf(": %s -> %s", valueOrNone(target.BeforeValue), valueOrNone(target.AfterValue))
+	}
+	if target.RequiresRecreation != "" && target.RequiresRecreation != types.RequiresRecreationNever {
+		path += fmt.Sprintf(" (requires recreation: %s)", target.RequiresRecreation)
+	}
+	return path
+}
+
+func valueOrNone(value *string) string {
+	if value == nil {
+		return "<none>"
+	}
+	return *value
+}
+
+// reviewChangeSet asks the approver whether the ChangeSet should be executed and deletes it if it is declined.
+func (c *StackCollection) reviewChangeSet(ctx context.Context, stackName, changeSetName string, changeSet *ChangeSet) error {
+	approved, err := c.changeSetApprover(stackName, changeSet)
+	if err != nil {
+		return err
+	}
+	if approved {
+		return nil
+	}
+	logger.Info("deleting ChangeSet %q for stack %q", changeSetName, stackName)
+	if _, err := c.cloudformationAPI.DeleteChangeSet(ctx, &cloudformation.DeleteChangeSetInput{
+		ChangeSetName: &changeSetName,
+		StackName:     &stackName,
+	}); err != nil {
+		logger.Warning("failed to delete ChangeSet %q for stack %q: %v", changeSetName, stackName, err)
+	}
+	return fmt.Errorf("ChangeSet %q for stack %q was not approved", changeSetName, stackName)
+}
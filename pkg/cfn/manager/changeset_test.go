@@ -0,0 +1,206 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfn "github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("ChangeSet preview", func() {
+	var (
+		stackName     = "eksctl-cluster-nodegroup-ng"
+		changeSetName = "eksctl-update-nodegroup"
+		changeSet     *ChangeSet
+	)
+
+	BeforeEach(func() {
+		changeSet = &cfn.DescribeChangeSetOutput{
+			StackName:     &stackName,
+			ChangeSetName: &changeSetName,
+			Status:        types.ChangeSetStatusCreateComplete,
+			Changes: []types.Change{
+				{
+					ResourceChange: &types.ResourceChange{
+						Action:            types.ChangeActionModify,
+						LogicalResourceId: aws.String("NodeGroupLaunchTemplate"),
+						ResourceType:      aws.String("AWS::EC2::LaunchTemplate"),
+						Replacement:       types.ReplacementFalse,
+						Details: []types.ResourceChangeDetail{
+							{
+								Target: &types.ResourceTargetDefinition{
+									Attribute:          types.ResourceAttributeProperties,
+									Name:               aws.String("LaunchTemplateData"),
+									RequiresRecreation: types.RequiresRecreationNever,
+								},
+							},
+						},
+					},
+				},
+				{
+					ResourceChange: &types.ResourceChange{
+						Action:            types.ChangeActionModify,
+						LogicalResourceId: aws.String("SG"),
+						ResourceType:      aws.String("AWS::EC2::SecurityGroup"),
+						Replacement:       types.ReplacementTrue,
+						Details: []types.ResourceChangeDetail{
+							{
+								Target: &types.ResourceTargetDefinition{
+									Attribute:          types.ResourceAttributeProperties,
+									Name:               aws.String("GroupDescription"),
+									BeforeValue:        aws.String("old"),
+									AfterValue:         aws.String("new"),
+									RequiresRecreation: types.RequiresRecreationAlways,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("formats resource changes and warns about replacements", func() {
+		out := FormatChangeSet(changeSet)
+		Expect(out).To(ContainSubstring("Properties.LaunchTemplateData"))
+		Expect(out).To(ContainSubstring("Properties.GroupDescription: old -> new (requires recreation: Always)"))
+		Expect(out).To(ContainSubstring(`WARNING: AWS::EC2::SecurityGroup "SG" will be replaced`))
+		Expect(out).NotTo(ContainSubstring(`"NodeGroupLaunchTemplate" will be replaced`))
+	})
+
+	DescribeTable("prompt", func(answer string, expectedApproval bool) {
+		var out bytes.Buffer
+		approve := NewChangeSetPrompt(strings.NewReader(answer), &out)
+		approved, err := approve(stackName, changeSet)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(approved).To(Equal(expectedApproval))
+		Expect(out.String()).To(ContainSubstring("Execute these changes? [y/N]"))
+	},
+		Entry("approves with y", "y\n", true),
+		Entry("approves with yes", "YES\n", true),
+		Entry("declines with n", "n\n", false),
+		Entry("declines by default", "\n", false),
+		Entry("declines on EOF", "", false),
+	)
+
+	It("serializes concurrent prompts", func() {
+		in, answers := io.Pipe()
+		out := &promptWriter{prompts: make(chan string)}
+		approve := NewChangeSetPrompt(in, out)
+
+		go func() {
+			defer GinkgoRecover()
+			for prompt := range out.prompts {
+				answer := "n\n"
+				if strings.Contains(prompt, `stack "approve-`) {
+					answer = "y\n"
+				}
+				_, err := answers.Write([]byte(answer))
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}()
+
+		stackNames := []string{"approve-1", "decline-1", "approve-2", "decline-2", "approve-3", "decline-3"}
+		var (
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			approvals = map[string]bool{}
+		)
+		for _, name := range stackNames {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				approved, err := approve(name, changeSet)
+				Expect(err).NotTo(HaveOccurred())
+				mu.Lock()
+				defer mu.Unlock()
+				approvals[name] = approved
+			}()
+		}
+		wg.Wait()
+		close(out.prompts)
+
+		for _, name := range stackNames {
+			Expect(approvals).To(HaveKeyWithValue(name, strings.HasPrefix(name, "approve-")))
+		}
+	})
+
+	Context("UpdateStack", func() {
+		var (
+			p  *mockprovider.MockProvider
+			sm *StackCollection
+		)
+
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+			p.MockCloudFormation().On("DescribeStacks", mock.Anything, mock.Anything).Return(&cfn.DescribeStacksOutput{Stacks: []types.Stack{{
+				StackName:   &stackName,
+				StackStatus: types.StackStatusCreateComplete,
+			}}}, nil)
+			p.MockCloudFormation().On("CreateChangeSet", mock.Anything, mock.Anything).Return(nil, nil)
+			p.MockCloudFormation().On("DescribeChangeSet", mock.Anything, mock.Anything, mock.Anything).Return(changeSet, nil)
+			p.MockCloudFormation().On("ExecuteChangeSet", mock.Anything, mock.Anything).Return(nil, nil)
+			p.MockCloudFormation().On("DeleteChangeSet", mock.Anything, mock.Anything).Return(nil, nil)
+			sm = NewStackCollection(p, api.NewClusterConfig()).(*StackCollection)
+		})
+
+		updateStack := func() error {
+			return sm.UpdateStack(context.Background(), UpdateStackOptions{
+				StackName:     stackName,
+				ChangeSetName: changeSetName,
+				Description:   "description",
+				TemplateData:  TemplateBody(""),
+			})
+		}
+
+		It("executes an approved ChangeSet", func() {
+			var reviewed *ChangeSet
+			sm.changeSetApprover = func(_ string, changeSet *ChangeSet) (bool, error) {
+				reviewed = changeSet
+				return true, nil
+			}
+			Expect(updateStack()).To(Succeed())
+			Expect(reviewed).To(Equal(changeSet))
+			p.MockCloudFormation().AssertCalled(GinkgoT(), "ExecuteChangeSet", mock.Anything, mock.Anything)
+			p.MockCloudFormation().AssertNotCalled(GinkgoT(), "DeleteChangeSet", mock.Anything, mock.Anything)
+
+			p.MockCloudFormation().AssertCalled(GinkgoT(), "DescribeChangeSet", mock.Anything, mock.MatchedBy(func(input *cfn.DescribeChangeSetInput) bool {
+				return aws.ToBool(input.IncludePropertyValues)
+			}), mock.Anything)
+		})
+
+		It("deletes a declined ChangeSet without executing it", func() {
+			sm.changeSetApprover = func(string, *ChangeSet) (bool, error) {
+				return false, nil
+			}
+			Expect(updateStack()).To(MatchError(ContainSubstring("was not approved")))
+			p.MockCloudFormation().AssertNotCalled(GinkgoT(), "ExecuteChangeSet", mock.Anything, mock.Anything)
+			p.MockCloudFormation().AssertCalled(GinkgoT(), "DeleteChangeSet", mock.Anything, &cfn.DeleteChangeSetInput{
+				ChangeSetName: &changeSetName,
+				StackName:     &stackName,
+			})
+		})
+	})
+})
+
+// promptWriter passes each prompt written by a ChangeSet prompt on to a responder.
+type promptWriter struct {
+	prompts chan string
+}
+
+func (w *promptWriter) Write(p []byte) (int, error) {
+	w.prompts <- string(p)
+	return len(p), nil
+}
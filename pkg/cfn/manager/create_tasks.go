@@ -154,6 +154,7 @@ func (c *StackCollection) NewTasksToCreateIAMServiceAccounts(serviceAccounts []*
 		if !api.IsEnabled(sa.RoleOnly) {
 			saTasks.Append(&kubernetesTask{
 				info:       fmt.Sprintf("create serviceaccount %q", sa.NameString()),
+				kind:       tasks.KindIAMServiceAccount,
 				kubernetes: clientSetGetter,
 				objectMeta: sa.ClusterIAMMeta.AsObjectMeta(),
 				call: func(clientSet kubernetes.Interface, objectMeta v1.ObjectMeta) error {
@@ -108,12 +108,14 @@ func (c *StackCollection) NewTasksToDeleteClusterWithNodeGroups(
 	if wait {
 		taskTree.Append(&taskWithStackSpec{
 			info:  info,
+			kind:  tasks.KindCluster,
 			stack: clusterStack,
 			call:  c.DeleteStackBySpecSync,
 		})
 	} else {
 		taskTree.Append(&asyncTaskWithStackSpec{
 			info:  info,
+			kind:  tasks.KindCluster,
 			stack: clusterStack,
 			call:  c.DeleteStackBySpec,
 		})
@@ -141,12 +143,14 @@ func (c *StackCollection) NewTasksToDeleteNodeGroups(nodeGroupStacks []NodeGroup
 		if wait {
 			taskTree.Append(&taskWithStackSpec{
 				info:  info,
+				kind:  nodeGroupKind(s.Type),
 				stack: s.Stack,
 				call:  c.DeleteStackBySpecSync,
 			})
 		} else {
 			taskTree.Append(&asyncTaskWithStackSpec{
 				info:  info,
+				kind:  nodeGroupKind(s.Type),
 				stack: s.Stack,
 				call:  c.DeleteStackBySpec,
 			})
@@ -156,6 +160,13 @@ func (c *StackCollection) NewTasksToDeleteNodeGroups(nodeGroupStacks []NodeGroup
 	return taskTree, nil
 }
 
+func nodeGroupKind(ngType api.NodeGroupType) tasks.ResourceKind {
+	if ngType == api.NodeGroupTypeManaged {
+		return tasks.KindManagedNodeGroup
+	}
+	return tasks.KindNodeGroup
+}
+
 func usesAccessEntry(stack *Stack) bool {
 	for _, output := range stack.Outputs {
 		if *output.OutputKey == outputs.NodeGroupUsesAccessEntry {
@@ -189,6 +200,10 @@ func (d *DeleteUnownedNodegroupTask) Describe() string {
 	return d.info
 }
 
+func (d *DeleteUnownedNodegroupTask) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: tasks.KindManagedNodeGroup}
+}
+
 func (d *DeleteUnownedNodegroupTask) Do() error {
 	out, err := d.nodeGroupDeleter.DeleteNodegroup(d.ctx, &awseks.DeleteNodegroupInput{
 		ClusterName:   &d.cluster,
@@ -294,6 +309,7 @@ func (c *StackCollection) NewTasksToDeleteOIDCProviderWithIAMServiceAccounts(ctx
 	if providerExists {
 		taskTree.Append(&asyncTaskWithoutParams{
 			info: "delete IAM OIDC provider",
+			kind: tasks.KindOIDCProvider,
 			call: func() error {
 				return oidc.DeleteProvider(ctx)
 			},
@@ -338,12 +354,14 @@ func (c *StackCollection) NewTasksToDeleteIAMServiceAccounts(ctx context.Context
 			if wait {
 				saTasks.Append(&taskWithStackSpec{
 					info:  info,
+					kind:  tasks.KindIAMServiceAccount,
 					stack: s,
 					call:  c.DeleteStackBySpecSync,
 				})
 			} else {
 				saTasks.Append(&asyncTaskWithStackSpec{
 					info:  info,
+					kind:  tasks.KindIAMServiceAccount,
 					stack: s,
 					call:  c.DeleteStackBySpec,
 				})
@@ -356,6 +374,7 @@ func (c *StackCollection) NewTasksToDeleteIAMServiceAccounts(ctx context.Context
 		}
 		saTasks.Append(&kubernetesTask{
 			info:       fmt.Sprintf("delete serviceaccount %q", serviceAccount),
+			kind:       tasks.KindIAMServiceAccount,
 			kubernetes: clientSetGetter,
 			objectMeta: meta.AsObjectMeta(),
 			call:       kubernetes.MaybeDeleteServiceAccount,
@@ -92,6 +92,10 @@ func (t *UnmanagedNodeGroupTask) Create(ctx context.Context, options CreateNodeG
 			Doer: func() error {
 				return t.createNodeGroup(ctx, ng, options, createAccessEntryInStack)
 			},
+			Resource: tasks.ResourceInfo{
+				Kind:      tasks.KindNodeGroup,
+				StackName: makeNodeGroupStackName(t.ClusterConfig.Metadata.Name, ng.Name),
+			},
 		}
 
 		if options.DisableAccessEntryCreation || createAccessEntryInStack {
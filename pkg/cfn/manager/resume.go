@@ -0,0 +1,71 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+// StackDescriber describes a CloudFormation stack.
+type StackDescriber interface {
+	DescribeStack(ctx context.Context, i *Stack) (*Stack, error)
+}
+
+// NewStackCreatedChecker returns a CompletionChecker that considers a task completed when the stack it creates
+// already exists, so that resuming a failed run does not attempt to create it again.
+func NewStackCreatedChecker(ctx context.Context, describer StackDescriber) tasks.CompletionChecker {
+	return func(task tasks.Task) (bool, error) {
+		stackName, ok := stackNameOf(task)
+		if !ok {
+			return false, nil
+		}
+		stack, err := describer.DescribeStack(ctx, &Stack{StackName: aws.String(stackName)})
+		if err != nil {
+			if IsStackDoesNotExistError(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		switch stack.StackStatus {
+		case types.StackStatusCreateComplete, types.StackStatusUpdateComplete, types.StackStatusUpdateRollbackComplete:
+			return true, nil
+		case types.StackStatusDeleteComplete:
+			return false, nil
+		default:
+			return false, fmt.Errorf("cannot resume task %q as stack %q is in state %q; wait for it to complete or delete it and try again",
+				task.Describe(), stackName, stack.StackStatus)
+		}
+	}
+}
+
+// NewStackDeletedChecker returns a CompletionChecker that considers a task completed when the stack it deletes
+// no longer exists.
+func NewStackDeletedChecker(ctx context.Context, describer StackDescriber) tasks.CompletionChecker {
+	return func(task tasks.Task) (bool, error) {
+		stackName, ok := stackNameOf(task)
+		if !ok {
+			return false, nil
+		}
+		stack, err := describer.DescribeStack(ctx, &Stack{StackName: aws.String(stackName)})
+		if err != nil {
+			if IsStackDoesNotExistError(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		return stack.StackStatus == types.StackStatusDeleteComplete, nil
+	}
+}
+
+func stackNameOf(task tasks.Task) (string, bool) {
+	describer, ok := task.(tasks.ResourceDescriber)
+	if !ok {
+		return "", false
+	}
+	stackName := describer.ResourceInfo().StackName
+	return stackName, stackName != ""
+}
@@ -0,0 +1,105 @@
+package manager
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+type stackDescriberFunc func(ctx context.Context, i *Stack) (*Stack, error)
+
+func (f stackDescriberFunc) DescribeStack(ctx context.Context, i *Stack) (*Stack, error) {
+	return f(ctx, i)
+}
+
+var _ = Describe("Resume", func() {
+	var (
+		task        tasks.Task
+		stackStatus types.StackStatus
+		describeErr error
+		describer   StackDescriber
+	)
+
+	BeforeEach(func() {
+		task = &tasks.GenericTask{
+			Description: "create nodegroup ng",
+			Resource:    tasks.ResourceInfo{Kind: tasks.KindNodeGroup, StackName: "eksctl-test-nodegroup-ng"},
+		}
+		stackStatus = ""
+		describeErr = nil
+		describer = stackDescriberFunc(func(_ context.Context, i *Stack) (*Stack, error) {
+			Expect(aws.ToString(i.StackName)).To(Equal("eksctl-test-nodegroup-ng"))
+			if describeErr != nil {
+				return nil, describeErr
+			}
+			return &Stack{StackName: i.StackName, StackStatus: stackStatus}, nil
+		})
+	})
+
+	notFound := &smithy.OperationError{
+		OperationName: "DescribeStacks",
+		Err:           errors.New("ValidationError: Stack with id eksctl-test-nodegroup-ng does not exist"),
+	}
+
+	Context("NewStackCreatedChecker", func() {
+		DescribeTable("reports whether the stack was created", func(status types.StackStatus, expected bool) {
+			stackStatus = status
+			completed, err := NewStackCreatedChecker(context.Background(), describer)(task)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(completed).To(Equal(expected))
+		},
+			Entry("create complete", types.StackStatusCreateComplete, true),
+			Entry("update complete", types.StackStatusUpdateComplete, true),
+			Entry("update rollback complete", types.StackStatusUpdateRollbackComplete, true),
+			Entry("delete complete", types.StackStatusDeleteComplete, false),
+		)
+
+		It("does not consider a missing stack completed", func() {
+			describeErr = notFound
+			completed, err := NewStackCreatedChecker(context.Background(), describer)(task)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(completed).To(BeFalse())
+		})
+
+		It("returns an error for a stack that is still in progress or failed", func() {
+			stackStatus = types.StackStatusRollbackComplete
+			_, err := NewStackCreatedChecker(context.Background(), describer)(task)
+			Expect(err).To(MatchError(ContainSubstring(`stack "eksctl-test-nodegroup-ng" is in state "ROLLBACK_COMPLETE"`)))
+		})
+
+		It("ignores tasks without a stack", func() {
+			task = &tasks.GenericTask{Description: "create addon", Resource: tasks.ResourceInfo{Kind: tasks.KindAddon}}
+			completed, err := NewStackCreatedChecker(context.Background(), describer)(task)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(completed).To(BeFalse())
+		})
+	})
+
+	Context("NewStackDeletedChecker", func() {
+		It("considers a missing stack deleted", func() {
+			describeErr = notFound
+			completed, err := NewStackDeletedChecker(context.Background(), describer)(task)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(completed).To(BeTrue())
+		})
+
+		It("reports whether the stack was deleted", func() {
+			stackStatus = types.StackStatusDeleteComplete
+			completed, err := NewStackDeletedChecker(context.Background(), describer)(task)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(completed).To(BeTrue())
+
+			stackStatus = types.StackStatusDeleteInProgress
+			completed, err = NewStackDeletedChecker(context.Background(), describer)(task)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(completed).To(BeFalse())
+		})
+	})
+})
@@ -10,6 +10,7 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
 	kubewrapper "github.com/weaveworks/eksctl/pkg/kubernetes"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 	"github.com/weaveworks/eksctl/pkg/vpc"
 )
 
@@ -21,6 +22,9 @@ type createClusterTask struct {
 }
 
 func (t *createClusterTask) Describe() string { return t.info }
+func (t *createClusterTask) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: tasks.KindCluster, StackName: t.stackCollection.MakeClusterStackName()}
+}
 
 func (t *createClusterTask) Do(errorCh chan error) error {
 	return t.stackCollection.createClusterTask(t.ctx, errorCh, t.supportsManagedNodes)
@@ -36,6 +40,9 @@ type managedNodeGroupTask struct {
 }
 
 func (t *managedNodeGroupTask) Describe() string { return t.info }
+func (t *managedNodeGroupTask) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: tasks.KindManagedNodeGroup, StackName: t.stackCollection.makeNodeGroupStackName(t.nodeGroup.Name)}
+}
 
 func (t *managedNodeGroupTask) Do(errorCh chan error) error {
 	return t.stackCollection.createManagedNodeGroupTask(t.ctx, errorCh, t.nodeGroup, t.forceAddCNIPolicy, t.vpcImporter)
@@ -49,6 +56,9 @@ type managedNodeGroupTagsToASGPropagationTask struct {
 }
 
 func (t *managedNodeGroupTagsToASGPropagationTask) Describe() string { return t.info }
+func (t *managedNodeGroupTagsToASGPropagationTask) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: tasks.KindManagedNodeGroup}
+}
 
 func (t *managedNodeGroupTagsToASGPropagationTask) Do(errorCh chan error) error {
 	return t.stackCollection.propagateManagedNodeGroupTagsToASGTask(t.ctx, errorCh, t.nodeGroup, t.stackCollection.PropagateManagedNodeGroupTagsToASG)
@@ -62,28 +72,42 @@ type taskWithClusterIAMServiceAccountSpec struct {
 }
 
 func (t *taskWithClusterIAMServiceAccountSpec) Describe() string { return t.info }
+func (t *taskWithClusterIAMServiceAccountSpec) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{
+		Kind:      tasks.KindIAMServiceAccount,
+		StackName: t.stackCollection.makeIAMServiceAccountStackName(t.serviceAccount.Namespace, t.serviceAccount.Name),
+	}
+}
 func (t *taskWithClusterIAMServiceAccountSpec) Do(errs chan error) error {
 	return t.stackCollection.createIAMServiceAccountTask(context.TODO(), errs, t.serviceAccount, t.oidc)
 }
 
 type taskWithStackSpec struct {
 	info  string
+	kind  tasks.ResourceKind
 	stack *Stack
 	call  func(context.Context, *Stack, chan error) error
 }
 
 func (t *taskWithStackSpec) Describe() string { return t.info }
+func (t *taskWithStackSpec) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: t.kind, StackName: *t.stack.StackName}
+}
 func (t *taskWithStackSpec) Do(errs chan error) error {
 	return t.call(context.TODO(), t.stack, errs)
 }
 
 type asyncTaskWithStackSpec struct {
 	info  string
+	kind  tasks.ResourceKind
 	stack *Stack
 	call  func(context.Context, *Stack) (*Stack, error)
 }
 
 func (t *asyncTaskWithStackSpec) Describe() string { return t.info + " [async]" }
+func (t *asyncTaskWithStackSpec) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: t.kind, StackName: *t.stack.StackName}
+}
 func (t *asyncTaskWithStackSpec) Do(errs chan error) error {
 	_, err := t.call(context.TODO(), t.stack)
 	close(errs)
@@ -92,10 +116,14 @@ func (t *asyncTaskWithStackSpec) Do(errs chan error) error {
 
 type asyncTaskWithoutParams struct {
 	info string
+	kind tasks.ResourceKind
 	call func() error
 }
 
 func (t *asyncTaskWithoutParams) Describe() string { return t.info }
+func (t *asyncTaskWithoutParams) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: t.kind}
+}
 func (t *asyncTaskWithoutParams) Do(errs chan error) error {
 	err := t.call()
 	close(errs)
@@ -104,12 +132,16 @@ func (t *asyncTaskWithoutParams) Do(errs chan error) error {
 
 type kubernetesTask struct {
 	info       string
+	kind       tasks.ResourceKind
 	kubernetes kubewrapper.ClientSetGetter
 	objectMeta v1.ObjectMeta
 	call       func(kubernetes.Interface, v1.ObjectMeta) error
 }
 
 func (t *kubernetesTask) Describe() string { return t.info }
+func (t *kubernetesTask) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: t.kind}
+}
 func (t *kubernetesTask) Do(errs chan error) error {
 	if t.kubernetes == nil {
 		return fmt.Errorf("cannot start task %q as Kubernetes client configurtaion wasn't provided", t.Describe())
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 
 	"github.com/kris-nova/logger"
 
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/utils/events"
 )
 
 // TroubleshootStackFailureCause identifies the cause of the stack's failure and prints the stack events
@@ -70,13 +72,27 @@ func (e *NoChangeError) Error() string {
 	return e.Msg
 }
 
+// stackStatusReporter returns a function that emits an event each time the status of the stack changes.
+func stackStatusReporter(stackName string) func(*cloudformation.DescribeStacksOutput) {
+	statusTracker := &events.StatusTracker{Type: events.StackStatus, Kind: "stack"}
+	return func(out *cloudformation.DescribeStacksOutput) {
+		if out == nil || len(out.Stacks) != 1 {
+			return
+		}
+		stack := out.Stacks[0]
+		statusTracker.Update(stackName, string(stack.StackStatus), aws.ToString(stack.StackStatusReason))
+	}
+}
+
 // DoWaitUntilStackIsCreated blocks until the given stack's
 // creation has completed.
 func (c *StackCollection) DoWaitUntilStackIsCreated(ctx context.Context, i *Stack) error {
 	setCustomRetryer := func(o *cloudformation.StackCreateCompleteWaiterOptions) {
 		defaultRetryer := o.Retryable
+		reportStatus := stackStatusReporter(*i.StackName)
 		o.Retryable = func(ctx context.Context, in *cloudformation.DescribeStacksInput, out *cloudformation.DescribeStacksOutput, err error) (bool, error) {
 			logger.Info("waiting for CloudFormation stack %q", *i.StackName)
+			reportStatus(out)
 			return defaultRetryer(ctx, in, out, err)
 		}
 	}
@@ -109,8 +125,10 @@ func (c *StackCollection) waitUntilStackIsCreated(ctx context.Context, i *Stack,
 func (c *StackCollection) doWaitUntilStackIsDeleted(ctx context.Context, i *Stack) error {
 	setCustomRetryer := func(o *cloudformation.StackDeleteCompleteWaiterOptions) {
 		defaultRetryer := o.Retryable
+		reportStatus := stackStatusReporter(*i.StackName)
 		o.Retryable = func(ctx context.Context, in *cloudformation.DescribeStacksInput, out *cloudformation.DescribeStacksOutput, err error) (bool, error) {
 			logger.Info("waiting for CloudFormation stack %q", *i.StackName)
+			reportStatus(out)
 			return defaultRetryer(ctx, in, out, err)
 		}
 	}
@@ -134,8 +152,10 @@ func (c *StackCollection) waitUntilStackIsDeleted(ctx context.Context, i *Stack,
 func (c *StackCollection) doWaitUntilStackIsUpdated(ctx context.Context, i *Stack) error {
 	setCustomRetryer := func(o *cloudformation.StackUpdateCompleteWaiterOptions) {
 		defaultRetryer := o.Retryable
+		reportStatus := stackStatusReporter(*i.StackName)
 		o.Retryable = func(ctx context.Context, in *cloudformation.DescribeStacksInput, out *cloudformation.DescribeStacksOutput, err error) (bool, error) {
 			logger.Info("waiting for CloudFormation stack %q", *i.StackName)
+			reportStatus(out)
 			return defaultRetryer(ctx, in, out, err)
 		}
 	}
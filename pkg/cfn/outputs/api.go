@@ -25,6 +25,11 @@ const (
 	ClusterSubnetsPublicExtended  = ClusterSubnetsPublic + "Extended"
 	ClusterFullyPrivate           = "ClusterFullyPrivate"
 
+	// prefixes of the outputs holding the subnets in each secondary CIDR block of the VPC
+	ClusterSubnetsPrivateSecondary = ClusterSubnetsPrivate + "Secondary"
+	ClusterSubnetsPublicSecondary  = ClusterSubnetsPublic + "Secondary"
+	ClusterSubnetsPodSecondary     = "SubnetsPodSecondary"
+
 	ClusterSubnetsPublicLegacy = "Subnets"
 
 	ClusterCertificateAuthorityData = "CertificateAuthorityData"
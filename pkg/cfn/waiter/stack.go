@@ -12,6 +12,7 @@ import (
 	"github.com/kris-nova/logger"
 
 	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/utils/events"
 )
 
 var ClusterCreationNextDelay = func(attempts int) time.Duration {
@@ -28,6 +29,7 @@ type NextDelay func(attempts int) time.Duration
 // WaitForStack waits for the cluster stack to reach a success or failure state, and returns the stack.
 func WaitForStack(ctx context.Context, cfnAPI awsapi.CloudFormation, stackID, stackName string, nextDelay NextDelay) (*types.Stack, error) {
 	var lastStack *types.Stack
+	statusTracker := &events.StatusTracker{Type: events.StackStatus, Kind: "stack"}
 	waiter := &Waiter{
 		NextDelay: nextDelay,
 		Operation: func() (bool, error) {
@@ -36,6 +38,9 @@ func WaitForStack(ctx context.Context, cfnAPI awsapi.CloudFormation, stackID, st
 				success bool
 			)
 			lastStack, success, err = describeStackStatus(context.Background(), cfnAPI, stackID, stackName)
+			if lastStack != nil {
+				statusTracker.Update(stackName, string(lastStack.StackStatus), aws.ToString(lastStack.StackStatusReason))
+			}
 			return success, err
 		},
 	}
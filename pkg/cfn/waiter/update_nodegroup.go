@@ -3,6 +3,7 @@ package waiter
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -11,12 +12,18 @@ import (
 	"github.com/kris-nova/logger"
 
 	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/utils/events"
 )
 
-// WaitForNodegroupUpdate waits for an update to finish. Once it's done it returns the final status.
+// WaitForNodegroupUpdate waits for an update of nodegroupName to finish. Once it's done it returns the final status.
 // If the status was not Successful it will also return an error.
-func WaitForNodegroupUpdate(ctx context.Context, updateID string, api awsapi.EKS, timeout time.Duration, nextDelay NextDelay) (string, error) {
+func WaitForNodegroupUpdate(ctx context.Context, nodegroupName, updateID string, api awsapi.EKS, timeout time.Duration, nextDelay NextDelay) (string, error) {
 	var lastStatus string
+	statusTracker := &events.StatusTracker{
+		Type:    events.NodeGroupUpdateStatus,
+		Kind:    "nodegroup",
+		Message: fmt.Sprintf("update %s", updateID),
+	}
 	waiter := &Waiter{
 		NextDelay: nextDelay,
 		Operation: func() (bool, error) {
@@ -25,6 +32,9 @@ func WaitForNodegroupUpdate(ctx context.Context, updateID string, api awsapi.EKS
 				success bool
 			)
 			lastStatus, success, err = describeUpdateStatus(ctx, updateID, api)
+			if lastStatus != "" {
+				statusTracker.Update(nodegroupName, lastStatus, events.ErrorCause(err))
+			}
 			return success, err
 		},
 	}
@@ -61,9 +71,22 @@ func describeUpdateStatus(ctx context.Context, updateID string, api awsapi.EKS)
 				}
 			}
 		}
-		return string(update.Update.Status), false, fmt.Errorf("update failed or was cancelled")
+		return string(update.Update.Status), false, fmt.Errorf("update failed or was cancelled%s", updateErrors(update.Update.Errors))
 	case ekstypes.UpdateStatusSuccessful:
 		return string(ekstypes.UpdateStatusSuccessful), true, nil
 	}
 	return string(update.Update.Status), false, nil
 }
+
+func updateErrors(errs []ekstypes.ErrorDetail) string {
+	var messages []string
+	for _, err := range errs {
+		if err.ErrorMessage != nil {
+			messages = append(messages, *err.ErrorMessage)
+		}
+	}
+	if len(messages) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(messages, "; ")
+}
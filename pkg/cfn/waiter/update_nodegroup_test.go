@@ -1,7 +1,9 @@
 package waiter
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/weaveworks/eksctl/pkg/eks/mocksv2"
+	"github.com/weaveworks/eksctl/pkg/utils/events"
 )
 
 var _ = Describe("WaitForNodegroupUpdate", func() {
@@ -25,7 +28,7 @@ var _ = Describe("WaitForNodegroupUpdate", func() {
 				Status: ekstypes.UpdateStatusSuccessful,
 			},
 		}, nil)
-		status, err := WaitForNodegroupUpdate(context.TODO(), "update-1", eksAPI, 35*time.Second, func(attempts int) time.Duration {
+		status, err := WaitForNodegroupUpdate(context.TODO(), "ng-1", "update-1", eksAPI, 35*time.Second, func(attempts int) time.Duration {
 			return 1 * time.Nanosecond
 		})
 		Expect(status).To(Equal(string(ekstypes.UpdateStatusSuccessful)))
@@ -41,7 +44,7 @@ var _ = Describe("WaitForNodegroupUpdate", func() {
 					Status: ekstypes.UpdateStatusFailed,
 				},
 			}, errors.New("nope"))
-			status, err := WaitForNodegroupUpdate(context.TODO(), "update-1", eksAPI, 35*time.Second, func(attempts int) time.Duration {
+			status, err := WaitForNodegroupUpdate(context.TODO(), "ng-1", "update-1", eksAPI, 35*time.Second, func(attempts int) time.Duration {
 				return 1 * time.Nanosecond
 			})
 			Expect(status).To(BeEmpty())
@@ -58,11 +61,47 @@ var _ = Describe("WaitForNodegroupUpdate", func() {
 					Status: ekstypes.UpdateStatusFailed,
 				},
 			}, nil)
-			status, err := WaitForNodegroupUpdate(context.TODO(), "update-1", eksAPI, 35*time.Second, func(attempts int) time.Duration {
+			status, err := WaitForNodegroupUpdate(context.TODO(), "ng-1", "update-1", eksAPI, 35*time.Second, func(attempts int) time.Duration {
 				return 1 * time.Nanosecond
 			})
 			Expect(status).To(Equal(string(ekstypes.UpdateStatusFailed)))
 			Expect(err).To(MatchError(ContainSubstring("update failed or was cancelled")))
 		})
 	})
+	It("emits an event for each status transition", func() {
+		out := &bytes.Buffer{}
+		Expect(events.Configure(events.FormatJSONLines, out)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(events.Configure("", nil)).To(Succeed())
+		})
+
+		eksAPI := &mocksv2.EKS{}
+		input := &eks.DescribeUpdateInput{UpdateId: aws.String("update-1")}
+		eksAPI.On("DescribeUpdate", mock.Anything, input).Return(&eks.DescribeUpdateOutput{
+			Update: &ekstypes.Update{Status: ekstypes.UpdateStatusInProgress},
+		}, nil).Twice()
+		eksAPI.On("DescribeUpdate", mock.Anything, input).Return(&eks.DescribeUpdateOutput{
+			Update: &ekstypes.Update{
+				Status: ekstypes.UpdateStatusFailed,
+				Errors: []ekstypes.ErrorDetail{{ErrorMessage: aws.String("insufficient capacity")}},
+			},
+		}, nil).Once()
+		_, err := WaitForNodegroupUpdate(context.TODO(), "ng-1", "update-1", eksAPI, 35*time.Second, func(attempts int) time.Duration {
+			return 1 * time.Nanosecond
+		})
+		Expect(err).To(MatchError("update failed or was cancelled: insufficient capacity"))
+
+		var emitted []events.Event
+		decoder := json.NewDecoder(out)
+		for decoder.More() {
+			var e events.Event
+			Expect(decoder.Decode(&e)).To(Succeed())
+			e.Time = time.Time{}
+			emitted = append(emitted, e)
+		}
+		Expect(emitted).To(Equal([]events.Event{
+			{Type: events.NodeGroupUpdateStatus, ResourceID: "ng-1", Kind: "nodegroup", Status: "InProgress", Message: "update update-1"},
+			{Type: events.NodeGroupUpdateStatus, ResourceID: "ng-1", Kind: "nodegroup", Status: "Failed", Message: "update update-1", Error: "update failed or was cancelled: insufficient capacity"},
+		}))
+	})
 })
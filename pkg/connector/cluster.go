@@ -0,0 +1,110 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kris-nova/logger"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	connectorNamespace   = "eks-connector"
+	connectorStatefulSet = "eks-connector"
+)
+
+// ResourceClient applies and deletes Kubernetes resources on the external cluster.
+type ResourceClient interface {
+	CreateOrReplace(manifest []byte, plan bool) error
+	Delete(manifest []byte) error
+	ClientSet() kubernetes.Interface
+}
+
+// NewResourceClient returns a client for the cluster of kubeconfigContext in the kubeconfig file at kubeconfigPath.
+// The default kubeconfig loading rules apply if kubeconfigPath is empty, and the current context is used if
+// kubeconfigContext is empty.
+func NewResourceClient(kubeconfigPath, kubeconfigContext string) (ResourceClient, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfigPath
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{
+		CurrentContext: kubeconfigContext,
+	}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error loading kubeconfig: %w", err)
+	}
+	clientSet, err := kubeclient.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Kubernetes client: %w", err)
+	}
+	rawClient, err := kubernetes.NewRawClient(clientSet, config)
+	if err != nil {
+		return nil, err
+	}
+	return rawClient, nil
+}
+
+// ApplyResources applies the EKS Connector resources to the external cluster and waits up to timeout
+// for the connector agent to become ready.
+func ApplyResources(ctx context.Context, client ResourceClient, manifestList *ManifestList, timeout time.Duration) error {
+	for _, m := range []ManifestFile{manifestList.ConnectorResources, manifestList.ClusterRoleResources, manifestList.ConsoleAccessResources} {
+		if err := client.CreateOrReplace(m.Data, false); err != nil {
+			return fmt.Errorf("error applying %s: %w", m.Filename, err)
+		}
+	}
+	warnConsoleAccess(manifestList)
+
+	logger.Info("waiting for the EKS Connector agent to become ready")
+	statefulSets := client.ClientSet().AppsV1().StatefulSets(connectorNamespace)
+	if err := wait.PollUntilContextTimeout(ctx, 5*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		s, err := statefulSets.Get(ctx, connectorStatefulSet, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("error getting StatefulSet %s/%s: %w", connectorNamespace, connectorStatefulSet, err)
+		}
+		return isStatefulSetReady(s), nil
+	}); err != nil {
+		return fmt.Errorf("error waiting for the EKS Connector agent to become ready: %w", err)
+	}
+	logger.Info("EKS Connector agent is ready")
+	return nil
+}
+
+func isStatefulSetReady(s *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+	return s.Status.ObservedGeneration >= s.Generation &&
+		s.Status.UpdatedReplicas == replicas &&
+		s.Status.ReadyReplicas == replicas
+}
+
+// DeleteResources deletes the EKS Connector resources in manifestTemplate from the external cluster.
+func DeleteResources(client ResourceClient, manifestTemplate ManifestTemplate) error {
+	// the console access resources refer to the cluster role, and are deleted first
+	for _, m := range []ManifestFile{manifestTemplate.ConsoleAccess, manifestTemplate.ClusterRole, manifestTemplate.Connector} {
+		if err := client.Delete(clearVariables(m.Data)); err != nil {
+			return fmt.Errorf("error deleting resources in %s: %w", m.Filename, err)
+		}
+	}
+	return nil
+}
+
+// clearVariables removes the template variables from a manifest template, as they are not valid YAML.
+// Only the kinds and names of the resources are needed to delete them.
+func clearVariables(template []byte) []byte {
+	for _, field := range []string{"%EKS_ACTIVATION_ID%", "%EKS_ACTIVATION_CODE%", "%AWS_REGION%", "%IAM_ARN%"} {
+		template = applyVariables(template, field, "")
+	}
+	return template
+}
@@ -0,0 +1,110 @@
+package connector_test
+
+import (
+	"context"
+	"os"
+	"path"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/connector"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+type fakeResourceClient struct {
+	clientSet *fake.Clientset
+	applied   [][]byte
+	deleted   [][]byte
+}
+
+func (f *fakeResourceClient) CreateOrReplace(manifest []byte, _ bool) error {
+	f.applied = append(f.applied, manifest)
+	return nil
+}
+
+func (f *fakeResourceClient) Delete(manifest []byte) error {
+	if _, err := kubernetes.NewRawExtensions(manifest); err != nil {
+		return err
+	}
+	f.deleted = append(f.deleted, manifest)
+	return nil
+}
+
+func (f *fakeResourceClient) ClientSet() kubernetes.Interface {
+	return f.clientSet
+}
+
+var _ = Describe("EKS Connector resources in the cluster", func() {
+	newStatefulSet := func(readyReplicas int32) *appsv1.StatefulSet {
+		replicas := int32(2)
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eks-connector",
+				Namespace: "eks-connector",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: &replicas,
+			},
+			Status: appsv1.StatefulSetStatus{
+				UpdatedReplicas: replicas,
+				ReadyReplicas:   readyReplicas,
+			},
+		}
+	}
+
+	Context("ApplyResources", func() {
+		It("should apply the manifests and wait for the connector agent to become ready", func() {
+			client := &fakeResourceClient{
+				clientSet: fake.NewSimpleClientset(newStatefulSet(2)),
+			}
+			manifestList := newManifestList()
+			Expect(connector.ApplyResources(context.Background(), client, manifestList, time.Second)).To(Succeed())
+			Expect(client.applied).To(Equal([][]byte{
+				manifestList.ConnectorResources.Data,
+				manifestList.ClusterRoleResources.Data,
+				manifestList.ConsoleAccessResources.Data,
+			}))
+		})
+
+		It("should time out if the connector agent does not become ready", func() {
+			client := &fakeResourceClient{
+				clientSet: fake.NewSimpleClientset(newStatefulSet(1)),
+			}
+			err := connector.ApplyResources(context.Background(), client, newManifestList(), 100*time.Millisecond)
+			Expect(err).To(MatchError(ContainSubstring("error waiting for the EKS Connector agent to become ready")))
+		})
+	})
+
+	Context("DeleteResources", func() {
+		It("should delete the resources in the manifest templates", func() {
+			var manifestTemplate connector.ManifestTemplate
+			for _, m := range []struct {
+				file     *connector.ManifestFile
+				filename string
+			}{
+				{file: &manifestTemplate.Connector, filename: "eks-connector.yaml"},
+				{file: &manifestTemplate.ClusterRole, filename: "eks-connector-clusterrole.yaml"},
+				{file: &manifestTemplate.ConsoleAccess, filename: "eks-connector-console-dashboard-full-access-group.yaml"},
+			} {
+				data, err := os.ReadFile(path.Join("testdata", m.filename))
+				Expect(err).NotTo(HaveOccurred())
+				*m.file = connector.ManifestFile{
+					Data:     data,
+					Filename: m.filename,
+				}
+			}
+
+			client := &fakeResourceClient{}
+			Expect(connector.DeleteResources(client, manifestTemplate)).To(Succeed())
+			Expect(client.deleted).To(HaveLen(3))
+			for _, manifest := range client.deleted {
+				Expect(string(manifest)).NotTo(Or(ContainSubstring("%EKS_ACTIVATION_"), ContainSubstring("%IAM_ARN%")))
+			}
+		})
+	})
+})
@@ -53,6 +53,15 @@ type ManifestList struct {
 	ConsoleAccessResources ManifestFile
 	Expiry                 time.Time
 	IAMIdentityARN         string
+	// Activation holds the activation details the connector agent registers with.
+	Activation Activation
+}
+
+// Activation holds the activation details for EKS Connector.
+type Activation struct {
+	ID     string
+	Code   string
+	Region string
 }
 
 // RegisterCluster registers the specified external cluster with EKS and returns a list of Kubernetes resources
@@ -124,6 +133,11 @@ func (c *EKSConnector) createManifests(ctx context.Context, cluster *ekstypes.Cl
 		ConsoleAccessResources: consoleAccessResources,
 		Expiry:                 *cluster.ConnectorConfig.ActivationExpiry,
 		IAMIdentityARN:         roleARN,
+		Activation: Activation{
+			ID:     *cluster.ConnectorConfig.ActivationId,
+			Code:   *cluster.ConnectorConfig.ActivationCode,
+			Region: c.Provider.Region(),
+		},
 	}, nil
 }
 
@@ -119,6 +119,11 @@ var _ = Describe("EKS Connector", func() {
 		assertManifestEquals(resourceList.ConnectorResources, "eks-connector-expected.yaml")
 		assertManifestEquals(resourceList.ClusterRoleResources, "eks-connector-clusterrole-expected.yaml")
 		assertManifestEquals(resourceList.ConsoleAccessResources, "eks-connector-console-dashboard-full-access-group-expected.yaml")
+		Expect(resourceList.Activation).To(Equal(connector.Activation{
+			ID:     "activation-id-123",
+			Code:   "activation-code-123",
+			Region: mockProvider.Region(),
+		}))
 
 	},
 		Entry("valid name and provider", connectorCase{
@@ -11,9 +11,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/spf13/afero"
-
 	"github.com/kris-nova/logger"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -107,33 +107,136 @@ func filenameFromURL(u string) (string, error) {
 	return filename, nil
 }
 
-// WriteResources writes the EKS Connector resources to the current directory.
-func WriteResources(fs afero.Fs, manifestList *ManifestList) error {
-	wd, err := os.Getwd()
+// OutputFormat is the format the EKS Connector resources are written in.
+type OutputFormat string
+
+const (
+	// OutputFormatManifests writes the raw Kubernetes manifests.
+	OutputFormatManifests OutputFormat = "manifests"
+	// OutputFormatHelmValues writes a values file for the EKS Connector Helm chart, along with the RBAC manifests.
+	OutputFormatHelmValues OutputFormat = "helm-values"
+	// OutputFormatKustomize writes the Kubernetes manifests along with a kustomization listing them.
+	OutputFormatKustomize OutputFormat = "kustomize"
+)
+
+// OutputFormats are the valid output formats.
+var OutputFormats = []OutputFormat{OutputFormatManifests, OutputFormatHelmValues, OutputFormatKustomize}
+
+const (
+	helmChart               = "oci://public.ecr.aws/eks-connector/eks-connector-chart"
+	helmValuesFilename      = "eks-connector-values.yaml"
+	kustomizationFilename   = "kustomization.yaml"
+	connectorGrantAccessURL = "https://docs.aws.amazon.com/eks/latest/userguide/connector-grant-access.html"
+)
+
+// WriteOutput writes the EKS Connector resources to dir in the specified format.
+// The current directory is used if dir is empty.
+func WriteOutput(fs afero.Fs, dir string, format OutputFormat, manifestList *ManifestList) error {
+	switch format {
+	case OutputFormatManifests, "":
+		return WriteResources(fs, dir, manifestList)
+	case OutputFormatHelmValues:
+		return WriteHelmValues(fs, dir, manifestList)
+	case OutputFormatKustomize:
+		return WriteKustomization(fs, dir, manifestList)
+	default:
+		return fmt.Errorf("invalid output format %q; must be one of %v", format, OutputFormats)
+	}
+}
+
+// WriteResources writes the EKS Connector resources to dir, or to the current directory if dir is empty.
+func WriteResources(fs afero.Fs, dir string, manifestList *ManifestList) error {
+	filenames, err := writeFiles(fs, dir, manifestList.ConnectorResources, manifestList.ClusterRoleResources, manifestList.ConsoleAccessResources)
+	if err != nil {
+		return err
+	}
+	warnConsoleAccess(manifestList)
+	logger.Info("run `kubectl apply -f %s` before %s to connect the cluster", strings.Join(filenames, ","), manifestList.Expiry.Format(time.RFC822))
+	return nil
+}
+
+// WriteHelmValues writes a values file for the EKS Connector Helm chart, and the manifests granting console access
+// that the chart does not include, to dir, or to the current directory if dir is empty.
+func WriteHelmValues(fs afero.Fs, dir string, manifestList *ManifestList) error {
+	values, err := yaml.Marshal(map[string]interface{}{
+		"eks": map[string]string{
+			"activationId":   manifestList.Activation.ID,
+			"activationCode": manifestList.Activation.Code,
+			"agentRegion":    manifestList.Activation.Region,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error marshalling Helm values: %w", err)
+	}
+	filenames, err := writeFiles(fs, dir, ManifestFile{
+		Data:     values,
+		Filename: helmValuesFilename,
+	}, manifestList.ClusterRoleResources, manifestList.ConsoleAccessResources)
 	if err != nil {
-		return fmt.Errorf("error getting current directory: %w", err)
+		return err
 	}
+	warnConsoleAccess(manifestList)
+	logger.Info("run `helm install eks-connector --namespace eks-connector --create-namespace %s --values %s` and `kubectl apply -f %s` before %s to connect the cluster",
+		helmChart, filenames[0], strings.Join(filenames[1:], ","), manifestList.Expiry.Format(time.RFC822))
+	return nil
+}
 
-	writeFile := func(filename string, data []byte) error {
-		if err := afero.WriteFile(fs, path.Join(wd, filename), data, 0664); err != nil {
-			return err
+// WriteKustomization writes the EKS Connector resources and a kustomization listing them to dir,
+// or to the current directory if dir is empty.
+func WriteKustomization(fs afero.Fs, dir string, manifestList *ManifestList) error {
+	resources := []ManifestFile{manifestList.ConnectorResources, manifestList.ClusterRoleResources, manifestList.ConsoleAccessResources}
+	var resourceFilenames []string
+	for _, m := range resources {
+		resourceFilenames = append(resourceFilenames, m.Filename)
+	}
+	kustomization, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  resourceFilenames,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshalling kustomization: %w", err)
+	}
+	if _, err := writeFiles(fs, dir, append(resources, ManifestFile{
+		Data:     kustomization,
+		Filename: kustomizationFilename,
+	})...); err != nil {
+		return err
+	}
+	warnConsoleAccess(manifestList)
+	if dir == "" {
+		dir = "."
+	}
+	logger.Info("run `kubectl apply -k %s` before %s to connect the cluster", dir, manifestList.Expiry.Format(time.RFC822))
+	return nil
+}
+
+// writeFiles writes files to dir and returns their paths relative to dir as passed in.
+func writeFiles(fs afero.Fs, dir string, files ...ManifestFile) ([]string, error) {
+	outputDir := dir
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("error getting current directory: %w", err)
 		}
-		logger.Info("wrote file %s to %s", filename, wd)
-		return nil
+		outputDir = wd
+	} else if err := fs.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating directory %s: %w", dir, err)
 	}
 
 	var filenames []string
-	for _, m := range []ManifestFile{manifestList.ConnectorResources, manifestList.ClusterRoleResources, manifestList.ConsoleAccessResources} {
-		if err := writeFile(m.Filename, m.Data); err != nil {
-			return fmt.Errorf("error writing file %s: %w", m.Filename, err)
+	for _, m := range files {
+		if err := afero.WriteFile(fs, path.Join(outputDir, m.Filename), m.Data, 0664); err != nil {
+			return nil, fmt.Errorf("error writing file %s: %w", m.Filename, err)
 		}
-		filenames = append(filenames, m.Filename)
+		logger.Info("wrote file %s to %s", m.Filename, outputDir)
+		filenames = append(filenames, path.Join(dir, m.Filename))
 	}
+	return filenames, nil
+}
 
+func warnConsoleAccess(manifestList *ManifestList) {
 	logger.Warning(`note: %q and %q give full EKS Console access to IAM identity %q, edit if required; read %s for more info`,
 		manifestList.ClusterRoleResources.Filename, manifestList.ConsoleAccessResources.Filename, manifestList.IAMIdentityARN,
-		"https://docs.aws.amazon.com/eks/latest/userguide/connector-grant-access.html")
-
-	logger.Info("run `kubectl apply -f %s` before %s to connect the cluster", strings.Join(filenames, ","), manifestList.Expiry.Format(time.RFC822))
-	return nil
+		connectorGrantAccessURL)
 }
@@ -29,7 +29,7 @@ var _ = Describe("Writing manifests", func() {
 					Filename: "eks-connector-console-dashboard-full-access-group.yaml",
 				},
 			}
-			err := connector.WriteResources(fs, manifestList)
+			err := connector.WriteResources(fs, "", manifestList)
 			Expect(err).NotTo(HaveOccurred())
 
 			wd, err := os.Getwd()
@@ -55,4 +55,77 @@ var _ = Describe("Writing manifests", func() {
 			}
 		})
 	})
+
+	Context("WriteHelmValues", func() {
+		It("should write a values file and the RBAC manifests to the output directory", func() {
+			fs := afero.NewMemMapFs()
+			Expect(connector.WriteHelmValues(fs, "out", newManifestList())).To(Succeed())
+
+			files, err := afero.ReadDir(fs, "out")
+			Expect(err).NotTo(HaveOccurred())
+			var filenames []string
+			for _, f := range files {
+				filenames = append(filenames, f.Name())
+			}
+			Expect(filenames).To(ConsistOf("eks-connector-values.yaml", "eks-connector-clusterrole.yaml", "eks-connector-console-dashboard-full-access-group.yaml"))
+
+			values, err := afero.ReadFile(fs, path.Join("out", "eks-connector-values.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(values)).To(Equal(`eks:
+  activationCode: activation-code-123
+  activationId: activation-id-123
+  agentRegion: us-west-2
+`))
+		})
+	})
+
+	Context("WriteKustomization", func() {
+		It("should write the manifests and a kustomization listing them to the output directory", func() {
+			fs := afero.NewMemMapFs()
+			Expect(connector.WriteKustomization(fs, "out", newManifestList())).To(Succeed())
+
+			files, err := afero.ReadDir(fs, "out")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(4))
+
+			kustomization, err := afero.ReadFile(fs, path.Join("out", "kustomization.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(kustomization)).To(Equal(`apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- eks-connector.yaml
+- eks-connector-clusterrole.yaml
+- eks-connector-console-dashboard-full-access-group.yaml
+`))
+		})
+	})
+
+	Context("WriteOutput", func() {
+		It("should reject an invalid output format", func() {
+			err := connector.WriteOutput(afero.NewMemMapFs(), "out", "helm-chart", newManifestList())
+			Expect(err).To(MatchError(ContainSubstring(`invalid output format "helm-chart"`)))
+		})
+	})
 })
+
+func newManifestList() *connector.ManifestList {
+	return &connector.ManifestList{
+		ConnectorResources: connector.ManifestFile{
+			Data:     []byte("connector"),
+			Filename: "eks-connector.yaml",
+		},
+		ClusterRoleResources: connector.ManifestFile{
+			Data:     []byte("clusterrole"),
+			Filename: "eks-connector-clusterrole.yaml",
+		},
+		ConsoleAccessResources: connector.ManifestFile{
+			Data:     []byte("console-dashboard-full-access-group"),
+			Filename: "eks-connector-console-dashboard-full-access-group.yaml",
+		},
+		Activation: connector.Activation{
+			ID:     "activation-id-123",
+			Code:   "activation-code-123",
+			Region: "us-west-2",
+		},
+	}
+}
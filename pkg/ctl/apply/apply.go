@@ -0,0 +1,171 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
+
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/apply"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+)
+
+type applyOptions struct {
+	prune bool
+	drain apply.DrainOptions
+}
+
+// ApplyCmd creates the `apply` command
+func ApplyCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"apply",
+		"Reconcile an existing cluster with a config file",
+		"Creates and updates nodegroups, addons, access entries, pod identity associations, Fargate profiles, "+
+			"logging, endpoint access and zonal shift settings so that the cluster matches the given config file. "+
+			"Resources missing from the config file are only deleted when --prune is set.",
+	)
+
+	var options applyOptions
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doApply(cmd, options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddApproveFlag(fs, cmd)
+		fs.BoolVar(&options.prune, "prune", false, "Delete resources that exist in the cluster but are missing from the config file")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("Drain", func(fs *pflag.FlagSet) {
+		fs.BoolVar(&options.drain.Enabled, "drain", true, "Drain and cordon all nodes in pruned nodegroups before deletion")
+		fs.DurationVar(&options.drain.MaxGracePeriod, "max-grace-period", 10*time.Minute, "Maximum pods termination grace period")
+		fs.DurationVar(&options.drain.PodEvictionWaitPeriod, "pod-eviction-wait-period", 10*time.Second, "Duration to wait after failing to evict a pod")
+		fs.BoolVar(&options.drain.DisableEviction, "disable-eviction", false, "Force drain to use delete, even if eviction is supported. This will bypass checking PodDisruptionBudgets, use with caution.")
+		fs.IntVar(&options.drain.Parallel, "parallel", 1, "Number of nodes to drain in parallel. Max 25")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, true)
+}
+
+func doApply(cmd *cmdutils.Cmd, options applyOptions) error {
+	if err := cmdutils.NewApplyLoader(cmd).Load(); err != nil {
+		return err
+	}
+	// validation fills in nodegroup defaults such as scaling settings, the plan must only
+	// consider what has been set in the config file
+	desiredConfig := cmd.ClusterConfig.DeepCopy()
+	api.SetClusterConfigDefaults(desiredConfig)
+
+	cfg := cmd.ClusterConfig
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	stackManager := ctl.NewStackManager(cfg)
+
+	addonManager, err := newAddonManager(ctx, ctl, cfg, clientSet)
+	if err != nil {
+		return err
+	}
+	instanceSelector, err := selector.New(ctx, ctl.AWSProvider.AWSConfig())
+	if err != nil {
+		return err
+	}
+	nodeGroupManager := nodegroup.New(cfg, ctl, clientSet, instanceSelector)
+	liveState, err := collectLiveState(ctx, ctl, cfg, stackManager, nodeGroupManager, addonManager)
+	if err != nil {
+		return err
+	}
+
+	plan := apply.ComputePlan(desiredConfig, liveState)
+	if !plan.HasChanges() {
+		logger.Success("cluster %q is in sync with %q", cfg.Metadata.Name, cmd.ClusterConfigFile)
+		return nil
+	}
+
+	reconciler := &apply.Reconciler{
+		ClusterConfig: cfg,
+		Executor: &apply.ClusterExecutor{
+			ClusterConfig:    cfg,
+			ClusterProvider:  ctl,
+			StackManager:     stackManager,
+			ClientSet:        clientSet,
+			AddonManager:     addonManager,
+			InstanceSelector: instanceSelector,
+			WaitTimeout:      cmd.ProviderConfig.WaitTimeout,
+			Drain:            options.drain,
+		},
+		Prune: options.prune,
+	}
+	taskTree := reconciler.Tasks(ctx, plan)
+	taskTree.PlanMode = cmd.Plan
+	logger.Info(taskTree.Describe())
+	if errs := taskTree.DoAllSync(); len(errs) > 0 {
+		logger.Info("%d error(s) occurred while applying config to cluster %q", len(errs), cfg.Metadata.Name)
+		for _, err := range errs {
+			logger.Critical("%s\n", err.Error())
+		}
+		return fmt.Errorf("failed to apply config to cluster %q", cfg.Metadata.Name)
+	}
+
+	cmdutils.LogCompletedAction(cmd.Plan, "applied %q to cluster %q", cmd.ClusterConfigFile, cfg.Metadata.Name)
+	cmdutils.LogPlanModeWarning(cmd.Plan && taskTree.Len() > 0)
+	return nil
+}
+
+func newAddonManager(ctx context.Context, ctl *eks.ClusterProvider, cfg *api.ClusterConfig, clientSet kubernetes.Interface) (*addon.Manager, error) {
+	oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	oidcProviderExists, err := oidc.CheckProviderExists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return addon.New(cfg, ctl.AWSProvider.EKS(), ctl.NewStackManager(cfg), oidcProviderExists, oidc, func() (kubernetes.Interface, error) {
+		return clientSet, nil
+	})
+}
+
+func collectLiveState(ctx context.Context, ctl *eks.ClusterProvider, cfg *api.ClusterConfig, stackManager manager.StackManager,
+	nodeGroupLister apply.NodeGroupLister, addonLister apply.AddonLister) (*apply.LiveState, error) {
+	fargateClient := fargate.NewFromProvider(cfg.Metadata.Name, ctl.AWSProvider, stackManager)
+	stateCollector := &apply.StateCollector{
+		NodeGroupLister:              nodeGroupLister,
+		AddonLister:                  addonLister,
+		AccessEntryGetter:            accessentryactions.NewGetter(cfg.Metadata.Name, ctl.AWSProvider.EKS()),
+		PodIdentityAssociationLister: podidentityassociation.NewGetter(cfg.Metadata.Name, ctl.AWSProvider.EKS()),
+		FargateProfileLister:         &fargateClient,
+		CallerARN:                    ctl.Status.IAMRoleARN,
+	}
+	liveState, err := stateCollector.Collect(ctx, ctl.Status.ClusterInfo.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("fetching live state of cluster %q: %w", cfg.Metadata.Name, err)
+	}
+	return liveState, nil
+}
@@ -0,0 +1,206 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/apply"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+// DriftExitCode is the exit code used by `eksctl diff` when the cluster differs from its config file,
+// errors exit with 1 as with every other command.
+const DriftExitCode = 2
+
+// DriftError is returned by `eksctl diff` when drift exists.
+type DriftError struct {
+	ClusterName string
+}
+
+func (e *DriftError) Error() string {
+	return fmt.Sprintf("cluster %q differs from its config file", e.ClusterName)
+}
+
+// ExitCode returns the exit code the process should terminate with.
+func (e *DriftError) ExitCode() int {
+	return DriftExitCode
+}
+
+// Difference is a resource that was added, changed or removed in the config file compared to the cluster.
+type Difference struct {
+	Kind   apply.ResourceKind  `json:"kind"`
+	Name   string              `json:"name"`
+	Change string              `json:"change"`
+	Fields []apply.FieldChange `json:"fields,omitempty"`
+}
+
+type differenceRow struct {
+	Kind    apply.ResourceKind
+	Name    string
+	Change  string
+	Field   string
+	Current string
+	Desired string
+}
+
+// DiffCmd creates the `diff` command
+func DiffCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"diff",
+		"Show the differences between a config file and an existing cluster",
+		fmt.Sprintf("Lists the resources that `eksctl apply` would add, change or remove. "+
+			"Exits with %d when differences are found, making it suitable for gating CI pipelines.", DriftExitCode),
+	)
+
+	var output printers.Type
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doDiff(cmd, output)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringVarP(&output, "output", "o", printers.TableType, "specifies the output format (valid option: table, json, yaml)")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doDiff(cmd *cmdutils.Cmd, output printers.Type) error {
+	if cmd.ClusterConfigFile == "" {
+		return cmdutils.ErrMustBeSet("--config-file")
+	}
+	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+		return err
+	}
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+	if output != printers.TableType {
+		// log warnings and errors to stderr so that the output can be parsed
+		logger.Writer = os.Stderr
+	}
+
+	// see doApply, the plan must only consider what has been set in the config file
+	desiredConfig := cmd.ClusterConfig.DeepCopy()
+	api.SetClusterConfigDefaults(desiredConfig)
+
+	cfg := cmd.ClusterConfig
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	stackManager := ctl.NewStackManager(cfg)
+	addonManager, err := addon.New(cfg, ctl.AWSProvider.EKS(), stackManager, api.IsEnabled(cfg.IAM.WithOIDC), nil, nil)
+	if err != nil {
+		return err
+	}
+	liveState, err := collectLiveState(ctx, ctl, cfg, stackManager, nodegroup.New(cfg, ctl, nil, nil), addonManager)
+	if err != nil {
+		return err
+	}
+
+	differences := toDifferences(apply.ComputePlan(desiredConfig, liveState))
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addDifferenceTableColumns(tablePrinter)
+		if err := printer.PrintObjWithKind("differences", toDifferenceRows(differences), cmd.CobraCommand.OutOrStdout()); err != nil {
+			return err
+		}
+	} else if err := printer.PrintObjWithKind("differences", differences, cmd.CobraCommand.OutOrStdout()); err != nil {
+		return err
+	}
+
+	if len(differences) > 0 {
+		return &DriftError{ClusterName: cfg.Metadata.Name}
+	}
+	return nil
+}
+
+func toDifferences(plan *apply.Plan) []Difference {
+	// create an empty slice so that an empty list rather than null is printed
+	differences := make([]Difference, 0, len(plan.Changes))
+	for _, c := range plan.Changes {
+		differences = append(differences, Difference{
+			Kind:   c.Kind,
+			Name:   c.Name,
+			Change: describeAction(c.Action),
+			Fields: c.Fields,
+		})
+	}
+	return differences
+}
+
+// describeAction describes an action from the point of view of the config file.
+func describeAction(action apply.Action) string {
+	switch action {
+	case apply.ActionCreate:
+		return "added"
+	case apply.ActionDelete:
+		return "removed"
+	default:
+		return "changed"
+	}
+}
+
+func toDifferenceRows(differences []Difference) []differenceRow {
+	var rows []differenceRow
+	for _, d := range differences {
+		if len(d.Fields) == 0 {
+			rows = append(rows, differenceRow{Kind: d.Kind, Name: d.Name, Change: d.Change})
+			continue
+		}
+		for _, f := range d.Fields {
+			change := d.Change
+			if f.Immutable {
+				change += " (immutable)"
+			}
+			rows = append(rows, differenceRow{
+				Kind:    d.Kind,
+				Name:    d.Name,
+				Change:  change,
+				Field:   f.Field,
+				Current: f.Current,
+				Desired: f.Desired,
+			})
+		}
+	}
+	return rows
+}
+
+func addDifferenceTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("KIND", func(r differenceRow) string {
+		return string(r.Kind)
+	})
+	printer.AddColumn("NAME", func(r differenceRow) string {
+		return r.Name
+	})
+	printer.AddColumn("CHANGE", func(r differenceRow) string {
+		return r.Change
+	})
+	printer.AddColumn("FIELD", func(r differenceRow) string {
+		return r.Field
+	})
+	printer.AddColumn("CURRENT", func(r differenceRow) string {
+		return r.Current
+	})
+	printer.AddColumn("DESIRED", func(r differenceRow) string {
+		return r.Desired
+	})
+}
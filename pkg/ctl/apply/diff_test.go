@@ -0,0 +1,54 @@
+package apply
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/weaveworks/eksctl/pkg/actions/apply"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("diff", func() {
+	It("requires a config file", func() {
+		rootCmd := &cobra.Command{Use: "eksctl"}
+		cmdutils.AddResourceCmd(cmdutils.NewGrouping(), rootCmd, DiffCmd)
+		rootCmd.SetArgs([]string{"diff"})
+		rootCmd.SetOut(new(bytes.Buffer))
+		rootCmd.SetErr(new(bytes.Buffer))
+		Expect(rootCmd.Execute()).To(MatchError("--config-file must be set"))
+	})
+
+	It("flattens differences into table rows", func() {
+		differences := toDifferences(&apply.Plan{
+			Changes: []apply.Change{
+				{Kind: apply.KindAddon, Name: "coredns", Action: apply.ActionCreate},
+				{
+					Kind:   apply.KindManagedNodeGroup,
+					Name:   "mng",
+					Action: apply.ActionUpdate,
+					Fields: []apply.FieldChange{
+						{Field: "desiredCapacity", Current: "2", Desired: "3"},
+						{Field: "instanceType", Current: "m5.large", Desired: "m5.xlarge", Immutable: true},
+					},
+				},
+				{Kind: apply.KindFargateProfile, Name: "fp", Action: apply.ActionDelete},
+			},
+		})
+
+		Expect(toDifferenceRows(differences)).To(Equal([]differenceRow{
+			{Kind: apply.KindAddon, Name: "coredns", Change: "added"},
+			{Kind: apply.KindManagedNodeGroup, Name: "mng", Change: "changed", Field: "desiredCapacity", Current: "2", Desired: "3"},
+			{Kind: apply.KindManagedNodeGroup, Name: "mng", Change: "changed (immutable)", Field: "instanceType", Current: "m5.large", Desired: "m5.xlarge"},
+			{Kind: apply.KindFargateProfile, Name: "fp", Change: "removed"},
+		}))
+	})
+
+	It("reports drift with a dedicated exit code", func() {
+		err := error(&DriftError{ClusterName: "test"})
+		Expect(err).To(MatchError(`cluster "test" differs from its config file`))
+		Expect(err.(interface{ ExitCode() int }).ExitCode()).To(Equal(DriftExitCode))
+	})
+})
@@ -0,0 +1,11 @@
+package cmdutils
+
+// NewApplyLoader loads the config file for `eksctl apply`; the command reconciles the whole
+// ClusterConfig, so a config file is always required.
+func NewApplyLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file")
+	}
+	return l
+}
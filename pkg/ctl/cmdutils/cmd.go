@@ -10,6 +10,7 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/eks"
 	"github.com/weaveworks/eksctl/pkg/outposts"
+	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
 // Cmd holds attributes that are common between commands;
@@ -28,6 +29,9 @@ type Cmd struct {
 	ClusterConfig  *api.ClusterConfig
 
 	Include, Exclude []string
+
+	// PlanOutput is the format in which the tasks of a command are printed instead of being executed
+	PlanOutput printers.Type
 }
 
 // NewCtl performs common defaulting and validation and constructs a new
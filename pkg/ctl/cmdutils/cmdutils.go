@@ -126,6 +126,11 @@ func AddCommonFlagsForAWS(cmd *Cmd, p *api.ProviderConfig, addCfnOptions bool) {
 	})
 }
 
+// AddChangeSetPreviewFlag adds the --preview flag to commands that update CloudFormation stacks
+func AddChangeSetPreviewFlag(fs *pflag.FlagSet, p *api.ProviderConfig) {
+	fs.BoolVar(&p.CloudFormationChangeSetPreview, "preview", false, "show the changes in each CloudFormation ChangeSet, including resource replacements, and ask for confirmation before executing it")
+}
+
 // AddTimeoutFlagWithValue configures the timeout flag with the provided value.
 func AddTimeoutFlagWithValue(fs *pflag.FlagSet, p *time.Duration, value time.Duration) {
 	fs.DurationVar(p, "timeout", value, "maximum waiting time for any long-running operation")
@@ -189,6 +194,17 @@ func AddUpdateAuthConfigMap(fs *pflag.FlagSet, description string) *bool {
 	return fs.Bool("update-auth-configmap", true, description)
 }
 
+// AddPDBAwareDrainFlags adds common --pdb-aware and --pdb-block-timeout flags
+func AddPDBAwareDrainFlags(fs *pflag.FlagSet, pdbAware *bool, pdbBlockTimeout *time.Duration) {
+	fs.BoolVar(pdbAware, "pdb-aware", false, "Drain nodes in an order where nodes drained in parallel never evict pods covered by the same PodDisruptionBudget, and report the PodDisruptionBudgets blocking evictions")
+	fs.DurationVar(pdbBlockTimeout, "pdb-block-timeout", 5*time.Minute, "With --pdb-aware, fail when a PodDisruptionBudget blocks the eviction of a pod for longer than this duration (0 to wait until --timeout)")
+}
+
+// AddSkipCapacityCheckFlag adds common --skip-capacity-check flag
+func AddSkipCapacityCheckFlag(fs *pflag.FlagSet, skipCapacityCheck *bool) {
+	fs.BoolVar(skipCapacityCheck, "skip-capacity-check", false, "Drain even if the pods to evict would not fit on the remaining nodes")
+}
+
 // AddSubnetIDs adds common --subnet-ids flag
 func AddSubnetIDs(fs *pflag.FlagSet, subnetIDs *[]string, description string) {
 	fs.StringSliceVar(subnetIDs, "subnet-ids", nil, description)
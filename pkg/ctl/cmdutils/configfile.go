@@ -731,6 +731,43 @@ func NewDeleteAndDrainNodeGroupLoader(cmd *Cmd, ng *api.NodeGroup, ngFilter *fil
 	return l
 }
 
+// NewReplaceNodeGroupLoader will load config for 'eksctl replace nodegroup', the nodegroup being replaced must be
+// defined in the config file as its spec is copied to the replacement
+func NewReplaceNodeGroupLoader(cmd *Cmd, name *string) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.flagsIncompatibleWithConfigFile.Delete("name")
+
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file")
+	}
+
+	l.validateWithConfigFile = func() error {
+		if err := validateUnsetNodeGroups(l.ClusterConfig); err != nil {
+			return err
+		}
+		if *name != "" && l.NameArg != "" {
+			return ErrFlagAndArg("--name", *name, l.NameArg)
+		}
+		if l.NameArg != "" {
+			*name = l.NameArg
+		}
+		if *name == "" {
+			return ErrMustBeSet("--name")
+		}
+		if !slices.Contains(l.ClusterConfig.GetAllNodeGroupNames(), *name) {
+			return fmt.Errorf("nodegroup %q not found in config file", *name)
+		}
+		if flag := l.CobraCommand.Flag("parallel"); flag != nil && flag.Changed {
+			if val, _ := strconv.Atoi(flag.Value.String()); val > 25 || val < 1 {
+				return fmt.Errorf("--parallel value must be of range 1-25")
+			}
+		}
+		return nil
+	}
+
+	return l
+}
+
 // NewUtilsEnableLoggingLoader will load config or use flags for 'eksctl utils update-cluster-logging'
 func NewUtilsEnableLoggingLoader(cmd *Cmd) ClusterConfigLoader {
 	l := newCommonClusterConfigLoader(cmd)
@@ -745,6 +782,39 @@ func NewUtilsEnableLoggingLoader(cmd *Cmd) ClusterConfigLoader {
 	return l
 }
 
+// NewUtilsRenderUserDataLoader will load config for 'eksctl utils render-userdata'
+func NewUtilsRenderUserDataLoader(cmd *Cmd, ngFilter *filter.NodeGroupFilter) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.validateWithConfigFile = func() error {
+		if err := validateUnsetNodeGroups(l.ClusterConfig); err != nil {
+			return err
+		}
+		return ngFilter.AppendGlobs(l.Include, l.Exclude, l.ClusterConfig.GetAllNodeGroupNames())
+	}
+
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file")
+	}
+
+	return l
+}
+
+// NewUtilsPlanIPCapacityLoader will load config for 'eksctl utils plan-ip-capacity'
+func NewUtilsPlanIPCapacityLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.validateWithConfigFile = func() error {
+		return validateUnsetNodeGroups(l.ClusterConfig)
+	}
+
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file")
+	}
+
+	return l
+}
+
 // NewUtilsEnableEndpointAccessLoader will load config or use flags for 'eksctl utils update-cluster-endpoints'.
 func NewUtilsEnableEndpointAccessLoader(cmd *Cmd, privateAccess, publicAccess bool) ClusterConfigLoader {
 	l := newCommonClusterConfigLoader(cmd)
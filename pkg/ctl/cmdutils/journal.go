@@ -0,0 +1,51 @@
+package cmdutils
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+// EksctlJournalDirEnvName is the environment variable that overrides the directory in which task journals are stored
+const EksctlJournalDirEnvName = "EKSCTL_JOURNAL_DIR"
+
+// AddResumeFlag adds the --resume flag
+func AddResumeFlag(fs *pflag.FlagSet, resume *bool) {
+	fs.BoolVar(resume, "resume", false, "Resume a previous run that failed, skipping the tasks it completed")
+}
+
+// NewJournal returns the journal of completed tasks for the given operation on the cluster; when resume is set
+// the journal of the previous run is loaded, otherwise the journal starts empty
+func NewJournal(cmd *Cmd, operation string, resume bool) (*tasks.Journal, error) {
+	path, err := journalPath(cmd, operation)
+	if err != nil {
+		return nil, err
+	}
+	if !resume {
+		journal := tasks.NewJournal(path)
+		return journal, journal.Remove()
+	}
+	journal, err := tasks.LoadJournal(path)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("resuming from journal %q with %d completed task(s)", path, journal.Len())
+	return journal, nil
+}
+
+func journalPath(cmd *Cmd, operation string) (string, error) {
+	dir := os.Getenv(EksctlJournalDirEnvName)
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".eksctl", "journal")
+	}
+	meta := cmd.ClusterConfig.Metadata
+	return filepath.Join(dir, meta.Region, meta.Name, operation+".json"), nil
+}
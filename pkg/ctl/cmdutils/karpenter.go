@@ -0,0 +1,28 @@
+package cmdutils
+
+import (
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// NewKarpenterLoader creates a new loader for commands that manage Karpenter on an existing cluster.
+func NewKarpenterLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.validateWithConfigFile = func() error {
+		meta := cmd.ClusterConfig.Metadata
+		if meta.Name == "" {
+			return ErrMustBeSet("metadata.name")
+		}
+		if meta.Region == "" {
+			return ErrMustBeSet("metadata.region")
+		}
+		if cmd.ClusterConfig.Karpenter == nil {
+			return ErrMustBeSet("karpenter")
+		}
+		api.SetClusterConfigDefaults(cmd.ClusterConfig)
+		return api.ValidateKarpenterConfig(cmd.ClusterConfig)
+	}
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file")
+	}
+	return l
+}
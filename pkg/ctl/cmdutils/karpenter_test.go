@@ -0,0 +1,39 @@
+package cmdutils
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("Karpenter loader", func() {
+	newCmd := func(configFile string) *Cmd {
+		return &Cmd{
+			CobraCommand: &cobra.Command{
+				Use: "test",
+				Run: func(_ *cobra.Command, _ []string) {},
+			},
+			ClusterConfigFile: configFile,
+			ClusterConfig:     api.NewClusterConfig(),
+			ProviderConfig:    api.ProviderConfig{},
+		}
+	}
+
+	It("loads the Karpenter config from the config file", func() {
+		cmd := newCmd("test_data/karpenter.yaml")
+		Expect(NewKarpenterLoader(cmd).Load()).To(Succeed())
+		Expect(cmd.ClusterConfig.Karpenter.Version).To(Equal("1.1.0"))
+		Expect(cmd.ClusterConfig.Karpenter.NodePools).To(HaveLen(1))
+	})
+
+	DescribeTable("invalid configs", func(configFile, expectedErr string) {
+		err := NewKarpenterLoader(newCmd(configFile)).Load()
+		Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("no config file", "", "--config-file must be set"),
+		Entry("no karpenter section", "test_data/karpenter-unset.yaml", "karpenter must be set"),
+		Entry("unsupported Karpenter version", "test_data/karpenter-unsupported-version.yaml", "nodePools and ec2NodeClasses require Karpenter v0.32.0 or later"),
+	)
+})
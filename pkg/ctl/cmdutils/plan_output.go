@@ -0,0 +1,41 @@
+package cmdutils
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+// AddPlanOutputFlag adds the --plan-output flag
+func AddPlanOutputFlag(fs *pflag.FlagSet, cmd *Cmd) {
+	fs.StringVar(&cmd.PlanOutput, "plan-output", "", "print the tasks that would be executed as a machine-readable plan and exit without executing them (valid options: json, yaml)")
+}
+
+// ValidatePlanOutput validates the value of --plan-output and, when it is set, logs to stderr
+// so that stdout only contains the plan
+func ValidatePlanOutput(planOutput printers.Type) error {
+	switch planOutput {
+	case "":
+		return nil
+	case printers.JSONType, printers.YAMLType:
+		logger.Writer = os.Stderr
+		return nil
+	default:
+		return fmt.Errorf("invalid value %q for --plan-output, valid options: %s, %s", planOutput, printers.JSONType, printers.YAMLType)
+	}
+}
+
+// PrintTaskPlan prints the plan of taskTree in the given format without executing any task
+func PrintTaskPlan(taskTree *tasks.TaskTree, planOutput printers.Type, writer io.Writer) error {
+	printer, err := printers.NewPrinter(planOutput)
+	if err != nil {
+		return err
+	}
+	return printer.PrintObj(tasks.NewPlan(taskTree), writer)
+}
@@ -0,0 +1,41 @@
+package cmdutils
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+var _ = Describe("plan output", func() {
+	It("rejects unsupported formats", func() {
+		Expect(ValidatePlanOutput("")).To(Succeed())
+		Expect(ValidatePlanOutput("table")).To(MatchError(ContainSubstring(`invalid value "table" for --plan-output`)))
+	})
+
+	It("prints the plan without executing any task", func() {
+		executed := false
+		taskTree := &tasks.TaskTree{}
+		taskTree.Append(&tasks.GenericTask{
+			Description: "create cluster control plane",
+			Doer: func() error {
+				executed = true
+				return nil
+			},
+			Resource: tasks.ResourceInfo{Kind: tasks.KindCluster, StackName: "eksctl-test-cluster"},
+		})
+
+		var out bytes.Buffer
+		Expect(PrintTaskPlan(taskTree, "json", &out)).To(Succeed())
+		Expect(executed).To(BeFalse())
+
+		var plan tasks.Plan
+		Expect(json.Unmarshal(out.Bytes(), &plan)).To(Succeed())
+		Expect(plan.Tasks).To(HaveLen(1))
+		Expect(plan.Tasks[0].Kind).To(Equal(tasks.KindCluster))
+		Expect(plan.Summary.Stacks).To(ConsistOf("eksctl-test-cluster"))
+	})
+})
@@ -94,6 +94,9 @@ func createAddonCmd(cmd *cmdutils.Cmd) {
 		if err != nil {
 			return err
 		}
+		if err := addonManager.ValidateConfigurationValues(ctx, cmd.ClusterConfig.Addons); err != nil {
+			return err
+		}
 
 		iamRoleCreator := &podidentityassociation.IAMRoleCreator{
 			ClusterName:  cmd.ClusterConfig.Metadata.Name,
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
+	"slices"
 	"strings"
 	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 
 	"github.com/kris-nova/logger"
@@ -104,6 +106,8 @@ func createClusterCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.C
 		fs.BoolVarP(&params.InstallWindowsVPCController, "install-vpc-controllers", "", false, "Install VPC controller that's required for Windows workloads")
 		fs.BoolVarP(&params.Fargate, "fargate", "", false, "Create a Fargate profile scheduling pods in the default and kube-system namespaces onto Fargate")
 		fs.BoolVarP(&params.DryRun, "dry-run", "", false, "Dry-run mode that skips cluster creation and outputs a ClusterConfig")
+		cmdutils.AddPlanOutputFlag(fs, cmd)
+		cmdutils.AddResumeFlag(fs, &params.Resume)
 
 		_ = fs.MarkDeprecated("install-vpc-controllers", vpcControllerInfoMessage)
 	})
@@ -154,6 +158,13 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 	}
 	printer := printers.NewJSONPrinter()
 
+	if err := cmdutils.ValidatePlanOutput(cmd.PlanOutput); err != nil {
+		return err
+	}
+	if params.DryRun && cmd.PlanOutput != "" {
+		return fmt.Errorf("--dry-run and --plan-output %s", cmdutils.IncompatibleFlags)
+	}
+
 	if params.DryRun {
 		originalWriter := logger.Writer
 		logger.Writer = io.Discard
@@ -297,12 +308,23 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 		return err
 	}
 
+	if err := validateAddonConfigurationValues(ctx, cfg, ctl); err != nil {
+		return err
+	}
+
 	if params.DryRun {
 		return cmdutils.PrintDryRunConfig(cfg, cmd.CobraCommand.OutOrStdout())
 	}
 
-	if err := nodeGroupService.Normalize(ctx, nodePools, cfg); err != nil {
-		return err
+	if cfg.VPC.ID == "" && len(nodePools) > 0 && !cfg.IPv6Enabled() {
+		warnUndersizedSubnets(ctx, cfg, ctl)
+	}
+
+	// normalizing imports SSH keys, which must not happen when only printing a plan
+	if cmd.PlanOutput == "" {
+		if err := nodeGroupService.Normalize(ctx, nodePools, cfg); err != nil {
+			return err
+		}
 	}
 
 	logger.Info("using Kubernetes version %s", meta.Version)
@@ -373,10 +395,33 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 
 	taskTree := stackManager.NewTasksToCreateCluster(ctx, cfg.NodeGroups, cfg.ManagedNodeGroups, cfg.AccessConfig, makeAccessEntryCreator(cfg.Metadata.Name, stackManager), params.NodeGroupParallelism, postClusterCreationTasks)
 
+	if cmd.PlanOutput != "" {
+		plan := &tasks.TaskTree{Parallel: false}
+		plan.Append(taskTree, ctl.ClusterTasksForNodeGroups(cfg, params.InstallNeuronDevicePlugin, params.InstallNvidiaDevicePlugin))
+		if postNodeGroupAddons.Len() > 0 {
+			plan.Append(postNodeGroupAddons)
+		}
+		return cmdutils.PrintTaskPlan(plan, cmd.PlanOutput, cmd.CobraCommand.OutOrStdout())
+	}
+
+	journal, err := cmdutils.NewJournal(cmd, "create-cluster", params.Resume)
+	if err != nil {
+		return err
+	}
+	if params.Resume {
+		journal.IsCompleted = manager.NewStackCreatedChecker(ctx, stackManager)
+		if err := loadExistingClusterStack(ctx, ctl, cfg, stackManager); err != nil {
+			return err
+		}
+	}
+	journal.Track(taskTree, "cluster")
+	journal.Track(postNodeGroupAddons, "addons")
+
 	logger.Info(taskTree.Describe())
 	if errs := taskTree.DoAllSync(); len(errs) > 0 {
 		logger.Warning("%d error(s) occurred and cluster hasn't been created properly, you may wish to check CloudFormation console", len(errs))
 		logger.Info("to cleanup resources, run 'eksctl delete cluster --region=%s --name=%s'", meta.Region, meta.Name)
+		logger.Info("alternatively, once the cause of the failure has been addressed, rerun the command with --resume to skip the completed tasks")
 		for _, err := range errs {
 			ufe := &api.UnsupportedFeatureError{}
 			if errors.As(err, &ufe) {
@@ -468,12 +513,16 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 		if postNodeGroupAddons != nil && postNodeGroupAddons.Len() > 0 {
 			if errs := postNodeGroupAddons.DoAllSync(); len(errs) > 0 {
 				logger.Warning("%d error(s) occurred while creating addons", len(errs))
+				logger.Info("once the cause of the failure has been addressed, rerun the command with --resume to skip the completed tasks")
 				for _, err := range errs {
 					logger.Critical("%s\n", err.Error())
 				}
 				return errors.New("failed to create addons")
 			}
 		}
+		if err := journal.Remove(); err != nil {
+			logger.Warning(err.Error())
+		}
 
 		if len(cfg.IAM.PodIdentityAssociations) > 0 {
 			clientSet, err := makeClientSet()
@@ -567,6 +616,62 @@ func installKarpenter(ctx context.Context, ctl *eks.ClusterProvider, cfg *api.Cl
 	return nil
 }
 
+// loadExistingClusterStack loads the outputs of the cluster stack into the config when resuming a run that created it,
+// as the task that would otherwise load them is skipped.
+func loadExistingClusterStack(ctx context.Context, ctl *eks.ClusterProvider, cfg *api.ClusterConfig, stackManager manager.StackManager) error {
+	stack, err := stackManager.DescribeClusterStackIfExists(ctx)
+	if err != nil {
+		return err
+	}
+	if stack == nil || stack.StackStatus != cfntypes.StackStatusCreateComplete {
+		return nil
+	}
+	logger.Info("found existing cluster stack %q", *stack.StackName)
+	return ctl.LoadClusterIntoSpecFromStack(ctx, cfg, stack)
+}
+
+// validateAddonConfigurationValues validates the configurationValues of addons against the configuration schemas
+// published by EKS, so that invalid values are reported before the cluster is created.
+func validateAddonConfigurationValues(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider) error {
+	if !slices.ContainsFunc(cfg.Addons, func(a *api.Addon) bool {
+		return a.ConfigurationValues != ""
+	}) {
+		return nil
+	}
+	addonManager, err := addon.New(cfg, ctl.AWSProvider.EKS(), nil, false, nil, nil)
+	if err != nil {
+		return err
+	}
+	return addonManager.ValidateConfigurationValues(ctx, cfg.Addons)
+}
+
+// warnUndersizedSubnets warns when the subnets created by eksctl do not have enough IP addresses
+// for the nodegroups at their maximum size.
+func warnUndersizedSubnets(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider) {
+	plan, err := vpc.PlanIPCapacity(ctx, ctl.AWSProvider.EC2(), cfg)
+	if err != nil {
+		logger.Warning("unable to plan the IP capacity of subnets: %v", err)
+		return
+	}
+	for _, s := range plan.UndersizedSubnets() {
+		kind := strings.ToLower(string(api.SubnetTopologyPublic))
+		switch {
+		case s.PodSubnet:
+			kind = "pod"
+		case s.Private:
+			kind = strings.ToLower(string(api.SubnetTopologyPrivate))
+		}
+		logger.Warning("%s subnet %s (%s) has %d IP addresses but nodegroups using it need up to %d at maximum size", kind, s.Name, s.CIDR, s.AvailableIPs, s.RequiredIPs)
+	}
+	if layout := plan.SuggestedLayout; len(plan.UndersizedSubnets()) > 0 && layout != nil {
+		if layout.ExceedsVPCCIDR {
+			logger.Warning("nodegroups need more IP addresses than fit in a single VPC CIDR block; add subnets in a secondary CIDR block with 'eksctl utils extend-vpc' or enable prefix delegation, see 'eksctl utils plan-ip-capacity' for details")
+		} else {
+			logger.Warning("use a VPC CIDR with a /%d prefix or larger to fit all nodegroups, see 'eksctl utils plan-ip-capacity' for details", layout.VPCPrefixLength)
+		}
+	}
+}
+
 func createOrImportVPC(ctx context.Context, cmd *cmdutils.Cmd, cfg *api.ClusterConfig, params *cmdutils.CreateClusterCmdParams, ctl *eks.ClusterProvider) error {
 	customNetworkingNotice := "custom VPC/subnets will be used; if resulting cluster doesn't function as expected, make sure to review the configuration of VPC/subnets"
 
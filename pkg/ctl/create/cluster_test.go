@@ -1043,6 +1043,19 @@ func defaultProviderMocks(p *mockprovider.MockProvider, output []cftypes.Output,
 			},
 		},
 	}, nil)
+	p.MockEC2().On("DescribeInstanceTypes", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, input *ec2.DescribeInstanceTypesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+		output := &ec2.DescribeInstanceTypesOutput{}
+		for _, instanceType := range input.InstanceTypes {
+			output.InstanceTypes = append(output.InstanceTypes, ec2types.InstanceTypeInfo{
+				InstanceType: instanceType,
+				NetworkInfo: &ec2types.NetworkInfo{
+					MaximumNetworkInterfaces:  aws.Int32(4),
+					Ipv4AddressesPerInterface: aws.Int32(15),
+				},
+			})
+		}
+		return output, nil
+	}, nil)
 
 	waiter.ClusterCreationNextDelay = func(_ int) time.Duration {
 		return 0
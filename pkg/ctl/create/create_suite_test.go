@@ -3,9 +3,11 @@ package create_test
 import (
 	"testing"
 
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/testutils"
 )
 
 func TestCtlCreate(t *testing.T) {
+	t.Setenv(cmdutils.EksctlJournalDirEnvName, t.TempDir())
 	testutils.RegisterAndRun(t)
 }
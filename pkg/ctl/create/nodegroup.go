@@ -35,6 +35,13 @@ func createNodeGroupCmd(cmd *cmdutils.Cmd) {
 			return fmt.Errorf("couldn't create node group filter from command line options: %w", err)
 		}
 
+		if err := cmdutils.ValidatePlanOutput(cmd.PlanOutput); err != nil {
+			return err
+		}
+		if options.DryRun && cmd.PlanOutput != "" {
+			return fmt.Errorf("--dry-run and --plan-output %s", cmdutils.IncompatibleFlags)
+		}
+
 		if options.DryRun {
 			originalWriter := logger.Writer
 			logger.Writer = io.Discard
@@ -78,6 +85,10 @@ func createNodeGroupCmd(cmd *cmdutils.Cmd) {
 				DryRun:    options.DryRun,
 				OutStream: cmd.CobraCommand.OutOrStdout(),
 			},
+			PlanSettings: nodegroup.PlanSettings{
+				Output:    cmd.PlanOutput,
+				OutStream: cmd.CobraCommand.OutOrStdout(),
+			},
 			SkipOutdatedAddonsCheck: options.SkipOutdatedAddonsCheck,
 			ConfigFileProvided:      cmd.ClusterConfigFile != "",
 			Parallelism:             options.NodeGroupParallelism,
@@ -114,6 +125,7 @@ func createNodeGroupCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc runFn) {
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 		cmdutils.AddSubnetIDs(fs, &options.SubnetIDs, "Define an optional list of subnet IDs to create the nodegroup in")
 		fs.BoolVarP(&options.DryRun, "dry-run", "", false, "Dry-run mode that skips nodegroup creation and outputs a ClusterConfig")
+		cmdutils.AddPlanOutputFlag(fs, cmd)
 		fs.BoolVarP(&options.SkipOutdatedAddonsCheck, "skip-outdated-addons-check", "", false, "whether the creation of ARM nodegroups should proceed when the cluster addons are outdated")
 	})
 
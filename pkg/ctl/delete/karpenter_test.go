@@ -0,0 +1,64 @@
+package delete
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/ctl/ctltest"
+)
+
+// the config file itself is validated by cmdutils.NewKarpenterLoader, see pkg/ctl/cmdutils/karpenter_test.go
+var _ = Describe("delete karpenter", func() {
+	var configFile string
+
+	newMockDeleteKarpenterCmd := func(args ...string) *ctltest.MockCmd {
+		return ctltest.NewMockCmd(func(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd) error) {
+			deleteKarpenterWithRunFunc(cmd, runFunc)
+		}, "delete", args...)
+	}
+
+	BeforeEach(func() {
+		configFile = ctltest.CreateConfigFile(&api.ClusterConfig{
+			TypeMeta: api.ClusterConfigTypeMeta(),
+			Metadata: &api.ClusterMeta{
+				Name:   "cluster-1",
+				Region: "us-west-2",
+			},
+			IAM: &api.ClusterIAM{
+				WithOIDC: api.Enabled(),
+			},
+			Karpenter: &api.Karpenter{
+				Version: "1.1.0",
+			},
+		})
+	})
+
+	AfterEach(func() {
+		os.Remove(configFile)
+	})
+
+	It("accepts the config file and timeout flags", func() {
+		cmd := newMockDeleteKarpenterCmd("karpenter", "-f", configFile, "--timeout", "10m")
+		_, err := cmd.Execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Cmd.ClusterConfig.Karpenter.Version).To(Equal("1.1.0"))
+		Expect(cmd.Cmd.ProviderConfig.WaitTimeout).To(Equal(10 * time.Minute))
+	})
+
+	It("requires a config file", func() {
+		cmd := newMockDeleteKarpenterCmd("karpenter")
+		_, err := cmd.Execute()
+		Expect(err).To(MatchError("--config-file must be set"))
+	})
+
+	It("does not accept the cluster name flag", func() {
+		cmd := newMockDeleteKarpenterCmd("karpenter", "-f", configFile, "--cluster", "cluster-1")
+		_, err := cmd.Execute()
+		Expect(err).To(MatchError(ContainSubstring("unknown flag: --cluster")))
+	})
+})
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"k8s.io/client-go/kubernetes"
-
 	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -26,6 +24,9 @@ type deleteNodeGroupOptions struct {
 	podEvictionWaitPeriod time.Duration
 	disableEviction       bool
 	parallel              int
+	pdbAware              bool
+	pdbBlockTimeout       time.Duration
+	skipCapacityCheck     bool
 }
 
 func deleteNodeGroupCmd(cmd *cmdutils.Cmd) {
@@ -64,6 +65,8 @@ func deleteNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cm
 		fs.DurationVar(&options.podEvictionWaitPeriod, "pod-eviction-wait-period", defaultPodEvictionWaitPeriod, "Duration to wait after failing to evict a pod")
 		fs.BoolVar(&options.disableEviction, "disable-eviction", false, "Force drain to use delete, even if eviction is supported. This will bypass checking PodDisruptionBudgets, use with caution.")
 		fs.IntVar(&options.parallel, "parallel", 1, "Number of nodes to drain in parallel. Max 25")
+		cmdutils.AddPDBAwareDrainFlags(fs, &options.pdbAware, &options.pdbBlockTimeout)
+		cmdutils.AddSkipCapacityCheckFlag(fs, &options.skipCapacityCheck)
 
 		cmd.Wait = false
 		cmdutils.AddWaitFlag(fs, &cmd.Wait, "deletion of all resources")
@@ -73,14 +76,6 @@ func deleteNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cm
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, true)
 }
 
-type authConfigMapUpdater struct {
-	clientSet kubernetes.Interface
-}
-
-func (a *authConfigMapUpdater) RemoveNodeGroup(ng *api.NodeGroup) error {
-	return authconfigmap.RemoveNodeGroup(a.clientSet, ng)
-}
-
 func doDeleteNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, options deleteNodeGroupOptions) error {
 	ngFilter := filter.NewNodeGroupFilter()
 
@@ -148,6 +143,9 @@ func doDeleteNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, options deleteNodeG
 			PodEvictionWaitPeriod: options.podEvictionWaitPeriod,
 			DisableEviction:       options.disableEviction,
 			Parallel:              options.parallel,
+			PDBAware:              options.pdbAware,
+			PDBBlockTimeout:       options.pdbBlockTimeout,
+			CheckCapacity:         !options.skipCapacityCheck,
 		}
 		drainCtx, cancel := context.WithTimeout(ctx, cmd.ProviderConfig.WaitTimeout)
 		defer cancel()
@@ -164,12 +162,10 @@ func doDeleteNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, options deleteNodeG
 	cmdutils.LogIntendedAction(cmd.Plan, "delete %d nodegroups from cluster %q", len(allNodeGroups), cfg.Metadata.Name)
 
 	deleter := &nodegroup.Deleter{
-		StackHelper:      stackManager,
-		NodeGroupDeleter: ctl.AWSProvider.EKS(),
-		ClusterName:      cfg.Metadata.Name,
-		AuthConfigMapUpdater: &authConfigMapUpdater{
-			clientSet: clientSet,
-		},
+		StackHelper:          stackManager,
+		NodeGroupDeleter:     ctl.AWSProvider.EKS(),
+		ClusterName:          cfg.Metadata.Name,
+		AuthConfigMapUpdater: &authconfigmap.Updater{ClientSet: clientSet},
 	}
 	if err := deleter.Delete(ctx, cfg.NodeGroups, cfg.ManagedNodeGroups, nodegroup.DeleteOptions{
 		Wait:                cmd.Wait,
@@ -14,13 +14,22 @@ import (
 	"github.com/weaveworks/eksctl/pkg/eks"
 )
 
+type deregisterClusterOptions struct {
+	deleteResources   bool
+	kubeconfigPath    string
+	kubeconfigContext string
+}
+
 func deregisterClusterCmd(cmd *cmdutils.Cmd) {
 	cmd.SetDescription("cluster", "Deregister a non-EKS Kubernetes cluster", "")
 
-	var clusterName string
+	var (
+		clusterName string
+		options     deregisterClusterOptions
+	)
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
-		return deregisterCluster(cmd, clusterName)
+		return deregisterCluster(cmd, clusterName, options)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -30,17 +39,38 @@ func deregisterClusterCmd(cmd *cmdutils.Cmd) {
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 	})
 
+	cmd.FlagSetGroup.InFlagSet("EKS Connector resources", func(fs *pflag.FlagSet) {
+		fs.BoolVar(&options.deleteResources, "delete-resources", false, "Delete the EKS Connector resources from the cluster")
+		fs.StringVar(&options.kubeconfigPath, "kubeconfig", "", "Path to the kubeconfig file of the cluster (requires --delete-resources)")
+		fs.StringVar(&options.kubeconfigContext, "kubeconfig-context", "", "Kubeconfig context of the cluster, defaults to the current context (requires --delete-resources)")
+	})
+
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 
 }
 
-func deregisterCluster(cmd *cmdutils.Cmd, clusterName string) error {
+func deregisterCluster(cmd *cmdutils.Cmd, clusterName string, options deregisterClusterOptions) error {
+	if !options.deleteResources {
+		for _, f := range []string{"kubeconfig", "kubeconfig-context"} {
+			if cmd.CobraCommand.Flags().Changed(f) {
+				return fmt.Errorf("--%s can only be used with --delete-resources", f)
+			}
+		}
+	}
+
 	ctx := context.Background()
 	clusterProvider, err := eks.New(ctx, &cmd.ProviderConfig, nil)
 	if err != nil {
 		return err
 	}
 
+	if options.deleteResources {
+		// the resources are deleted before deregistering the cluster, so that the command can be rerun if it fails
+		if err := deleteConnectorResources(options); err != nil {
+			return err
+		}
+	}
+
 	c := connector.EKSConnector{
 		Provider: clusterProvider.AWSProvider,
 	}
@@ -50,6 +80,9 @@ func deregisterCluster(cmd *cmdutils.Cmd, clusterName string) error {
 	}
 
 	logger.Info("unregistered cluster %q successfully", clusterName)
+	if options.deleteResources {
+		return nil
+	}
 	manifestFilenames, err := connector.GetManifestFilenames()
 	if err != nil {
 		return err
@@ -57,3 +90,19 @@ func deregisterCluster(cmd *cmdutils.Cmd, clusterName string) error {
 	logger.Info("run `kubectl delete -f %s` on your cluster to remove EKS Connector resources", strings.Join(manifestFilenames, ","))
 	return nil
 }
+
+func deleteConnectorResources(options deregisterClusterOptions) error {
+	client, err := connector.NewResourceClient(options.kubeconfigPath, options.kubeconfigContext)
+	if err != nil {
+		return err
+	}
+	manifestTemplate, err := connector.GetManifestTemplate()
+	if err != nil {
+		return fmt.Errorf("error getting manifests for EKS Connector: %w", err)
+	}
+	if err := connector.DeleteResources(client, manifestTemplate); err != nil {
+		return fmt.Errorf("error deleting EKS Connector resources: %w", err)
+	}
+	logger.Info("deleted EKS Connector resources from the cluster")
+	return nil
+}
@@ -27,9 +27,13 @@ func getAccessEntryCmd(cmd *cmdutils.Cmd) {
 		"accessentries",
 	)
 
-	var principalARN api.ARN
+	var (
+		principalARN api.ARN
+		export       bool
+	)
 	cmd.FlagSetGroup.InFlagSet("AccessEntry", func(fs *pflag.FlagSet) {
 		fs.VarP(&principalARN, "principal-arn", "", "principal ARN to which the access entry is associated")
+		fs.BoolVar(&export, "export", false, "print the access entries as a ClusterConfig file that can be used with `eksctl create accessentry -f`")
 	})
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -42,16 +46,19 @@ func getAccessEntryCmd(cmd *cmdutils.Cmd) {
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return doGetAccessEntry(cmd, principalARN, params)
+		return doGetAccessEntry(cmd, principalARN, params, export)
 	}
 }
 
-func doGetAccessEntry(cmd *cmdutils.Cmd, principalARN api.ARN, params *getCmdParams) error {
+func doGetAccessEntry(cmd *cmdutils.Cmd, principalARN api.ARN, params *getCmdParams, export bool) error {
 	if err := cmdutils.NewGetAccessEntryLoader(cmd).Load(); err != nil {
 		return err
 	}
+	if err := validateExportFlag(cmd, export); err != nil {
+		return err
+	}
 
-	if params.output != printers.TableType {
+	if params.output != printers.TableType || export {
 		//log warnings and errors to stdout
 		logger.Writer = os.Stderr
 	}
@@ -76,6 +83,10 @@ func doGetAccessEntry(cmd *cmdutils.Cmd, principalARN api.ARN, params *getCmdPar
 		return fmt.Errorf("failed to retrieve access entries for cluster %s: %w", cmd.ClusterConfig.Metadata.Name, err)
 	}
 
+	if export {
+		return printAccessEntriesExport(cmd.CobraCommand.OutOrStdout(), cmd.ClusterConfig.Metadata, summaries)
+	}
+
 	printer, err := printers.NewPrinter(params.output)
 	if err != nil {
 		return err
@@ -36,5 +36,9 @@ var _ = Describe("get access entry", func() {
 			expectedErr: "Error: cannot use --cluster when --config-file/-f is set",
 			args:        []string{"--cluster", "test", "--config-file", "../../../examples/01-simple-cluster.yaml"},
 		}),
+		Entry("setting --export and --output at the same time", getAccessEntryTest{
+			expectedErr: "Error: cannot use --output with --export",
+			args:        []string{"--cluster", "test", "--export", "--output", "json"},
+		}),
 	)
 })
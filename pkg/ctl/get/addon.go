@@ -30,9 +30,13 @@ func getAddonCmd(cmd *cmdutils.Cmd) {
 		"addons",
 	)
 
-	var a api.Addon
+	var (
+		a          api.Addon
+		checkDrift bool
+	)
 	cmd.FlagSetGroup.InFlagSet("Addon", func(fs *pflag.FlagSet) {
 		fs.StringVar(&a.Name, "name", "", "Addon name")
+		fs.BoolVar(&checkDrift, "check-drift", false, "compare the addons in the config file with the addons on the cluster and report the fields that have drifted")
 	})
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -46,11 +50,14 @@ func getAddonCmd(cmd *cmdutils.Cmd) {
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return getAddon(cmd, &a, params)
+		return getAddon(cmd, &a, params, checkDrift)
 	}
 }
 
-func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams) error {
+func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams, checkDrift bool) error {
+	if checkDrift && cmd.ClusterConfigFile == "" {
+		return fmt.Errorf("--check-drift requires a config file (--config-file/-f)")
+	}
 	if err := cmdutils.NewGetAddonsLoader(cmd).Load(); err != nil {
 		return err
 	}
@@ -84,6 +91,10 @@ func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams) error {
 		return err
 	}
 
+	if checkDrift {
+		return printAddonDrift(ctx, cmd, addonManager, params.output)
+	}
+
 	var summaries []addon.Summary
 	if a.Name == "" {
 		summaries, err = addonManager.GetAll(ctx)
@@ -130,6 +141,58 @@ func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams) error {
 	return nil
 }
 
+func printAddonDrift(ctx context.Context, cmd *cmdutils.Cmd, addonManager *addon.Manager, output printers.Type) error {
+	if len(cmd.ClusterConfig.Addons) == 0 {
+		return fmt.Errorf("no addons specified in the config file")
+	}
+	drifts, err := addonManager.CheckDrift(ctx, cmd.ClusterConfig.Addons)
+	if err != nil {
+		return err
+	}
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addAddonDriftTableColumns(tablePrinter)
+	}
+	if err := printer.PrintObjWithKind("addons", drifts, cmd.CobraCommand.OutOrStdout()); err != nil {
+		return err
+	}
+
+	if slices.ContainsFunc(drifts, addon.Drift.HasDrifted) {
+		if output == printers.TableType {
+			logger.Info("to view the live and desired values of the drifted fields, rerun the command with --output=json or --output=yaml")
+		}
+		logger.Info("to reconcile the drifted addons, run `eksctl update addon --only-drifted -f <config-file>`")
+	}
+	return nil
+}
+
+func addAddonDriftTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("NAME", func(d addon.Drift) string {
+		return d.Name
+	})
+	printer.AddColumn("STATUS", func(d addon.Drift) string {
+		switch {
+		case !d.Installed:
+			return "NOT INSTALLED"
+		case len(d.Fields) > 0:
+			return "DRIFTED"
+		default:
+			return "IN SYNC"
+		}
+	})
+	printer.AddColumn("DRIFTED FIELDS", func(d addon.Drift) string {
+		var fields []string
+		for _, f := range d.Fields {
+			fields = append(fields, f.Field)
+		}
+		return strings.Join(fields, ",")
+	})
+}
+
 func addAddonSummaryTableColumns(printer *printers.TablePrinter) {
 	printer.AddColumn("NAME", func(s addon.Summary) string {
 		return s.Name
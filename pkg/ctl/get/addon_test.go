@@ -32,5 +32,9 @@ var _ = Describe("get addon", func() {
 			expectedErr: "Error: cannot use --cluster when --config-file/-f is set",
 			args:        []string{"--cluster", "test", "--config-file", "../../../examples/01-simple-cluster.yaml"},
 		}),
+		Entry("setting --check-drift without --config-file", getAddonEntry{
+			expectedErr: "Error: --check-drift requires a config file (--config-file/-f)",
+			args:        []string{"--cluster", "test", "--check-drift"},
+		}),
 	)
 })
@@ -0,0 +1,109 @@
+package get
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/iam"
+)
+
+const nodeRoleComment = "# node role"
+
+// exportedItem is an item of a ClusterConfig fragment written by printExport.
+type exportedItem struct {
+	value interface{}
+	// nodeRole marks the items that grant access to the nodes of the cluster.
+	nodeRole bool
+}
+
+// printExport writes a ClusterConfig that holds items in the list at path, so that it can be loaded
+// by the corresponding create command. Items for node roles are preceded by a comment.
+func printExport(w io.Writer, meta *api.ClusterMeta, path []string, items []exportedItem) error {
+	header, err := yaml.Marshal(struct {
+		metav1.TypeMeta `json:",inline"`
+		Metadata        *api.ClusterMeta `json:"metadata"`
+	}{
+		TypeMeta: api.ClusterConfigTypeMeta(),
+		Metadata: &api.ClusterMeta{
+			Name:   meta.Name,
+			Region: meta.Region,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	values := []interface{}{}
+	for _, item := range items {
+		values = append(values, item.value)
+	}
+	var body interface{} = values
+	for i := len(path) - 1; i >= 0; i-- {
+		body = map[string]interface{}{path[i]: body}
+	}
+	data, err := yaml.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	// list items are not indented under their key, so every item starts with the same prefix
+	indent := strings.Repeat("  ", len(path)-1)
+	itemPrefix := indent + "- "
+	var out strings.Builder
+	out.Write(header)
+	itemIndex := 0
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		if strings.HasPrefix(line, itemPrefix) && itemIndex < len(items) {
+			if items[itemIndex].nodeRole {
+				fmt.Fprintf(&out, "%s%s\n", indent, nodeRoleComment)
+			}
+			itemIndex++
+		}
+		out.WriteString(line)
+	}
+	_, err = io.WriteString(w, out.String())
+	return err
+}
+
+func printAccessEntriesExport(w io.Writer, meta *api.ClusterMeta, summaries []accessentryactions.Summary) error {
+	accessEntries, err := accessentryactions.Export(summaries)
+	if err != nil {
+		return err
+	}
+	var items []exportedItem
+	for _, ae := range accessEntries {
+		items = append(items, exportedItem{
+			value:    ae,
+			nodeRole: accessentryactions.IsNodeType(ae.Type),
+		})
+	}
+	return printExport(w, meta, []string{"accessConfig", "accessEntries"}, items)
+}
+
+func printIAMIdentityMappingsExport(w io.Writer, meta *api.ClusterMeta, identities []iam.Identity) error {
+	mappings := authconfigmap.ToIAMIdentityMappings(identities)
+	var items []exportedItem
+	for i, m := range mappings {
+		items = append(items, exportedItem{
+			value:    m,
+			nodeRole: authconfigmap.IsNodeRole(identities[i]),
+		})
+	}
+	return printExport(w, meta, []string{"iamIdentityMappings"}, items)
+}
+
+func validateExportFlag(cmd *cmdutils.Cmd, export bool) error {
+	if export && cmd.CobraCommand.Flag("output").Changed {
+		return errors.New("cannot use --output with --export")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package get
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/iam"
+)
+
+var _ = Describe("export", func() {
+	meta := &api.ClusterMeta{
+		Name:   "my-cluster",
+		Region: "us-west-2",
+	}
+
+	BeforeEach(func() {
+		// ParseConfig needs the ClusterConfig kind to be registered, which only happens when a config file is loaded
+		Expect(api.Register()).To(Succeed())
+	})
+
+	It("prints access entries as a config file, marking node roles", func() {
+		var out bytes.Buffer
+		Expect(printAccessEntriesExport(&out, meta, []accessentryactions.Summary{
+			{
+				PrincipalARN:     "arn:aws:iam::111122223333:user/dev",
+				KubernetesGroups: []string{"dev"},
+				AccessPolicies: []api.AccessPolicy{
+					{
+						PolicyARN: api.MustParseARN("arn:aws:eks::aws:cluster-access-policy/AmazonEKSViewPolicy"),
+						AccessScope: api.AccessScope{
+							Type:       "namespace",
+							Namespaces: []string{"dev"},
+						},
+					},
+				},
+			},
+			{
+				PrincipalARN: "arn:aws:iam::111122223333:role/node-role",
+				Type:         "EC2_LINUX",
+			},
+		})).To(Succeed())
+
+		Expect(out.String()).To(Equal(`apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfig
+metadata:
+  name: my-cluster
+  region: us-west-2
+accessConfig:
+  accessEntries:
+  - accessPolicies:
+    - accessScope:
+        namespaces:
+        - dev
+        type: namespace
+      policyARN: arn:aws:eks::aws:cluster-access-policy/AmazonEKSViewPolicy
+    kubernetesGroups:
+    - dev
+    principalARN: arn:aws:iam::111122223333:user/dev
+  # node role
+  - principalARN: arn:aws:iam::111122223333:role/node-role
+    type: EC2_LINUX
+`))
+
+		cfg, err := eks.ParseConfig(out.Bytes())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Metadata.Name).To(Equal("my-cluster"))
+		Expect(cfg.AccessConfig.AccessEntries).To(HaveLen(2))
+		Expect(cfg.AccessConfig.AccessEntries[1].Type).To(Equal("EC2_LINUX"))
+	})
+
+	It("prints IAM identity mappings as a config file, marking node roles", func() {
+		var out bytes.Buffer
+		Expect(printIAMIdentityMappingsExport(&out, meta, []iam.Identity{
+			iam.RoleIdentity{
+				RoleARN: "arn:aws:iam::111122223333:role/node-role",
+				KubernetesIdentity: iam.KubernetesIdentity{
+					KubernetesUsername: "system:node:{{EC2PrivateDNSName}}",
+					KubernetesGroups:   []string{"system:bootstrappers", "system:nodes"},
+				},
+			},
+			iam.UserIdentity{
+				UserARN: "arn:aws:iam::111122223333:user/dev",
+				KubernetesIdentity: iam.KubernetesIdentity{
+					KubernetesUsername: "dev",
+					KubernetesGroups:   []string{"dev"},
+				},
+			},
+			iam.AccountIdentity{
+				KubernetesAccount: "444455556666",
+			},
+		})).To(Succeed())
+
+		Expect(out.String()).To(Equal(`apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfig
+metadata:
+  name: my-cluster
+  region: us-west-2
+iamIdentityMappings:
+# node role
+- arn: arn:aws:iam::111122223333:role/node-role
+  groups:
+  - system:bootstrappers
+  - system:nodes
+  username: system:node:{{EC2PrivateDNSName}}
+- arn: arn:aws:iam::111122223333:user/dev
+  groups:
+  - dev
+  username: dev
+- account: "444455556666"
+`))
+
+		cfg, err := eks.ParseConfig(out.Bytes())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.IAMIdentityMappings).To(HaveLen(3))
+		Expect(cfg.IAMIdentityMappings[2].Account).To(Equal("444455556666"))
+	})
+
+	It("prints an empty list when there is nothing to export", func() {
+		var out bytes.Buffer
+		Expect(printIAMIdentityMappingsExport(&out, meta, nil)).To(Succeed())
+		Expect(out.String()).To(HaveSuffix("iamIdentityMappings: []\n"))
+		_, err := eks.ParseConfig(out.Bytes())
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -21,7 +21,10 @@ func getIAMIdentityMappingCmd(cmd *cmdutils.Cmd) {
 	cfg := api.NewClusterConfig()
 	cmd.ClusterConfig = cfg
 
-	var arn string
+	var (
+		arn    string
+		export bool
+	)
 
 	params := &getCmdParams{}
 
@@ -29,7 +32,7 @@ func getIAMIdentityMappingCmd(cmd *cmdutils.Cmd) {
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return doGetIAMIdentityMapping(cmd, params, arn)
+		return doGetIAMIdentityMapping(cmd, params, arn, export)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -39,19 +42,23 @@ func getIAMIdentityMappingCmd(cmd *cmdutils.Cmd) {
 		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+		fs.BoolVar(&export, "export", false, "print the IAM identity mappings as a ClusterConfig file that can be used with `eksctl create iamidentitymapping -f`")
 	})
 
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 }
 
-func doGetIAMIdentityMapping(cmd *cmdutils.Cmd, params *getCmdParams, arn string) error {
+func doGetIAMIdentityMapping(cmd *cmdutils.Cmd, params *getCmdParams, arn string, export bool) error {
 	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
 		return err
 	}
+	if err := validateExportFlag(cmd, export); err != nil {
+		return err
+	}
 
 	cfg := cmd.ClusterConfig
 
-	if params.output != printers.TableType {
+	if params.output != printers.TableType || export {
 		logger.Writer = os.Stderr
 	}
 
@@ -96,6 +103,10 @@ func doGetIAMIdentityMapping(cmd *cmdutils.Cmd, params *getCmdParams, arn string
 		}
 	}
 
+	if export {
+		return printIAMIdentityMappingsExport(cmd.CobraCommand.OutOrStdout(), cfg.Metadata, identities)
+	}
+
 	printer, err := printers.NewPrinter(params.output)
 	if err != nil {
 		return err
@@ -14,13 +14,24 @@ import (
 	"github.com/weaveworks/eksctl/pkg/eks"
 )
 
+type registerClusterOptions struct {
+	apply             bool
+	kubeconfigPath    string
+	kubeconfigContext string
+	outputFormat      string
+	outputDir         string
+}
+
 func registerClusterCmd(cmd *cmdutils.Cmd) {
 	cmd.SetDescription("cluster", "Register a non-EKS Kubernetes cluster", "")
 
-	var cluster connector.ExternalCluster
+	var (
+		cluster connector.ExternalCluster
+		options registerClusterOptions
+	)
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
-		return registerCluster(cmd, cluster)
+		return registerCluster(cmd, cluster, options)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -33,13 +44,59 @@ func registerClusterCmd(cmd *cmdutils.Cmd) {
 			_ = cobra.MarkFlagRequired(fs, f)
 		}
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("EKS Connector resources", func(fs *pflag.FlagSet) {
+		fs.BoolVar(&options.apply, "apply", false, "Apply the EKS Connector resources to the cluster and wait for the connector agent to become ready, instead of writing them to files")
+		fs.StringVar(&options.kubeconfigPath, "kubeconfig", "", "Path to the kubeconfig file of the cluster (requires --apply)")
+		fs.StringVar(&options.kubeconfigContext, "kubeconfig-context", "", "Kubeconfig context of the cluster, defaults to the current context (requires --apply)")
+		fs.StringVar(&options.outputFormat, "output-format", string(connector.OutputFormatManifests), fmt.Sprintf("Format to write the EKS Connector resources in (one of %v)", connector.OutputFormats))
+		fs.StringVar(&options.outputDir, "output-dir", "", "Directory to write the EKS Connector resources to, defaults to the current directory")
 	})
 
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 
 }
 
-func registerCluster(cmd *cmdutils.Cmd, cluster connector.ExternalCluster) error {
+func (o registerClusterOptions) validate(flags *pflag.FlagSet) error {
+	if o.apply {
+		for _, f := range []string{"output-format", "output-dir"} {
+			if flags.Changed(f) {
+				return fmt.Errorf("--apply and --%s %s", f, cmdutils.IncompatibleFlags)
+			}
+		}
+		return nil
+	}
+	for _, f := range []string{"kubeconfig", "kubeconfig-context"} {
+		if flags.Changed(f) {
+			return fmt.Errorf("--%s can only be used with --apply", f)
+		}
+	}
+	for _, f := range connector.OutputFormats {
+		if string(f) == o.outputFormat {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %q; must be one of %v", o.outputFormat, connector.OutputFormats)
+}
+
+func registerCluster(cmd *cmdutils.Cmd, cluster connector.ExternalCluster, options registerClusterOptions) error {
+	if err := options.validate(cmd.CobraCommand.Flags()); err != nil {
+		return err
+	}
+
+	var client connector.ResourceClient
+	if options.apply {
+		var err error
+		// create the client before registering the cluster, so that an invalid kubeconfig does not leave the
+		// cluster registered.
+		client, err = connector.NewResourceClient(options.kubeconfigPath, options.kubeconfigContext)
+		if err != nil {
+			return err
+		}
+	}
+
 	ctx := context.Background()
 	clusterProvider, err := eks.New(ctx, &cmd.ProviderConfig, nil)
 	if err != nil {
@@ -62,6 +119,12 @@ func registerCluster(cmd *cmdutils.Cmd, cluster connector.ExternalCluster) error
 
 	logger.Info("registered cluster %q successfully", cluster.Name)
 
-	// TODO consider providing a manifests-dir argument to allow writing EKS Connector resources to a specific directory.
-	return connector.WriteResources(afero.NewOsFs(), resourceList)
+	if options.apply {
+		if err := connector.ApplyResources(ctx, client, resourceList, cmd.ProviderConfig.WaitTimeout); err != nil {
+			return fmt.Errorf("error applying EKS Connector resources; run `eksctl deregister cluster --name %s --region %s` to clean up: %w", cluster.Name, clusterProvider.AWSProvider.Region(), err)
+		}
+		logger.Success("cluster %q is connected to EKS", cluster.Name)
+		return nil
+	}
+	return connector.WriteOutput(afero.NewOsFs(), options.outputDir, connector.OutputFormat(options.outputFormat), resourceList)
 }
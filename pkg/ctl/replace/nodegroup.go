@@ -0,0 +1,153 @@
+package replace
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
+)
+
+type replaceNodeGroupOptions struct {
+	name                  string
+	newName               string
+	ami                   string
+	instanceTypes         []string
+	releaseVersion        string
+	updateAuthConfigMap   *bool
+	maxGracePeriod        time.Duration
+	nodeDrainWaitPeriod   time.Duration
+	podEvictionWaitPeriod time.Duration
+	disableEviction       bool
+	parallel              int
+	pdbAware              bool
+	pdbBlockTimeout       time.Duration
+	skipCapacityCheck     bool
+}
+
+func replaceNodeGroupCmd(cmd *cmdutils.Cmd) {
+	replaceNodeGroupWithRunFunc(cmd, doReplaceNodeGroup)
+}
+
+func replaceNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, options *replaceNodeGroupOptions) error) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	var options replaceNodeGroupOptions
+
+	cmd.SetDescription("nodegroup", "Replace a nodegroup with a new one created from the config file",
+		"Creates a copy of the nodegroup under a new name, waits for its nodes to become ready, then drains and deletes the original nodegroup", "ng")
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return runFunc(cmd, &options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringVarP(&options.name, "name", "n", "", "Name of the nodegroup to replace")
+		fs.StringVar(&options.newName, "new-name", "", "Name of the replacement nodegroup (default: the name of the nodegroup with a -v2 suffix, or the next version if it already has one)")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("Replacement nodegroup", func(fs *pflag.FlagSet) {
+		fs.StringVar(&options.ami, "node-ami", "", "AMI to use for the replacement nodegroup")
+		fs.StringSliceVar(&options.instanceTypes, "instance-types", nil, "Instance types to use for the replacement nodegroup")
+		fs.StringVar(&options.releaseVersion, "release-version", "", "AMI release version to use for the replacement managed nodegroup")
+		options.updateAuthConfigMap = cmdutils.AddUpdateAuthConfigMap(fs, "Add the replacement nodegroup IAM role to aws-auth configmap and remove the replaced one")
+	})
+
+	cmd.FlagSetGroup.InFlagSet("Drain", func(fs *pflag.FlagSet) {
+		defaultMaxGracePeriod, _ := time.ParseDuration("10m")
+		fs.DurationVar(&options.maxGracePeriod, "max-grace-period", defaultMaxGracePeriod, "Maximum pods termination grace period")
+		defaultPodEvictionWaitPeriod, _ := time.ParseDuration("10s")
+		fs.DurationVar(&options.podEvictionWaitPeriod, "pod-eviction-wait-period", defaultPodEvictionWaitPeriod, "Duration to wait after failing to evict a pod")
+		fs.BoolVar(&options.disableEviction, "disable-eviction", false, "Force drain to use delete, even if eviction is supported. This will bypass checking PodDisruptionBudgets, use with caution.")
+		fs.DurationVar(&options.nodeDrainWaitPeriod, "node-drain-wait-period", 0, "Amount of time to wait between draining nodes in a nodegroup")
+		fs.IntVar(&options.parallel, "parallel", 1, "Number of nodes to drain in parallel. Max 25")
+		cmdutils.AddPDBAwareDrainFlags(fs, &options.pdbAware, &options.pdbBlockTimeout)
+		cmdutils.AddSkipCapacityCheckFlag(fs, &options.skipCapacityCheck)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, true)
+}
+
+func doReplaceNodeGroup(cmd *cmdutils.Cmd, options *replaceNodeGroupOptions) error {
+	if err := cmdutils.NewReplaceNodeGroupLoader(cmd, &options.name).Load(); err != nil {
+		return err
+	}
+
+	cfg := cmd.ClusterConfig
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	instanceSelector, err := selector.New(ctx, ctl.AWSProvider.AWSConfig())
+	if err != nil {
+		return err
+	}
+
+	stackManager := ctl.NewStackManager(cfg)
+	replacer := &nodegroup.Replacer{
+		StackHelper: stackManager,
+		Create: func(ctx context.Context, replacementCfg *api.ClusterConfig) error {
+			return nodegroup.New(replacementCfg, ctl, clientSet, instanceSelector).Create(ctx, nodegroup.CreateOpts{
+				UpdateAuthConfigMap: options.updateAuthConfigMap,
+				ConfigFileProvided:  true,
+			}, filter.NewNodeGroupFilter())
+		},
+		Drainer: &nodegroup.Drainer{
+			ClientSet: clientSet,
+		},
+		Deleter: &nodegroup.Deleter{
+			StackHelper:          stackManager,
+			NodeGroupDeleter:     ctl.AWSProvider.EKS(),
+			ClusterName:          cfg.Metadata.Name,
+			AuthConfigMapUpdater: &authconfigmap.Updater{ClientSet: clientSet},
+		},
+		GetNodeGroupIAM: func(ctx context.Context, ng *api.NodeGroup) error {
+			return ctl.GetNodeGroupIAM(ctx, stackManager, ng)
+		},
+	}
+
+	return replacer.Replace(ctx, cfg, nodegroup.ReplaceOptions{
+		Name:           options.name,
+		NewName:        options.newName,
+		AMI:            options.ami,
+		InstanceTypes:  options.instanceTypes,
+		ReleaseVersion: options.releaseVersion,
+		Drain: nodegroup.DrainInput{
+			MaxGracePeriod:        options.maxGracePeriod,
+			NodeDrainWaitPeriod:   options.nodeDrainWaitPeriod,
+			PodEvictionWaitPeriod: options.podEvictionWaitPeriod,
+			DisableEviction:       options.disableEviction,
+			Parallel:              options.parallel,
+			PDBAware:              options.pdbAware,
+			PDBBlockTimeout:       options.pdbBlockTimeout,
+			CheckCapacity:         !options.skipCapacityCheck,
+		},
+		DrainTimeout:        cmd.ProviderConfig.WaitTimeout,
+		UpdateAuthConfigMap: !api.IsDisabled(options.updateAuthConfigMap),
+	})
+}
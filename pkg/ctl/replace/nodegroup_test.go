@@ -0,0 +1,70 @@
+package replace
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+const replaceNodeGroupConfig = `apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfig
+metadata:
+  name: cluster
+  region: us-west-2
+managedNodeGroups:
+  - name: ng-1
+`
+
+var _ = Describe("replace nodegroup", func() {
+	var configFile string
+
+	BeforeEach(func() {
+		configFile = filepath.Join(GinkgoT().TempDir(), "cluster.yaml")
+		Expect(os.WriteFile(configFile, []byte(replaceNodeGroupConfig), 0o644)).To(Succeed())
+	})
+
+	It("parses the flags", func() {
+		cmd := newMockEmptyCmd("nodegroup", "-f", configFile, "--name", "ng-1", "--new-name", "ng-2",
+			"--instance-types", "m6i.large,m6a.large", "--release-version", "1.30.0-20240703", "--parallel", "5", "--max-grace-period", "5m")
+		count := 0
+		cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+			replaceNodeGroupWithRunFunc(cmd, func(cmd *cmdutils.Cmd, options *replaceNodeGroupOptions) error {
+				Expect(cmd.ClusterConfigFile).To(Equal(configFile))
+				Expect(options.name).To(Equal("ng-1"))
+				Expect(options.newName).To(Equal("ng-2"))
+				Expect(options.instanceTypes).To(Equal([]string{"m6i.large", "m6a.large"}))
+				Expect(options.releaseVersion).To(Equal("1.30.0-20240703"))
+				Expect(options.parallel).To(Equal(5))
+				Expect(options.maxGracePeriod).To(Equal(5 * time.Minute))
+				count++
+				return nil
+			})
+		})
+		_, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+	})
+
+	DescribeTable("invalid flags or arguments", func(expectedErr string, args ...string) {
+		for i, arg := range args {
+			if arg == "CONFIG" {
+				args[i] = configFile
+			}
+		}
+		cmd := newDefaultCmd(append([]string{"nodegroup"}, args...)...)
+		_, err := cmd.execute()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(expectedErr))
+	},
+		Entry("without a config file", "Error: --config-file must be set", "--cluster", "cluster", "--name", "ng-1"),
+		Entry("without a name", "Error: --name must be set", "-f", "CONFIG"),
+		Entry("with --name and an argument", "Error: --name=ng-1 and argument ng-1 cannot be used at the same time", "ng-1", "-f", "CONFIG", "--name", "ng-1"),
+		Entry("with a nodegroup that is not in the config file", `Error: nodegroup "ng-2" not found in config file`, "-f", "CONFIG", "--name", "ng-2"),
+		Entry("with --parallel above 25", "Error: --parallel value must be of range 1-25", "-f", "CONFIG", "--name", "ng-1", "--parallel", "26"),
+	)
+})
@@ -0,0 +1,11 @@
+package replace
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestCtlReplace(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
@@ -3,6 +3,7 @@ package update
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
 
@@ -25,7 +26,7 @@ func updateAddonCmd(cmd *cmdutils.Cmd) {
 		"",
 	)
 
-	var force, wait bool
+	var force, wait, onlyDrifted bool
 	cmd.ClusterConfig.Addons = []*api.Addon{{}}
 	cmd.FlagSetGroup.InFlagSet("Addon", func(fs *pflag.FlagSet) {
 		fs.StringVar(&cmd.ClusterConfig.Addons[0].Name, "name", "", "Addon name")
@@ -33,6 +34,7 @@ func updateAddonCmd(cmd *cmdutils.Cmd) {
 		fs.StringVar(&cmd.ClusterConfig.Addons[0].ServiceAccountRoleARN, "service-account-role-arn", "", "Addon serviceAccountRoleARN")
 		fs.BoolVar(&force, "force", false, "Force migrates an existing self-managed add-on to an EKS managed add-on")
 		fs.BoolVar(&wait, "wait", false, "Wait for the addon update to complete")
+		fs.BoolVar(&onlyDrifted, "only-drifted", false, "Only update the addons in the config file that have drifted from the addons on the cluster")
 	})
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -40,16 +42,20 @@ func updateAddonCmd(cmd *cmdutils.Cmd) {
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+		cmdutils.AddChangeSetPreviewFlag(fs, &cmd.ProviderConfig)
 	})
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return updateAddon(cmd, force, wait)
+		return updateAddon(cmd, force, wait, onlyDrifted)
 	}
 }
 
-func updateAddon(cmd *cmdutils.Cmd, force, wait bool) error {
+func updateAddon(cmd *cmdutils.Cmd, force, wait, onlyDrifted bool) error {
+	if onlyDrifted && cmd.ClusterConfigFile == "" {
+		return fmt.Errorf("--only-drifted requires a config file (--config-file/-f)")
+	}
 	if err := cmdutils.NewCreateOrUpgradeAddonLoader(cmd).Load(); err != nil {
 		return err
 	}
@@ -97,6 +103,21 @@ func updateAddon(cmd *cmdutils.Cmd, force, wait bool) error {
 		return err
 	}
 
+	if onlyDrifted {
+		drifted, err := driftedAddons(ctx, addonManager, cmd.ClusterConfig.Addons)
+		if err != nil {
+			return err
+		}
+		if len(drifted) == 0 {
+			logger.Info("no addons have drifted from the config file")
+			return nil
+		}
+		cmd.ClusterConfig.Addons = drifted
+	}
+	if err := addonManager.ValidateConfigurationValues(ctx, cmd.ClusterConfig.Addons); err != nil {
+		return err
+	}
+
 	piaUpdater := &addon.PodIdentityAssociationUpdater{
 		ClusterName: cmd.ClusterConfig.Metadata.Name,
 		IAMRoleCreator: &podidentityassociation.IAMRoleCreator{
@@ -122,6 +143,31 @@ func updateAddon(cmd *cmdutils.Cmd, force, wait bool) error {
 	return nil
 }
 
+// driftedAddons returns the addons that are installed and differ from the config file.
+func driftedAddons(ctx context.Context, addonManager *addon.Manager, addons []*api.Addon) ([]*api.Addon, error) {
+	drifts, err := addonManager.CheckDrift(ctx, addons)
+	if err != nil {
+		return nil, err
+	}
+	var drifted []*api.Addon
+	for i, d := range drifts {
+		switch {
+		case !d.Installed:
+			logger.Warning("addon %q is not installed, skipping; use `eksctl create addon` to create it", d.Name)
+		case d.HasDrifted():
+			var fields []string
+			for _, f := range d.Fields {
+				fields = append(fields, f.Field)
+			}
+			logger.Info("addon %q has drifted: %s", d.Name, strings.Join(fields, ", "))
+			drifted = append(drifted, addons[i])
+		default:
+			logger.Info("addon %q is in sync with the config file, skipping", d.Name)
+		}
+	}
+	return drifted, nil
+}
+
 func validatePodIdentityAgentAddon(ctx context.Context, eksAPI awsapi.EKS, cfg *api.ClusterConfig) error {
 	if isPodIdentityAgentInstalled, err := podidentityassociation.IsPodIdentityAgentInstalled(ctx, eksAPI, cfg.Metadata.Name); err != nil {
 		return fmt.Errorf("checking if %q addon is installed on the cluster: %w", api.PodIdentityAgentAddon, err)
@@ -30,4 +30,10 @@ var _ = Describe("update addon", func() {
 			Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("\"%s\" is not valid, supported format(s) are: JSON and YAML", cfg.Addons[0].ConfigurationValues))))
 		})
 	})
+
+	It("requires a config file with --only-drifted", func() {
+		cmd := newMockCmd("addon", "--cluster", "cluster-1", "--name", "coredns", "--only-drifted")
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring("--only-drifted requires a config file (--config-file/-f)")))
+	})
 })
@@ -2,6 +2,8 @@ package upgrade
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/weaveworks/eksctl/pkg/actions/cluster"
@@ -18,11 +20,36 @@ import (
 // increased to 50 for flex fleet changes
 const upgradeClusterTimeout = 65 * time.Minute
 
+type upgradeClusterOptions struct {
+	all   bool
+	to    string
+	drain drainOptions
+}
+
+// drainOptions control how the nodes of self-managed nodegroups are drained when they are replaced with --all.
+type drainOptions struct {
+	maxGracePeriod        time.Duration
+	nodeDrainWaitPeriod   time.Duration
+	podEvictionWaitPeriod time.Duration
+	disableEviction       bool
+	parallel              int
+	pdbAware              bool
+	pdbBlockTimeout       time.Duration
+	skipCapacityCheck     bool
+}
+
+var drainFlags = []string{"max-grace-period", "node-drain-wait-period", "pod-eviction-wait-period", "disable-eviction", "parallel", "pdb-aware", "pdb-block-timeout", "skip-capacity-check"}
+
 func upgradeCluster(cmd *cmdutils.Cmd) {
-	upgradeClusterWithRunFunc(cmd, DoUpgradeCluster)
+	upgradeClusterWithRunFunc(cmd, func(cmd *cmdutils.Cmd, options upgradeClusterOptions) error {
+		if options.all {
+			return doUpgradeClusterAll(cmd, options)
+		}
+		return DoUpgradeCluster(cmd)
+	})
 }
 
-func upgradeClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd) error) {
+func upgradeClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, options upgradeClusterOptions) error) {
 	cfg := api.NewClusterConfig()
 	// Reset version
 	cfg.Metadata.Version = ""
@@ -33,7 +60,10 @@ func upgradeClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd
 
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 
-	var force bool
+	var (
+		force   bool
+		options upgradeClusterOptions
+	)
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name")
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
@@ -46,6 +76,23 @@ func upgradeClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd
 		cmdutils.AddApproveFlag(fs, cmd)
 
 		cmdutils.AddTimeoutFlagWithValue(fs, &cmd.ProviderConfig.WaitTimeout, upgradeClusterTimeout)
+		cmdutils.AddChangeSetPreviewFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddPlanOutputFlag(fs, cmd)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("All components", func(fs *pflag.FlagSet) {
+		fs.BoolVar(&options.all, "all", false, "Upgrade the control plane, the default addons and all nodegroups one minor version at a time, up to the version set by --to")
+		fs.StringVar(&options.to, "to", "", `Kubernetes version to upgrade to with --all, "latest" can be used (default: the version set by --version or in the config file)`)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("Drain (self-managed nodegroups replaced with --all)", func(fs *pflag.FlagSet) {
+		fs.DurationVar(&options.drain.maxGracePeriod, "max-grace-period", 10*time.Minute, "Maximum pods termination grace period")
+		fs.DurationVar(&options.drain.podEvictionWaitPeriod, "pod-eviction-wait-period", 10*time.Second, "Duration to wait after failing to evict a pod")
+		fs.BoolVar(&options.drain.disableEviction, "disable-eviction", false, "Force drain to use delete, even if eviction is supported. This will bypass checking PodDisruptionBudgets, use with caution.")
+		fs.DurationVar(&options.drain.nodeDrainWaitPeriod, "node-drain-wait-period", 0, "Amount of time to wait between draining nodes in a nodegroup")
+		fs.IntVar(&options.drain.parallel, "parallel", 1, "Number of nodes to drain in parallel. Max 25")
+		cmdutils.AddPDBAwareDrainFlags(fs, &options.drain.pdbAware, &options.drain.pdbBlockTimeout)
+		cmdutils.AddSkipCapacityCheckFlag(fs, &options.drain.skipCapacityCheck)
 	})
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
@@ -54,12 +101,37 @@ func upgradeClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd
 		if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
 			return err
 		}
+		if err := cmdutils.ValidatePlanOutput(cmd.PlanOutput); err != nil {
+			return err
+		}
 		// Override force from provided config file if cli flag is provided
 		if force {
 			cmd.ClusterConfig.Metadata.ForceUpdateVersion = &force
 		}
 
-		return runFunc(cmd)
+		if !options.all {
+			if options.to != "" {
+				return errors.New("--to can only be used with --all")
+			}
+			for _, flag := range drainFlags {
+				if cmd.CobraCommand.Flags().Changed(flag) {
+					return fmt.Errorf("--%s can only be used with --all", flag)
+				}
+			}
+			return runFunc(cmd, options)
+		}
+		if cmd.PlanOutput != "" {
+			return errors.New("--plan-output cannot be used with --all")
+		}
+		if version := cmd.ClusterConfig.Metadata.Version; options.to == "" {
+			options.to = version
+		} else if version != "" && version != options.to {
+			return fmt.Errorf("--to %q does not match version %q", options.to, version)
+		}
+		if options.to == "" {
+			return cmdutils.ErrMustBeSet("--to")
+		}
+		return runFunc(cmd, options)
 	}
 }
 
@@ -86,5 +158,13 @@ func DoUpgradeCluster(cmd *cmdutils.Cmd) error {
 		return err
 	}
 
+	if cmd.PlanOutput != "" {
+		taskTree, err := c.UpgradeTasks(ctx)
+		if err != nil {
+			return err
+		}
+		return cmdutils.PrintTaskPlan(taskTree, cmd.PlanOutput, cmd.CobraCommand.OutOrStdout())
+	}
+
 	return c.Upgrade(ctx, cmd.Plan)
 }
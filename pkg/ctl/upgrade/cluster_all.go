@@ -0,0 +1,200 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	defaultaddons "github.com/weaveworks/eksctl/pkg/addons/default"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+const (
+	// healthCheckTimeout is how long the cluster has to become healthy after each version
+	healthCheckTimeout      = 15 * time.Minute
+	healthCheckPollInterval = 15 * time.Second
+)
+
+func doUpgradeClusterAll(cmd *cmdutils.Cmd, options upgradeClusterOptions) error {
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg := cmd.ClusterConfig
+	if ok, err := ctl.CanUpdate(cfg); !ok {
+		return err
+	}
+
+	eksAPI := ctl.AWSProvider.EKS()
+	cvm, err := eks.NewClusterVersionsManager(eksAPI)
+	if err != nil {
+		return err
+	}
+	targetVersion, err := cvm.ResolveClusterVersion(options.to)
+	if err != nil {
+		return err
+	}
+	currentVersion := ctl.ControlPlaneVersion()
+	steps, err := cluster.UpgradeSteps(cvm, currentVersion, targetVersion)
+	if err != nil {
+		return err
+	}
+
+	c, err := cluster.New(ctx, cfg, ctl)
+	if err != nil {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	instanceSelector, err := selector.New(ctx, ctl.AWSProvider.AWSConfig())
+	if err != nil {
+		return err
+	}
+	oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	oidcProviderExists, err := oidc.CheckProviderExists(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the addon manager looks up the addon versions for the Kubernetes version in the config
+	cfg.Metadata.Version = currentVersion
+	stackManager := ctl.NewStackManager(cfg)
+	addonManager, err := addon.New(cfg, eksAPI, stackManager, oidcProviderExists, oidc, nil)
+	if err != nil {
+		return err
+	}
+
+	upgrader := &cluster.AllUpgrader{
+		ClusterName: cfg.Metadata.Name,
+		UpgradeControlPlane: func(ctx context.Context, version string) error {
+			cfg.Metadata.Version = version
+			if err := c.Upgrade(ctx, false); err != nil {
+				return err
+			}
+			return ctl.RefreshClusterStatus(ctx, cfg)
+		},
+		Addons: &cluster.DefaultAddonsUpdater{
+			ClusterConfig: cfg,
+			EKSAPI:        eksAPI,
+			AddonManager:  addonManager,
+			PodIdentityIAMUpdater: &addon.PodIdentityAssociationUpdater{
+				ClusterName: cfg.Metadata.Name,
+				IAMRoleCreator: &podidentityassociation.IAMRoleCreator{
+					ClusterName:  cfg.Metadata.Name,
+					StackCreator: stackManager,
+				},
+				IAMRoleUpdater: &podidentityassociation.IAMRoleUpdater{
+					StackUpdater: stackManager,
+				},
+				EKSPodIdentityDescriber: eksAPI,
+				StackDeleter:            stackManager,
+			},
+			WaitTimeout: cmd.ProviderConfig.WaitTimeout,
+			UpdateSelfManaged: func(ctx context.Context, addonName string) error {
+				return updateSelfManagedAddon(ctx, cmd, ctl, addonName)
+			},
+		},
+		NodeGroups: &cluster.AllNodeGroupsUpgrader{
+			ClusterConfig:   cfg,
+			EKSAPI:          eksAPI,
+			StackHelper:     stackManager,
+			ClientSet:       clientSet,
+			ManagedUpgrader: nodegroup.New(cfg, ctl, clientSet, instanceSelector),
+			Replacer: &nodegroup.Replacer{
+				StackHelper: stackManager,
+				Create: func(ctx context.Context, replacementCfg *api.ClusterConfig) error {
+					return nodegroup.New(replacementCfg, ctl, clientSet, instanceSelector).Create(ctx, nodegroup.CreateOpts{
+						ConfigFileProvided: true,
+					}, filter.NewNodeGroupFilter())
+				},
+				Drainer: &nodegroup.Drainer{
+					ClientSet: clientSet,
+				},
+				Deleter: &nodegroup.Deleter{
+					StackHelper:          stackManager,
+					NodeGroupDeleter:     eksAPI,
+					ClusterName:          cfg.Metadata.Name,
+					AuthConfigMapUpdater: &authconfigmap.Updater{ClientSet: clientSet},
+				},
+				GetNodeGroupIAM: func(ctx context.Context, ng *api.NodeGroup) error {
+					return ctl.GetNodeGroupIAM(ctx, stackManager, ng)
+				},
+			},
+			ReplaceOptions: nodegroup.ReplaceOptions{
+				Drain: nodegroup.DrainInput{
+					MaxGracePeriod:        options.drain.maxGracePeriod,
+					NodeDrainWaitPeriod:   options.drain.nodeDrainWaitPeriod,
+					PodEvictionWaitPeriod: options.drain.podEvictionWaitPeriod,
+					DisableEviction:       options.drain.disableEviction,
+					Parallel:              options.drain.parallel,
+					PDBAware:              options.drain.pdbAware,
+					PDBBlockTimeout:       options.drain.pdbBlockTimeout,
+					CheckCapacity:         !options.drain.skipCapacityCheck,
+				},
+				DrainTimeout:        cmd.ProviderConfig.WaitTimeout,
+				UpdateAuthConfigMap: true,
+			},
+		},
+		Health: &cluster.ClusterHealthChecker{
+			ClusterName:  cfg.Metadata.Name,
+			ClientSet:    clientSet,
+			EKSAPI:       eksAPI,
+			Timeout:      healthCheckTimeout,
+			PollInterval: healthCheckPollInterval,
+		},
+	}
+
+	if err := upgrader.Upgrade(ctx, currentVersion, steps, cmd.Plan); err != nil {
+		return err
+	}
+	if !cmd.Plan {
+		logger.Success("cluster %q has been upgraded to version %q", cfg.Metadata.Name, targetVersion)
+	}
+	return nil
+}
+
+func updateSelfManagedAddon(ctx context.Context, cmd *cmdutils.Cmd, ctl *eks.ClusterProvider, addonName string) error {
+	rawClient, err := ctl.NewRawClient(cmd.ClusterConfig)
+	if err != nil {
+		return err
+	}
+	kubernetesVersion, err := rawClient.ServerVersion()
+	if err != nil {
+		return err
+	}
+	input := defaultaddons.AddonInput{
+		RawClient:             rawClient,
+		ControlPlaneVersion:   kubernetesVersion,
+		Region:                cmd.ClusterConfig.Metadata.Region,
+		AddonVersionDescriber: ctl.AWSProvider.EKS(),
+	}
+	switch addonName {
+	case api.VPCCNIAddon:
+		_, err = defaultaddons.UpdateAWSNode(ctx, input, false)
+	case api.CoreDNSAddon:
+		_, err = defaultaddons.UpdateCoreDNS(ctx, input, false)
+	case api.KubeProxyAddon:
+		_, err = defaultaddons.UpdateKubeProxy(ctx, input, false)
+	default:
+		return fmt.Errorf("addon %q cannot be updated as a self-managed addon", addonName)
+	}
+	return err
+}
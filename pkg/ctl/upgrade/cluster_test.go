@@ -8,15 +8,27 @@ import (
 	. "github.com/onsi/gomega"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/ctl/ctltest"
 )
 
 var _ = Describe("upgrade cluster", func() {
 
+	var options upgradeClusterOptions
+
 	newMockUpgradeClusterCmd := func(args ...string) *ctltest.MockCmd {
-		return ctltest.NewMockCmd(upgradeClusterWithRunFunc, "upgrade", args...)
+		return ctltest.NewMockCmd(func(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd) error) {
+			upgradeClusterWithRunFunc(cmd, func(cmd *cmdutils.Cmd, o upgradeClusterOptions) error {
+				options = o
+				return runFunc(cmd)
+			})
+		}, "upgrade", args...)
 	}
 
+	BeforeEach(func() {
+		options = upgradeClusterOptions{}
+	})
+
 	Describe("without a config file", func() {
 
 		It("should accept a name argument", func() {
@@ -76,7 +88,58 @@ var _ = Describe("upgrade cluster", func() {
 			Expect(cmd.Cmd.ProviderConfig.Region).To(Equal("us-west-2"))
 			Expect(cmd.Cmd.Plan).To(BeFalse())
 			Expect(cmd.Cmd.ProviderConfig.WaitTimeout).To(Equal(123 * time.Minute))
+			Expect(options.all).To(BeFalse())
 		})
+
+		It("accepts --all with --to", func() {
+			cmd := newMockUpgradeClusterCmd("cluster", "--name", "clus-1", "--all", "--to", "1.31")
+			_, err := cmd.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(options.all).To(BeTrue())
+			Expect(options.to).To(Equal("1.31"))
+			Expect(options.drain).To(Equal(drainOptions{
+				maxGracePeriod:        10 * time.Minute,
+				podEvictionWaitPeriod: 10 * time.Second,
+				parallel:              1,
+				pdbBlockTimeout:       5 * time.Minute,
+			}))
+		})
+
+		It("accepts drain flags with --all", func() {
+			cmd := newMockUpgradeClusterCmd("cluster", "--name", "clus-1", "--all", "--to", "1.31",
+				"--skip-capacity-check", "--parallel", "3", "--pdb-aware", "--max-grace-period", "5m", "--disable-eviction")
+			_, err := cmd.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(options.drain).To(Equal(drainOptions{
+				maxGracePeriod:        5 * time.Minute,
+				podEvictionWaitPeriod: 10 * time.Second,
+				disableEviction:       true,
+				parallel:              3,
+				pdbAware:              true,
+				pdbBlockTimeout:       5 * time.Minute,
+				skipCapacityCheck:     true,
+			}))
+		})
+
+		It("upgrades to --version with --all", func() {
+			cmd := newMockUpgradeClusterCmd("cluster", "--name", "clus-1", "--all", "--version", "1.31")
+			_, err := cmd.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(options.all).To(BeTrue())
+			Expect(options.to).To(Equal("1.31"))
+		})
+
+		DescribeTable("rejects invalid --all flags", func(expectedErr string, args ...string) {
+			cmd := newMockUpgradeClusterCmd(append([]string{"cluster", "--name", "clus-1"}, args...)...)
+			_, err := cmd.Execute()
+			Expect(err).To(MatchError(expectedErr))
+		},
+			Entry("--to without --all", "--to can only be used with --all", "--to", "1.31"),
+			Entry("drain flags without --all", "--skip-capacity-check can only be used with --all", "--skip-capacity-check"),
+			Entry("--all without a version", "--to must be set", "--all"),
+			Entry("--all with a different --version", `--to "1.31" does not match version "1.30"`, "--all", "--to", "1.31", "--version", "1.30"),
+			Entry("--all with --plan-output", "--plan-output cannot be used with --all", "--all", "--to", "1.31", "--plan-output", "json"),
+		)
 	})
 
 	Describe("with a config file", func() {
@@ -0,0 +1,58 @@
+package upgrade
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func upgradeKarpenterCmd(cmd *cmdutils.Cmd) {
+	upgradeKarpenterWithRunFunc(cmd, doUpgradeKarpenter)
+}
+
+func upgradeKarpenterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"karpenter",
+		"Upgrade Karpenter",
+		"Upgrade Karpenter to the version in the config file, update its IAM permissions and apply the NodePools and EC2NodeClasses in the config file",
+	)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewKarpenterLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return runFunc(cmd)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doUpgradeKarpenter(cmd *cmdutils.Cmd) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+
+	cfg := cmd.ClusterConfig
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	installer, err := karpenter.NewInstallerForExistingCluster(ctx, cfg, ctl)
+	if err != nil {
+		return err
+	}
+	return installer.Upgrade(ctx)
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func addonUpgradePlanCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"addon-upgrade-plan",
+		"Show the addon versions compatible with the current and target control plane versions",
+		"Lists the installed addons with their versions compatible with the current and target Kubernetes versions, and flags the addons that must be updated before the control plane is upgraded",
+	)
+
+	var (
+		targetVersion string
+		output        printers.Type
+	)
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		fs.StringVar(&targetVersion, "to", "", "Kubernetes version to plan the upgrade to (defaults to the next version after the control plane version)")
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return addonUpgradePlan(cmd, targetVersion, output)
+	}
+}
+
+func addonUpgradePlan(cmd *cmdutils.Cmd, targetVersion string, output printers.Type) error {
+	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+		return err
+	}
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+	if output != printers.TableType {
+		logger.Writer = os.Stderr
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := cmd.ClusterConfig
+	cfg.Metadata.Version = ctl.ControlPlaneVersion()
+
+	if targetVersion == "" {
+		cvm, err := eks.NewClusterVersionsManager(ctl.AWSProvider.EKS())
+		if err != nil {
+			return err
+		}
+		if targetVersion, err = cvm.ResolveUpgradeVersion("", cfg.Metadata.Version); err != nil {
+			return err
+		}
+		if targetVersion == "" {
+			return fmt.Errorf("cluster %q is on the latest Kubernetes version %q, use --to to plan for a specific version", cfg.Metadata.Name, cfg.Metadata.Version)
+		}
+	}
+
+	addonManager, err := addon.New(cfg, ctl.AWSProvider.EKS(), nil, false, nil, nil)
+	if err != nil {
+		return err
+	}
+	plans, err := addonManager.PlanUpgrade(ctx, targetVersion)
+	if err != nil {
+		return err
+	}
+
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addAddonUpgradePlanTableColumns(tablePrinter)
+	}
+	if err := printer.PrintObjWithKind("addons", plans, cmd.CobraCommand.OutOrStdout()); err != nil {
+		return err
+	}
+
+	for _, plan := range plans {
+		if plan.BlocksUpgrade {
+			logger.Warning("addon %q version %s is not compatible with Kubernetes %s: %s", plan.Name, plan.CurrentVersion, targetVersion, plan.Reason)
+		}
+	}
+	return nil
+}
+
+func addAddonUpgradePlanTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("NAME", func(p addon.UpgradePlan) string {
+		return p.Name
+	})
+	printer.AddColumn("VERSION", func(p addon.UpgradePlan) string {
+		return p.CurrentVersion
+	})
+	printer.AddColumn("CURRENT K8S LATEST", func(p addon.UpgradePlan) string {
+		return valueOrDash(p.Current.LatestVersion)
+	})
+	printer.AddColumn("CURRENT K8S DEFAULT", func(p addon.UpgradePlan) string {
+		return valueOrDash(p.Current.DefaultVersion)
+	})
+	printer.AddColumn("TARGET K8S LATEST", func(p addon.UpgradePlan) string {
+		return valueOrDash(p.Target.LatestVersion)
+	})
+	printer.AddColumn("TARGET K8S DEFAULT", func(p addon.UpgradePlan) string {
+		return valueOrDash(p.Target.DefaultVersion)
+	})
+	printer.AddColumn("MAJOR CHANGE", func(p addon.UpgradePlan) bool {
+		return p.MajorVersionChange
+	})
+	printer.AddColumn("BLOCKS UPGRADE", func(p addon.UpgradePlan) bool {
+		return p.BlocksUpgrade
+	})
+	printer.AddColumn("REASON", func(p addon.UpgradePlan) string {
+		return p.Reason
+	})
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
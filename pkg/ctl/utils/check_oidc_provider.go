@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func checkOIDCProviderCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	var (
+		fix    bool
+		output printers.Type
+	)
+
+	cmd.SetDescription("check-oidc-provider", "Compare the IAM OIDC provider of a cluster with the current certificate chain of its OIDC issuer",
+		"Reports thumbprints that no longer match the issuer's certificate chain, and missing client IDs and tags. Use --fix to update the thumbprints and client IDs in place.")
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doCheckOIDCProvider(cmd, fix, output)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.BoolVar(&fix, "fix", false, "Replace stale thumbprints with the thumbprint of the issuer's root CA and add missing client IDs")
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doCheckOIDCProvider(cmd *cmdutils.Cmd, fix bool, output printers.Type) error {
+	if err := cmdutils.NewUtilsAssociateIAMOIDCProviderLoader(cmd).Load(); err != nil {
+		return err
+	}
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+	if output != printers.TableType {
+		logger.Writer = os.Stderr
+	}
+
+	cfg := cmd.ClusterConfig
+	meta := cmd.ClusterConfig.Metadata
+
+	ctx := context.TODO()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	check, err := oidc.CheckProvider(ctx)
+	if err != nil {
+		return err
+	}
+
+	if output != printers.TableType {
+		if err := printer.PrintObj(check, cmd.CobraCommand.OutOrStdout()); err != nil {
+			return err
+		}
+	} else {
+		addOIDCProviderCheckTableColumns(printer.(*printers.TablePrinter))
+		if err := printer.PrintObjWithKind("providers", []*iamoidc.ProviderCheck{check}, cmd.CobraCommand.OutOrStdout()); err != nil {
+			return err
+		}
+	}
+
+	if check.Healthy() {
+		logger.Info("IAM Open ID Connect provider of cluster %q in %q matches its issuer", meta.Name, meta.Region)
+		return nil
+	}
+	if check.MissingThumbprint != "" {
+		logger.Warning("thumbprint %s of the issuer's root CA is not registered with the IAM Open ID Connect provider", check.MissingThumbprint)
+	}
+	for _, thumbprint := range check.StaleThumbprints {
+		logger.Warning("thumbprint %s does not match any certificate of the issuer", thumbprint)
+	}
+	for _, clientID := range check.MissingClientIDs {
+		logger.Warning("client ID %q is not registered with the IAM Open ID Connect provider", clientID)
+	}
+	if len(check.MissingTags) > 0 {
+		logger.Warning("IAM Open ID Connect provider is missing tags %s; tags are not updated by --fix", strings.Join(check.MissingTags, ", "))
+	}
+
+	if !check.NeedsFix() {
+		return nil
+	}
+	if !fix {
+		logger.Info("re-run the command with --fix to update the IAM Open ID Connect provider")
+		return nil
+	}
+	if err := oidc.FixProvider(ctx, check); err != nil {
+		return err
+	}
+	logger.Success("updated IAM Open ID Connect provider for cluster %q in %q", meta.Name, meta.Region)
+	return nil
+}
+
+func addOIDCProviderCheckTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("PROVIDER", func(c *iamoidc.ProviderCheck) string {
+		return c.ProviderARN
+	})
+	printer.AddColumn("THUMBPRINTS", func(c *iamoidc.ProviderCheck) string {
+		return strings.Join(c.Thumbprints, ",")
+	})
+	printer.AddColumn("ISSUER THUMBPRINT", func(c *iamoidc.ProviderCheck) string {
+		return c.IssuerThumbprint
+	})
+	printer.AddColumn("CLIENT IDS", func(c *iamoidc.ProviderCheck) string {
+		return strings.Join(c.ClientIDs, ",")
+	})
+	printer.AddColumn("STATUS", func(c *iamoidc.ProviderCheck) string {
+		switch {
+		case c.NeedsFix():
+			return "NEEDS FIX"
+		case !c.Healthy():
+			return "MISSING TAGS"
+		default:
+			return "OK"
+		}
+	})
+}
@@ -3,3 +3,5 @@ package utils
 func ValidateLoggingFlags(toEnable, toDisable []string) error {
 	return validateLoggingFlags(toEnable, toDisable)
 }
+
+var IsClusterInAccount = isClusterInAccount
@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+type extendVPCOptions struct {
+	cidr        string
+	subnetTypes []string
+	zones       []string
+}
+
+func extendVPCCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("extend-vpc", "Add a secondary CIDR block and subnets to the VPC of a cluster created by eksctl",
+		dedent.Dedent(`Associates a secondary IPv4 CIDR block with the VPC created by eksctl for a cluster, and splits it into
+			public, private or pod subnets in each availability zone.
+
+			New private and pod subnets use the route table of the private subnet in their availability zone. Private and public
+			subnets are used by nodegroups created after the VPC is extended, and pod subnets can be referenced in ENIConfig
+			resources for VPC CNI custom networking.
+		`),
+	)
+
+	var options extendVPCOptions
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doExtendVPC(cmd, options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("Secondary CIDR", func(fs *pflag.FlagSet) {
+		fs.StringVar(&options.cidr, "cidr", "", "IPv4 CIDR block to associate with the VPC, with a prefix between /16 and /28")
+		fs.StringSliceVar(&options.subnetTypes, "subnet-types", []string{string(vpc.SubnetTypePrivate)}, fmt.Sprintf("types of subnets to create in each availability zone (valid options: %v)", vpc.SubnetTypes()))
+		fs.StringSliceVar(&options.zones, "zones", nil, "availability zones to create subnets in (defaults to the zones of the cluster's private subnets)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doExtendVPC(cmd *cmdutils.Cmd, options extendVPCOptions) error {
+	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+		return err
+	}
+	if options.cidr == "" {
+		return cmdutils.ErrMustBeSet("--cidr")
+	}
+	cidr, err := ipnet.ParseCIDR(options.cidr)
+	if err != nil {
+		return fmt.Errorf("invalid value %q for --cidr: %w", options.cidr, err)
+	}
+	var subnetTypes []vpc.SubnetType
+	for _, t := range options.subnetTypes {
+		subnetTypes = append(subnetTypes, vpc.SubnetType(t))
+	}
+
+	cfg := cmd.ClusterConfig
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanUpdate(cfg); !ok {
+		return err
+	}
+
+	stackManager := ctl.NewStackManager(cfg)
+	hasDedicatedVPC, err := stackManager.ClusterHasDedicatedVPC(ctx)
+	if err != nil {
+		return fmt.Errorf("error checking if cluster has a dedicated VPC: %w", err)
+	}
+	if !hasDedicatedVPC {
+		return fmt.Errorf("cluster %q does not use a VPC created by eksctl; add CIDR blocks and subnets to the VPC directly and reference them in nodegroups", cfg.Metadata.Name)
+	}
+	stack, err := stackManager.DescribeClusterStack(ctx)
+	if err != nil {
+		return fmt.Errorf("error describing cluster stack: %w", err)
+	}
+	if err := ctl.LoadClusterVPC(ctx, cfg, stack, false); err != nil {
+		return fmt.Errorf("getting VPC configuration for cluster %q: %w", cfg.Metadata.Name, err)
+	}
+
+	cmdutils.LogIntendedAction(cmd.Plan, "associate CIDR block %s with VPC %s of cluster %q", cidr, cfg.VPC.ID, cfg.Metadata.Name)
+	extender := &vpc.ClusterExtender{
+		StackUpdater: stackManager,
+		EC2API:       ctl.AWSProvider.EC2(),
+	}
+	subnets, err := extender.ExtendWithSecondaryCIDR(ctx, cfg, vpc.ExtendOptions{
+		CIDR:              cidr,
+		SubnetTypes:       subnetTypes,
+		AvailabilityZones: options.zones,
+		Plan:              cmd.Plan,
+	})
+	if err != nil {
+		return err
+	}
+
+	cmdutils.LogCompletedAction(cmd.Plan, "extended VPC %s with CIDR block %s", cfg.VPC.ID, cidr)
+	cmdutils.LogPlanModeWarning(cmd.Plan)
+	if cmd.Plan {
+		return nil
+	}
+	var hasPodSubnets bool
+	for _, s := range subnets {
+		logger.Info("created %s subnet %s (%s) in %s", s.Type, s.ID, s.CIDR, s.AZ)
+		hasPodSubnets = hasPodSubnets || s.Type == vpc.SubnetTypePod
+	}
+	if hasPodSubnets {
+		logger.Info("to use pod subnets, enable custom networking in the %s addon and create an ENIConfig for each availability zone, see https://eksctl.io/usage/vpc-cni-custom-networking/", api.VPCCNIAddon)
+	}
+	return nil
+}
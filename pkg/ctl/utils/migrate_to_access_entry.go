@@ -2,13 +2,20 @@ package utils
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
 
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
 func migrateAccessEntryCmd(cmd *cmdutils.Cmd) {
@@ -17,9 +24,15 @@ func migrateAccessEntryCmd(cmd *cmdutils.Cmd) {
 
 	cmd.SetDescription("migrate-to-access-entry", "Migrates aws-auth to API authentication mode for the cluster", "")
 
-	var options accessentryactions.MigrationOptions
+	var (
+		options accessentryactions.MigrationOptions
+		report  bool
+		output  printers.Type
+	)
 	cmd.FlagSetGroup.InFlagSet("Migrate to Access Entry", func(fs *pflag.FlagSet) {
 		fs.StringVar(&options.TargetAuthMode, "target-authentication-mode", "API_AND_CONFIG_MAP", "Target Authentication mode of migration")
+		fs.BoolVar(&report, "report", false, "List the access entry each aws-auth identity would become, and the identities that cannot be migrated, without making any changes")
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format of the report (valid option: table, json, yaml)")
 	})
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -32,29 +45,89 @@ func migrateAccessEntryCmd(cmd *cmdutils.Cmd) {
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
 		options.Approve = !cmd.Plan
+		if report {
+			return doMigrateToAccessEntryReport(cmd, options, output)
+		}
+		if cmd.CobraCommand.Flag("output").Changed {
+			return errors.New("--output can only be used with --report")
+		}
 		return doMigrateToAccessEntry(cmd, options)
 	}
 }
 
 func doMigrateToAccessEntry(cmd *cmdutils.Cmd, options accessentryactions.MigrationOptions) error {
+	ctx := context.Background()
+	migrator, err := newAccessEntryMigrator(ctx, cmd, options)
+	if err != nil {
+		return err
+	}
+	if err := migrator.MigrateToAccessEntry(ctx, options); err != nil {
+		return err
+	}
+
+	cmdutils.LogPlanModeWarning(cmd.Plan)
+	return nil
+}
+
+func doMigrateToAccessEntryReport(cmd *cmdutils.Cmd, options accessentryactions.MigrationOptions, output printers.Type) error {
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+	if output != printers.TableType {
+		logger.Writer = os.Stderr
+	}
+
+	ctx := context.Background()
+	migrator, err := newAccessEntryMigrator(ctx, cmd, options)
+	if err != nil {
+		return err
+	}
+	report, err := migrator.Report(ctx)
+	if err != nil {
+		return err
+	}
+
+	if output != printers.TableType {
+		return printer.PrintObj(report, cmd.CobraCommand.OutOrStdout())
+	}
+	if report.CurrentAuthenticationMode == ekstypes.AuthenticationModeApi {
+		logger.Info("cluster authentication mode is already %s; there is no need to migrate to access entries", ekstypes.AuthenticationModeApi)
+		return nil
+	}
+	addMigrationReportTableColumns(printer.(*printers.TablePrinter))
+	if err := printer.PrintObjWithKind("identities", report.Identities, cmd.CobraCommand.OutOrStdout()); err != nil {
+		return err
+	}
+	switch {
+	case report.SwitchesToAPI:
+		logger.Info("the authentication mode would be updated to %s and the aws-auth ConfigMap deleted; only access entries would grant access to the cluster", ekstypes.AuthenticationModeApi)
+	case report.TargetAuthenticationMode == ekstypes.AuthenticationModeApi:
+		logger.Warning("one or more identities cannot be migrated, the authentication mode would not be updated to %s", ekstypes.AuthenticationModeApi)
+	default:
+		logger.Info("the authentication mode would be %s; aws-auth would continue to grant access alongside access entries", ekstypes.AuthenticationModeApiAndConfigMap)
+	}
+	return nil
+}
+
+func newAccessEntryMigrator(ctx context.Context, cmd *cmdutils.Cmd, options accessentryactions.MigrationOptions) (*accessentryactions.Migrator, error) {
 	cfg := cmd.ClusterConfig
 	if cfg.Metadata.Name == "" {
-		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+		return nil, cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
 	}
 
-	ctx := context.Background()
 	ctl, err := cmd.NewProviderForExistingCluster(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if ok, err := ctl.CanOperate(cfg); !ok {
-		return err
+		return nil, err
 	}
 
 	clientSet, err := ctl.NewStdClientSet(cfg)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	stackManager := ctl.NewStackManager(cfg)
@@ -64,7 +137,7 @@ func doMigrateToAccessEntry(cmd *cmdutils.Cmd, options accessentryactions.Migrat
 	}
 	aeGetter := accessentryactions.NewGetter(cfg.Metadata.Name, ctl.AWSProvider.EKS())
 
-	if err := accessentryactions.NewMigrator(
+	return accessentryactions.NewMigrator(
 		cfg.Metadata.Name,
 		ctl.AWSProvider.EKS(),
 		ctl.AWSProvider.IAM(),
@@ -73,10 +146,36 @@ func doMigrateToAccessEntry(cmd *cmdutils.Cmd, options accessentryactions.Migrat
 		aeGetter,
 		ctl.GetClusterState().AccessConfig.AuthenticationMode,
 		ekstypes.AuthenticationMode(options.TargetAuthMode),
-	).MigrateToAccessEntry(ctx, options); err != nil {
-		return err
-	}
+	), nil
+}
 
-	cmdutils.LogPlanModeWarning(cmd.Plan)
-	return nil
+func addMigrationReportTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("IDENTITY", func(im accessentryactions.IdentityMigration) string {
+		if im.ARN == "" {
+			return "account/" + im.Account
+		}
+		return im.ARN
+	})
+	printer.AddColumn("GROUPS", func(im accessentryactions.IdentityMigration) string {
+		return strings.Join(im.Groups, ",")
+	})
+	printer.AddColumn("STATUS", func(im accessentryactions.IdentityMigration) accessentryactions.MigrationStatus {
+		return im.Status
+	})
+	printer.AddColumn("ACCESS ENTRY", func(im accessentryactions.IdentityMigration) string {
+		if im.AccessEntry == nil {
+			return "-"
+		}
+		var policies []string
+		for _, p := range im.AccessEntry.AccessPolicies {
+			policies = append(policies, path.Base(p.PolicyARN.Resource))
+		}
+		if len(policies) > 0 {
+			return fmt.Sprintf("%s (%s)", im.AccessEntry.Type, strings.Join(policies, ","))
+		}
+		return im.AccessEntry.Type
+	})
+	printer.AddColumn("REASON", func(im accessentryactions.IdentityMigration) string {
+		return im.Reason
+	})
 }
@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/workload"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/utils/retry"
+)
+
+func moveWorkloadCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	var (
+		namespace string
+		target    string
+		output    printers.Type
+	)
+
+	cmd.SetDescription("move-workload", "Move the Deployments and StatefulSets of a namespace between nodegroups and Fargate",
+		"Sets the compute type annotation on the pod templates, adds the labels a Fargate profile requires when moving to Fargate, and waits for the pods to be rescheduled.")
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doMoveWorkload(cmd, namespace, workload.Target(target), output)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVar(&namespace, "namespace", "", "Namespace of the workloads to move")
+		fs.StringVar(&target, "to", "", fmt.Sprintf("Where to move the workloads (valid options: %v)", workload.Targets))
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doMoveWorkload(cmd *cmdutils.Cmd, namespace string, target workload.Target, output printers.Type) error {
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name != "" && cmd.NameArg != "" {
+		return cmdutils.ErrClusterFlagAndArg(cmd, cfg.Metadata.Name, cmd.NameArg)
+	}
+	if cmd.NameArg != "" {
+		cfg.Metadata.Name = cmd.NameArg
+	}
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+	}
+	if namespace == "" {
+		return cmdutils.ErrMustBeSet("--namespace")
+	}
+	switch target {
+	case workload.TargetNodeGroup, workload.TargetFargate:
+	case "":
+		return cmdutils.ErrMustBeSet("--to")
+	default:
+		return fmt.Errorf("invalid value %q for --to, valid options are %v", target, workload.Targets)
+	}
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+	if output != printers.TableType {
+		logger.Writer = os.Stderr
+	}
+
+	ctx := context.TODO()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	var profiles []*api.FargateProfile
+	if target == workload.TargetFargate {
+		fargateClient := fargate.NewFromProvider(cfg.Metadata.Name, ctl.AWSProvider, ctl.NewStackManager(cfg))
+		if profiles, err = fargateClient.ReadProfiles(ctx); err != nil {
+			return err
+		}
+	}
+
+	retryPolicy := retry.NewTimingOutExponentialBackoff(ctl.AWSProvider.WaitTimeout())
+	placements, err := workload.NewMover(clientSet, &retryPolicy).Move(ctx, namespace, target, profiles)
+	if err != nil {
+		return err
+	}
+	if output == printers.TableType {
+		if len(placements) == 0 {
+			return nil
+		}
+		addPlacementTableColumns(printer.(*printers.TablePrinter))
+	}
+	if err := printer.PrintObjWithKind("placements", placements, cmd.CobraCommand.OutOrStdout()); err != nil {
+		return err
+	}
+	logger.Success("all pods in namespace %q are running on %s", namespace, target.Description())
+	return nil
+}
+
+func addPlacementTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("WORKLOAD", func(p workload.Placement) string {
+		return p.Workload
+	})
+	printer.AddColumn("POD", func(p workload.Placement) string {
+		return p.Pod
+	})
+	printer.AddColumn("NODE", func(p workload.Placement) string {
+		return p.Node
+	})
+}
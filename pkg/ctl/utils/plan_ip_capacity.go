@@ -0,0 +1,184 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+func planIPCapacityCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"plan-ip-capacity",
+		"Check that the subnets of a cluster have enough IP addresses for its nodegroups",
+		"Computes the IPv4 addresses needed by the nodegroups in a config file at their maximum size, based on the ENI limits of their instance types "+
+			"and the VPC CNI mode, and compares them with the free addresses of their subnets. When eksctl creates the VPC, a subnet layout that fits all nodegroups is suggested.",
+	)
+
+	var output printers.Type
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doPlanIPCapacity(cmd, output)
+	}
+}
+
+func doPlanIPCapacity(cmd *cmdutils.Cmd, output printers.Type) error {
+	if cmd.NameArg != "" {
+		return cmdutils.ErrUnsupportedNameArg()
+	}
+	if err := cmdutils.NewUtilsPlanIPCapacityLoader(cmd).Load(); err != nil {
+		return err
+	}
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+	if output != printers.TableType {
+		logger.Writer = os.Stderr
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewCtl()
+	if err != nil {
+		return err
+	}
+	cfg := cmd.ClusterConfig
+
+	if !cfg.HasAnySubnets() {
+		// plan for the subnets that `create cluster` would create
+		if _, err := eks.SetAvailabilityZones(ctx, cfg, nil, ctl.AWSProvider.EC2(), ctl.AWSProvider.Region()); err != nil {
+			return err
+		}
+		if err := vpc.SetSubnets(cfg.VPC, cfg.AvailabilityZones, cfg.LocalZones); err != nil {
+			return err
+		}
+	}
+
+	plan, err := vpc.PlanIPCapacity(ctx, ctl.AWSProvider.EC2(), cfg)
+	if err != nil {
+		return err
+	}
+
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addNodeGroupIPCapacityTableColumns(tablePrinter)
+		if err := tablePrinter.PrintObjWithKind("nodegroups", plan.NodeGroups, cmd.CobraCommand.OutOrStdout()); err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.CobraCommand.OutOrStdout())
+		subnetPrinter := printers.NewTablePrinter().(*printers.TablePrinter)
+		addSubnetIPCapacityTableColumns(subnetPrinter)
+		if err := subnetPrinter.PrintObjWithKind("subnets", plan.Subnets, cmd.CobraCommand.OutOrStdout()); err != nil {
+			return err
+		}
+		if len(plan.PodSubnets) > 0 {
+			fmt.Fprintln(cmd.CobraCommand.OutOrStdout())
+			if err := subnetPrinter.PrintObjWithKind("pod subnets", plan.PodSubnets, cmd.CobraCommand.OutOrStdout()); err != nil {
+				return err
+			}
+		}
+	} else if err := printer.PrintObj(plan, cmd.CobraCommand.OutOrStdout()); err != nil {
+		return err
+	}
+
+	logIPCapacityPlan(plan)
+	if plan.CustomNetworking && len(plan.PodSubnets) == 0 {
+		logger.Info("custom networking is enabled without vpc.podSubnets; the IP addresses used by pods in pod subnets are not checked")
+	}
+	return nil
+}
+
+// logIPCapacityPlan warns about the subnets in plan that are too small for their nodegroups.
+func logIPCapacityPlan(plan *vpc.IPCapacityPlan) {
+	undersized := plan.UndersizedSubnets()
+	if len(undersized) == 0 {
+		logger.Info("all subnets have enough IP addresses for their nodegroups at maximum size")
+		return
+	}
+	var names []string
+	for _, s := range undersized {
+		names = append(names, fmt.Sprintf("%s (%d short)", s.Name, -s.HeadroomIPs))
+	}
+	logger.Warning("subnets %s do not have enough IP addresses for their nodegroups at maximum size", strings.Join(names, ", "))
+	switch layout := plan.SuggestedLayout; {
+	case layout == nil:
+	case layout.ExceedsVPCCIDR:
+		logger.Info("/%d subnets that fit all nodegroups need a /%d VPC CIDR, which is larger than a VPC CIDR block can be (/16); add subnets in a secondary CIDR block with 'eksctl utils extend-vpc' or enable prefix delegation",
+			layout.SubnetPrefixLength, layout.VPCPrefixLength)
+	default:
+		logger.Info("use a VPC CIDR with a /%d prefix or larger to create /%d subnets that fit all nodegroups",
+			layout.VPCPrefixLength, layout.SubnetPrefixLength)
+	}
+	if !plan.PrefixDelegation {
+		logger.Info("enabling prefix delegation in the %s addon reduces the IP addresses used by nodes that run fewer pods than their secondary IP limit", api.VPCCNIAddon)
+	}
+}
+
+func addNodeGroupIPCapacityTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("NODEGROUP", func(c vpc.NodeGroupIPCapacity) string {
+		return c.Name
+	})
+	printer.AddColumn("INSTANCE TYPE", func(c vpc.NodeGroupIPCapacity) string {
+		return c.InstanceType
+	})
+	printer.AddColumn("MAX SIZE", func(c vpc.NodeGroupIPCapacity) int {
+		return c.MaxSize
+	})
+	printer.AddColumn("MAX PODS", func(c vpc.NodeGroupIPCapacity) int {
+		return c.MaxPodsPerNode
+	})
+	printer.AddColumn("IPS PER NODE", func(c vpc.NodeGroupIPCapacity) int {
+		return c.IPsPerNode
+	})
+	printer.AddColumn("REQUIRED IPS", func(c vpc.NodeGroupIPCapacity) int {
+		return c.RequiredIPs
+	})
+	printer.AddColumn("MAX NODES", func(c vpc.NodeGroupIPCapacity) int {
+		return c.MaxNodes
+	})
+	printer.AddColumn("SUBNETS", func(c vpc.NodeGroupIPCapacity) string {
+		return strings.Join(c.Subnets, ",")
+	})
+}
+
+func addSubnetIPCapacityTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("SUBNET", func(s vpc.SubnetIPCapacity) string {
+		return valueOrDash(s.ID)
+	})
+	printer.AddColumn("NAME", func(s vpc.SubnetIPCapacity) string {
+		return s.Name
+	})
+	printer.AddColumn("CIDR", func(s vpc.SubnetIPCapacity) string {
+		return valueOrDash(s.CIDR)
+	})
+	printer.AddColumn("PRIVATE", func(s vpc.SubnetIPCapacity) bool {
+		return s.Private
+	})
+	printer.AddColumn("AVAILABLE IPS", func(s vpc.SubnetIPCapacity) int {
+		return s.AvailableIPs
+	})
+	printer.AddColumn("REQUIRED IPS", func(s vpc.SubnetIPCapacity) int {
+		return s.RequiredIPs
+	})
+	printer.AddColumn("HEADROOM", func(s vpc.SubnetIPCapacity) int {
+		return s.HeadroomIPs
+	})
+}
@@ -0,0 +1,202 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
+	"github.com/weaveworks/eksctl/pkg/nodebootstrap"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+const (
+	textOutput = "text"
+
+	// placeholders for the cluster details that are only known once the cluster exists
+	placeholderEndpoint             = "https://<cluster-endpoint>"
+	placeholderCertificateAuthority = "<certificate-authority-data>"
+	placeholderClusterID            = "<cluster-id>"
+	placeholderServiceIPv4CIDR      = "10.100.0.0/16"
+	placeholderServiceIPv6CIDR      = "fd00::/108"
+)
+
+func renderUserDataCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	var output string
+
+	cmd.SetDescription("render-userdata", "Render and validate the user data of nodegroups in a config file",
+		"Renders the decoded user data generated for the nodegroups in a config file without calling AWS, and reports problems found in it. "+
+			"Details of the cluster that are only known once it exists, such as its endpoint, are replaced by placeholders.")
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doRenderUserData(cmd, output)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddNodeGroupFilterFlags(fs, &cmd.Include, &cmd.Exclude)
+		fs.StringVarP(&output, "output", "o", textOutput, "specifies the output format (valid option: text, json, yaml)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doRenderUserData(cmd *cmdutils.Cmd, output string) error {
+	if cmd.NameArg != "" {
+		return cmdutils.ErrUnsupportedNameArg()
+	}
+
+	ngFilter := filter.NewNodeGroupFilter()
+	if err := cmdutils.NewUtilsRenderUserDataLoader(cmd, ngFilter).Load(); err != nil {
+		return err
+	}
+
+	var printer printers.OutputPrinter
+	if output != textOutput {
+		var err error
+		if printer, err = printers.NewPrinter(output); err != nil {
+			return err
+		}
+		logger.Writer = os.Stderr
+	}
+
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Version == "" {
+		cfg.Metadata.Version = api.DefaultVersion
+	}
+	setPlaceholderStatus(cfg)
+
+	var renderedList []*nodebootstrap.RenderedUserData
+	for i, ng := range cfg.NodeGroups {
+		if !ngFilter.Match(ng.Name) {
+			continue
+		}
+		api.SetNodeGroupDefaults(ng, cfg.Metadata, cfg.IsControlPlaneOnOutposts())
+		rendered, err := renderNodeGroupUserData(cfg, ng, func() error {
+			return api.ValidateNodeGroup(i, ng, cfg)
+		})
+		if err != nil {
+			return err
+		}
+		renderedList = append(renderedList, rendered)
+	}
+	for i, ng := range cfg.ManagedNodeGroups {
+		if !ngFilter.Match(ng.Name) {
+			continue
+		}
+		api.SetManagedNodeGroupDefaults(ng, cfg.Metadata, cfg.IsControlPlaneOnOutposts())
+		rendered, err := renderNodeGroupUserData(cfg, ng, func() error {
+			return api.ValidateManagedNodeGroup(i, ng)
+		})
+		if err != nil {
+			return err
+		}
+		renderedList = append(renderedList, rendered)
+	}
+	if len(renderedList) == 0 {
+		return fmt.Errorf("no nodegroups found in %s", cmd.ClusterConfigFile)
+	}
+
+	out := cmd.CobraCommand.OutOrStdout()
+	if printer != nil {
+		if err := printer.PrintObj(renderedList, out); err != nil {
+			return err
+		}
+	} else {
+		for _, rendered := range renderedList {
+			if err := printRenderedUserData(rendered, out); err != nil {
+				return err
+			}
+		}
+	}
+
+	errorCount := 0
+	for _, rendered := range renderedList {
+		for _, p := range rendered.Problems {
+			if p.Severity == nodebootstrap.SeverityError {
+				logger.Critical("nodegroup %q: %s", rendered.NodeGroup, p.Message)
+				errorCount++
+			} else {
+				logger.Warning("nodegroup %q: %s", rendered.NodeGroup, p.Message)
+			}
+		}
+	}
+	if errorCount > 0 {
+		return fmt.Errorf("found %d error(s) in the user data of nodegroups", errorCount)
+	}
+	logger.Success("no errors found in the user data of %d nodegroup(s)", len(renderedList))
+	return nil
+}
+
+// renderNodeGroupUserData validates np and renders its user data. Validation errors are reported as problems.
+func renderNodeGroupUserData(cfg *api.ClusterConfig, np api.NodePool, validate func() error) (*nodebootstrap.RenderedUserData, error) {
+	if err := validate(); err != nil {
+		ng := np.BaseNodeGroup()
+		_, managed := np.(*api.ManagedNodeGroup)
+		return &nodebootstrap.RenderedUserData{
+			NodeGroup: ng.Name,
+			Managed:   managed,
+			AMIFamily: ng.AMIFamily,
+			Format:    nodebootstrap.UserDataFormatNone,
+			Problems: []nodebootstrap.Problem{
+				{
+					Severity: nodebootstrap.SeverityError,
+					Message:  err.Error(),
+				},
+			},
+		}, nil
+	}
+	return nodebootstrap.RenderUserData(cfg, np)
+}
+
+// setPlaceholderStatus sets placeholders for the cluster details used in user data that are only known once the cluster exists.
+func setPlaceholderStatus(cfg *api.ClusterConfig) {
+	networkConfig := &api.KubernetesNetworkConfig{
+		ServiceIPv4CIDR: placeholderServiceIPv4CIDR,
+	}
+	if cfg.KubernetesNetworkConfig != nil && cfg.KubernetesNetworkConfig.ServiceIPv4CIDR != "" {
+		networkConfig.ServiceIPv4CIDR = cfg.KubernetesNetworkConfig.ServiceIPv4CIDR
+	}
+	if cfg.IPv6Enabled() {
+		networkConfig.ServiceIPv6CIDR = placeholderServiceIPv6CIDR
+	}
+	cfg.Status = &api.ClusterStatus{
+		Endpoint:                 placeholderEndpoint,
+		CertificateAuthorityData: []byte(placeholderCertificateAuthority),
+		KubernetesNetworkConfig:  networkConfig,
+	}
+	if cfg.IsControlPlaneOnOutposts() {
+		cfg.Status.ID = placeholderClusterID
+	}
+}
+
+func printRenderedUserData(rendered *nodebootstrap.RenderedUserData, out io.Writer) error {
+	nodeGroupType := "self-managed"
+	if rendered.Managed {
+		nodeGroupType = "managed"
+	}
+	fmt.Fprintf(out, "# nodegroup %q (%s, %s, %s)\n", rendered.NodeGroup, nodeGroupType, rendered.AMIFamily, rendered.Format)
+	for _, part := range rendered.Parts {
+		fmt.Fprintf(out, "\n--- %s\n%s\n", part.Name, part.Content)
+	}
+	if len(rendered.KubeletConfig) > 0 {
+		kubeletConfig, err := yaml.Marshal(rendered.KubeletConfig)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "\n--- kubelet config (merged)\n%s", kubeletConfig)
+	}
+	fmt.Fprintln(out)
+	return nil
+}
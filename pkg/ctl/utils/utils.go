@@ -15,9 +15,11 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateKubeProxyCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateAWSNodeCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateCoreDNSCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, moveWorkloadCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateLegacySubnetSettings)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, enableLoggingCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, associateIAMOIDCProviderCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, checkOIDCProviderCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, installWindowsVPCController)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateClusterEndpointsCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, publicAccessCIDRsCmd)
@@ -25,8 +27,12 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, enableSecretsEncryptionCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, schemaCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, nodeGroupHealthCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, renderUserDataCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, planIPCapacityCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, extendVPCCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, describeClusterVersionsCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, describeAddonVersionsCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, addonUpgradePlanCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, describeAddonConfigurationCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, migrateToPodIdentityCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, migrateAccessEntryCmd)
@@ -3,6 +3,8 @@ package utils_test
 import (
 	"bytes"
 	"errors"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -12,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/ctl/utils"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
 )
 
 func TestValidateLoggingFlags(t *testing.T) {
@@ -131,6 +134,129 @@ var _ = Describe("utils", func() {
 			Expect(err.Error()).To(ContainSubstring("usage"))
 		})
 	})
+
+	Describe("write-kubeconfig", func() {
+		It("rejects a cluster name with --all-regions", func() {
+			cmd := newMockCmd("write-kubeconfig", "--cluster", "dev", "--all-regions")
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring("--all-regions is for writing contexts for all clusters, it must be used without cluster name flag/argument")))
+		})
+		It("rejects --auto-kubeconfig with --all-regions", func() {
+			cmd := newMockCmd("write-kubeconfig", "--all-regions", "--auto-kubeconfig")
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring("--auto-kubeconfig and --all-regions")))
+		})
+
+		DescribeTable("only prunes clusters whose account can be verified", func(ref kubeconfig.ClusterRef, profile string, expected bool) {
+			ok, _ := utils.IsClusterInAccount(ref, profile, "111122223333")
+			Expect(ok).To(Equal(expected))
+		},
+			Entry("ARN entry in the current account", kubeconfig.ClusterRef{AccountID: "111122223333"}, "", true),
+			Entry("ARN entry in another account", kubeconfig.ClusterRef{AccountID: "444455556666", Profile: "dev"}, "dev", false),
+			Entry("role in the current account", kubeconfig.ClusterRef{RoleARN: "arn:aws:iam::111122223333:role/admin"}, "", true),
+			Entry("role in another account", kubeconfig.ClusterRef{RoleARN: "arn:aws:iam::444455556666:role/admin", Profile: "dev"}, "dev", false),
+			Entry("matching named profile", kubeconfig.ClusterRef{Profile: "dev"}, "dev", true),
+			Entry("default profile", kubeconfig.ClusterRef{}, "", false),
+		)
+	})
+
+	Describe("move-workload", func() {
+		It("requires --namespace", func() {
+			cmd := newMockCmd("move-workload", "--cluster", "dev", "--to", "nodegroup")
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring("--namespace must be set")))
+		})
+		It("rejects an invalid target", func() {
+			cmd := newMockCmd("move-workload", "--cluster", "dev", "--namespace", "apps", "--to", "spot")
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring(`invalid value "spot" for --to`)))
+		})
+	})
+
+	Describe("render-userdata", func() {
+		writeConfig := func(config string) string {
+			configFile := filepath.Join(GinkgoT().TempDir(), "cluster.yaml")
+			Expect(os.WriteFile(configFile, []byte(config), 0644)).To(Succeed())
+			return configFile
+		}
+
+		It("requires a config file", func() {
+			cmd := newMockCmd("render-userdata")
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring("--config-file must be set")))
+		})
+
+		It("renders the user data of the nodegroups in the config file", func() {
+			configFile := writeConfig(`apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfig
+metadata:
+  name: dev
+  region: us-west-2
+nodeGroups:
+  - name: ng-1
+    amiFamily: AmazonLinux2023
+    maxPodsPerNode: 30
+`)
+			cmd := newMockCmd("render-userdata", "--config-file", configFile)
+			out, err := cmd.execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring(`# nodegroup "ng-1" (self-managed, AmazonLinux2023, mime-multipart)`))
+			Expect(out).To(ContainSubstring("apiServerEndpoint: https://<cluster-endpoint>"))
+			Expect(out).To(ContainSubstring("maxPods: 30"))
+		})
+
+		It("fails if errors are found in the user data", func() {
+			configFile := writeConfig(`apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfig
+metadata:
+  name: dev
+  region: us-west-2
+nodeGroups:
+  - name: ng-1
+    amiFamily: Bottlerocket
+    bottlerocket:
+      settings:
+        moted: hello
+`)
+			cmd := newMockCmd("render-userdata", "--config-file", configFile)
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring("found 1 error(s) in the user data of nodegroups")))
+		})
+	})
+
+	Describe("plan-ip-capacity", func() {
+		It("requires a config file", func() {
+			cmd := newMockCmd("plan-ip-capacity")
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring("--config-file must be set")))
+		})
+
+		It("does not accept a cluster name", func() {
+			cmd := newMockCmd("plan-ip-capacity", "dev")
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring("name argument is not supported")))
+		})
+	})
+
+	Describe("extend-vpc", func() {
+		It("requires a cluster name", func() {
+			cmd := newMockCmd("extend-vpc", "--cidr", "100.64.0.0/16")
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring("--cluster must be set")))
+		})
+
+		It("requires a CIDR block", func() {
+			cmd := newMockCmd("extend-vpc", "--cluster", "dev")
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring("--cidr must be set")))
+		})
+
+		It("rejects an invalid CIDR block", func() {
+			cmd := newMockCmd("extend-vpc", "--cluster", "dev", "--cidr", "100.64.0.0")
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring(`invalid value "100.64.0.0" for --cidr`)))
+		})
+	})
 })
 
 func newMockCmd(args ...string) *mockVerbCmd {
@@ -2,33 +2,46 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/eks"
 	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
 )
 
+type writeKubeconfigOptions struct {
+	outputPath           string
+	authenticatorRoleARN string
+	setContext, autoPath bool
+	allRegions           bool
+	prune                bool
+	contextNameTemplate  string
+}
+
 func writeKubeconfigCmd(cmd *cmdutils.Cmd) {
 	cfg := api.NewClusterConfig()
 	cmd.ClusterConfig = cfg
 
-	var (
-		outputPath           string
-		authenticatorRoleARN string
-		setContext, autoPath bool
-	)
+	var options writeKubeconfigOptions
 
 	cmd.SetDescription("write-kubeconfig", "Write kubeconfig file for a given cluster", "")
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return doWriteKubeconfigCmd(cmd, outputPath, authenticatorRoleARN, setContext, autoPath)
+		if options.allRegions || (options.prune && cfg.Metadata.Name == "" && cmd.NameArg == "" && cmd.ClusterConfigFile == "") {
+			return doWriteKubeconfigForAllClusters(cmd, options)
+		}
+		return doWriteKubeconfigCmd(cmd, options)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -36,16 +49,20 @@ func writeKubeconfigCmd(cmd *cmdutils.Cmd) {
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.BoolVarP(&options.allRegions, "all-regions", "A", false, "Write contexts for all clusters across all supported regions")
+		fs.BoolVar(&options.prune, "prune", false, "Remove the clusters, contexts and users of EKS clusters that no longer exist from the kubeconfig, and refresh the endpoint and certificate authority of the others")
+		cmdutils.AddApproveFlag(fs, cmd)
 	})
 
 	cmd.FlagSetGroup.InFlagSet("Output kubeconfig", func(fs *pflag.FlagSet) {
-		cmdutils.AddCommonFlagsForKubeconfig(fs, &outputPath, &authenticatorRoleARN, &setContext, &autoPath, "<name>")
+		cmdutils.AddCommonFlagsForKubeconfig(fs, &options.outputPath, &options.authenticatorRoleARN, &options.setContext, &options.autoPath, "<name>")
+		fs.StringVar(&options.contextNameTemplate, "context-name-template", "", "Go template for the names of the contexts and users, e.g. \"{{.ClusterName}}-{{.Region}}\" (available fields: ClusterName, Region, Username)")
 	})
 
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 }
 
-func doWriteKubeconfigCmd(cmd *cmdutils.Cmd, outputPath, roleARN string, setContext, autoPath bool) error {
+func doWriteKubeconfigCmd(cmd *cmdutils.Cmd, options writeKubeconfigOptions) error {
 	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
 		return err
 	}
@@ -65,14 +82,16 @@ func doWriteKubeconfigCmd(cmd *cmdutils.Cmd, outputPath, roleARN string, setCont
 		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
 	}
 
-	if autoPath {
+	outputPath := options.outputPath
+	if options.autoPath {
 		if outputPath != kubeconfig.DefaultPath() {
 			return fmt.Errorf("--kubeconfig and --auto-kubeconfig %s", cmdutils.IncompatibleFlags)
 		}
 		outputPath = kubeconfig.AutoPath(cfg.Metadata.Name)
 	}
 
-	ctl, err := cmd.NewProviderForExistingCluster(context.Background())
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
 	if err != nil {
 		return err
 	}
@@ -81,13 +100,212 @@ func doWriteKubeconfigCmd(cmd *cmdutils.Cmd, outputPath, roleARN string, setCont
 		return err
 	}
 
-	kubectlConfig := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), roleARN, ctl.AWSProvider.Profile().Name)
-	filename, err := kubeconfig.Write(outputPath, *kubectlConfig, setContext)
+	kubectlConfig, err := newKubectlConfig(ctl, cfg, options)
+	if err != nil {
+		return err
+	}
+	filename, err := kubeconfig.Write(outputPath, *kubectlConfig, options.setContext)
 	if err != nil {
 		return fmt.Errorf("writing kubeconfig: %w", err)
 	}
 
 	logger.Success("saved kubeconfig as %q", filename)
 
+	if options.prune {
+		return pruneKubeconfig(ctx, newRegionalProviders(ctl), outputPath, cmd.Plan)
+	}
 	return nil
 }
+
+func doWriteKubeconfigForAllClusters(cmd *cmdutils.Cmd, options writeKubeconfigOptions) error {
+	cfg := cmd.ClusterConfig
+	if cmd.ClusterConfigFile != "" {
+		return fmt.Errorf("--config-file and --all-regions %s", cmdutils.IncompatibleFlags)
+	}
+	if cfg.Metadata.Name != "" || cmd.NameArg != "" {
+		return fmt.Errorf("--all-regions is for writing contexts for all clusters, it must be used without cluster name flag/argument")
+	}
+	if options.autoPath {
+		return fmt.Errorf("--auto-kubeconfig and --all-regions %s", cmdutils.IncompatibleFlags)
+	}
+
+	ctx := context.Background()
+	ctl, err := eks.New(ctx, &cmd.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+	providers := newRegionalProviders(ctl)
+
+	if options.allRegions {
+		if cmd.CobraCommand.Flag("region").Changed {
+			logger.Warning("--region=%s is ignored, as --all-regions is given", cmd.ProviderConfig.Region)
+		}
+		clusters, err := cluster.GetClusters(ctx, ctl.AWSProvider, true, 100)
+		if err != nil {
+			return err
+		}
+		written := 0
+		for _, c := range clusters {
+			if err := writeKubeconfigForCluster(ctx, providers, c, options); err != nil {
+				logger.Warning("skipping cluster %q in %q: %v", c.Name, c.Region, err)
+				continue
+			}
+			written++
+		}
+		logger.Success("saved contexts for %d cluster(s) to %q", written, options.outputPath)
+	}
+
+	if options.prune {
+		return pruneKubeconfig(ctx, providers, options.outputPath, cmd.Plan)
+	}
+	return nil
+}
+
+func writeKubeconfigForCluster(ctx context.Context, providers *regionalProviders, c cluster.Description, options writeKubeconfigOptions) error {
+	ctl, err := providers.get(ctx, c.Region)
+	if err != nil {
+		return err
+	}
+	cfg := api.NewClusterConfig()
+	cfg.Metadata.Name = c.Name
+	cfg.Metadata.Region = c.Region
+	if err := ctl.RefreshClusterStatus(ctx, cfg); err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	kubectlConfig, err := newKubectlConfig(ctl, cfg, options)
+	if err != nil {
+		return err
+	}
+	if _, err := kubeconfig.Write(options.outputPath, *kubectlConfig, false); err != nil {
+		return fmt.Errorf("writing kubeconfig: %w", err)
+	}
+	logger.Info("saved context %q", kubectlConfig.CurrentContext)
+	return nil
+}
+
+func newKubectlConfig(ctl *eks.ClusterProvider, cfg *api.ClusterConfig, options writeKubeconfigOptions) (*clientcmdapi.Config, error) {
+	username := eks.GetUsername(ctl.Status.IAMRoleARN)
+	kubectlConfig := kubeconfig.NewForKubectl(cfg, username, options.authenticatorRoleARN, ctl.AWSProvider.Profile().Name)
+	if options.contextNameTemplate == "" {
+		return kubectlConfig, nil
+	}
+	contextName, err := kubeconfig.RenderContextName(options.contextNameTemplate, kubeconfig.ContextNameData{
+		ClusterName: cfg.Metadata.Name,
+		Region:      cfg.Metadata.Region,
+		Username:    username,
+	})
+	if err != nil {
+		return nil, err
+	}
+	kubeconfig.RenameContext(kubectlConfig, contextName)
+	return kubectlConfig, nil
+}
+
+func pruneKubeconfig(ctx context.Context, providers *regionalProviders, outputPath string, plan bool) error {
+	profile := providers.base.AWSProvider.Profile().Name
+	var accountID string
+	if callerARN, err := arn.Parse(providers.base.Status.IAMRoleARN); err == nil {
+		accountID = callerARN.AccountID
+	}
+
+	result, err := kubeconfig.Prune(outputPath, func(ref kubeconfig.ClusterRef) (*api.ClusterStatus, error) {
+		if ref.Profile != profile {
+			logger.Info("skipping cluster %q, as it is not accessed with the current AWS profile", ref.Entry)
+			return nil, kubeconfig.ErrSkipCluster
+		}
+		if ok, reason := isClusterInAccount(ref, profile, accountID); !ok {
+			logger.Info("skipping cluster %q, as %s", ref.Entry, reason)
+			return nil, kubeconfig.ErrSkipCluster
+		}
+		ctl, err := providers.get(ctx, ref.Region)
+		if err != nil {
+			logger.Warning("skipping cluster %q: %v", ref.Entry, err)
+			return nil, kubeconfig.ErrSkipCluster
+		}
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = ref.ClusterName
+		cfg.Metadata.Region = ref.Region
+		if err := ctl.RefreshClusterStatus(ctx, cfg); err != nil {
+			var notFoundErr *ekstypes.ResourceNotFoundException
+			if errors.As(err, &notFoundErr) {
+				return nil, nil
+			}
+			logger.Warning("skipping cluster %q: %v", ref.Entry, err)
+			return nil, kubeconfig.ErrSkipCluster
+		}
+		return cfg.Status, nil
+	}, plan)
+	if err != nil {
+		return fmt.Errorf("pruning kubeconfig: %w", err)
+	}
+	for _, entry := range result.Removed {
+		cmdutils.LogIntendedAction(plan, "remove cluster %q, which no longer exists, from kubeconfig", entry)
+	}
+	for _, entry := range result.Refreshed {
+		cmdutils.LogIntendedAction(plan, "refresh endpoint and certificate authority of cluster %q in kubeconfig", entry)
+	}
+	cmdutils.LogCompletedAction(plan, "pruned kubeconfig: %d cluster(s) removed, %d refreshed", len(result.Removed), len(result.Refreshed))
+	if len(result.Removed) > 0 || len(result.Refreshed) > 0 {
+		cmdutils.LogPlanModeWarning(plan)
+	}
+	return nil
+}
+
+// isClusterInAccount reports whether the cluster of ref can be verified to belong to accountID, the account of the
+// current credentials. Entries named "<name>.<region>.eksctl.io" carry no account, so they are only verified when
+// they are accessed with a named profile that matches the current one, or assume a role in accountID.
+func isClusterInAccount(ref kubeconfig.ClusterRef, profile, accountID string) (bool, string) {
+	switch {
+	case accountID == "":
+		return false, "the account of the current credentials is unknown"
+	case ref.AccountID != "":
+		if ref.AccountID != accountID {
+			return false, fmt.Sprintf("it belongs to account %s", ref.AccountID)
+		}
+		return true, ""
+	case ref.RoleARN != "":
+		roleARN, err := arn.Parse(ref.RoleARN)
+		if err != nil || roleARN.AccountID != accountID {
+			return false, fmt.Sprintf("it is accessed with role %s, which is not in account %s", ref.RoleARN, accountID)
+		}
+		return true, ""
+	case ref.Profile != "" && ref.Profile == profile:
+		return true, ""
+	default:
+		return false, "the account it belongs to cannot be verified"
+	}
+}
+
+// regionalProviders creates and caches cluster providers for the regions of the clusters in a kubeconfig file.
+type regionalProviders struct {
+	base      *eks.ClusterProvider
+	providers map[string]*eks.ClusterProvider
+}
+
+func newRegionalProviders(base *eks.ClusterProvider) *regionalProviders {
+	return &regionalProviders{
+		base: base,
+		providers: map[string]*eks.ClusterProvider{
+			base.AWSProvider.Region(): base,
+		},
+	}
+}
+
+func (r *regionalProviders) get(ctx context.Context, region string) (*eks.ClusterProvider, error) {
+	if ctl, ok := r.providers[region]; ok {
+		return ctl, nil
+	}
+	ctl, err := eks.New(ctx, &api.ProviderConfig{
+		Region:      region,
+		Profile:     r.base.AWSProvider.Profile(),
+		WaitTimeout: r.base.AWSProvider.WaitTimeout(),
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating provider in %q region: %w", region, err)
+	}
+	r.providers[region] = ctl
+	return ctl, nil
+}
@@ -0,0 +1,245 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/weaveworks/eksctl/pkg/utils/events"
+)
+
+// blockedReportInterval is how often an eviction that is still blocked by a PodDisruptionBudget is reported
+const blockedReportInterval = time.Minute
+
+// A BudgetScheduler decides which nodes can be drained at the same time so that parallel drains never evict pods
+// covered by the same PodDisruptionBudget, and diagnoses evictions that a PodDisruptionBudget keeps blocking.
+type BudgetScheduler struct {
+	clientSet    kubernetes.Interface
+	budgets      []budget
+	blockTimeout time.Duration
+	now          func() time.Time
+
+	mu sync.Mutex
+	// draining maps a budget to the node whose pods covered by it are being evicted
+	draining map[string]string
+	// released is closed and replaced every time a node releases its budgets
+	released chan struct{}
+	blocked  map[string]*blockedEviction
+}
+
+type budget struct {
+	pdb      policyv1.PodDisruptionBudget
+	selector labels.Selector
+}
+
+type blockedEviction struct {
+	since        time.Time
+	lastReported time.Time
+}
+
+// NewBudgetScheduler loads every PodDisruptionBudget in the cluster. Evictions blocked by a PodDisruptionBudget
+// for longer than blockTimeout fail, a zero blockTimeout retries them until the drain times out.
+func NewBudgetScheduler(ctx context.Context, clientSet kubernetes.Interface, blockTimeout time.Duration) (*BudgetScheduler, error) {
+	pdbs, err := clientSet.PolicyV1().PodDisruptionBudgets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing PodDisruptionBudgets: %w", err)
+	}
+	s := &BudgetScheduler{
+		clientSet:    clientSet,
+		blockTimeout: blockTimeout,
+		now:          time.Now,
+		draining:     map[string]string{},
+		released:     make(chan struct{}),
+		blocked:      map[string]*blockedEviction{},
+	}
+	for _, pdb := range pdbs.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			logger.Warning("ignoring PodDisruptionBudget %s: %v", budgetKey(pdb), err)
+			continue
+		}
+		s.budgets = append(s.budgets, budget{pdb: pdb, selector: selector})
+	}
+	logger.Debug("loaded %d PodDisruptionBudget(s)", len(s.budgets))
+	return s, nil
+}
+
+// Order returns the nodes in the order they should be drained. Nodes are arranged in batches of nodes that do not
+// share a PodDisruptionBudget, starting with the nodes whose pods are covered by the most budgets. Drainers admit
+// nodes in this order, so a node waiting in Acquire holds back the nodes after it.
+func (s *BudgetScheduler) Order(podsByNode map[string][]corev1.Pod) []string {
+	budgetsByNode := map[string]sets.Set[string]{}
+	exhausted := sets.New[string]()
+	var remaining []string
+	for node, pods := range podsByNode {
+		budgets := sets.New[string]()
+		for _, pod := range pods {
+			if b := s.budgetFor(pod); b != nil {
+				budgets.Insert(budgetKey(b.pdb))
+				if b.pdb.Status.DisruptionsAllowed == 0 {
+					exhausted.Insert(budgetKey(b.pdb))
+				}
+			}
+		}
+		budgetsByNode[node] = budgets
+		remaining = append(remaining, node)
+	}
+	for _, key := range sets.List(exhausted) {
+		logger.Warning("PodDisruptionBudget %s currently allows no disruptions, evicting the pods it covers will wait until enough of them are healthy", key)
+	}
+
+	sort.Slice(remaining, func(i, j int) bool {
+		if li, lj := budgetsByNode[remaining[i]].Len(), budgetsByNode[remaining[j]].Len(); li != lj {
+			return li > lj
+		}
+		return remaining[i] < remaining[j]
+	})
+
+	var order []string
+	for len(remaining) > 0 {
+		batch := sets.New[string]()
+		var deferred []string
+		for _, node := range remaining {
+			if batch.HasAny(sets.List(budgetsByNode[node])...) {
+				deferred = append(deferred, node)
+				continue
+			}
+			batch = batch.Union(budgetsByNode[node])
+			order = append(order, node)
+		}
+		remaining = deferred
+	}
+	return order
+}
+
+// Acquire waits until no other node is being drained of pods covered by the same PodDisruptionBudgets as pods.
+func (s *BudgetScheduler) Acquire(ctx context.Context, node string, pods []corev1.Pod) error {
+	keys := sets.New[string]()
+	for _, pod := range pods {
+		if b := s.budgetFor(pod); b != nil {
+			keys.Insert(budgetKey(b.pdb))
+		}
+	}
+	for {
+		s.mu.Lock()
+		var busy []string
+		for _, key := range sets.List(keys) {
+			if owner, ok := s.draining[key]; ok && owner != node {
+				busy = append(busy, key)
+			}
+		}
+		if len(busy) == 0 {
+			for key := range keys {
+				s.draining[key] = node
+			}
+			s.mu.Unlock()
+			return nil
+		}
+		released := s.released
+		s.mu.Unlock()
+
+		logger.Debug("waiting to drain node %s, PodDisruptionBudget(s) %v are used by other nodes", node, busy)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for PodDisruptionBudget(s) %v to drain node %q", busy, node)
+		case <-released:
+		}
+	}
+}
+
+// Release releases the PodDisruptionBudgets acquired for node.
+func (s *BudgetScheduler) Release(node string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, owner := range s.draining {
+		if owner == node {
+			delete(s.draining, key)
+		}
+	}
+	close(s.released)
+	s.released = make(chan struct{})
+}
+
+// EvictionBlocked records that evicting pod was refused. It reports the PodDisruptionBudget blocking the eviction
+// and for how long, and returns an error diagnosing it once it has been blocked for longer than the block timeout.
+func (s *BudgetScheduler) EvictionBlocked(ctx context.Context, pod corev1.Pod) error {
+	b := s.budgetFor(pod)
+	if b == nil {
+		// not caused by a PodDisruptionBudget, e.g. the API server is throttling requests
+		return nil
+	}
+
+	now := s.now()
+	podKey := pod.Namespace + "/" + pod.Name
+	s.mu.Lock()
+	blocked, ok := s.blocked[podKey]
+	if !ok {
+		blocked = &blockedEviction{since: now}
+		s.blocked[podKey] = blocked
+	}
+	blockedFor := now.Sub(blocked.since)
+	report := !ok || now.Sub(blocked.lastReported) >= blockedReportInterval
+	if report {
+		blocked.lastReported = now
+	}
+	s.mu.Unlock()
+
+	timedOut := s.blockTimeout > 0 && blockedFor >= s.blockTimeout
+	if !report && !timedOut {
+		return nil
+	}
+
+	pdb := b.pdb
+	if current, err := s.clientSet.PolicyV1().PodDisruptionBudgets(pdb.Namespace).Get(ctx, pdb.Name, metav1.GetOptions{}); err == nil {
+		pdb = *current
+	}
+	diagnosis := describeBudget(pdb)
+	if timedOut {
+		return fmt.Errorf("eviction of pod %s has been blocked for %s: %s; scale up the workload or relax the budget, or use --disable-eviction to bypass PodDisruptionBudgets",
+			podKey, blockedFor.Round(time.Second), diagnosis)
+	}
+	logger.Warning("eviction of pod %s blocked for %s: %s", podKey, blockedFor.Round(time.Second), diagnosis)
+	events.Emit(events.Event{
+		Type:       events.DrainProgress,
+		ResourceID: podKey,
+		Kind:       "pod",
+		Status:     "blocked",
+		Message:    diagnosis,
+	})
+	return nil
+}
+
+// EvictionSucceeded clears the blocked state of pod.
+func (s *BudgetScheduler) EvictionSucceeded(pod corev1.Pod) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.blocked, pod.Namespace+"/"+pod.Name)
+}
+
+func (s *BudgetScheduler) budgetFor(pod corev1.Pod) *budget {
+	for i, b := range s.budgets {
+		if b.pdb.Namespace == pod.Namespace && b.selector.Matches(labels.Set(pod.Labels)) {
+			return &s.budgets[i]
+		}
+	}
+	return nil
+}
+
+func budgetKey(pdb policyv1.PodDisruptionBudget) string {
+	return pdb.Namespace + "/" + pdb.Name
+}
+
+func describeBudget(pdb policyv1.PodDisruptionBudget) string {
+	return fmt.Sprintf("PodDisruptionBudget %s allows %d disruption(s), %d of %d pods are healthy and %d must stay healthy",
+		budgetKey(pdb), pdb.Status.DisruptionsAllowed, pdb.Status.CurrentHealthy, pdb.Status.ExpectedPods, pdb.Status.DesiredHealthy)
+}
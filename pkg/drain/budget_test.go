@@ -0,0 +1,198 @@
+package drain_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/sync/semaphore"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/drain"
+	"github.com/weaveworks/eksctl/pkg/drain/evictor"
+	"github.com/weaveworks/eksctl/pkg/drain/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks/mocks"
+)
+
+var _ = Describe("BudgetScheduler", func() {
+	var (
+		fakeClientSet *fake.Clientset
+		scheduler     *drain.BudgetScheduler
+	)
+
+	newPDB := func(name, app string, disruptionsAllowed int32) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{
+				DisruptionsAllowed: disruptionsAllowed,
+				CurrentHealthy:     2,
+				DesiredHealthy:     2,
+				ExpectedPods:       2,
+			},
+		}
+	}
+
+	newPod := func(name, app string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": app}},
+		}
+	}
+
+	BeforeEach(func() {
+		fakeClientSet = fake.NewSimpleClientset(newPDB("web", "web", 1), newPDB("db", "db", 0))
+		var err error
+		scheduler, err = drain.NewBudgetScheduler(context.Background(), fakeClientSet, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("orders nodes so that nodes sharing a budget are not drained together", func() {
+		order := scheduler.Order(map[string][]corev1.Pod{
+			"node-a": {newPod("web-1", "web"), newPod("db-1", "db")},
+			"node-b": {newPod("web-2", "web")},
+			"node-c": {newPod("db-2", "db")},
+			"node-d": {newPod("other", "other")},
+		})
+		Expect(order).To(Equal([]string{"node-a", "node-d", "node-b", "node-c"}))
+	})
+
+	It("starts draining nodes in the order of the scheduler", func() {
+		mockNG := mocks.KubeNodeGroup{}
+		mockNG.Mock.On("NameString").Return("ng-1")
+		mockNG.Mock.On("ListOptions").Return(metav1.ListOptions{})
+		podsByNode := map[string][]corev1.Pod{
+			"node-a": {newPod("web-1", "web"), newPod("db-1", "db")},
+			"node-b": {newPod("web-2", "web")},
+			"node-c": {newPod("db-2", "db")},
+			"node-d": {newPod("other", "other")},
+		}
+		for node := range podsByNode {
+			_, err := fakeClientSet.CoreV1().Nodes().Create(context.Background(), &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: node},
+			}, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		var (
+			mu      sync.Mutex
+			evicted []string
+		)
+		fakeEvictor := new(fakes.FakeEvictor)
+		fakeEvictor.GetPodsForEvictionStub = func(node string) (*evictor.PodDeleteList, []error) {
+			mu.Lock()
+			defer mu.Unlock()
+			list := &evictor.PodDeleteList{}
+			for _, pod := range podsByNode[node] {
+				list.Items = append(list.Items, evictor.PodDelete{Pod: pod, Status: evictor.PodDeleteStatus{Delete: true}})
+			}
+			return list, nil
+		}
+		fakeEvictor.EvictOrDeletePodStub = func(pod corev1.Pod) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for node, pods := range podsByNode {
+				if len(pods) > 0 && pods[0].Name == pod.Name {
+					podsByNode[node] = pods[1:]
+					if len(podsByNode[node]) == 0 {
+						evicted = append(evicted, node)
+					}
+				}
+			}
+			return nil
+		}
+
+		nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, 0, 0, false, false, 1)
+		nodeGroupDrainer.SetDrainer(fakeEvictor)
+		nodeGroupDrainer.UseBudgetScheduler(scheduler)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+		Expect(nodeGroupDrainer.Drain(ctx, semaphore.NewWeighted(1))).To(Succeed())
+		Expect(evicted).To(Equal([]string{"node-a", "node-d", "node-b", "node-c"}))
+	})
+
+	It("waits for the nodes sharing a budget to be released", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		Expect(scheduler.Acquire(ctx, "node-a", []corev1.Pod{newPod("web-1", "web")})).To(Succeed())
+		Expect(scheduler.Acquire(ctx, "node-c", []corev1.Pod{newPod("db-1", "db")})).To(Succeed())
+
+		acquired := make(chan error)
+		go func() {
+			acquired <- scheduler.Acquire(ctx, "node-b", []corev1.Pod{newPod("web-2", "web")})
+		}()
+		Consistently(acquired, 100*time.Millisecond).ShouldNot(Receive())
+		scheduler.Release("node-c")
+		Consistently(acquired, 100*time.Millisecond).ShouldNot(Receive())
+		scheduler.Release("node-a")
+		Eventually(acquired).Should(Receive(BeNil()))
+	})
+
+	It("times out waiting for a budget", func() {
+		Expect(scheduler.Acquire(context.Background(), "node-a", []corev1.Pod{newPod("web-1", "web")})).To(Succeed())
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err := scheduler.Acquire(ctx, "node-b", []corev1.Pod{newPod("web-2", "web")})
+		Expect(err).To(MatchError(`timed out waiting for PodDisruptionBudget(s) [default/web] to drain node "node-b"`))
+	})
+
+	It("fails with a diagnosis once an eviction has been blocked for too long", func() {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		scheduler.SetNow(func() time.Time { return now })
+		pod := newPod("db-1", "db")
+
+		Expect(scheduler.EvictionBlocked(context.Background(), pod)).To(Succeed())
+		now = now.Add(30 * time.Second)
+		Expect(scheduler.EvictionBlocked(context.Background(), pod)).To(Succeed())
+		now = now.Add(30 * time.Second)
+		err := scheduler.EvictionBlocked(context.Background(), pod)
+		Expect(err).To(MatchError(ContainSubstring("eviction of pod default/db-1 has been blocked for 1m0s: " +
+			"PodDisruptionBudget default/db allows 0 disruption(s), 2 of 2 pods are healthy and 2 must stay healthy")))
+
+		scheduler.EvictionSucceeded(pod)
+		Expect(scheduler.EvictionBlocked(context.Background(), pod)).To(Succeed())
+	})
+
+	It("ignores evictions that are not blocked by a budget", func() {
+		scheduler.SetNow(func() time.Time { return time.Now().Add(time.Hour) })
+		Expect(scheduler.EvictionBlocked(context.Background(), newPod("other", "other"))).To(Succeed())
+		Expect(scheduler.EvictionBlocked(context.Background(), newPod("other", "other"))).To(Succeed())
+	})
+
+	It("fails the drain when a budget keeps blocking an eviction", func() {
+		scheduler, err := drain.NewBudgetScheduler(context.Background(), fakeClientSet, time.Nanosecond)
+		Expect(err).NotTo(HaveOccurred())
+
+		mockNG := mocks.KubeNodeGroup{}
+		mockNG.Mock.On("NameString").Return("ng-1")
+		mockNG.Mock.On("ListOptions").Return(metav1.ListOptions{})
+		_, err = fakeClientSet.CoreV1().Nodes().Create(context.Background(), &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		}, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeEvictor := new(fakes.FakeEvictor)
+		fakeEvictor.GetPodsForEvictionReturns(&evictor.PodDeleteList{
+			Items: []evictor.PodDelete{{Pod: newPod("db-1", "db"), Status: evictor.PodDeleteStatus{Delete: true}}},
+		}, nil)
+		fakeEvictor.EvictOrDeletePodReturns(apierrors.NewTooManyRequestsError("Cannot evict pod as it would violate the pod's disruption budget."))
+
+		nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, 0, 0, false, false, 1)
+		nodeGroupDrainer.SetDrainer(fakeEvictor)
+		nodeGroupDrainer.UseBudgetScheduler(scheduler)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+		err = nodeGroupDrainer.Drain(ctx, semaphore.NewWeighted(1))
+		Expect(err).To(MatchError(ContainSubstring("eviction of pod default/db-1 has been blocked for")))
+		Expect(err).To(MatchError(ContainSubstring("PodDisruptionBudget default/db allows 0 disruption(s)")))
+	})
+})
@@ -0,0 +1,328 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+)
+
+// UnschedulablePod is a pod that would not fit on any of the nodes remaining after a drain.
+type UnschedulablePod struct {
+	Pod corev1.Pod
+	// Reason summarises why each remaining node cannot run the pod, like the scheduler does
+	Reason string
+}
+
+func (p UnschedulablePod) String() string {
+	return fmt.Sprintf("%s/%s: %s", p.Pod.Namespace, p.Pod.Name, p.Reason)
+}
+
+// CheckCapacity simulates rescheduling pods, which are evicted from drainingNodes, onto the schedulable nodes that
+// remain in the cluster. It takes into account resource requests, taints and tolerations, node selectors, required
+// node affinity and topology spread constraints, and returns the pods that would not fit.
+func CheckCapacity(ctx context.Context, clientSet kubernetes.Interface, drainingNodes sets.Set[string], pods []corev1.Pod) ([]UnschedulablePod, error) {
+	nodeList, err := clientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing nodes: %w", err)
+	}
+	podList, err := clientSet.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+
+	var nodes []*simulatedNode
+	nodesByName := map[string]*simulatedNode{}
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if drainingNodes.Has(node.Name) || node.Spec.Unschedulable || !isNodeReady(node) {
+			continue
+		}
+		n := &simulatedNode{node: node, requested: corev1.ResourceList{}}
+		nodes = append(nodes, n)
+		nodesByName[node.Name] = n
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].node.Name < nodes[j].node.Name
+	})
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if n, ok := nodesByName[pod.Spec.NodeName]; ok && !isPodTerminated(pod) {
+			n.add(pod)
+		}
+	}
+
+	// place the largest pods first, as a scheduler would struggle most to find room for them
+	var toPlace []*corev1.Pod
+	for i := range pods {
+		// pods without a controller are not recreated once evicted
+		if metav1.GetControllerOf(&pods[i]) != nil {
+			toPlace = append(toPlace, &pods[i])
+		}
+	}
+	sort.SliceStable(toPlace, func(i, j int) bool {
+		ri, rj := podRequests(toPlace[i]), podRequests(toPlace[j])
+		if c := ri.Cpu().Cmp(*rj.Cpu()); c != 0 {
+			return c > 0
+		}
+		return ri.Memory().Cmp(*rj.Memory()) > 0
+	})
+
+	var unschedulable []UnschedulablePod
+	for _, pod := range toPlace {
+		reasons := map[string]int{}
+		placed := false
+		for _, n := range nodes {
+			if reason := n.fits(pod, nodes); reason != "" {
+				reasons[reason]++
+				continue
+			}
+			n.add(pod)
+			placed = true
+			break
+		}
+		if !placed {
+			unschedulable = append(unschedulable, UnschedulablePod{
+				Pod:    *pod,
+				Reason: describeReasons(len(nodes), reasons),
+			})
+		}
+	}
+	return unschedulable, nil
+}
+
+type simulatedNode struct {
+	node      *corev1.Node
+	pods      []*corev1.Pod
+	requested corev1.ResourceList
+}
+
+func (n *simulatedNode) add(pod *corev1.Pod) {
+	n.pods = append(n.pods, pod)
+	for name, quantity := range podRequests(pod) {
+		total := n.requested[name]
+		total.Add(quantity)
+		n.requested[name] = total
+	}
+}
+
+// fits returns why pod cannot run on the node, or an empty string if it can.
+func (n *simulatedNode) fits(pod *corev1.Pod, nodes []*simulatedNode) string {
+	if !toleratesTaints(pod, n.node) {
+		return "node(s) had untolerated taint"
+	}
+	if !matchesNodeSelectorAndAffinity(pod, n.node) {
+		return "node(s) didn't match Pod's node affinity/selector"
+	}
+	if allowed, ok := n.node.Status.Allocatable[corev1.ResourcePods]; ok && int64(len(n.pods)+1) > allowed.Value() {
+		return "Too many pods"
+	}
+	for _, name := range sortedResourceNames(podRequests(pod)) {
+		request := podRequests(pod)[name]
+		if request.IsZero() {
+			continue
+		}
+		free := n.node.Status.Allocatable[name]
+		free.Sub(n.requested[name])
+		if free.Cmp(request) < 0 {
+			return fmt.Sprintf("Insufficient %s", name)
+		}
+	}
+	if !satisfiesTopologySpread(pod, n, nodes) {
+		return "node(s) didn't match pod topology spread constraints"
+	}
+	return ""
+}
+
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		for name, quantity := range c.Resources.Requests {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+	// init containers run one at a time before the other containers
+	for _, c := range pod.Spec.InitContainers {
+		for name, quantity := range c.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	for name, quantity := range pod.Spec.Overhead {
+		total := requests[name]
+		total.Add(quantity)
+		requests[name] = total
+	}
+	if _, ok := requests[corev1.ResourceCPU]; !ok {
+		requests[corev1.ResourceCPU] = resource.Quantity{}
+	}
+	if _, ok := requests[corev1.ResourceMemory]; !ok {
+		requests[corev1.ResourceMemory] = resource.Quantity{}
+	}
+	return requests
+}
+
+func sortedResourceNames(resources corev1.ResourceList) []corev1.ResourceName {
+	var names []corev1.ResourceName
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
+
+func toleratesTaints(pod *corev1.Pod, node *corev1.Node) bool {
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range pod.Spec.Tolerations {
+			if pod.Spec.Tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesNodeSelectorAndAffinity(pod *corev1.Pod, node *corev1.Node) bool {
+	if !labels.SelectorFromSet(pod.Spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	// node selector terms are ORed
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if matchesNodeSelectorTerm(term, node) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesNodeSelectorTerm(term corev1.NodeSelectorTerm, node *corev1.Node) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+	for _, expr := range term.MatchExpressions {
+		if !matchesNodeSelectorRequirement(expr, labels.Set(node.Labels)) {
+			return false
+		}
+	}
+	for _, expr := range term.MatchFields {
+		if expr.Key != metav1.ObjectNameField || !matchesNodeSelectorRequirement(expr, labels.Set{metav1.ObjectNameField: node.Name}) {
+			return false
+		}
+	}
+	return true
+}
+
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+func matchesNodeSelectorRequirement(expr corev1.NodeSelectorRequirement, set labels.Set) bool {
+	op, ok := nodeSelectorOperators[expr.Operator]
+	if !ok {
+		return false
+	}
+	requirement, err := labels.NewRequirement(expr.Key, op, expr.Values)
+	if err != nil {
+		return false
+	}
+	return requirement.Matches(set)
+}
+
+// satisfiesTopologySpread checks the DoNotSchedule topology spread constraints of pod, counting the pods already
+// running on the remaining nodes as well as the pods placed by the simulation.
+func satisfiesTopologySpread(pod *corev1.Pod, candidate *simulatedNode, nodes []*simulatedNode) bool {
+	for _, constraint := range pod.Spec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable != corev1.DoNotSchedule {
+			continue
+		}
+		domain, ok := candidate.node.Labels[constraint.TopologyKey]
+		if !ok {
+			return false
+		}
+		selector, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector)
+		if err != nil {
+			continue
+		}
+		counts := map[string]int{}
+		for _, n := range nodes {
+			d, ok := n.node.Labels[constraint.TopologyKey]
+			if !ok || !matchesNodeSelectorAndAffinity(pod, n.node) {
+				continue
+			}
+			if _, seen := counts[d]; !seen {
+				counts[d] = 0
+			}
+			for _, p := range n.pods {
+				if p.Namespace == pod.Namespace && selector.Matches(labels.Set(p.Labels)) {
+					counts[d]++
+				}
+			}
+		}
+		minCount := -1
+		for _, count := range counts {
+			if minCount == -1 || count < minCount {
+				minCount = count
+			}
+		}
+		if int32(counts[domain]+1-minCount) > constraint.MaxSkew {
+			return false
+		}
+	}
+	return true
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func isPodTerminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+func describeReasons(nodeCount int, reasons map[string]int) string {
+	if nodeCount == 0 {
+		return "no schedulable nodes remain"
+	}
+	var parts []string
+	for reason, count := range reasons {
+		parts = append(parts, fmt.Sprintf("%d %s", count, reason))
+	}
+	sort.Strings(parts)
+	return fmt.Sprintf("0/%d nodes are available: %s", nodeCount, strings.Join(parts, ", "))
+}
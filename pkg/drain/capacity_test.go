@@ -0,0 +1,188 @@
+package drain_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/weaveworks/eksctl/pkg/drain"
+)
+
+var _ = Describe("CheckCapacity", func() {
+	newNode := func(name, zone, cpu string, modify ...func(*corev1.Node)) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"topology.kubernetes.io/zone": zone},
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+					corev1.ResourcePods:   resource.MustParse("110"),
+				},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}
+		for _, m := range modify {
+			m(node)
+		}
+		return node
+	}
+
+	newPod := func(name, nodeName, cpu string, modify ...func(*corev1.Pod)) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{"app": "web"},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       "ReplicaSet",
+					Name:       "web",
+					Controller: ptr.To(true),
+				}},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		for _, m := range modify {
+			m(pod)
+		}
+		return pod
+	}
+
+	check := func(objects []runtime.Object, evicted ...*corev1.Pod) []string {
+		var pods []corev1.Pod
+		for _, pod := range evicted {
+			objects = append(objects, pod)
+			pods = append(pods, *pod)
+		}
+		clientSet := fake.NewSimpleClientset(objects...)
+		unschedulable, err := drain.CheckCapacity(context.Background(), clientSet, sets.New("draining"), pods)
+		Expect(err).NotTo(HaveOccurred())
+		var result []string
+		for _, p := range unschedulable {
+			result = append(result, p.String())
+		}
+		return result
+	}
+
+	It("fits pods on the remaining nodes", func() {
+		Expect(check([]runtime.Object{
+			newNode("draining", "a", "4"),
+			newNode("node-1", "a", "2"),
+			newNode("node-2", "b", "2"),
+			newPod("running", "node-1", "1"),
+		},
+			newPod("web-1", "draining", "1500m"),
+			newPod("web-2", "draining", "1"),
+		)).To(BeEmpty())
+	})
+
+	It("reports pods that do not fit", func() {
+		Expect(check([]runtime.Object{
+			newNode("draining", "a", "4"),
+			newNode("node-1", "a", "2"),
+			newNode("cordoned", "a", "8", func(n *corev1.Node) { n.Spec.Unschedulable = true }),
+			newPod("running", "node-1", "1"),
+		},
+			newPod("web-1", "draining", "1500m"),
+			newPod("web-2", "draining", "500m"),
+		)).To(Equal([]string{"default/web-1: 0/1 nodes are available: 1 Insufficient cpu"}))
+	})
+
+	It("ignores pods that are not recreated once evicted", func() {
+		Expect(check([]runtime.Object{
+			newNode("draining", "a", "4"),
+		},
+			newPod("standalone", "draining", "1", func(p *corev1.Pod) { p.OwnerReferences = nil }),
+		)).To(BeEmpty())
+	})
+
+	It("reports when no nodes remain", func() {
+		Expect(check([]runtime.Object{
+			newNode("draining", "a", "4"),
+		},
+			newPod("web-1", "draining", "1"),
+		)).To(Equal([]string{"default/web-1: no schedulable nodes remain"}))
+	})
+
+	It("takes taints and tolerations into account", func() {
+		taint := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}
+		objects := []runtime.Object{
+			newNode("draining", "a", "4"),
+			newNode("gpu", "a", "4", func(n *corev1.Node) { n.Spec.Taints = []corev1.Taint{taint} }),
+		}
+		Expect(check(objects, newPod("web-1", "draining", "1"))).To(Equal([]string{
+			"default/web-1: 0/1 nodes are available: 1 node(s) had untolerated taint",
+		}))
+		Expect(check(objects, newPod("web-1", "draining", "1", func(p *corev1.Pod) {
+			p.Spec.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu"}}
+		}))).To(BeEmpty())
+	})
+
+	It("takes node selectors and node affinity into account", func() {
+		objects := []runtime.Object{
+			newNode("draining", "a", "4"),
+			newNode("node-1", "a", "4"),
+			newNode("node-2", "b", "4"),
+		}
+		Expect(check(objects, newPod("web-1", "draining", "1", func(p *corev1.Pod) {
+			p.Spec.NodeSelector = map[string]string{"topology.kubernetes.io/zone": "c"}
+		}))).To(Equal([]string{
+			"default/web-1: 0/2 nodes are available: 2 node(s) didn't match Pod's node affinity/selector",
+		}))
+		Expect(check(objects, newPod("web-1", "draining", "1", func(p *corev1.Pod) {
+			p.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      "topology.kubernetes.io/zone",
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"b"},
+						}},
+					}},
+				},
+			}}
+		}))).To(BeEmpty())
+	})
+
+	It("takes topology spread constraints into account", func() {
+		spread := func(p *corev1.Pod) {
+			p.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.DoNotSchedule,
+				LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			}}
+		}
+		Expect(check([]runtime.Object{
+			newNode("draining", "a", "4"),
+			newNode("node-1", "a", "4"),
+			newNode("node-2", "b", "1"),
+			newPod("running", "node-2", "1", func(p *corev1.Pod) { p.Labels = map[string]string{"app": "other"} }),
+		},
+			newPod("web-1", "draining", "500m", spread),
+			newPod("web-2", "draining", "500m", spread),
+		)).To(Equal([]string{
+			"default/web-2: 0/2 nodes are available: 1 Insufficient cpu, 1 node(s) didn't match pod topology spread constraints",
+		}))
+	})
+})
@@ -1,5 +1,11 @@
 package drain
 
+import "time"
+
 func (n *NodeGroupDrainer) SetDrainer(drainer Evictor) {
 	n.evictor = drainer
 }
+
+func (s *BudgetScheduler) SetNow(now func() time.Time) {
+	s.now = now
+}
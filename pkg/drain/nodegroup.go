@@ -18,6 +18,7 @@ import (
 
 	"github.com/weaveworks/eksctl/pkg/drain/evictor"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/utils/events"
 )
 
 // this is our custom addition, it's not part of the package
@@ -42,6 +43,7 @@ type NodeGroupDrainer struct {
 	podEvictionWaitPeriod time.Duration
 	undo                  bool
 	parallel              int
+	budgets               *BudgetScheduler
 }
 
 func NewNodeGroupDrainer(clientSet kubernetes.Interface, ng eks.KubeNodeGroup, maxGracePeriod, nodeDrainWaitPeriod time.Duration, podEvictionWaitPeriod time.Duration, undo, disableEviction bool, parallel int) NodeGroupDrainer {
@@ -82,6 +84,36 @@ func NewNodeGroupDrainer(clientSet kubernetes.Interface, ng eks.KubeNodeGroup, m
 	}
 }
 
+// UseBudgetScheduler makes the drainer order and throttle the nodes it drains using the PodDisruptionBudgets
+// known to budgets, which may be shared by several drainers. Nodes then start draining in the order returned by
+// BudgetScheduler.Order.
+func (n *NodeGroupDrainer) UseBudgetScheduler(budgets *BudgetScheduler) {
+	n.budgets = budgets
+}
+
+// PodsForEviction returns the nodes of the nodegroup and the pods that draining them would evict.
+func (n *NodeGroupDrainer) PodsForEviction(ctx context.Context) ([]string, []corev1.Pod, error) {
+	nodes, err := n.clientSet.CoreV1().Nodes().List(ctx, n.ng.ListOptions())
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		nodeNames []string
+		pods      []corev1.Pod
+	)
+	for _, node := range nodes.Items {
+		nodeNames = append(nodeNames, node.Name)
+		list, errs := n.evictor.GetPodsForEviction(node.Name)
+		if len(errs) > 0 {
+			return nil, nil, fmt.Errorf("errs: %v", errs)
+		}
+		if list != nil {
+			pods = append(pods, list.Pods()...)
+		}
+	}
+	return nodeNames, pods, nil
+}
+
 // Drain drains a nodegroup
 func (n *NodeGroupDrainer) Drain(ctx context.Context, sem *semaphore.Weighted) error {
 	if err := n.evictor.CanUseEvictions(); err != nil {
@@ -133,45 +165,100 @@ func (n *NodeGroupDrainer) Drain(ctx context.Context, sem *semaphore.Weighted) e
 
 			if newPendingNodes.Len() == 0 {
 				logger.Success("drained all nodes: %v", mapToList(drainedNodes.Items()))
+				n.emitDrainEvent(n.ng.NameString(), "nodegroup", "drained", nil)
 				return nil // no new nodes were seen
 			}
 
 			logger.Debug("already drained: %v", mapToList(drainedNodes.Items()))
 			logger.Debug("will drain: %v", sets.List(newPendingNodes))
 
-			g, ctx := errgroup.WithContext(ctx)
-			for _, node := range sets.List(newPendingNodes) {
-				node := node
-				g.Go(func() error {
-					if err := sem.Acquire(ctx, 1); err != nil {
-						return fmt.Errorf("failed to acquire semaphore: %w", err)
+			pendingNodes := sets.List(newPendingNodes)
+			var podsByNode map[string][]corev1.Pod
+			if n.budgets != nil {
+				podsByNode = map[string][]corev1.Pod{}
+				for _, node := range pendingNodes {
+					list, errs := n.evictor.GetPodsForEviction(node)
+					if len(errs) > 0 {
+						return fmt.Errorf("errs: %v", errs)
 					}
-					defer sem.Release(1)
-
-					drainedNodes.Set(node, nil)
-					logger.Debug("starting drain of node %s", node)
-					if err := n.evictPods(ctx, node); err != nil {
-						logger.Warning("pod eviction error (%q) on node %s", err, node)
-						time.Sleep(retryDelay)
-						return err
+					if list != nil {
+						podsByNode[node] = list.Pods()
 					}
+				}
+				pendingNodes = n.budgets.Order(podsByNode)
+				logger.Debug("drain order based on PodDisruptionBudgets: %v", pendingNodes)
+			}
 
-					drainedNodes.Set(node, nil)
+			g, ctx := errgroup.WithContext(ctx)
+			drainNode := func(node string) error {
+				drainedNodes.Set(node, nil)
+				logger.Debug("starting drain of node %s", node)
+				n.emitDrainEvent(node, "node", "draining", nil)
+				if err := n.evictPods(ctx, node); err != nil {
+					logger.Warning("pod eviction error (%q) on node %s", err, node)
+					n.emitDrainEvent(node, "node", "failed", err)
+					time.Sleep(retryDelay)
+					return err
+				}
 
-					if n.nodeDrainWaitPeriod > 0 {
-						logger.Debug("waiting for %.0f seconds before draining next node", n.nodeDrainWaitPeriod.Seconds())
-						time.Sleep(n.nodeDrainWaitPeriod)
-					}
-					return nil
+				drainedNodes.Set(node, nil)
+				n.emitDrainEvent(node, "node", "drained", nil)
+
+				if n.nodeDrainWaitPeriod > 0 {
+					logger.Debug("waiting for %.0f seconds before draining next node", n.nodeDrainWaitPeriod.Seconds())
+					time.Sleep(n.nodeDrainWaitPeriod)
+				}
+				return nil
+			}
+			var admitErr error
+			for _, node := range pendingNodes {
+				node := node
+				if n.budgets == nil {
+					g.Go(func() error {
+						if err := sem.Acquire(ctx, 1); err != nil {
+							return fmt.Errorf("failed to acquire semaphore: %w", err)
+						}
+						defer sem.Release(1)
+						return drainNode(node)
+					})
+					continue
+				}
+				// nodes are admitted one at a time in the order of the budget scheduler, so that a node never
+				// starts draining before the nodes ordered ahead of it
+				if admitErr = n.budgets.Acquire(ctx, node, podsByNode[node]); admitErr != nil {
+					break
+				}
+				if err := sem.Acquire(ctx, 1); err != nil {
+					n.budgets.Release(node)
+					admitErr = fmt.Errorf("failed to acquire semaphore: %w", err)
+					break
+				}
+				g.Go(func() error {
+					defer n.budgets.Release(node)
+					defer sem.Release(1)
+					return drainNode(node)
 				})
 			}
 			// We need to loop even if this is an error to check whether the error was a
 			// context timeout or something else.  This lets us log timout errors consistently
-			evictErr = g.Wait()
+			if evictErr = g.Wait(); evictErr == nil {
+				evictErr = admitErr
+			}
 		}
 	}
 }
 
+func (n *NodeGroupDrainer) emitDrainEvent(resourceID, kind, status string, err error) {
+	events.Emit(events.Event{
+		Type:       events.DrainProgress,
+		ResourceID: resourceID,
+		Kind:       kind,
+		Status:     status,
+		Message:    fmt.Sprintf("nodegroup %q", n.ng.NameString()),
+		Error:      events.ErrorCause(err),
+	})
+}
+
 func mapToList(m map[string]interface{}) []string {
 	var list []string
 	for key := range m {
@@ -233,6 +320,14 @@ func (n *NodeGroupDrainer) evictPods(ctx context.Context, node string) error {
 					}
 					logger.Debug("recoverable pod eviction failure: %q", err)
 					failedEvictions = true
+					// PodDisruptionBudgets refuse evictions with 429 Too Many Requests
+					if n.budgets != nil && apierrors.IsTooManyRequests(err) {
+						if err := n.budgets.EvictionBlocked(ctx, pod); err != nil {
+							return err
+						}
+					}
+				} else if n.budgets != nil {
+					n.budgets.EvictionSucceeded(pod)
 				}
 			}
 			if failedEvictions {
@@ -84,6 +84,11 @@ func (p ProviderServices) CloudFormationDisableRollback() bool {
 	return p.spec.CloudFormationDisableRollback
 }
 
+// CloudFormationChangeSetPreview returns whether stack updates should be previewed and confirmed before they are executed
+func (p ProviderServices) CloudFormationChangeSetPreview() bool {
+	return p.spec.CloudFormationChangeSetPreview
+}
+
 // ASG returns a representation of the AutoScaling API
 func (p ProviderServices) ASG() awsapi.ASG { return p.asg }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/utils/apierrors"
 	"github.com/weaveworks/eksctl/pkg/utils/retry"
 	"github.com/weaveworks/eksctl/pkg/utils/strings"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 )
@@ -35,6 +36,10 @@ type fargateProfilesTask struct {
 
 func (t *fargateProfilesTask) Describe() string { return t.info }
 
+func (t *fargateProfilesTask) ResourceInfo() tasks.ResourceInfo {
+	return tasks.ResourceInfo{Kind: tasks.KindFargateProfile}
+}
+
 func (t *fargateProfilesTask) Do(errCh chan error) error {
 	defer close(errCh)
 	if err := DoCreateFargateProfiles(t.ctx, t.spec, t.manager); err != nil {
@@ -21,6 +21,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/outposts"
 	"github.com/weaveworks/eksctl/pkg/ssh"
 	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+	"github.com/weaveworks/eksctl/pkg/vpc"
 )
 
 // MaxInstanceTypes is the maximum number of instance types you can specify in
@@ -55,6 +56,15 @@ const defaultCPUArch = "x86_64"
 
 // Normalize normalizes nodegroups.
 func (n *NodeGroupService) Normalize(ctx context.Context, nodePools []api.NodePool, clusterConfig *api.ClusterConfig) error {
+	// max pods cannot be set for managed nodegroups using a launch template or a custom AMI
+	var maxPodsNodePools []api.NodePool
+	for _, np := range nodePools {
+		if ng, ok := np.(*api.ManagedNodeGroup); ok && (ng.LaunchTemplate != nil || ng.AMI != "") {
+			continue
+		}
+		maxPodsNodePools = append(maxPodsNodePools, np)
+	}
+
 	for _, np := range nodePools {
 		switch ng := np.(type) {
 		case *api.ManagedNodeGroup:
@@ -114,7 +124,7 @@ func (n *NodeGroupService) Normalize(ctx context.Context, nodePools []api.NodePo
 			ng.SSH.PublicKeyName = &publicKeyName
 		}
 	}
-	return nil
+	return vpc.SetCustomNetworkingMaxPods(ctx, n.provider.EC2(), clusterConfig, maxPodsNodePools)
 }
 
 // ExpandInstanceSelectorOptions sets instance types to instances matched by the instance selector criteria.
@@ -24,6 +24,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
 	instanceutils "github.com/weaveworks/eksctl/pkg/utils/instance"
 	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+	"github.com/weaveworks/eksctl/pkg/vpc"
 	"github.com/weaveworks/eksctl/pkg/windows"
 )
 
@@ -107,6 +108,34 @@ func (v *VPCControllerTask) Do(errCh chan error) error {
 	return nil
 }
 
+// ENIConfigTask is a task for creating the ENIConfig resources for the pod subnets in vpc.podSubnets.
+type ENIConfigTask struct {
+	Context         context.Context
+	Info            string
+	ClusterProvider *ClusterProvider
+	ClusterConfig   *api.ClusterConfig
+}
+
+// Describe implements Task
+func (e *ENIConfigTask) Describe() string { return e.Info }
+
+// Do implements Task
+func (e *ENIConfigTask) Do(errCh chan error) error {
+	defer close(errCh)
+	manifest, err := vpc.MakeENIConfigs(e.ClusterConfig)
+	if err != nil {
+		return err
+	}
+	newClient := func() (vpc.ResourceClient, error) {
+		rawClient, err := e.ClusterProvider.NewRawClient(e.ClusterConfig)
+		if err != nil {
+			return nil, err
+		}
+		return rawClient, nil
+	}
+	return vpc.ApplyENIConfigs(e.Context, newClient, manifest, e.ClusterProvider.AWSProvider.WaitTimeout())
+}
+
 type devicePluginTask struct {
 	kind            string
 	clusterProvider *ClusterProvider
@@ -203,6 +232,14 @@ func (c *ClusterProvider) CreateExtraClusterConfigTasks(ctx context.Context, cfg
 			return c.RefreshClusterStatus(ctx, cfg)
 		},
 	})
+	if cfg.VPC != nil && cfg.VPC.PodSubnets != nil {
+		newTasks.Append(&ENIConfigTask{
+			Context:         ctx,
+			Info:            "create ENIConfigs for pod subnets",
+			ClusterProvider: c,
+			ClusterConfig:   cfg,
+		})
+	}
 	if cfg.IsAutoModeEnabled() && cfg.VPC != nil && cfg.VPC.ID != "" {
 		logger.Info("subnets supplied in subnets.private and subnets.public will be used for nodes launched by Auto Mode; please create a new NodeClass " +
 			"resource if you do not want to use cluster subnets")
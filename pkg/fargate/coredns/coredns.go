@@ -26,7 +26,12 @@ const (
 	// ComputeTypeAnnotationKey is the key of the annotation driving CoreDNS'
 	// scheduling.
 	ComputeTypeAnnotationKey = "eks.amazonaws.com/compute-type"
-	computeTypeFargate       = "fargate"
+	// ComputeTypeFargate is the compute type of pods scheduled onto Fargate.
+	ComputeTypeFargate = "fargate"
+	// ComputeTypeEC2 is the compute type of pods scheduled onto EC2 nodes.
+	ComputeTypeEC2 = "ec2"
+	// FargateNodeNamePrefix is the prefix of the names of the nodes Fargate pods run on.
+	FargateNodeNamePrefix = "fargate-"
 )
 
 // IsSchedulableOnFargate analyzes the provided profiles to determine whether
@@ -48,18 +53,27 @@ func selectsCoreDNS(selector api.FargateProfileSelector) bool {
 
 // IsScheduledOnFargate checks if EKS' coredns is scheduled onto Fargate.
 func IsScheduledOnFargate(clientSet kubeclient.Interface) (bool, error) {
-	isDepOnFargate, err := isDeploymentScheduledOnFargate(clientSet)
+	return isScheduledOn(clientSet, ComputeTypeFargate)
+}
+
+// IsScheduledOnEC2 checks if EKS' coredns is scheduled onto EC2 nodes.
+func IsScheduledOnEC2(clientSet kubeclient.Interface) (bool, error) {
+	return isScheduledOn(clientSet, ComputeTypeEC2)
+}
+
+func isScheduledOn(clientSet kubeclient.Interface, computeType string) (bool, error) {
+	isDepScheduled, err := isDeploymentScheduledOn(clientSet, computeType)
 	if err != nil {
 		return false, err
 	}
-	arePodsOnFargate, err := arePodsScheduledOnFargate(clientSet)
+	arePodsScheduled, err := arePodsScheduledOn(clientSet, computeType)
 	if err != nil {
 		return false, err
 	}
-	return isDepOnFargate && arePodsOnFargate, nil
+	return isDepScheduled && arePodsScheduled, nil
 }
 
-func isDeploymentScheduledOnFargate(clientSet kubeclient.Interface) (bool, error) {
+func isDeploymentScheduledOn(clientSet kubeclient.Interface, computeType string) (bool, error) {
 	coredns, err := clientSet.AppsV1().Deployments(Namespace).Get(context.TODO(), Name, metav1.GetOptions{})
 	if err != nil {
 		return false, err
@@ -67,18 +81,18 @@ func isDeploymentScheduledOnFargate(clientSet kubeclient.Interface) (bool, error
 	if coredns.Spec.Replicas == nil {
 		return false, errors.New("nil spec.replicas in coredns deployment")
 	}
-	computeType, exists := safeGetAnnotationValue(coredns.Spec.Template.Annotations, ComputeTypeAnnotationKey)
-	logger.Debug("deployment %q with compute type %q currently has %v/%v replicas running", Name, computeType, coredns.Status.ReadyReplicas, *coredns.Spec.Replicas)
+	currentComputeType, exists := safeGetAnnotationValue(coredns.Spec.Template.Annotations, ComputeTypeAnnotationKey)
+	logger.Debug("deployment %q with compute type %q currently has %v/%v replicas running", Name, currentComputeType, coredns.Status.ReadyReplicas, *coredns.Spec.Replicas)
 	scheduled := exists &&
-		computeType == computeTypeFargate &&
+		currentComputeType == computeType &&
 		*coredns.Spec.Replicas == coredns.Status.ReadyReplicas
 	if scheduled {
-		logger.Info("%q is now scheduled onto Fargate", Name)
+		logger.Info("%q is now scheduled onto %s", Name, computeTypeName(computeType))
 	}
 	return scheduled, nil
 }
 
-func arePodsScheduledOnFargate(clientSet kubeclient.Interface) (bool, error) {
+func arePodsScheduledOn(clientSet kubeclient.Interface, computeType string) (bool, error) {
 	pods, err := clientSet.CoreV1().Pods(Namespace).List(context.TODO(), metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("eks.amazonaws.com/component = %s", Name),
 	})
@@ -86,40 +100,63 @@ func arePodsScheduledOnFargate(clientSet kubeclient.Interface) (bool, error) {
 		return false, err
 	}
 	for _, pod := range pods.Items {
-		if !isRunningOnFargate(&pod) {
+		if !isRunningOn(&pod, computeType) {
 			return false, nil
 		}
 	}
-	logger.Info("%q pods are now scheduled onto Fargate", Name)
+	logger.Info("%q pods are now scheduled onto %s", Name, computeTypeName(computeType))
 	return true, nil
 }
 
-func isRunningOnFargate(pod *v1.Pod) bool {
-	computeType, exists := safeGetAnnotationValue(pod.Annotations, ComputeTypeAnnotationKey)
-	logger.Debug("pod %q with compute type %q and status %q is scheduled on %q", pod.Name, computeType, pod.Status.Phase, pod.Spec.NodeName)
+func isRunningOn(pod *v1.Pod, computeType string) bool {
+	currentComputeType, exists := safeGetAnnotationValue(pod.Annotations, ComputeTypeAnnotationKey)
+	logger.Debug("pod %q with compute type %q and status %q is scheduled on %q", pod.Name, currentComputeType, pod.Status.Phase, pod.Spec.NodeName)
 	return exists &&
-		computeType == computeTypeFargate &&
+		currentComputeType == computeType &&
 		pod.Status.Phase == v1.PodRunning &&
-		strings.HasPrefix(pod.Spec.NodeName, "fargate-")
+		IsFargateNode(pod.Spec.NodeName) == (computeType == ComputeTypeFargate)
+}
+
+// IsFargateNode reports whether nodeName is the name of a Fargate node.
+func IsFargateNode(nodeName string) bool {
+	return strings.HasPrefix(nodeName, FargateNodeNamePrefix)
+}
+
+func computeTypeName(computeType string) string {
+	if computeType == ComputeTypeFargate {
+		return "Fargate"
+	}
+	return "EC2 nodes"
 }
 
 // ScheduleOnFargate modifies EKS' coredns deployment so that it can be scheduled
 // on Fargate.
 func ScheduleOnFargate(clientSet kubeclient.Interface) error {
-	if err := scheduleOnFargate(clientSet); err != nil {
+	if err := scheduleOn(clientSet, ComputeTypeFargate); err != nil {
 		return fmt.Errorf("failed to make %q deployment schedulable on Fargate: %w", Name, err)
 	}
 	logger.Info("%q is now schedulable onto Fargate", Name)
 	return nil
 }
 
-func scheduleOnFargate(clientSet kubeclient.Interface) error {
+// ScheduleOnEC2 reverts ScheduleOnFargate: it modifies EKS' coredns deployment
+// so that it is scheduled on EC2 nodes, e.g. once nodegroups have been added to
+// a Fargate-only cluster.
+func ScheduleOnEC2(clientSet kubeclient.Interface) error {
+	if err := scheduleOn(clientSet, ComputeTypeEC2); err != nil {
+		return fmt.Errorf("failed to make %q deployment schedulable on EC2 nodes: %w", Name, err)
+	}
+	logger.Info("%q is now schedulable onto EC2 nodes", Name)
+	return nil
+}
+
+func scheduleOn(clientSet kubeclient.Interface, computeType string) error {
 	deployments := clientSet.AppsV1().Deployments(Namespace)
 	coredns, err := deployments.Get(context.TODO(), Name, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
-	coredns.Spec.Template.Annotations = safeSetAnnotation(coredns.Spec.Template.Annotations, ComputeTypeAnnotationKey, computeTypeFargate)
+	coredns.Spec.Template.Annotations = safeSetAnnotation(coredns.Spec.Template.Annotations, ComputeTypeAnnotationKey, computeType)
 	bytes, err := runtime.Encode(unstructured.UnstructuredJSONScheme, coredns)
 	if err != nil {
 		return fmt.Errorf("failed to marshal %q deployment: %w", Name, err)
@@ -133,7 +170,7 @@ func scheduleOnFargate(clientSet kubeclient.Interface) error {
 	if !exists {
 		return fmt.Errorf("could not find annotation %q on patched deployment %q: patching must have failed", ComputeTypeAnnotationKey, Name)
 	}
-	if value != computeTypeFargate {
+	if value != computeType {
 		return fmt.Errorf("unexpected value %q for annotation %q on %q patched deployment", value, ComputeTypeAnnotationKey, Name)
 	}
 	return nil
@@ -143,10 +180,21 @@ func scheduleOnFargate(clientSet kubeclient.Interface) error {
 // It will wait until it has detected that the scheduling has been successful,
 // or until the retry policy times out, whichever happens first.
 func WaitForScheduleOnFargate(clientSet kubeclient.Interface, retryPolicy retry.Policy) error {
+	return waitForScheduleOn(clientSet, retryPolicy, ComputeTypeFargate)
+}
+
+// WaitForScheduleOnEC2 waits for coredns to be scheduled on EC2 nodes.
+// It will wait until it has detected that the scheduling has been successful,
+// or until the retry policy times out, whichever happens first.
+func WaitForScheduleOnEC2(clientSet kubeclient.Interface, retryPolicy retry.Policy) error {
+	return waitForScheduleOn(clientSet, retryPolicy, ComputeTypeEC2)
+}
+
+func waitForScheduleOn(clientSet kubeclient.Interface, retryPolicy retry.Policy, computeType string) error {
 	// Clone the retry policy to ensure this method is re-entrant/thread-safe:
 	retryPolicy = retryPolicy.Clone()
 	for !retryPolicy.Done() {
-		isScheduled, err := IsScheduledOnFargate(clientSet)
+		isScheduled, err := isScheduledOn(clientSet, computeType)
 		if err != nil {
 			return err
 		}
@@ -155,7 +203,7 @@ func WaitForScheduleOnFargate(clientSet kubeclient.Interface, retryPolicy retry.
 		}
 		time.Sleep(retryPolicy.Duration())
 	}
-	return fmt.Errorf("timed out while waiting for %q to be scheduled on Fargate", Name)
+	return fmt.Errorf("timed out while waiting for %q to be scheduled on %s", Name, computeTypeName(computeType))
 }
 
 // safeGetAnnotationValue safely gets the value of an annotation from a map. It
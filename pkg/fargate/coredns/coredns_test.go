@@ -135,6 +135,49 @@ var _ = Describe("coredns", func() {
 		})
 	})
 
+	Describe("ScheduleOnEC2", func() {
+		It("should set the compute-type annotation to 'ec2'", func() {
+			// Given:
+			mockClientset := mockClientsetWith(deployment("fargate", 2, 2))
+			// When:
+			err := coredns.ScheduleOnEC2(mockClientset)
+			Expect(err).To(Not(HaveOccurred()))
+			// Then:
+			deployment, err := mockClientset.AppsV1().Deployments(coredns.Namespace).Get(context.Background(), coredns.Name, metav1.GetOptions{})
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(deployment.Spec.Template.Annotations).To(HaveKeyWithValue(coredns.ComputeTypeAnnotationKey, "ec2"))
+		})
+	})
+
+	Describe("WaitForScheduleOnEC2", func() {
+		It("should wait for coredns to be scheduled on EC2 nodes and return w/o any error", func() {
+			// Given:
+			mockClientset := mockClientsetWith(
+				deployment("ec2", 2, 2), pod("ec2", v1.PodRunning), pod("ec2", v1.PodRunning),
+			)
+			// When:
+			err := coredns.WaitForScheduleOnEC2(mockClientset, retryPolicy)
+			// Then:
+			Expect(err).To(Not(HaveOccurred()))
+		})
+
+		It("should time out if coredns pods are still running on Fargate", func() {
+			failureCases := [][]runtime.Object{
+				{deployment("fargate", 2, 2), pod("fargate", v1.PodRunning), pod("fargate", v1.PodRunning)},
+				{deployment("ec2", 1, 2), pod("ec2", v1.PodRunning), pod("fargate", v1.PodRunning)},
+				{deployment("ec2", 0, 2), pod("ec2", v1.PodPending), pod("ec2", v1.PodPending)},
+			}
+			for _, failureCase := range failureCases {
+				// Given:
+				mockClientset := mockClientsetWith(failureCase...)
+				// When:
+				err := coredns.WaitForScheduleOnEC2(mockClientset, retryPolicy)
+				// Then:
+				Expect(err).To(MatchError("timed out while waiting for \"coredns\" to be scheduled on EC2 nodes"))
+			}
+		})
+	})
+
 	Describe("WaitForScheduleOnFargate", func() {
 		It("should error if the annotations are not set", func() {
 			// Given:
@@ -77,9 +77,7 @@ func NewOpenIDConnectManager(iamapi awsapi.IAM, accountID, issuer, partition str
 // if it was unable to call IAM API
 func (m *OpenIDConnectManager) CheckProviderExists(ctx context.Context) (bool, error) {
 	input := &iam.GetOpenIDConnectProviderInput{
-		OpenIDConnectProviderArn: aws.String(
-			fmt.Sprintf("arn:%s:iam::%s:oidc-provider/%s", m.partition, m.accountID, m.hostnameAndPath()),
-		),
+		OpenIDConnectProviderArn: aws.String(m.providerARN()),
 	}
 	_, err := m.iam.GetOpenIDConnectProvider(ctx, input)
 	if err != nil {
@@ -143,6 +141,17 @@ func (m *OpenIDConnectManager) DeleteProvider(ctx context.Context) error {
 // getIssuerCAThumbprint obtains thumbprint of root CA by connecting to the
 // OIDC issuer and parsing certificates
 func (m *OpenIDConnectManager) getIssuerCAThumbprint() error {
+	thumbprints, err := m.getIssuerThumbprints()
+	if err != nil {
+		return err
+	}
+	m.issuerCAThumbprint = thumbprints[len(thumbprints)-1]
+	return nil
+}
+
+// getIssuerThumbprints returns the thumbprints of the certificate chain of the OIDC issuer,
+// the last one being the thumbprint of the root CA
+func (m *OpenIDConnectManager) getIssuerThumbprints() ([]string, error) {
 	client := &http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{
@@ -155,17 +164,17 @@ func (m *OpenIDConnectManager) getIssuerCAThumbprint() error {
 
 	response, err := client.Get(m.issuerURL.String())
 	if err != nil {
-		return fmt.Errorf("connecting to issuer OIDC: %w", err)
+		return nil, fmt.Errorf("connecting to issuer OIDC: %w", err)
 	}
 	defer response.Body.Close()
-	if response.TLS != nil {
-		if numCerts := len(response.TLS.PeerCertificates); numCerts >= 1 {
-			root := response.TLS.PeerCertificates[numCerts-1]
-			m.issuerCAThumbprint = fmt.Sprintf("%x", sha1.Sum(root.Raw))
-			return nil
+	if response.TLS != nil && len(response.TLS.PeerCertificates) > 0 {
+		var thumbprints []string
+		for _, cert := range response.TLS.PeerCertificates {
+			thumbprints = append(thumbprints, fmt.Sprintf("%x", sha1.Sum(cert.Raw)))
 		}
+		return thumbprints, nil
 	}
-	return fmt.Errorf("unable to get OIDC issuer's certificate")
+	return nil, fmt.Errorf("unable to get OIDC issuer's certificate")
 }
 
 // MakeAssumeRolePolicyDocumentWithServiceAccountConditions constructs a trust policy document for the given
@@ -0,0 +1,133 @@
+package iamoidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+// maxThumbprints is the maximum number of thumbprints IAM allows for an OIDC provider.
+const maxThumbprints = 5
+
+// ProviderCheck is the result of comparing the IAM OIDC provider of a cluster with its OIDC issuer.
+type ProviderCheck struct {
+	ProviderARN string            `json:"providerARN"`
+	ClientIDs   []string          `json:"clientIDs"`
+	Thumbprints []string          `json:"thumbprints"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	// IssuerThumbprint is the thumbprint of the root CA of the issuer's current certificate chain.
+	IssuerThumbprint string `json:"issuerThumbprint"`
+
+	// MissingThumbprint is set to IssuerThumbprint when it is not registered with the provider.
+	MissingThumbprint string `json:"missingThumbprint,omitempty"`
+	// StaleThumbprints are the registered thumbprints that match no certificate in the issuer's chain.
+	StaleThumbprints []string `json:"staleThumbprints,omitempty"`
+	// MissingClientIDs are the client IDs required by IAM roles for service accounts that are not registered.
+	MissingClientIDs []string `json:"missingClientIDs,omitempty"`
+	// MissingTags are the tags eksctl sets on the provider that it does not have.
+	MissingTags []string `json:"missingTags,omitempty"`
+}
+
+// Healthy reports whether the provider matches the issuer.
+func (c *ProviderCheck) Healthy() bool {
+	return !c.NeedsFix() && len(c.MissingTags) == 0
+}
+
+// NeedsFix reports whether the thumbprints or client IDs of the provider need to be updated.
+func (c *ProviderCheck) NeedsFix() bool {
+	return c.MissingThumbprint != "" || len(c.StaleThumbprints) > 0 || len(c.MissingClientIDs) > 0
+}
+
+// CheckProvider compares the IAM OIDC provider with the current certificate chain of the issuer.
+func (m *OpenIDConnectManager) CheckProvider(ctx context.Context) (*ProviderCheck, error) {
+	issuerThumbprints, err := m.getIssuerThumbprints()
+	if err != nil {
+		return nil, err
+	}
+	return m.checkProvider(ctx, issuerThumbprints)
+}
+
+func (m *OpenIDConnectManager) checkProvider(ctx context.Context, issuerThumbprints []string) (*ProviderCheck, error) {
+	providerARN := m.providerARN()
+	output, err := m.iam.GetOpenIDConnectProvider(ctx, &iam.GetOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(providerARN),
+	})
+	if err != nil {
+		var oe *iamtypes.NoSuchEntityException
+		if errors.As(err, &oe) {
+			return nil, fmt.Errorf("IAM OIDC provider %q does not exist", providerARN)
+		}
+		return nil, fmt.Errorf("getting IAM OIDC provider: %w", err)
+	}
+	m.ProviderARN = providerARN
+
+	check := &ProviderCheck{
+		ProviderARN:      providerARN,
+		ClientIDs:        output.ClientIDList,
+		Thumbprints:      output.ThumbprintList,
+		IssuerThumbprint: issuerThumbprints[len(issuerThumbprints)-1],
+	}
+	for _, tag := range output.Tags {
+		if check.Tags == nil {
+			check.Tags = map[string]string{}
+		}
+		check.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	if !slices.Contains(output.ThumbprintList, check.IssuerThumbprint) {
+		check.MissingThumbprint = check.IssuerThumbprint
+	}
+	for _, thumbprint := range output.ThumbprintList {
+		if !slices.Contains(issuerThumbprints, thumbprint) {
+			check.StaleThumbprints = append(check.StaleThumbprints, thumbprint)
+		}
+	}
+	if !slices.Contains(output.ClientIDList, m.audience) {
+		check.MissingClientIDs = append(check.MissingClientIDs, m.audience)
+	}
+	for key := range m.tags {
+		if _, ok := check.Tags[key]; !ok {
+			check.MissingTags = append(check.MissingTags, key)
+		}
+	}
+	sort.Strings(check.MissingTags)
+	return check, nil
+}
+
+// FixProvider replaces the stale thumbprints of the provider with the thumbprint of the issuer's root CA,
+// and adds the missing client IDs.
+func (m *OpenIDConnectManager) FixProvider(ctx context.Context, check *ProviderCheck) error {
+	if check.MissingThumbprint != "" || len(check.StaleThumbprints) > 0 {
+		thumbprints := []string{check.IssuerThumbprint}
+		for _, thumbprint := range check.Thumbprints {
+			if thumbprint != check.IssuerThumbprint && !slices.Contains(check.StaleThumbprints, thumbprint) && len(thumbprints) < maxThumbprints {
+				thumbprints = append(thumbprints, thumbprint)
+			}
+		}
+		if _, err := m.iam.UpdateOpenIDConnectProviderThumbprint(ctx, &iam.UpdateOpenIDConnectProviderThumbprintInput{
+			OpenIDConnectProviderArn: aws.String(check.ProviderARN),
+			ThumbprintList:           thumbprints,
+		}); err != nil {
+			return fmt.Errorf("updating thumbprints of IAM OIDC provider: %w", err)
+		}
+	}
+	for _, clientID := range check.MissingClientIDs {
+		if _, err := m.iam.AddClientIDToOpenIDConnectProvider(ctx, &iam.AddClientIDToOpenIDConnectProviderInput{
+			OpenIDConnectProviderArn: aws.String(check.ProviderARN),
+			ClientID:                 aws.String(clientID),
+		}); err != nil {
+			return fmt.Errorf("adding client ID %q to IAM OIDC provider: %w", clientID, err)
+		}
+	}
+	return nil
+}
+
+func (m *OpenIDConnectManager) providerARN() string {
+	return fmt.Sprintf("arn:%s:iam::%s:oidc-provider/%s", m.partition, m.accountID, m.hostnameAndPath())
+}
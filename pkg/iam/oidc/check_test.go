@@ -0,0 +1,120 @@
+package iamoidc
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("OIDC provider check", func() {
+	const (
+		providerARN = "arn:aws:iam::12345:oidc-provider/oidc.eks.us-west-2.amazonaws.com/id/ABCDEF"
+		root        = "root-thumbprint"
+		stale       = "stale-thumbprint"
+	)
+
+	var (
+		p    *mockprovider.MockProvider
+		oidc *OpenIDConnectManager
+	)
+
+	mockGetProvider := func(output *iam.GetOpenIDConnectProviderOutput) {
+		p.MockIAM().On("GetOpenIDConnectProvider", mock.Anything, &iam.GetOpenIDConnectProviderInput{
+			OpenIDConnectProviderArn: aws.String(providerARN),
+		}).Return(output, nil)
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		var err error
+		oidc, err = NewOpenIDConnectManager(p.IAM(), "12345", "https://oidc.eks.us-west-2.amazonaws.com/id/ABCDEF", "aws", map[string]string{
+			"alpha.eksctl.io/cluster-name": "my-cluster",
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("reports a healthy provider", func() {
+		mockGetProvider(&iam.GetOpenIDConnectProviderOutput{
+			ClientIDList:   []string{"sts.amazonaws.com"},
+			ThumbprintList: []string{root},
+			Tags:           []iamtypes.Tag{{Key: aws.String("alpha.eksctl.io/cluster-name"), Value: aws.String("my-cluster")}},
+		})
+		check, err := oidc.checkProvider(context.Background(), []string{"leaf-thumbprint", root})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(check.ProviderARN).To(Equal(providerARN))
+		Expect(check.IssuerThumbprint).To(Equal(root))
+		Expect(check.Healthy()).To(BeTrue())
+		Expect(check.NeedsFix()).To(BeFalse())
+	})
+
+	It("reports stale thumbprints, missing client IDs and missing tags", func() {
+		mockGetProvider(&iam.GetOpenIDConnectProviderOutput{
+			ClientIDList:   []string{"other-audience"},
+			ThumbprintList: []string{stale},
+		})
+		check, err := oidc.checkProvider(context.Background(), []string{root})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(check.MissingThumbprint).To(Equal(root))
+		Expect(check.StaleThumbprints).To(ConsistOf(stale))
+		Expect(check.MissingClientIDs).To(ConsistOf("sts.amazonaws.com"))
+		Expect(check.MissingTags).To(ConsistOf("alpha.eksctl.io/cluster-name"))
+		Expect(check.Healthy()).To(BeFalse())
+		Expect(check.NeedsFix()).To(BeTrue())
+	})
+
+	It("does not report thumbprints of intermediate certificates as stale", func() {
+		mockGetProvider(&iam.GetOpenIDConnectProviderOutput{
+			ClientIDList:   []string{"sts.amazonaws.com"},
+			ThumbprintList: []string{"intermediate-thumbprint"},
+			Tags:           []iamtypes.Tag{{Key: aws.String("alpha.eksctl.io/cluster-name"), Value: aws.String("my-cluster")}},
+		})
+		check, err := oidc.checkProvider(context.Background(), []string{"leaf-thumbprint", "intermediate-thumbprint", root})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(check.StaleThumbprints).To(BeEmpty())
+		Expect(check.MissingThumbprint).To(Equal(root))
+	})
+
+	It("errors when the provider does not exist", func() {
+		p.MockIAM().On("GetOpenIDConnectProvider", mock.Anything, mock.Anything).Return(nil, &iamtypes.NoSuchEntityException{})
+		_, err := oidc.checkProvider(context.Background(), []string{root})
+		Expect(err).To(MatchError(ContainSubstring("does not exist")))
+	})
+
+	It("replaces stale thumbprints and adds missing client IDs", func() {
+		p.MockIAM().On("UpdateOpenIDConnectProviderThumbprint", mock.Anything, &iam.UpdateOpenIDConnectProviderThumbprintInput{
+			OpenIDConnectProviderArn: aws.String(providerARN),
+			ThumbprintList:           []string{root, "intermediate-thumbprint"},
+		}).Return(&iam.UpdateOpenIDConnectProviderThumbprintOutput{}, nil).Once()
+		p.MockIAM().On("AddClientIDToOpenIDConnectProvider", mock.Anything, &iam.AddClientIDToOpenIDConnectProviderInput{
+			OpenIDConnectProviderArn: aws.String(providerARN),
+			ClientID:                 aws.String("sts.amazonaws.com"),
+		}).Return(&iam.AddClientIDToOpenIDConnectProviderOutput{}, nil).Once()
+
+		Expect(oidc.FixProvider(context.Background(), &ProviderCheck{
+			ProviderARN:       providerARN,
+			Thumbprints:       []string{stale, "intermediate-thumbprint"},
+			IssuerThumbprint:  root,
+			MissingThumbprint: root,
+			StaleThumbprints:  []string{stale},
+			MissingClientIDs:  []string{"sts.amazonaws.com"},
+		})).To(Succeed())
+		p.MockIAM().AssertExpectations(GinkgoT())
+	})
+
+	It("does not update a provider that only misses tags", func() {
+		Expect(oidc.FixProvider(context.Background(), &ProviderCheck{
+			ProviderARN:      providerARN,
+			Thumbprints:      []string{root},
+			IssuerThumbprint: root,
+			MissingTags:      []string{"alpha.eksctl.io/cluster-name"},
+		})).To(Succeed())
+		Expect(p.MockIAM().Calls).To(BeEmpty())
+	})
+})
@@ -25,6 +25,8 @@ const (
 	defaultInstanceProfile   = "defaultInstanceProfile"
 	helmChartName            = "oci://public.ecr.aws/karpenter/karpenter"
 	releaseName              = "karpenter"
+	crdChartName             = "oci://public.ecr.aws/karpenter/karpenter-crd"
+	crdReleaseName           = "karpenter-crd"
 	serviceAccount           = "serviceAccount"
 	serviceAccountAnnotation = "annotations"
 	serviceAccountName       = "name"
@@ -45,6 +47,8 @@ type Options struct {
 //counterfeiter:generate -o fakes/fake_chart_installer.go . ChartInstaller
 type ChartInstaller interface {
 	Install(ctx context.Context, serviceAccountRoleARN string, instanceProfileName string) error
+	Upgrade(ctx context.Context, serviceAccountRoleARN string, instanceProfileName string) error
+	Uninstall(ctx context.Context) error
 }
 
 // Installer implements the Karpenter installer functionality.
@@ -64,6 +68,93 @@ func (k *Installer) Install(ctx context.Context, serviceAccountRoleARN string, i
 	logger.Info("adding Karpenter to cluster %s", k.ClusterConfig.Metadata.Name)
 	logger.Debug("cluster endpoint used by Karpenter: %s", k.ClusterConfig.Status.Endpoint)
 
+	registryClient, err := registry.NewClient(
+		registry.ClientOptEnableCache(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create registry client: %w", err)
+	}
+
+	options := providers.InstallChartOpts{
+		ChartName:       helmChartName,
+		CreateNamespace: true,
+		Namespace:       DefaultNamespace,
+		ReleaseName:     releaseName,
+		Values:          k.chartValues(serviceAccountRoleARN, instanceProfileName),
+		Version:         k.ClusterConfig.Karpenter.Version,
+		RegistryClient:  registryClient,
+	}
+
+	logger.Debug("the following chartOptions will be applied to the install: %+v", options)
+
+	if err := k.HelmInstaller.InstallChart(ctx, options); err != nil {
+		return fmt.Errorf("failed to install Karpenter chart: %w", err)
+	}
+	return nil
+}
+
+// Upgrade upgrades the Karpenter CRDs and then the Karpenter release to the configured version and values.
+// Helm does not upgrade the CRDs of a chart, so they are upgraded through the Karpenter CRD chart, which is
+// installed if it is not yet. Existing CRDs must have been adopted into the CRD release with AdoptCRDs.
+func (k *Installer) Upgrade(ctx context.Context, serviceAccountRoleARN string, instanceProfileName string) error {
+	logger.Info("upgrading Karpenter on cluster %s to version %s", k.ClusterConfig.Metadata.Name, k.ClusterConfig.Karpenter.Version)
+
+	registryClient, err := registry.NewClient(
+		registry.ClientOptEnableCache(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create registry client: %w", err)
+	}
+
+	crdOptions := providers.UpgradeChartOpts{
+		ChartName:   crdChartName,
+		Namespace:   DefaultNamespace,
+		ReleaseName: crdReleaseName,
+		Values: map[string]interface{}{
+			"webhook": map[string]interface{}{
+				"serviceNamespace": DefaultNamespace,
+			},
+		},
+		Version:        k.ClusterConfig.Karpenter.Version,
+		RegistryClient: registryClient,
+		Install:        true,
+	}
+	if err := k.HelmInstaller.UpgradeChart(ctx, crdOptions); err != nil {
+		return fmt.Errorf("failed to upgrade Karpenter CRD chart: %w", err)
+	}
+
+	options := providers.UpgradeChartOpts{
+		ChartName:      helmChartName,
+		Namespace:      DefaultNamespace,
+		ReleaseName:    releaseName,
+		Values:         k.chartValues(serviceAccountRoleARN, instanceProfileName),
+		Version:        k.ClusterConfig.Karpenter.Version,
+		RegistryClient: registryClient,
+	}
+
+	logger.Debug("the following chartOptions will be applied to the upgrade: %+v", options)
+
+	if err := k.HelmInstaller.UpgradeChart(ctx, options); err != nil {
+		return fmt.Errorf("failed to upgrade Karpenter chart: %w", err)
+	}
+	return nil
+}
+
+// Uninstall removes the Karpenter release from the cluster.
+func (k *Installer) Uninstall(ctx context.Context) error {
+	logger.Info("uninstalling Karpenter from cluster %s", k.ClusterConfig.Metadata.Name)
+	if err := k.HelmInstaller.UninstallChart(ctx, providers.UninstallChartOpts{
+		Namespace:   DefaultNamespace,
+		ReleaseName: releaseName,
+	}); err != nil {
+		return fmt.Errorf("failed to uninstall Karpenter chart: %w", err)
+	}
+	return nil
+}
+
+// chartValues builds the values of the Karpenter chart for the configured version, with the values
+// in the Karpenter config merged over them.
+func (k *Installer) chartValues(serviceAccountRoleARN string, instanceProfileName string) map[string]interface{} {
 	serviceAccountMap := map[string]interface{}{
 		create: api.IsEnabled(k.ClusterConfig.Karpenter.CreateServiceAccount),
 		serviceAccountAnnotation: map[string]interface{}{
@@ -87,35 +178,27 @@ func (k *Installer) Install(ctx context.Context, serviceAccountRoleARN string, i
 		serviceAccount: serviceAccountMap,
 	}
 
-	version := k.ClusterConfig.Karpenter.Version
-	compareVersions, err := utils.CompareVersions(version, "0.33.0")
+	compareVersions, err := utils.CompareVersions(k.ClusterConfig.Karpenter.Version, "0.33.0")
 	if err == nil && compareVersions < 0 {
 		values[settings] = map[string]interface{}{
 			aws: values[settings],
 		}
 	}
 
-	registryClient, err := registry.NewClient(
-		registry.ClientOptEnableCache(true),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create registry client: %w", err)
-	}
-
-	options := providers.InstallChartOpts{
-		ChartName:       helmChartName,
-		CreateNamespace: true,
-		Namespace:       DefaultNamespace,
-		ReleaseName:     releaseName,
-		Values:          values,
-		Version:         version,
-		RegistryClient:  registryClient,
-	}
-
-	logger.Debug("the following chartOptions will be applied to the install: %+v", options)
+	return mergeValues(values, k.ClusterConfig.Karpenter.Values)
+}
 
-	if err := k.HelmInstaller.InstallChart(ctx, options); err != nil {
-		return fmt.Errorf("failed to install Karpenter chart: %w", err)
+// mergeValues recursively merges overrides into values. Maps are merged, any other value in overrides
+// replaces the value in values.
+func mergeValues(values, overrides map[string]interface{}) map[string]interface{} {
+	for key, override := range overrides {
+		overrideMap, isMap := override.(map[string]interface{})
+		valueMap, valueIsMap := values[key].(map[string]interface{})
+		if isMap && valueIsMap {
+			values[key] = mergeValues(valueMap, overrideMap)
+			continue
+		}
+		values[key] = override
 	}
-	return nil
+	return values
 }
@@ -99,7 +99,6 @@ var _ = Describe("Install", func() {
 		When("install chart fails", func() {
 
 			BeforeEach(func() {
-				fakeHelmInstaller.AddRepoReturns(nil)
 				fakeHelmInstaller.InstallChartReturns(errors.New("nope"))
 			})
 
@@ -138,5 +137,109 @@ var _ = Describe("Install", func() {
 				Expect(opts.Values).To(Equal(values))
 			})
 		})
+
+		When("values are set in the Karpenter config", func() {
+			It("merges them over the values set by eksctl", func() {
+				cfg.Karpenter.Version = "1.0.6"
+				cfg.Karpenter.Values = api.InlineDocument{
+					"replicas": 1,
+					"settings": map[string]interface{}{
+						"featureGates": map[string]interface{}{"spotToSpotConsolidation": true},
+					},
+					"serviceAccount": map[string]interface{}{
+						"annotations": map[string]interface{}{"team": "platform"},
+					},
+				}
+				Expect(installerUnderTest.Install(context.Background(), "role/account", "role/profile")).To(Succeed())
+				_, opts := fakeHelmInstaller.InstallChartArgsForCall(0)
+				Expect(opts.Values["replicas"]).To(Equal(1))
+				Expect(opts.Values[settings]).To(Equal(map[string]interface{}{
+					defaultInstanceProfile: "role/profile",
+					clusterName:            cfg.Metadata.Name,
+					clusterEndpoint:        cfg.Status.Endpoint,
+					interruptionQueueName:  cfg.Metadata.Name,
+					"featureGates":         map[string]interface{}{"spotToSpotConsolidation": true},
+				}))
+				Expect(opts.Values[serviceAccount]).To(Equal(map[string]interface{}{
+					create: false,
+					serviceAccountAnnotation: map[string]interface{}{
+						api.AnnotationEKSRoleARN: "role/account",
+						"team":                   "platform",
+					},
+					serviceAccountName: DefaultServiceAccountName,
+				}))
+			})
+		})
+	})
+
+	Context("Upgrade", func() {
+		var (
+			fakeHelmInstaller  *fakes.FakeHelmInstaller
+			installerUnderTest *Installer
+			cfg                *api.ClusterConfig
+		)
+
+		BeforeEach(func() {
+			cfg = api.NewClusterConfig()
+			cfg.Metadata.Name = "test-cluster"
+			cfg.Karpenter = &api.Karpenter{
+				Version: "1.0.6",
+			}
+			cfg.Status = &api.ClusterStatus{
+				Endpoint: "https://endpoint.com",
+			}
+			fakeHelmInstaller = &fakes.FakeHelmInstaller{}
+			installerUnderTest = NewKarpenterInstaller(Options{
+				HelmInstaller: fakeHelmInstaller,
+				Namespace:     "karpenter",
+				ClusterConfig: cfg,
+			})
+		})
+
+		It("upgrades the CRDs and then the release to the configured version", func() {
+			Expect(installerUnderTest.Upgrade(context.Background(), "role-arn", "role/profile")).To(Succeed())
+			Expect(fakeHelmInstaller.UpgradeChartCallCount()).To(Equal(2))
+			_, crdOpts := fakeHelmInstaller.UpgradeChartArgsForCall(0)
+			Expect(crdOpts.ChartName).To(Equal("oci://public.ecr.aws/karpenter/karpenter-crd"))
+			Expect(crdOpts.ReleaseName).To(Equal("karpenter-crd"))
+			Expect(crdOpts.Namespace).To(Equal("karpenter"))
+			Expect(crdOpts.Version).To(Equal("1.0.6"))
+			Expect(crdOpts.Install).To(BeTrue())
+			Expect(crdOpts.Values).To(HaveKeyWithValue("webhook", HaveKeyWithValue("serviceNamespace", "karpenter")))
+			_, opts := fakeHelmInstaller.UpgradeChartArgsForCall(1)
+			Expect(opts.Install).To(BeFalse())
+			Expect(opts.ChartName).To(Equal("oci://public.ecr.aws/karpenter/karpenter"))
+			Expect(opts.ReleaseName).To(Equal("karpenter"))
+			Expect(opts.Namespace).To(Equal("karpenter"))
+			Expect(opts.Version).To(Equal("1.0.6"))
+			Expect(opts.Values[settings]).To(HaveKeyWithValue(defaultInstanceProfile, "role/profile"))
+		})
+
+		When("upgrading the CRD chart fails", func() {
+			It("does not upgrade the release", func() {
+				fakeHelmInstaller.UpgradeChartReturnsOnCall(0, errors.New("nope"))
+				Expect(installerUnderTest.Upgrade(context.Background(), "role-arn", "role/profile")).
+					To(MatchError(ContainSubstring("failed to upgrade Karpenter CRD chart: nope")))
+				Expect(fakeHelmInstaller.UpgradeChartCallCount()).To(Equal(1))
+			})
+		})
+
+		When("upgrade chart fails", func() {
+			It("errors", func() {
+				fakeHelmInstaller.UpgradeChartReturnsOnCall(1, errors.New("nope"))
+				Expect(installerUnderTest.Upgrade(context.Background(), "role-arn", "role/profile")).
+					To(MatchError(ContainSubstring("failed to upgrade Karpenter chart: nope")))
+			})
+		})
+
+		It("uninstalls the release", func() {
+			Expect(installerUnderTest.Uninstall(context.Background())).To(Succeed())
+			Expect(fakeHelmInstaller.UninstallChartCallCount()).To(Equal(1))
+			_, opts := fakeHelmInstaller.UninstallChartArgsForCall(0)
+			Expect(opts).To(Equal(providers.UninstallChartOpts{
+				Namespace:   "karpenter",
+				ReleaseName: "karpenter",
+			}))
+		})
 	})
 })
@@ -0,0 +1,147 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kris-nova/logger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils"
+)
+
+const (
+	nodePoolGroup          = "karpenter.sh"
+	ec2NodeClassGroup      = "karpenter.k8s.aws"
+	nodePoolKind           = "NodePool"
+	ec2NodeClassKind       = "EC2NodeClass"
+	nodePoolsResource      = "nodepools"
+	ec2NodeClassesResource = "ec2nodeclasses"
+	fieldManager           = "eksctl"
+)
+
+// NodePoolManager applies and deletes Karpenter NodePools and EC2NodeClasses.
+type NodePoolManager struct {
+	Client dynamic.Interface
+	// Version is the Karpenter version, used to select the API version of NodePools and EC2NodeClasses.
+	Version string
+}
+
+// APIVersion returns the API version of NodePools and EC2NodeClasses served by the Karpenter version.
+func APIVersion(karpenterVersion string) (string, error) {
+	compareVersions, err := utils.CompareVersions(karpenterVersion, "1.0.0")
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Karpenter version %q: %w", karpenterVersion, err)
+	}
+	if compareVersions >= 0 {
+		return "v1", nil
+	}
+	compareVersions, err = utils.CompareVersions(karpenterVersion, api.KarpenterNodePoolsMinimumVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Karpenter version %q: %w", karpenterVersion, err)
+	}
+	if compareVersions < 0 {
+		return "", fmt.Errorf("version %s of Karpenter does not serve NodePools, %s or later is required", karpenterVersion, api.KarpenterNodePoolsMinimumVersion)
+	}
+	return "v1beta1", nil
+}
+
+// Apply creates or updates the EC2NodeClasses and then the NodePools in the Karpenter config, using server-side apply.
+func (m *NodePoolManager) Apply(ctx context.Context, cfg *api.Karpenter) error {
+	if len(cfg.NodePools) == 0 && len(cfg.EC2NodeClasses) == 0 {
+		return nil
+	}
+	nodePoolGVR, ec2NodeClassGVR, err := m.resources()
+	if err != nil {
+		return err
+	}
+	for _, nc := range cfg.EC2NodeClasses {
+		logger.Info("applying EC2NodeClass %q", nc.Name)
+		if err := m.apply(ctx, ec2NodeClassGVR, ec2NodeClassKind, nc.Name, nc.Spec); err != nil {
+			return err
+		}
+	}
+	for _, np := range cfg.NodePools {
+		logger.Info("applying NodePool %q", np.Name)
+		if err := m.apply(ctx, nodePoolGVR, nodePoolKind, np.Name, np.Spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteAll deletes all NodePools and then all EC2NodeClasses on the cluster, and waits for them to be removed so that
+// Karpenter can terminate the nodes it launched before it is uninstalled.
+func (m *NodePoolManager) DeleteAll(ctx context.Context, timeout time.Duration) error {
+	nodePoolGVR, ec2NodeClassGVR, err := m.resources()
+	if err != nil {
+		return err
+	}
+	for _, resource := range []schema.GroupVersionResource{nodePoolGVR, ec2NodeClassGVR} {
+		if err := m.deleteAll(ctx, resource, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *NodePoolManager) resources() (nodePoolGVR, ec2NodeClassGVR schema.GroupVersionResource, err error) {
+	version, err := APIVersion(m.Version)
+	if err != nil {
+		return nodePoolGVR, ec2NodeClassGVR, err
+	}
+	return schema.GroupVersionResource{Group: nodePoolGroup, Version: version, Resource: nodePoolsResource},
+		schema.GroupVersionResource{Group: ec2NodeClassGroup, Version: version, Resource: ec2NodeClassesResource}, nil
+}
+
+func (m *NodePoolManager) apply(ctx context.Context, resource schema.GroupVersionResource, kind, name string, spec api.InlineDocument) error {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": resource.GroupVersion().String(),
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"spec": map[string]interface{}(spec),
+		},
+	}
+	if _, err := m.Client.Resource(resource).Apply(ctx, name, obj, metav1.ApplyOptions{FieldManager: fieldManager, Force: true}); err != nil {
+		return fmt.Errorf("applying %s %q: %w", kind, name, err)
+	}
+	return nil
+}
+
+func (m *NodePoolManager) deleteAll(ctx context.Context, resource schema.GroupVersionResource, timeout time.Duration) error {
+	list, err := m.Client.Resource(resource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("%s are not served by the cluster, skipping", resource.Resource)
+			return nil
+		}
+		return fmt.Errorf("listing %s: %w", resource.Resource, err)
+	}
+	if len(list.Items) == 0 {
+		return nil
+	}
+	for _, item := range list.Items {
+		logger.Info("deleting %s %q", item.GetKind(), item.GetName())
+		if err := m.Client.Resource(resource).Delete(ctx, item.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting %s %q: %w", resource.Resource, item.GetName(), err)
+		}
+	}
+	logger.Info("waiting for %s to be deleted", resource.Resource)
+	return wait.PollUntilContextTimeout(ctx, 5*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		list, err := m.Client.Resource(resource).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, fmt.Errorf("listing %s: %w", resource.Resource, err)
+		}
+		return len(list.Items) == 0, nil
+	})
+}
@@ -0,0 +1,126 @@
+package karpenter
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("NodePoolManager", func() {
+	var (
+		nodePoolGVR     = schema.GroupVersionResource{Group: "karpenter.sh", Version: "v1", Resource: "nodepools"}
+		ec2NodeClassGVR = schema.GroupVersionResource{Group: "karpenter.k8s.aws", Version: "v1", Resource: "ec2nodeclasses"}
+		client          *dynamicfake.FakeDynamicClient
+		manager         *NodePoolManager
+		applied         []*unstructured.Unstructured
+	)
+
+	newClient := func(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+		return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			nodePoolGVR:     "NodePoolList",
+			ec2NodeClassGVR: "EC2NodeClassList",
+		}, objects...)
+	}
+
+	newObject := func(gvr schema.GroupVersionResource, kind, name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": gvr.GroupVersion().String(),
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+		}}
+	}
+
+	BeforeEach(func() {
+		client = newClient()
+		applied = nil
+		// the object tracker of the fake client cannot create objects with server-side apply
+		client.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			patchAction := action.(k8stesting.PatchAction)
+			Expect(patchAction.GetPatchType()).To(Equal(types.ApplyPatchType))
+			obj := &unstructured.Unstructured{}
+			Expect(obj.UnmarshalJSON(patchAction.GetPatch())).To(Succeed())
+			applied = append(applied, obj)
+			return true, obj, nil
+		})
+		manager = &NodePoolManager{Client: client, Version: "1.0.6"}
+	})
+
+	It("applies EC2NodeClasses and then NodePools", func() {
+		Expect(manager.Apply(context.Background(), &api.Karpenter{
+			NodePools: []api.KarpenterNodePool{
+				{Name: "default", Spec: api.InlineDocument{"limits": map[string]interface{}{"cpu": "100"}}},
+			},
+			EC2NodeClasses: []api.KarpenterEC2NodeClass{
+				{Name: "default", Spec: api.InlineDocument{"role": "KarpenterNodeRole"}},
+			},
+		})).To(Succeed())
+
+		actions := client.Actions()
+		Expect(actions).To(HaveLen(2))
+		Expect(actions[0].GetResource()).To(Equal(ec2NodeClassGVR))
+		Expect(actions[1].GetResource()).To(Equal(nodePoolGVR))
+
+		Expect(applied).To(HaveLen(2))
+		Expect(applied[0].GetAPIVersion()).To(Equal("karpenter.k8s.aws/v1"))
+		Expect(applied[0].GetKind()).To(Equal("EC2NodeClass"))
+		Expect(applied[1].GetAPIVersion()).To(Equal("karpenter.sh/v1"))
+		Expect(applied[1].GetKind()).To(Equal("NodePool"))
+		Expect(applied[1].GetName()).To(Equal("default"))
+		Expect(applied[1].Object["spec"]).To(Equal(map[string]interface{}{"limits": map[string]interface{}{"cpu": "100"}}))
+	})
+
+	It("uses the v1beta1 API for versions before v1.0.0", func() {
+		manager.Version = "0.37.0"
+		Expect(manager.Apply(context.Background(), &api.Karpenter{
+			NodePools: []api.KarpenterNodePool{
+				{Name: "default", Spec: api.InlineDocument{"weight": "10"}},
+			},
+		})).To(Succeed())
+		Expect(applied).To(HaveLen(1))
+		Expect(applied[0].GetAPIVersion()).To(Equal("karpenter.sh/v1beta1"))
+	})
+
+	It("does not call the API when there is nothing to apply", func() {
+		Expect(manager.Apply(context.Background(), &api.Karpenter{})).To(Succeed())
+		Expect(client.Actions()).To(BeEmpty())
+	})
+
+	It("errors for versions that do not serve NodePools", func() {
+		manager.Version = "0.31.0"
+		Expect(manager.Apply(context.Background(), &api.Karpenter{
+			NodePools: []api.KarpenterNodePool{
+				{Name: "default", Spec: api.InlineDocument{"weight": "10"}},
+			},
+		})).To(MatchError("version 0.31.0 of Karpenter does not serve NodePools, v0.32.0 or later is required"))
+	})
+
+	It("deletes all NodePools and EC2NodeClasses", func() {
+		client = newClient(
+			newObject(nodePoolGVR, "NodePool", "default"),
+			newObject(nodePoolGVR, "NodePool", "gpu"),
+			newObject(ec2NodeClassGVR, "EC2NodeClass", "default"),
+		)
+		manager.Client = client
+		Expect(manager.DeleteAll(context.Background(), time.Minute)).To(Succeed())
+
+		nodePools, err := client.Resource(nodePoolGVR).List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodePools.Items).To(BeEmpty())
+		nodeClasses, err := client.Resource(ec2NodeClassGVR).List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodeClasses.Items).To(BeEmpty())
+	})
+})
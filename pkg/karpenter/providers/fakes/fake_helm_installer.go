@@ -9,18 +9,6 @@ import (
 )
 
 type FakeHelmInstaller struct {
-	AddRepoStub        func(string, string) error
-	addRepoMutex       sync.RWMutex
-	addRepoArgsForCall []struct {
-		arg1 string
-		arg2 string
-	}
-	addRepoReturns struct {
-		result1 error
-	}
-	addRepoReturnsOnCall map[int]struct {
-		result1 error
-	}
 	InstallChartStub        func(context.Context, providers.InstallChartOpts) error
 	installChartMutex       sync.RWMutex
 	installChartArgsForCall []struct {
@@ -33,70 +21,32 @@ type FakeHelmInstaller struct {
 	installChartReturnsOnCall map[int]struct {
 		result1 error
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
-}
-
-func (fake *FakeHelmInstaller) AddRepo(arg1 string, arg2 string) error {
-	fake.addRepoMutex.Lock()
-	ret, specificReturn := fake.addRepoReturnsOnCall[len(fake.addRepoArgsForCall)]
-	fake.addRepoArgsForCall = append(fake.addRepoArgsForCall, struct {
-		arg1 string
-		arg2 string
-	}{arg1, arg2})
-	stub := fake.AddRepoStub
-	fakeReturns := fake.addRepoReturns
-	fake.recordInvocation("AddRepo", []interface{}{arg1, arg2})
-	fake.addRepoMutex.Unlock()
-	if stub != nil {
-		return stub(arg1, arg2)
+	UninstallChartStub        func(context.Context, providers.UninstallChartOpts) error
+	uninstallChartMutex       sync.RWMutex
+	uninstallChartArgsForCall []struct {
+		arg1 context.Context
+		arg2 providers.UninstallChartOpts
 	}
-	if specificReturn {
-		return ret.result1
+	uninstallChartReturns struct {
+		result1 error
 	}
-	return fakeReturns.result1
-}
-
-func (fake *FakeHelmInstaller) AddRepoCallCount() int {
-	fake.addRepoMutex.RLock()
-	defer fake.addRepoMutex.RUnlock()
-	return len(fake.addRepoArgsForCall)
-}
-
-func (fake *FakeHelmInstaller) AddRepoCalls(stub func(string, string) error) {
-	fake.addRepoMutex.Lock()
-	defer fake.addRepoMutex.Unlock()
-	fake.AddRepoStub = stub
-}
-
-func (fake *FakeHelmInstaller) AddRepoArgsForCall(i int) (string, string) {
-	fake.addRepoMutex.RLock()
-	defer fake.addRepoMutex.RUnlock()
-	argsForCall := fake.addRepoArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
-}
-
-func (fake *FakeHelmInstaller) AddRepoReturns(result1 error) {
-	fake.addRepoMutex.Lock()
-	defer fake.addRepoMutex.Unlock()
-	fake.AddRepoStub = nil
-	fake.addRepoReturns = struct {
+	uninstallChartReturnsOnCall map[int]struct {
 		result1 error
-	}{result1}
-}
-
-func (fake *FakeHelmInstaller) AddRepoReturnsOnCall(i int, result1 error) {
-	fake.addRepoMutex.Lock()
-	defer fake.addRepoMutex.Unlock()
-	fake.AddRepoStub = nil
-	if fake.addRepoReturnsOnCall == nil {
-		fake.addRepoReturnsOnCall = make(map[int]struct {
-			result1 error
-		})
 	}
-	fake.addRepoReturnsOnCall[i] = struct {
+	UpgradeChartStub        func(context.Context, providers.UpgradeChartOpts) error
+	upgradeChartMutex       sync.RWMutex
+	upgradeChartArgsForCall []struct {
+		arg1 context.Context
+		arg2 providers.UpgradeChartOpts
+	}
+	upgradeChartReturns struct {
 		result1 error
-	}{result1}
+	}
+	upgradeChartReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
 }
 
 func (fake *FakeHelmInstaller) InstallChart(arg1 context.Context, arg2 providers.InstallChartOpts) error {
@@ -161,13 +111,139 @@ func (fake *FakeHelmInstaller) InstallChartReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeHelmInstaller) UninstallChart(arg1 context.Context, arg2 providers.UninstallChartOpts) error {
+	fake.uninstallChartMutex.Lock()
+	ret, specificReturn := fake.uninstallChartReturnsOnCall[len(fake.uninstallChartArgsForCall)]
+	fake.uninstallChartArgsForCall = append(fake.uninstallChartArgsForCall, struct {
+		arg1 context.Context
+		arg2 providers.UninstallChartOpts
+	}{arg1, arg2})
+	stub := fake.UninstallChartStub
+	fakeReturns := fake.uninstallChartReturns
+	fake.recordInvocation("UninstallChart", []interface{}{arg1, arg2})
+	fake.uninstallChartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeHelmInstaller) UninstallChartCallCount() int {
+	fake.uninstallChartMutex.RLock()
+	defer fake.uninstallChartMutex.RUnlock()
+	return len(fake.uninstallChartArgsForCall)
+}
+
+func (fake *FakeHelmInstaller) UninstallChartCalls(stub func(context.Context, providers.UninstallChartOpts) error) {
+	fake.uninstallChartMutex.Lock()
+	defer fake.uninstallChartMutex.Unlock()
+	fake.UninstallChartStub = stub
+}
+
+func (fake *FakeHelmInstaller) UninstallChartArgsForCall(i int) (context.Context, providers.UninstallChartOpts) {
+	fake.uninstallChartMutex.RLock()
+	defer fake.uninstallChartMutex.RUnlock()
+	argsForCall := fake.uninstallChartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeHelmInstaller) UninstallChartReturns(result1 error) {
+	fake.uninstallChartMutex.Lock()
+	defer fake.uninstallChartMutex.Unlock()
+	fake.UninstallChartStub = nil
+	fake.uninstallChartReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHelmInstaller) UninstallChartReturnsOnCall(i int, result1 error) {
+	fake.uninstallChartMutex.Lock()
+	defer fake.uninstallChartMutex.Unlock()
+	fake.UninstallChartStub = nil
+	if fake.uninstallChartReturnsOnCall == nil {
+		fake.uninstallChartReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uninstallChartReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHelmInstaller) UpgradeChart(arg1 context.Context, arg2 providers.UpgradeChartOpts) error {
+	fake.upgradeChartMutex.Lock()
+	ret, specificReturn := fake.upgradeChartReturnsOnCall[len(fake.upgradeChartArgsForCall)]
+	fake.upgradeChartArgsForCall = append(fake.upgradeChartArgsForCall, struct {
+		arg1 context.Context
+		arg2 providers.UpgradeChartOpts
+	}{arg1, arg2})
+	stub := fake.UpgradeChartStub
+	fakeReturns := fake.upgradeChartReturns
+	fake.recordInvocation("UpgradeChart", []interface{}{arg1, arg2})
+	fake.upgradeChartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeHelmInstaller) UpgradeChartCallCount() int {
+	fake.upgradeChartMutex.RLock()
+	defer fake.upgradeChartMutex.RUnlock()
+	return len(fake.upgradeChartArgsForCall)
+}
+
+func (fake *FakeHelmInstaller) UpgradeChartCalls(stub func(context.Context, providers.UpgradeChartOpts) error) {
+	fake.upgradeChartMutex.Lock()
+	defer fake.upgradeChartMutex.Unlock()
+	fake.UpgradeChartStub = stub
+}
+
+func (fake *FakeHelmInstaller) UpgradeChartArgsForCall(i int) (context.Context, providers.UpgradeChartOpts) {
+	fake.upgradeChartMutex.RLock()
+	defer fake.upgradeChartMutex.RUnlock()
+	argsForCall := fake.upgradeChartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeHelmInstaller) UpgradeChartReturns(result1 error) {
+	fake.upgradeChartMutex.Lock()
+	defer fake.upgradeChartMutex.Unlock()
+	fake.UpgradeChartStub = nil
+	fake.upgradeChartReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHelmInstaller) UpgradeChartReturnsOnCall(i int, result1 error) {
+	fake.upgradeChartMutex.Lock()
+	defer fake.upgradeChartMutex.Unlock()
+	fake.UpgradeChartStub = nil
+	if fake.upgradeChartReturnsOnCall == nil {
+		fake.upgradeChartReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.upgradeChartReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeHelmInstaller) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.addRepoMutex.RLock()
-	defer fake.addRepoMutex.RUnlock()
 	fake.installChartMutex.RLock()
 	defer fake.installChartMutex.RUnlock()
+	fake.uninstallChartMutex.RLock()
+	defer fake.uninstallChartMutex.RUnlock()
+	fake.upgradeChartMutex.RLock()
+	defer fake.upgradeChartMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -28,6 +28,24 @@ type InstallChartOpts struct {
 	RegistryClient  *registry.Client
 }
 
+// UpgradeChartOpts defines parameters for UpgradeChart.
+type UpgradeChartOpts struct {
+	ChartName      string
+	Namespace      string
+	ReleaseName    string
+	Values         map[string]interface{}
+	Version        string
+	RegistryClient *registry.Client
+	// Install installs the chart if the release does not exist.
+	Install bool
+}
+
+// UninstallChartOpts defines parameters for UninstallChart.
+type UninstallChartOpts struct {
+	Namespace   string
+	ReleaseName string
+}
+
 // HelmInstaller deals with setting up Helm related resources.
 //
 //counterfeiter:generate -o fakes/fake_helm_installer.go . HelmInstaller
@@ -35,4 +53,9 @@ type HelmInstaller interface {
 	// InstallChart takes a releaseName's name and a chart name and installs it. If namespace is not empty
 	// it will install into that namespace and create the namespace. Version is required.
 	InstallChart(ctx context.Context, opts InstallChartOpts) error
+	// UpgradeChart upgrades an installed release to the given chart version, replacing its values. If Install is set,
+	// the chart is installed when the release does not exist. Version is required.
+	UpgradeChart(ctx context.Context, opts UpgradeChartOpts) error
+	// UninstallChart removes a release and the resources it created. It does not fail if the release does not exist.
+	UninstallChart(ctx context.Context, opts UninstallChartOpts) error
 }
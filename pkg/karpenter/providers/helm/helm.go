@@ -2,6 +2,7 @@ package helm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/storage/driver"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/weaveworks/eksctl/pkg/karpenter/providers"
@@ -74,3 +76,61 @@ func (i *Installer) InstallChart(ctx context.Context, opts providers.InstallChar
 	logger.Debug("successfully installed %s helm chart: %s/%s", release.Name, opts.ChartName, opts.Version)
 	return nil
 }
+
+// UpgradeChart upgrades an installed release to the given chart version, replacing its values. If Install is set,
+// the chart is installed when the release does not exist. Version is required.
+func (i *Installer) UpgradeChart(ctx context.Context, opts providers.UpgradeChartOpts) error {
+	if opts.Install {
+		history := action.NewHistory(i.ActionConfig)
+		history.Max = 1
+		if _, err := history.Run(opts.ReleaseName); errors.Is(err, driver.ErrReleaseNotFound) {
+			logger.Debug("release %s does not exist, installing it", opts.ReleaseName)
+			return i.InstallChart(ctx, providers.InstallChartOpts{
+				ChartName:      opts.ChartName,
+				Namespace:      opts.Namespace,
+				ReleaseName:    opts.ReleaseName,
+				Values:         opts.Values,
+				Version:        opts.Version,
+				RegistryClient: opts.RegistryClient,
+			})
+		}
+	}
+
+	i.ActionConfig.RegistryClient = opts.RegistryClient
+	client := action.NewUpgrade(i.ActionConfig)
+	client.Wait = true
+	client.Namespace = opts.Namespace
+	client.Version = opts.Version
+	client.Timeout = 10 * time.Minute
+
+	chartPath, err := client.ChartPathOptions.LocateChart(opts.ChartName, i.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to locate chart: %w", err)
+	}
+
+	ch, err := loader.Load(chartPath)
+	if err != nil {
+		return fmt.Errorf("failed to load chart: %w", err)
+	}
+
+	release, err := client.RunWithContext(ctx, opts.ReleaseName, ch, opts.Values)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade chart: %w", err)
+	}
+	logger.Debug("successfully upgraded %s helm chart: %s/%s", release.Name, opts.ChartName, opts.Version)
+	return nil
+}
+
+// UninstallChart removes a release and the resources it created. It does not fail if the release does not exist.
+func (i *Installer) UninstallChart(_ context.Context, opts providers.UninstallChartOpts) error {
+	client := action.NewUninstall(i.ActionConfig)
+	client.Wait = true
+	client.IgnoreNotFound = true
+	client.Timeout = 10 * time.Minute
+
+	if _, err := client.Run(opts.ReleaseName); err != nil {
+		return fmt.Errorf("failed to uninstall release %q: %w", opts.ReleaseName, err)
+	}
+	logger.Debug("successfully uninstalled %s helm release from namespace %s", opts.ReleaseName, opts.Namespace)
+	return nil
+}
@@ -25,61 +25,60 @@ import (
 
 var _ = Describe("HelmInstaller", func() {
 
-	Context("InstallChart", func() {
+	var (
+		fakeGetter         *fakes.FakeURLGetter
+		getters            getter.Providers
+		tmp                string
+		err                error
+		installerUnderTest *Installer
+		values             map[string]interface{}
+		actionConfig       *action.Configuration
+		fakeKubeClient     *fakes.PrintingKubeClient
+		registryClient     *registry.Client
+	)
 
-		var (
-			fakeGetter         *fakes.FakeURLGetter
-			getters            getter.Providers
-			tmp                string
-			err                error
-			installerUnderTest *Installer
-			values             map[string]interface{}
-			actionConfig       *action.Configuration
-			fakeKubeClient     *fakes.PrintingKubeClient
-			registryClient     *registry.Client
+	BeforeEach(func() {
+		tmp, err = os.MkdirTemp("", "helm-testing")
+		Expect(err).NotTo(HaveOccurred())
+		fakeGetter = &fakes.FakeURLGetter{}
+		provider := getter.Provider{
+			Schemes: []string{"http", "https", registry.OCIScheme},
+			New: func(options ...getter.Option) (getter.Getter, error) {
+				return fakeGetter, nil
+			},
+		}
+		getters = append(getters, provider)
+		store := storage.Init(driver.NewMemory())
+		fakeKubeClient = &fakes.PrintingKubeClient{Out: io.Discard}
+		actionConfig = &action.Configuration{
+			Releases:     store,
+			KubeClient:   fakeKubeClient,
+			Capabilities: chartutil.DefaultCapabilities,
+			Log:          func(format string, v ...interface{}) {},
+		}
+		installerUnderTest = &Installer{
+			Getters: getters,
+			Settings: &cli.EnvSettings{
+				RepositoryCache:  tmp,
+				RepositoryConfig: filepath.Join(tmp, "repositories.yaml"),
+				Debug:            true,
+			},
+			ActionConfig: actionConfig,
+		}
+		values = map[string]interface{}{
+			"some": "value",
+		}
+		registryClient, _ = registry.NewClient(
+			registry.ClientOptEnableCache(true),
 		)
 
-		BeforeEach(func() {
-			tmp, err = os.MkdirTemp("", "helm-testing")
-			Expect(err).NotTo(HaveOccurred())
-			fakeGetter = &fakes.FakeURLGetter{}
-			provider := getter.Provider{
-				Schemes: []string{"http", "https", registry.OCIScheme},
-				New: func(options ...getter.Option) (getter.Getter, error) {
-					return fakeGetter, nil
-				},
-			}
-			getters = append(getters, provider)
-			store := storage.Init(driver.NewMemory())
-			fakeKubeClient = &fakes.PrintingKubeClient{Out: io.Discard}
-			actionConfig = &action.Configuration{
-				Releases:     store,
-				KubeClient:   fakeKubeClient,
-				Capabilities: chartutil.DefaultCapabilities,
-				Log:          func(format string, v ...interface{}) {},
-			}
-			installerUnderTest = &Installer{
-				Getters: getters,
-				Settings: &cli.EnvSettings{
-					RepositoryCache:  tmp,
-					RepositoryConfig: filepath.Join(tmp, "repositories.yaml"),
-					Debug:            true,
-				},
-				ActionConfig: actionConfig,
-			}
-			values = map[string]interface{}{
-				"some": "value",
-			}
-			registryClient, _ = registry.NewClient(
-				registry.ClientOptEnableCache(true),
-			)
-
-		})
+	})
 
-		AfterEach(func() {
-			_ = os.RemoveAll(tmp)
-		})
+	AfterEach(func() {
+		_ = os.RemoveAll(tmp)
+	})
 
+	Context("InstallChart", func() {
 		It("can install a test chart", func() {
 			// write out repo config
 			Expect(os.WriteFile(filepath.Join(tmp, "repositories.yaml"), []byte(expectedRepositoryYaml), 0644)).To(Succeed())
@@ -174,6 +173,103 @@ var _ = Describe("HelmInstaller", func() {
 			})
 		})
 	})
+
+	Context("UpgradeChart", func() {
+		BeforeEach(func() {
+			Expect(os.WriteFile(filepath.Join(tmp, "repositories.yaml"), []byte(expectedRepositoryYaml), 0644)).To(Succeed())
+			Expect(copy.Copy(filepath.Join("testdata", "karpenter-0.18.0.tgz"), filepath.Join(tmp, "karpenter-0.18.0.tgz"))).To(Succeed())
+			Expect(copy.Copy(filepath.Join("testdata", "karpenter-index.yaml"), filepath.Join(tmp, "karpenter-index.yaml"))).To(Succeed())
+		})
+
+		It("upgrades an installed release with the new values", func() {
+			Expect(installerUnderTest.InstallChart(context.Background(), providers.InstallChartOpts{
+				ChartName:       "oci://public.ecr.aws/karpenter/karpenter",
+				CreateNamespace: true,
+				Namespace:       "karpenter",
+				ReleaseName:     "karpenter",
+				Values:          values,
+				Version:         "v0.18.0",
+				RegistryClient:  registryClient,
+			})).To(Succeed())
+			Expect(installerUnderTest.UpgradeChart(context.Background(), providers.UpgradeChartOpts{
+				ChartName:      "oci://public.ecr.aws/karpenter/karpenter",
+				Namespace:      "karpenter",
+				ReleaseName:    "karpenter",
+				Values:         map[string]interface{}{"some": "other-value"},
+				Version:        "v0.18.0",
+				RegistryClient: registryClient,
+			})).To(Succeed())
+
+			rel, err := actionConfig.Releases.Last("karpenter")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rel.Version).To(Equal(2))
+			Expect(rel.Config).To(Equal(map[string]interface{}{"some": "other-value"}))
+		})
+
+		When("the release is not installed", func() {
+			It("errors", func() {
+				err := installerUnderTest.UpgradeChart(context.Background(), providers.UpgradeChartOpts{
+					ChartName:      "oci://public.ecr.aws/karpenter/karpenter",
+					Namespace:      "karpenter",
+					ReleaseName:    "karpenter",
+					Values:         values,
+					Version:        "v0.18.0",
+					RegistryClient: registryClient,
+				})
+				Expect(err).To(MatchError(ContainSubstring(`failed to upgrade chart: "karpenter" has no deployed releases`)))
+			})
+
+			It("installs the release when Install is set", func() {
+				Expect(installerUnderTest.UpgradeChart(context.Background(), providers.UpgradeChartOpts{
+					ChartName:      "oci://public.ecr.aws/karpenter/karpenter",
+					Namespace:      "karpenter",
+					ReleaseName:    "karpenter",
+					Values:         values,
+					Version:        "v0.18.0",
+					RegistryClient: registryClient,
+					Install:        true,
+				})).To(Succeed())
+
+				rel, err := actionConfig.Releases.Last("karpenter")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rel.Version).To(Equal(1))
+				Expect(rel.Config).To(Equal(values))
+			})
+		})
+	})
+
+	Context("UninstallChart", func() {
+		It("uninstalls an installed release", func() {
+			Expect(os.WriteFile(filepath.Join(tmp, "repositories.yaml"), []byte(expectedRepositoryYaml), 0644)).To(Succeed())
+			Expect(copy.Copy(filepath.Join("testdata", "karpenter-0.18.0.tgz"), filepath.Join(tmp, "karpenter-0.18.0.tgz"))).To(Succeed())
+			Expect(copy.Copy(filepath.Join("testdata", "karpenter-index.yaml"), filepath.Join(tmp, "karpenter-index.yaml"))).To(Succeed())
+			Expect(installerUnderTest.InstallChart(context.Background(), providers.InstallChartOpts{
+				ChartName:       "oci://public.ecr.aws/karpenter/karpenter",
+				CreateNamespace: true,
+				Namespace:       "karpenter",
+				ReleaseName:     "karpenter",
+				Values:          values,
+				Version:         "v0.18.0",
+				RegistryClient:  registryClient,
+			})).To(Succeed())
+
+			Expect(installerUnderTest.UninstallChart(context.Background(), providers.UninstallChartOpts{
+				Namespace:   "karpenter",
+				ReleaseName: "karpenter",
+			})).To(Succeed())
+			_, err := actionConfig.Releases.Last("karpenter")
+			Expect(err).To(MatchError(driver.ErrReleaseNotFound))
+		})
+
+		When("the release is not installed", func() {
+			It("does not error", func() {
+				Expect(installerUnderTest.UninstallChart(context.Background(), providers.UninstallChartOpts{
+					Namespace:   "karpenter",
+					ReleaseName: "karpenter",
+				})).To(Succeed())
+			})
+		})
+	})
 })
 
 var expectedRepositoryYaml = `apiVersion: ""
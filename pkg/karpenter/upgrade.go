@@ -0,0 +1,91 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/kris-nova/logger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// v1CompatibleVersions are the first patch versions of each v0.33+ minor version that can be upgraded to Karpenter v1,
+// as they serve the v1 APIs through conversion webhooks.
+var v1CompatibleVersions = []string{"0.33.6", "0.34.7", "0.35.6", "0.36.2", "0.37.0"}
+
+// crdNames are the CRDs that the Karpenter CRD chart manages across Karpenter versions.
+var crdNames = []string{
+	"nodepools.karpenter.sh",
+	"nodeclaims.karpenter.sh",
+	"ec2nodeclasses.karpenter.k8s.aws",
+	"provisioners.karpenter.sh",
+	"machines.karpenter.sh",
+	"awsnodetemplates.karpenter.k8s.aws",
+}
+
+var crdResource = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// ValidateUpgrade checks that Karpenter can be upgraded from installedVersion to version. Downgrades, upgrades
+// across the v1alpha5 to v1beta1 API change, upgrades to v1 from versions that do not serve the v1 APIs and
+// upgrades that skip a major version are rejected.
+func ValidateUpgrade(installedVersion, version string) error {
+	installed, err := semver.ParseTolerant(installedVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse installed Karpenter version %q: %w", installedVersion, err)
+	}
+	target, err := semver.ParseTolerant(version)
+	if err != nil {
+		return fmt.Errorf("failed to parse Karpenter version %q: %w", version, err)
+	}
+
+	switch {
+	case target.LT(installed):
+		return fmt.Errorf("cannot downgrade Karpenter from version %s to %s", installedVersion, version)
+	case installed.Major == 0 && installed.Minor < 33 && (target.Major > 0 || target.Minor >= 33):
+		return fmt.Errorf("cannot upgrade Karpenter from version %s to %s, versions before 0.33.0 must be migrated to the v1beta1 APIs manually", installedVersion, version)
+	case target.Major > installed.Major+1:
+		return fmt.Errorf("cannot upgrade Karpenter from version %s to %s, upgrade to version %d first", installedVersion, version, installed.Major+1)
+	case installed.Major == 0 && target.Major == 1 && !isV1Compatible(installed):
+		return fmt.Errorf("cannot upgrade Karpenter from version %s to %s, upgrade to the latest patch version of %d.%d first (one of %s)",
+			installedVersion, version, installed.Major, installed.Minor, strings.Join(v1CompatibleVersions, ", "))
+	}
+	return nil
+}
+
+// isV1Compatible returns whether a v0.x version can be upgraded to Karpenter v1.
+func isV1Compatible(installed semver.Version) bool {
+	for _, v := range v1CompatibleVersions {
+		compatible := semver.MustParse(v)
+		if installed.Minor == compatible.Minor {
+			return installed.GTE(compatible)
+		}
+	}
+	return installed.Minor > 37
+}
+
+// AdoptCRDs labels and annotates the Karpenter CRDs on the cluster as part of the Karpenter CRD release, so that
+// the CRD chart can take over CRDs that were installed from the crds directory of the Karpenter chart. Helm does
+// not upgrade CRDs in that directory, so they are only upgraded once they are managed by the CRD chart.
+func AdoptCRDs(ctx context.Context, client dynamic.Interface) error {
+	patch := fmt.Sprintf(`{"metadata":{"labels":{"app.kubernetes.io/managed-by":"Helm"},"annotations":{"meta.helm.sh/release-name":%q,"meta.helm.sh/release-namespace":%q}}}`,
+		crdReleaseName, DefaultNamespace)
+	for _, name := range crdNames {
+		if _, err := client.Resource(crdResource).Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{FieldManager: fieldManager}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to adopt CRD %q into the %s release: %w", name, crdReleaseName, err)
+		}
+		logger.Debug("adopted CRD %q into the %s release", name, crdReleaseName)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package karpenter
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var _ = Describe("Upgrade", func() {
+	DescribeTable("ValidateUpgrade", func(installedVersion, version, expectedErr string) {
+		err := ValidateUpgrade(installedVersion, version)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+			return
+		}
+		Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("same version", "1.0.6", "1.0.6", ""),
+		Entry("patch upgrade", "1.0.5", "1.0.6", ""),
+		Entry("minor upgrade", "1.0.6", "1.1.0", ""),
+		Entry("v1beta1 upgrade", "0.33.0", "0.37.0", ""),
+		Entry("v1 upgrade from a compatible version", "0.36.2", "1.0.6", ""),
+		Entry("v1 upgrade from 0.37", "0.37.0", "1.1.0", ""),
+		Entry("downgrade", "1.1.0", "1.0.6", "cannot downgrade Karpenter from version 1.1.0 to 1.0.6"),
+		Entry("upgrade across the v1beta1 APIs", "0.32.10", "0.33.0", "versions before 0.33.0 must be migrated to the v1beta1 APIs manually"),
+		Entry("v1 upgrade from an incompatible patch version", "0.36.1", "1.0.6", "upgrade to the latest patch version of 0.36 first"),
+		Entry("skipping a major version", "0.37.0", "2.0.0", "upgrade to version 1 first"),
+		Entry("invalid installed version", "latest", "1.0.6", `failed to parse installed Karpenter version "latest"`),
+	)
+
+	It("AdoptCRDs adds the Helm ownership metadata of the CRD release to existing CRDs", func() {
+		crdGVR := schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+		newCRD := func(name string) *unstructured.Unstructured {
+			return &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "apiextensions.k8s.io/v1",
+				"kind":       "CustomResourceDefinition",
+				"metadata": map[string]interface{}{
+					"name": name,
+				},
+			}}
+		}
+		client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			crdGVR: "CustomResourceDefinitionList",
+		}, newCRD("nodepools.karpenter.sh"), newCRD("ec2nodeclasses.karpenter.k8s.aws"))
+
+		Expect(AdoptCRDs(context.Background(), client)).To(Succeed())
+
+		for _, name := range []string{"nodepools.karpenter.sh", "ec2nodeclasses.karpenter.k8s.aws"} {
+			crd, err := client.Resource(crdGVR).Get(context.Background(), name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(crd.GetLabels()).To(HaveKeyWithValue("app.kubernetes.io/managed-by", "Helm"))
+			Expect(crd.GetAnnotations()).To(Equal(map[string]string{
+				"meta.helm.sh/release-name":      "karpenter-crd",
+				"meta.helm.sh/release-namespace": "karpenter",
+			}))
+		}
+	})
+})
@@ -59,19 +59,12 @@ func (m *AL2023) UserData() (string, error) {
 		m.nodeConfigs = append(m.nodeConfigs, minimalNodeConfig)
 	}
 
-	if ng.MaxPodsPerNode > 0 {
-		nodeConfig := &nodeadm.NodeConfig{
-			TypeMeta: metav1.TypeMeta{
-				Kind:       nodeadmapi.KindNodeConfig,
-				APIVersion: nodeadm.GroupVersion.String(),
-			},
-		}
-		kubeletConfig, err := ToKubeletConfig(api.InlineDocument{"maxPods": ng.MaxPodsPerNode})
-		if err != nil {
-			return "", err
-		}
-		nodeConfig.Spec.Kubelet.Config = kubeletConfig
-		m.nodeConfigs = append(m.nodeConfigs, nodeConfig)
+	kubeletNodeConfig, err := m.createKubeletNodeConfig()
+	if err != nil {
+		return "", err
+	}
+	if kubeletNodeConfig != nil {
+		m.nodeConfigs = append(m.nodeConfigs, kubeletNodeConfig)
 	}
 
 	for _, command := range m.nodePool.BaseNodeGroup().PreBootstrapCommands {
@@ -150,6 +143,41 @@ func (m *AL2023) createMinimalNodeConfig() (*nodeadm.NodeConfig, error) {
 	}, nil
 }
 
+// createKubeletNodeConfig returns a NodeConfig for maxPods and the kubelet settings of the nodegroup, if any are set.
+// It is kept separate from the minimal NodeConfig, which is not generated for managed nodegroups using the EKS AMI.
+func (m *AL2023) createKubeletNodeConfig() (*nodeadm.NodeConfig, error) {
+	ng := m.nodePool.BaseNodeGroup()
+	kubeletConfig := api.InlineDocument{}
+	if ng.Kubelet != nil {
+		var err error
+		if kubeletConfig, err = ng.Kubelet.ToKubeletConfig(); err != nil {
+			return nil, err
+		}
+	}
+	if maxPods := ng.GetMaxPodsPerNode(); maxPods > 0 {
+		kubeletConfig["maxPods"] = maxPods
+	}
+	if len(kubeletConfig) == 0 {
+		return nil, nil
+	}
+
+	nodeKubeletConfig, err := ToKubeletConfig(kubeletConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &nodeadm.NodeConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       nodeadmapi.KindNodeConfig,
+			APIVersion: nodeadm.GroupVersion.String(),
+		},
+		Spec: nodeadm.NodeConfigSpec{
+			Kubelet: nodeadm.KubeletOptions{
+				Config: nodeKubeletConfig,
+			},
+		},
+	}, nil
+}
+
 // ToKubeletConfig generates a kubelet config that can be used with nodeadm.NodeConfig.
 func ToKubeletConfig(kubeletExtraConfig api.InlineDocument) (map[string]runtime.RawExtension, error) {
 	kubeletConfig := map[string]runtime.RawExtension{}
@@ -275,6 +275,62 @@ var _ = DescribeTable("AL2023 override node config", func(e al2023OverrideNodeCo
 		},
 	}),
 
+	Entry("nodegroup with kubelet settings", al2023OverrideNodeConfigEntry{
+		updateNodeGroup: func(ng *api.NodeGroup) {
+			ng.Kubelet = &api.NodeGroupKubelet{
+				MaxPods: aws.Int(11),
+				EvictionHard: map[string]string{
+					"memory.available": "200Mi",
+				},
+				ImageGCHighThresholdPercent: aws.Int(85),
+				CPUManagerPolicy:            api.KubeletCPUManagerPolicyStatic,
+			}
+		},
+
+		expectedNodeConfigs: []nodeadm.NodeConfig{
+			{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       nodeadmapi.KindNodeConfig,
+					APIVersion: nodeadm.GroupVersion.String(),
+				},
+				Spec: nodeadm.NodeConfigSpec{
+					Cluster: nodeadm.ClusterDetails{
+						APIServerEndpoint:    "https://test.xxx.us-west-2.eks.amazonaws.com",
+						CertificateAuthority: []byte("test CA"),
+						CIDR:                 "10.100.0.0/16",
+						Name:                 "al2023-test",
+					},
+					Kubelet: nodeadm.KubeletOptions{
+						Config: mustToKubeletConfig(map[string]interface{}{
+							"clusterDNS": []string{"10.100.0.10"},
+						}),
+						Flags: []string{
+							"--node-labels=",
+						},
+					},
+				},
+			},
+			{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       nodeadmapi.KindNodeConfig,
+					APIVersion: nodeadm.GroupVersion.String(),
+				},
+				Spec: nodeadm.NodeConfigSpec{
+					Kubelet: nodeadm.KubeletOptions{
+						Config: mustToKubeletConfig(map[string]interface{}{
+							"maxPods": 11,
+							"evictionHard": map[string]string{
+								"memory.available": "200Mi",
+							},
+							"imageGCHighThresholdPercent": 85,
+							"cpuManagerPolicy":            "static",
+						}),
+					},
+				},
+			},
+		},
+	}),
+
 	Entry("nodegroup with overrideBootstrapCommand", al2023OverrideNodeConfigEntry{
 		updateNodeGroup: func(ng *api.NodeGroup) {
 			nodeConfig := nodeadm.NodeConfig{
@@ -222,6 +222,32 @@ CONTAINER_RUNTIME=`, "\n")))
 		})
 	})
 
+	When("kubelet settings are provided by the user", func() {
+		BeforeEach(func() {
+			ng.KubeletExtraConfig = &api.InlineDocument{"foo": "bar"}
+			ng.Kubelet = &api.NodeGroupKubelet{
+				MaxPods: aws.Int(50),
+				KubeReserved: map[string]string{
+					"cpu": "300m",
+				},
+				TopologyManagerScope: api.KubeletTopologyManagerScopePod,
+			}
+			bootstrapper = newBootstrapper(clusterConfig, ng)
+		})
+
+		It("merges them into the kubelet extra args file and sets MAX_PODS in the env file", func() {
+			userData, err := bootstrapper.UserData()
+			Expect(err).NotTo(HaveOccurred())
+
+			cloudCfg := decode(userData)
+			Expect(cloudCfg.WriteFiles[0].Path).To(Equal("/etc/eksctl/kubelet-extra.json"))
+			Expect(cloudCfg.WriteFiles[0].Content).To(MatchJSON(`{"foo":"bar","kubeReserved":{"cpu":"300m"},"topologyManagerScope":"pod"}`))
+			Expect(cloudCfg.WriteFiles[1].Path).To(Equal("/etc/eksctl/kubelet.env"))
+			Expect(strings.Split(cloudCfg.WriteFiles[1].Content, "\n")).To(ContainElement("MAX_PODS=50"))
+			Expect(*ng.KubeletExtraConfig).To(Equal(api.InlineDocument{"foo": "bar"}))
+		})
+	})
+
 	When("labels are set on the node config", func() {
 		BeforeEach(func() {
 			ng.Labels = map[string]string{"foo": "bar"}
@@ -66,8 +66,11 @@ func setDerivedBottlerocketSettings(np api.NodePool) error {
 	if taints := np.NGTaints(); len(taints) != 0 {
 		kubernetesSettings["node-taints"] = taintsToMap(taints)
 	}
-	if ng.MaxPodsPerNode != 0 {
-		kubernetesSettings["max-pods"] = ng.MaxPodsPerNode
+	if maxPods := ng.GetMaxPodsPerNode(); maxPods != 0 {
+		kubernetesSettings["max-pods"] = maxPods
+	}
+	if err := setBottlerocketKubeletSettings(kubernetesSettings, ng); err != nil {
+		return err
 	}
 
 	if ng, ok := np.(*api.NodeGroup); ok {
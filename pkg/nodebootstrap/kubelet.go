@@ -0,0 +1,120 @@
+package nodebootstrap
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// A kubeletFlag is a kubelet command-line flag, for AMI families whose kubelet is configured through flags.
+type kubeletFlag struct {
+	name  string
+	value string
+}
+
+// kubeletFlags returns the command-line flags for the settings in kubelet, except maxPods.
+func kubeletFlags(kubelet *api.NodeGroupKubelet) []kubeletFlag {
+	if kubelet == nil {
+		return nil
+	}
+	var flags []kubeletFlag
+	addFlag := func(name, value string) {
+		if value != "" {
+			flags = append(flags, kubeletFlag{name: name, value: value})
+		}
+	}
+	addFlag("eviction-hard", formatMap(kubelet.EvictionHard, "<"))
+	addFlag("eviction-soft", formatMap(kubelet.EvictionSoft, "<"))
+	addFlag("eviction-soft-grace-period", formatMap(kubelet.EvictionSoftGracePeriod, "="))
+	addFlag("kube-reserved", formatMap(kubelet.KubeReserved, "="))
+	addFlag("system-reserved", formatMap(kubelet.SystemReserved, "="))
+	if kubelet.ImageGCHighThresholdPercent != nil {
+		addFlag("image-gc-high-threshold", strconv.Itoa(*kubelet.ImageGCHighThresholdPercent))
+	}
+	if kubelet.ImageGCLowThresholdPercent != nil {
+		addFlag("image-gc-low-threshold", strconv.Itoa(*kubelet.ImageGCLowThresholdPercent))
+	}
+	addFlag("image-maximum-gc-age", kubelet.ImageMaximumGCAge)
+	addFlag("cpu-manager-policy", kubelet.CPUManagerPolicy)
+	addFlag("topology-manager-policy", kubelet.TopologyManagerPolicy)
+	addFlag("topology-manager-scope", kubelet.TopologyManagerScope)
+	return flags
+}
+
+// formatMap formats m as a comma-separated list of key-value pairs sorted by key.
+func formatMap(m map[string]string, separator string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+separator+m[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// mergeKubeletConfig returns a copy of kubeletExtraConfig with the settings in kubelet, except maxPods, added to it.
+func mergeKubeletConfig(kubeletExtraConfig *api.InlineDocument, kubelet *api.NodeGroupKubelet) (*api.InlineDocument, error) {
+	if kubelet == nil {
+		return kubeletExtraConfig, nil
+	}
+	merged := api.InlineDocument{}
+	if kubeletExtraConfig != nil {
+		merged = *kubeletExtraConfig.DeepCopy()
+	}
+	kubeletConfig, err := kubelet.ToKubeletConfig()
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range kubeletConfig {
+		merged[k] = v
+	}
+	return &merged, nil
+}
+
+// setBottlerocketKubeletSettings sets the Bottlerocket Kubernetes settings for the kubelet settings in ng,
+// except maxPods.
+func setBottlerocketKubeletSettings(kubernetesSettings map[string]interface{}, ng *api.NodeGroupBase) error {
+	kubelet := ng.Kubelet
+	if kubelet == nil {
+		return nil
+	}
+	settings := map[string]interface{}{}
+	setMap := func(key string, m map[string]string) {
+		if len(m) > 0 {
+			settings[key] = m
+		}
+	}
+	setString := func(key, value string) {
+		if value != "" {
+			settings[key] = value
+		}
+	}
+	setMap("eviction-hard", kubelet.EvictionHard)
+	setMap("eviction-soft", kubelet.EvictionSoft)
+	setMap("eviction-soft-grace-period", kubelet.EvictionSoftGracePeriod)
+	setMap("kube-reserved", kubelet.KubeReserved)
+	setMap("system-reserved", kubelet.SystemReserved)
+	if kubelet.ImageGCHighThresholdPercent != nil {
+		settings["image-gc-high-threshold-percent"] = *kubelet.ImageGCHighThresholdPercent
+	}
+	if kubelet.ImageGCLowThresholdPercent != nil {
+		settings["image-gc-low-threshold-percent"] = *kubelet.ImageGCLowThresholdPercent
+	}
+	setString("cpu-manager-policy", kubelet.CPUManagerPolicy)
+	setString("topology-manager-policy", kubelet.TopologyManagerPolicy)
+	setString("topology-manager-scope", kubelet.TopologyManagerScope)
+
+	for key, value := range settings {
+		if _, ok := kubernetesSettings[key]; ok {
+			return fmt.Errorf("cannot set settings.kubernetes.%s in bottlerocket.settings when it is set by kubelet", key)
+		}
+		kubernetesSettings[key] = value
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"strings"
 
 	nodeadm "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1alpha1"
 	"sigs.k8s.io/yaml"
@@ -47,8 +48,8 @@ func (m *ManagedAL2) UserData() (string, error) {
 
 	if ng.OverrideBootstrapCommand != nil {
 		scripts = append(scripts, *ng.OverrideBootstrapCommand)
-	} else if ng.MaxPodsPerNode != 0 {
-		scripts = append(scripts, makeMaxPodsScript(ng.MaxPodsPerNode))
+	} else if args := makeKubeletExtraArgs(ng.NodeGroupBase); len(args) > 0 {
+		scripts = append(scripts, makeKubeletExtraArgsScript(args))
 	}
 
 	if len(scripts) == 0 && len(cloudboot) == 0 {
@@ -87,11 +88,23 @@ func makeCustomAMIUserData(ng *api.NodeGroupBase, mimeBoundary string) (string,
 	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
 }
 
-func makeMaxPodsScript(maxPods int) string {
+// makeKubeletExtraArgs returns the kubelet flags for maxPods and the kubelet settings of ng.
+func makeKubeletExtraArgs(ng *api.NodeGroupBase) []string {
+	var args []string
+	if maxPods := ng.GetMaxPodsPerNode(); maxPods != 0 {
+		args = append(args, fmt.Sprintf("--max-pods=%d", maxPods))
+	}
+	for _, flag := range kubeletFlags(ng.Kubelet) {
+		args = append(args, fmt.Sprintf("--%s=%s", flag.name, flag.value))
+	}
+	return args
+}
+
+func makeKubeletExtraArgsScript(args []string) string {
 	script := `#!/bin/sh
 set -ex
 `
-	script += fmt.Sprintf(`sed -i 's/KUBELET_EXTRA_ARGS=$2/KUBELET_EXTRA_ARGS="$2 --max-pods=%d"/' /etc/eks/bootstrap.sh`, maxPods)
+	script += fmt.Sprintf(`sed -i 's/KUBELET_EXTRA_ARGS=$2/KUBELET_EXTRA_ARGS="$2 %s"/' /etc/eks/bootstrap.sh`, strings.Join(args, " "))
 	return script
 }
 
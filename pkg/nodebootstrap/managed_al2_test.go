@@ -110,6 +110,38 @@ Content-Type: charset="us-ascii"
 set -ex
 sed -i 's/KUBELET_EXTRA_ARGS=$2/KUBELET_EXTRA_ARGS="$2 --max-pods=142"/' /etc/eks/bootstrap.sh
 --//--
+`,
+	}),
+
+	Entry("kubelet set", managedEntry{
+		ng: &api.ManagedNodeGroup{
+			NodeGroupBase: &api.NodeGroupBase{
+				Name: "ng",
+				Kubelet: &api.NodeGroupKubelet{
+					MaxPods: aws.Int(50),
+					EvictionHard: map[string]string{
+						"nodefs.available": "10%",
+						"memory.available": "200Mi",
+					},
+					KubeReserved: map[string]string{
+						"cpu": "300m",
+					},
+					ImageGCHighThresholdPercent: aws.Int(85),
+					CPUManagerPolicy:            api.KubeletCPUManagerPolicyStatic,
+				},
+			},
+		},
+		expectedUserData: `MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary=//
+
+--//
+Content-Type: text/x-shellscript
+Content-Type: charset="us-ascii"
+
+#!/bin/sh
+set -ex
+sed -i 's/KUBELET_EXTRA_ARGS=$2/KUBELET_EXTRA_ARGS="$2 --max-pods=50 --eviction-hard=memory.available<200Mi,nodefs.available<10% --kube-reserved=cpu=300m --image-gc-high-threshold=85 --cpu-manager-policy=static"/' /etc/eks/bootstrap.sh
+--//--
 `,
 	}),
 )
@@ -66,11 +66,11 @@ func (b *ManagedBottlerocket) setDerivedSettings() error {
 		return err
 	}
 
-	if b.ng.MaxPodsPerNode != 0 {
-		kubernetesSettings["max-pods"] = b.ng.MaxPodsPerNode
+	if maxPods := b.ng.GetMaxPodsPerNode(); maxPods != 0 {
+		kubernetesSettings["max-pods"] = maxPods
 	}
 
-	return nil
+	return setBottlerocketKubeletSettings(kubernetesSettings, b.ng.NodeGroupBase)
 }
 
 // validateBottlerocketSettings validates the supplied Kubernetes settings to ensure fields related to bootstrapping
@@ -3,6 +3,7 @@ package nodebootstrap_test
 import (
 	"encoding/base64"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -67,6 +68,45 @@ var _ = Describe("Managed Bottlerocket", func() {
 `,
 		}),
 
+		Entry("kubelet set", bottlerocketEntry{
+			setFields: func(ng *api.ManagedNodeGroup) {
+				ng.Kubelet = &api.NodeGroupKubelet{
+					MaxPods: aws.Int(50),
+					EvictionHard: map[string]string{
+						"memory.available": "200Mi",
+					},
+					ImageGCHighThresholdPercent: aws.Int(85),
+					TopologyManagerPolicy:       api.KubeletTopologyManagerPolicyBestEffort,
+				}
+			},
+			expectedUserData: `
+[settings]
+
+  [settings.kubernetes]
+    image-gc-high-threshold-percent = 85
+    max-pods = 50
+    topology-manager-policy = "best-effort"
+
+    [settings.kubernetes.eviction-hard]
+      "memory.available" = "200Mi"
+`,
+		}),
+
+		Entry("kubelet setting also set in Bottlerocket settings", bottlerocketEntry{
+			setFields: func(ng *api.ManagedNodeGroup) {
+				ng.Kubelet = &api.NodeGroupKubelet{
+					CPUManagerPolicy: api.KubeletCPUManagerPolicyStatic,
+				}
+				ng.Bottlerocket.Settings = &api.InlineDocument{
+					"kubernetes": map[string]interface{}{
+						"cpu-manager-policy": "none",
+					},
+				}
+			},
+
+			expectedErr: "cannot set settings.kubernetes.cpu-manager-policy in bottlerocket.settings when it is set by kubelet",
+		}),
+
 		Entry("enableAdminContainer set", bottlerocketEntry{
 			setFields: func(ng *api.ManagedNodeGroup) {
 				ng.Bottlerocket.EnableAdminContainer = api.Enabled()
@@ -0,0 +1,447 @@
+package nodebootstrap
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"slices"
+	"sort"
+	"strings"
+
+	nodeadm "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1alpha1"
+	toml "github.com/pelletier/go-toml"
+	kubeletapi "k8s.io/kubelet/config/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cloudconfig"
+)
+
+// UserDataFormat is the format of the user data of a nodegroup.
+type UserDataFormat string
+
+const (
+	// UserDataFormatNone is used when a nodegroup has no user data.
+	UserDataFormatNone UserDataFormat = "none"
+	// UserDataFormatCloudConfig is a gzipped cloud-config document, used by self-managed AmazonLinux2 and Ubuntu nodegroups.
+	UserDataFormatCloudConfig UserDataFormat = "cloud-config"
+	// UserDataFormatMIME is a MIME multi-part document, used by AmazonLinux2023 and managed AmazonLinux2 nodegroups.
+	UserDataFormatMIME UserDataFormat = "mime-multipart"
+	// UserDataFormatTOML is a TOML document of Bottlerocket settings.
+	UserDataFormatTOML UserDataFormat = "toml"
+	// UserDataFormatPowerShell is a PowerShell script, used by Windows nodegroups.
+	UserDataFormatPowerShell UserDataFormat = "powershell"
+)
+
+// Severity is the severity of a problem found in user data.
+type Severity string
+
+const (
+	// SeverityError is used for problems that prevent nodes from joining the cluster, or that fail nodegroup creation.
+	SeverityError Severity = "error"
+	// SeverityWarning is used for problems that may prevent nodes from joining the cluster.
+	SeverityWarning Severity = "warning"
+)
+
+// Problem is a problem found in user data.
+type Problem struct {
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// UserDataPart is a decoded part of user data.
+type UserDataPart struct {
+	// Name describes the part, e.g. the MIME content type of the part or the path of a cloud-config file.
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// RenderedUserData is the decoded user data of a nodegroup, along with the problems found in it.
+type RenderedUserData struct {
+	NodeGroup string         `json:"nodeGroup"`
+	Managed   bool           `json:"managed"`
+	AMIFamily string         `json:"amiFamily"`
+	Format    UserDataFormat `json:"format"`
+	Parts     []UserDataPart `json:"parts,omitempty"`
+	// KubeletConfig is the kubelet configuration set by the user data, merged from all the documents setting it.
+	KubeletConfig map[string]interface{} `json:"kubeletConfig,omitempty"`
+	Problems      []Problem              `json:"problems,omitempty"`
+}
+
+// HasErrors reports whether an error was found in the user data.
+func (r *RenderedUserData) HasErrors() bool {
+	for _, p := range r.Problems {
+		if p.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RenderedUserData) addProblem(severity Severity, format string, a ...interface{}) {
+	r.Problems = append(r.Problems, Problem{
+		Severity: severity,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+// RenderUserData generates the user data of np, which must be a *api.NodeGroup or a *api.ManagedNodeGroup,
+// decodes it and validates it. Errors generating the user data are reported as problems.
+func RenderUserData(clusterConfig *api.ClusterConfig, np api.NodePool) (*RenderedUserData, error) {
+	ng := np.BaseNodeGroup()
+	rendered := &RenderedUserData{
+		NodeGroup: ng.Name,
+		AMIFamily: ng.AMIFamily,
+		Format:    UserDataFormatNone,
+	}
+
+	var (
+		bootstrapper Bootstrapper
+		err          error
+	)
+	switch np := np.(type) {
+	case *api.NodeGroup:
+		bootstrapper, err = NewBootstrapper(clusterConfig, np)
+	case *api.ManagedNodeGroup:
+		rendered.Managed = true
+		bootstrapper, err = NewManagedBootstrapper(clusterConfig, np)
+		if err == nil && bootstrapper == nil {
+			err = fmt.Errorf("unrecognized AMI family %q for creating bootstrapper", ng.AMIFamily)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected nodegroup type %T", np)
+	}
+	if err != nil {
+		rendered.addProblem(SeverityError, "%v", err)
+		return rendered, nil
+	}
+
+	checkBootstrapCommands(rendered, ng)
+
+	userData, err := bootstrapper.UserData()
+	if err != nil {
+		rendered.addProblem(SeverityError, "generating user data: %v", err)
+		return rendered, nil
+	}
+	if userData == "" {
+		return rendered, nil
+	}
+	if err := decodeUserData(rendered, userData); err != nil {
+		return nil, fmt.Errorf("decoding user data of nodegroup %q: %w", ng.Name, err)
+	}
+	if rendered.KubeletConfig != nil {
+		validateKubeletConfig(rendered)
+	}
+	return rendered, nil
+}
+
+func decodeUserData(rendered *RenderedUserData, userData string) error {
+	data, err := base64.StdEncoding.DecodeString(userData)
+	if err != nil {
+		return err
+	}
+	switch {
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		return decodeCloudConfig(rendered, userData)
+	case bytes.HasPrefix(data, []byte("MIME-Version:")):
+		return decodeMIME(rendered, data)
+	case rendered.AMIFamily == api.NodeImageFamilyBottlerocket:
+		return decodeBottlerocket(rendered, data)
+	case bytes.HasPrefix(data, []byte("<powershell>")):
+		rendered.Format = UserDataFormatPowerShell
+		rendered.Parts = append(rendered.Parts, UserDataPart{
+			Name:    "powershell",
+			Content: string(data),
+		})
+		return nil
+	default:
+		return errors.New("unrecognized user data format")
+	}
+}
+
+func decodeCloudConfig(rendered *RenderedUserData, userData string) error {
+	rendered.Format = UserDataFormatCloudConfig
+	config, err := cloudconfig.DecodeCloudConfig(userData)
+	if err != nil {
+		return err
+	}
+	commands, err := yaml.Marshal(config.Commands)
+	if err != nil {
+		return err
+	}
+	rendered.Parts = append(rendered.Parts, UserDataPart{
+		Name:    "runcmd",
+		Content: string(commands),
+	})
+
+	var env map[string]string
+	for _, f := range config.WriteFiles {
+		rendered.Parts = append(rendered.Parts, UserDataPart{
+			Name:    f.Path,
+			Content: f.Content,
+		})
+		switch f.Path {
+		case configDir + extraKubeConfFile:
+			if err := json.Unmarshal([]byte(f.Content), &rendered.KubeletConfig); err != nil {
+				return fmt.Errorf("parsing %s: %w", f.Path, err)
+			}
+		case configDir + envFile:
+			env = parseKeyValues(f.Content)
+		}
+	}
+
+	// the bootstrap script passes these to kubelet along with the extra kubelet config
+	if rendered.KubeletConfig == nil {
+		rendered.KubeletConfig = map[string]interface{}{}
+	}
+	if clusterDNS := env["CLUSTER_DNS"]; clusterDNS != "" {
+		rendered.KubeletConfig["clusterDNS"] = []interface{}{clusterDNS}
+	}
+	if maxPods := env["MAX_PODS"]; maxPods != "" {
+		var n float64
+		if err := json.Unmarshal([]byte(maxPods), &n); err == nil {
+			rendered.KubeletConfig["maxPods"] = n
+		}
+	}
+	return nil
+}
+
+func parseKeyValues(content string) map[string]string {
+	kv := map[string]string{}
+	for _, line := range strings.Split(content, "\n") {
+		if k, v, ok := strings.Cut(line, "="); ok {
+			kv[k] = v
+		}
+	}
+	return kv
+}
+
+func decodeMIME(rendered *RenderedUserData, data []byte) error {
+	rendered.Format = UserDataFormatMIME
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return err
+		}
+		contentType := part.Header.Get("Content-Type")
+		rendered.Parts = append(rendered.Parts, UserDataPart{
+			Name:    contentType,
+			Content: string(content),
+		})
+		if strings.HasPrefix(contentType, "application/node.eks.aws") {
+			if err := mergeNodeConfig(rendered, content); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// mergeNodeConfig merges the kubelet config of a nodeadm NodeConfig into the kubelet config of rendered,
+// the way nodeadm merges the NodeConfig documents in user data.
+func mergeNodeConfig(rendered *RenderedUserData, data []byte) error {
+	var nodeConfig nodeadm.NodeConfig
+	if err := yaml.Unmarshal(data, &nodeConfig); err != nil {
+		rendered.addProblem(SeverityError, "invalid NodeConfig: %v", err)
+		return nil
+	}
+	if len(nodeConfig.Spec.Kubelet.Config) == 0 {
+		return nil
+	}
+	if rendered.KubeletConfig == nil {
+		rendered.KubeletConfig = map[string]interface{}{}
+	}
+	for k, v := range nodeConfig.Spec.Kubelet.Config {
+		var value interface{}
+		if err := json.Unmarshal(v.Raw, &value); err != nil {
+			return fmt.Errorf("parsing kubelet config field %q: %w", k, err)
+		}
+		rendered.KubeletConfig[k] = value
+	}
+	return nil
+}
+
+func decodeBottlerocket(rendered *RenderedUserData, data []byte) error {
+	rendered.Format = UserDataFormatTOML
+	rendered.Parts = append(rendered.Parts, UserDataPart{
+		Name:    "settings",
+		Content: string(data),
+	})
+	tree, err := toml.LoadBytes(data)
+	if err != nil {
+		return err
+	}
+	settings, ok := tree.ToMap()["settings"].(map[string]interface{})
+	if !ok {
+		return errors.New("expected settings table in Bottlerocket user data")
+	}
+	for _, key := range unknownKeys(settings, knownBottlerocketSettings) {
+		rendered.addProblem(SeverityError, "unknown Bottlerocket setting %q", "settings."+key)
+	}
+	if kubernetes, ok := settings["kubernetes"].(map[string]interface{}); ok {
+		for _, key := range unknownKeys(kubernetes, knownBottlerocketKubernetesSettings) {
+			rendered.addProblem(SeverityError, "unknown Bottlerocket setting %q", "settings.kubernetes."+key)
+		}
+	}
+	return nil
+}
+
+func unknownKeys(m map[string]interface{}, known []string) []string {
+	var unknown []string
+	for key := range m {
+		if !slices.Contains(known, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// validateKubeletConfig checks that the kubelet config of rendered only contains valid KubeletConfiguration fields.
+func validateKubeletConfig(rendered *RenderedUserData) {
+	data, err := json.Marshal(rendered.KubeletConfig)
+	if err != nil {
+		rendered.addProblem(SeverityError, "invalid kubelet config: %v", err)
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&kubeletapi.KubeletConfiguration{}); err != nil {
+		rendered.addProblem(SeverityError, "invalid kubelet config: %v", err)
+	}
+}
+
+// checkBootstrapCommands checks that preBootstrapCommands do not bootstrap the node when overrideBootstrapCommand,
+// or the AMI, already does.
+func checkBootstrapCommands(rendered *RenderedUserData, ng *api.NodeGroupBase) {
+	runs := func(command string) bool {
+		for _, c := range ng.PreBootstrapCommands {
+			if strings.Contains(c, command) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case ng.AMIFamily == api.NodeImageFamilyAmazonLinux2023:
+		if runs("nodeadm init") {
+			rendered.addProblem(SeverityError, "preBootstrapCommands run `nodeadm init`, which is run by the AMI; use NodeConfig documents in overrideBootstrapCommand to configure nodeadm instead")
+		}
+	case api.IsWindowsImage(ng.AMIFamily), ng.AMIFamily == api.NodeImageFamilyBottlerocket:
+	case ng.OverrideBootstrapCommand != nil:
+		const bootstrapScript = "/etc/eks/bootstrap.sh"
+		if runs(bootstrapScript) {
+			rendered.addProblem(SeverityError, "both preBootstrapCommands and overrideBootstrapCommand run %s; the node would be bootstrapped twice", bootstrapScript)
+		}
+		if !strings.Contains(*ng.OverrideBootstrapCommand, bootstrapScript) {
+			rendered.addProblem(SeverityWarning, "overrideBootstrapCommand does not run %s; nodes may not join the cluster", bootstrapScript)
+		}
+	}
+}
+
+var knownBottlerocketSettings = []string{
+	"autoscaling",
+	"aws",
+	"boot",
+	"bootstrap-commands",
+	"bootstrap-containers",
+	"cloudformation",
+	"container-registry",
+	"container-runtime",
+	"container-runtime-plugins",
+	"dns",
+	"ecs",
+	"host-containers",
+	"kernel",
+	"kubelet-device-plugins",
+	"kubernetes",
+	"metrics",
+	"motd",
+	"network",
+	"ntp",
+	"nvidia-container-runtime",
+	"oci-defaults",
+	"oci-hooks",
+	"pki",
+	"updates",
+}
+
+var knownBottlerocketKubernetesSettings = []string{
+	"allowed-unsafe-sysctls",
+	"api-server",
+	"authentication-mode",
+	"bootstrap-token",
+	"cloud-provider",
+	"cluster-certificate",
+	"cluster-dns-ip",
+	"cluster-domain",
+	"cluster-name",
+	"container-log-max-files",
+	"container-log-max-size",
+	"cpu-cfs-quota-enforced",
+	"cpu-manager-policy",
+	"cpu-manager-policy-options",
+	"cpu-manager-reconcile-period",
+	"credential-providers",
+	"device-ownership-from-security-context",
+	"event-burst",
+	"event-qps",
+	"eviction-hard",
+	"eviction-max-pod-grace-period",
+	"eviction-soft",
+	"eviction-soft-grace-period",
+	"hostname-override",
+	"hostname-override-source",
+	"image-gc-high-threshold-percent",
+	"image-gc-low-threshold-percent",
+	"kube-api-burst",
+	"kube-api-qps",
+	"kube-reserved",
+	"log-level",
+	"max-pods",
+	"memory-manager-policy",
+	"memory-manager-reserved-memory",
+	"node-ip",
+	"node-labels",
+	"node-taints",
+	"pod-infra-container-image",
+	"pod-pids-limit",
+	"provider-id",
+	"registry-burst",
+	"registry-qps",
+	"reserved-cpus",
+	"seccomp-default",
+	"server-certificate",
+	"server-key",
+	"server-tls-bootstrap",
+	"shutdown-grace-period",
+	"shutdown-grace-period-for-critical-pods",
+	"single-process-oom-kill",
+	"standalone-mode",
+	"static-pods",
+	"system-reserved",
+	"topology-manager-policy",
+	"topology-manager-scope",
+}
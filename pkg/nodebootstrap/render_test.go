@@ -0,0 +1,223 @@
+package nodebootstrap_test
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/nodebootstrap"
+)
+
+type renderEntry struct {
+	nodePool func() api.NodePool
+
+	expectedFormat        nodebootstrap.UserDataFormat
+	expectedParts         []string
+	expectedKubeletConfig map[string]interface{}
+	expectedProblems      []nodebootstrap.Problem
+}
+
+var _ = DescribeTable("RenderUserData", func(e renderEntry) {
+	cfg, _ := makeDefaultClusterSettings()
+	cfg.Metadata.Version = api.DefaultVersion
+	np := e.nodePool()
+	switch ng := np.(type) {
+	case *api.NodeGroup:
+		api.SetNodeGroupDefaults(ng, cfg.Metadata, false)
+	case *api.ManagedNodeGroup:
+		api.SetManagedNodeGroupDefaults(ng, cfg.Metadata, false)
+	}
+
+	rendered, err := nodebootstrap.RenderUserData(cfg, np)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(rendered.Format).To(Equal(e.expectedFormat))
+	var parts []string
+	for _, p := range rendered.Parts {
+		parts = append(parts, p.Name)
+	}
+	Expect(parts).To(Equal(e.expectedParts))
+	if e.expectedKubeletConfig != nil {
+		Expect(rendered.KubeletConfig).To(Equal(e.expectedKubeletConfig))
+	}
+	Expect(rendered.Problems).To(Equal(e.expectedProblems))
+	Expect(rendered.HasErrors()).To(Equal(hasErrors(e.expectedProblems)))
+},
+	Entry("AmazonLinux2", renderEntry{
+		nodePool: func() api.NodePool {
+			ng := api.NewNodeGroup()
+			ng.Name = "al2"
+			ng.AMIFamily = api.NodeImageFamilyAmazonLinux2
+			ng.MaxPodsPerNode = 20
+			ng.KubeletExtraConfig = &api.InlineDocument{
+				"kubeReserved": map[string]interface{}{
+					"cpu": "300m",
+				},
+			}
+			return ng
+		},
+		expectedFormat: nodebootstrap.UserDataFormatCloudConfig,
+		expectedParts: []string{
+			"runcmd",
+			"/etc/eksctl/kubelet-extra.json",
+			"/etc/eksctl/kubelet.env",
+			"/var/lib/cloud/scripts/eksctl/bootstrap.al2.sh",
+			"/var/lib/cloud/scripts/eksctl/bootstrap.helper.sh",
+		},
+		expectedKubeletConfig: map[string]interface{}{
+			"kubeReserved": map[string]interface{}{
+				"cpu": "300m",
+			},
+			"clusterDNS": []interface{}{"10.100.0.10"},
+			"maxPods":    float64(20),
+		},
+	}),
+
+	Entry("AmazonLinux2 with an invalid kubelet config field", renderEntry{
+		nodePool: func() api.NodePool {
+			ng := api.NewNodeGroup()
+			ng.Name = "al2"
+			ng.AMIFamily = api.NodeImageFamilyAmazonLinux2
+			ng.KubeletExtraConfig = &api.InlineDocument{
+				"maxPod": 20,
+			}
+			return ng
+		},
+		expectedFormat: nodebootstrap.UserDataFormatCloudConfig,
+		expectedParts: []string{
+			"runcmd",
+			"/etc/eksctl/kubelet-extra.json",
+			"/etc/eksctl/kubelet.env",
+			"/var/lib/cloud/scripts/eksctl/bootstrap.al2.sh",
+			"/var/lib/cloud/scripts/eksctl/bootstrap.helper.sh",
+		},
+		expectedProblems: []nodebootstrap.Problem{
+			{
+				Severity: nodebootstrap.SeverityError,
+				Message:  `invalid kubelet config: json: unknown field "maxPod"`,
+			},
+		},
+	}),
+
+	Entry("AmazonLinux2 with conflicting bootstrap commands", renderEntry{
+		nodePool: func() api.NodePool {
+			ng := api.NewNodeGroup()
+			ng.Name = "al2"
+			ng.AMIFamily = api.NodeImageFamilyAmazonLinux2
+			ng.PreBootstrapCommands = []string{"/etc/eks/bootstrap.sh cluster"}
+			ng.OverrideBootstrapCommand = aws.String("echo bootstrapped")
+			return ng
+		},
+		expectedFormat: nodebootstrap.UserDataFormatCloudConfig,
+		expectedParts: []string{
+			"runcmd",
+			"/etc/eksctl/kubelet-extra.json",
+			"/etc/eksctl/kubelet.env",
+			"/var/lib/cloud/scripts/eksctl/bootstrap.helper.sh",
+		},
+		expectedProblems: []nodebootstrap.Problem{
+			{
+				Severity: nodebootstrap.SeverityError,
+				Message:  "both preBootstrapCommands and overrideBootstrapCommand run /etc/eks/bootstrap.sh; the node would be bootstrapped twice",
+			},
+			{
+				Severity: nodebootstrap.SeverityWarning,
+				Message:  "overrideBootstrapCommand does not run /etc/eks/bootstrap.sh; nodes may not join the cluster",
+			},
+		},
+	}),
+
+	Entry("AmazonLinux2023", renderEntry{
+		nodePool: func() api.NodePool {
+			ng := api.NewNodeGroup()
+			ng.Name = "al2023"
+			ng.AMIFamily = api.NodeImageFamilyAmazonLinux2023
+			ng.MaxPodsPerNode = 30
+			ng.PreBootstrapCommands = []string{"echo pre-bootstrap"}
+			return ng
+		},
+		expectedFormat: nodebootstrap.UserDataFormatMIME,
+		expectedParts:  []string{"text/x-shellscript", "application/node.eks.aws", "application/node.eks.aws"},
+		expectedKubeletConfig: map[string]interface{}{
+			"clusterDNS": []interface{}{"10.100.0.10"},
+			"maxPods":    float64(30),
+		},
+	}),
+
+	Entry("AmazonLinux2023 running nodeadm in preBootstrapCommands", renderEntry{
+		nodePool: func() api.NodePool {
+			ng := api.NewNodeGroup()
+			ng.Name = "al2023"
+			ng.AMIFamily = api.NodeImageFamilyAmazonLinux2023
+			ng.PreBootstrapCommands = []string{"nodeadm init --config-source file:///etc/nodeadm.yaml"}
+			return ng
+		},
+		expectedFormat: nodebootstrap.UserDataFormatMIME,
+		expectedParts:  []string{"text/x-shellscript", "application/node.eks.aws"},
+		expectedProblems: []nodebootstrap.Problem{
+			{
+				Severity: nodebootstrap.SeverityError,
+				Message:  "preBootstrapCommands run `nodeadm init`, which is run by the AMI; use NodeConfig documents in overrideBootstrapCommand to configure nodeadm instead",
+			},
+		},
+	}),
+
+	Entry("managed AmazonLinux2023 without user data", renderEntry{
+		nodePool: func() api.NodePool {
+			ng := api.NewManagedNodeGroup()
+			ng.Name = "mng"
+			return ng
+		},
+		expectedFormat: nodebootstrap.UserDataFormatNone,
+	}),
+
+	Entry("Bottlerocket with unknown settings", renderEntry{
+		nodePool: func() api.NodePool {
+			ng := api.NewNodeGroup()
+			ng.Name = "bottlerocket"
+			ng.AMIFamily = api.NodeImageFamilyBottlerocket
+			ng.Bottlerocket = &api.NodeGroupBottlerocket{
+				Settings: &api.InlineDocument{
+					"motd":  "hello",
+					"moted": "hello",
+					"kubernetes": map[string]interface{}{
+						"max-pod": 20,
+					},
+				},
+			}
+			return ng
+		},
+		expectedFormat: nodebootstrap.UserDataFormatTOML,
+		expectedParts:  []string{"settings"},
+		expectedProblems: []nodebootstrap.Problem{
+			{
+				Severity: nodebootstrap.SeverityError,
+				Message:  `unknown Bottlerocket setting "settings.moted"`,
+			},
+			{
+				Severity: nodebootstrap.SeverityError,
+				Message:  `unknown Bottlerocket setting "settings.kubernetes.max-pod"`,
+			},
+		},
+	}),
+
+	Entry("Windows", renderEntry{
+		nodePool: func() api.NodePool {
+			ng := api.NewNodeGroup()
+			ng.Name = "windows"
+			ng.AMIFamily = api.NodeImageFamilyWindowsServer2022CoreContainer
+			return ng
+		},
+		expectedFormat: nodebootstrap.UserDataFormatPowerShell,
+		expectedParts:  []string{"powershell"},
+	}),
+)
+
+func hasErrors(problems []nodebootstrap.Problem) bool {
+	for _, p := range problems {
+		if p.Severity == nodebootstrap.SeverityError {
+			return true
+		}
+	}
+	return false
+}
@@ -143,6 +143,10 @@ func linuxConfig(clusterConfig *api.ClusterConfig, bootScriptName, bootScriptCon
 	if unmanaged, ok := np.(*api.NodeGroup); ok {
 		kubeletExtraConf = unmanaged.KubeletExtraConfig
 	}
+	kubeletExtraConf, err := mergeKubeletConfig(kubeletExtraConf, ng.Kubelet)
+	if err != nil {
+		return "", err
+	}
 	kubeletConf, err := makeKubeletExtraConf(kubeletExtraConf)
 	if err != nil {
 		return "", err
@@ -199,8 +203,8 @@ func makeBootstrapEnv(clusterConfig *api.ClusterConfig, np api.NodePool, cluster
 		variables["ENABLE_LOCAL_OUTPOST"] = strconv.FormatBool(true)
 	}
 
-	if ng.MaxPodsPerNode > 0 {
-		variables["MAX_PODS"] = strconv.Itoa(ng.MaxPodsPerNode)
+	if maxPods := ng.GetMaxPodsPerNode(); maxPods > 0 {
+		variables["MAX_PODS"] = strconv.Itoa(maxPods)
 	}
 
 	if clusterDNS != "" {
@@ -112,10 +112,16 @@ func (b *Windows) makeKubeletOptions() []powershell.KeyValue {
 		},
 	}
 
-	if ng.MaxPodsPerNode != 0 {
+	if maxPods := ng.GetMaxPodsPerNode(); maxPods != 0 {
 		kubeletOptions = append(kubeletOptions, powershell.KeyValue{
 			Key:   "max-pods",
-			Value: strconv.Itoa(ng.MaxPodsPerNode),
+			Value: strconv.Itoa(maxPods),
+		})
+	}
+	for _, flag := range kubeletFlags(ng.Kubelet) {
+		kubeletOptions = append(kubeletOptions, powershell.KeyValue{
+			Key:   flag.name,
+			Value: flag.value,
 		})
 	}
 	return kubeletOptions
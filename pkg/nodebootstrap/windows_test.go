@@ -105,6 +105,29 @@ var _ = Describe("Windows", func() {
 `,
 		}),
 
+		Entry("with kubelet settings", windowsEntry{
+			updateNodeGroup: func(ng *api.NodeGroup) {
+				ng.Kubelet = &api.NodeGroupKubelet{
+					MaxPods: aws.Int(50),
+					EvictionHard: map[string]string{
+						"memory.available": "200Mi",
+					},
+					SystemReserved: map[string]string{
+						"memory": "500Mi",
+						"cpu":    "500m",
+					},
+					ImageGCLowThresholdPercent: aws.Int(70),
+				}
+			},
+
+			expectedUserData: `
+<powershell>
+[string]$EKSBootstrapScriptFile = "$env:ProgramFiles\Amazon\EKS\Start-EKSBootstrap.ps1"
+& $EKSBootstrapScriptFile -EKSClusterName "windohs" -APIServerEndpoint "https://test.com" -Base64ClusterCA "dGVzdA==" -ServiceCIDR "10.100.0.0/16" -ContainerRuntime "docker" -KubeletExtraArgs "--node-labels= --register-with-taints= --max-pods=50 --eviction-hard=memory.available<200Mi --system-reserved=cpu=500m,memory=500Mi --image-gc-low-threshold=70" 3>&1 4>&1 5>&1 6>&1
+</powershell>
+`,
+		}),
+
 		Entry("with a preBootstrapCommand", windowsEntry{
 			updateNodeGroup: func(ng *api.NodeGroup) {
 				ng.PreBootstrapCommands = []string{
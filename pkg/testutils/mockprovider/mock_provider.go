@@ -129,6 +129,11 @@ func (m MockProvider) CloudFormationDisableRollback() bool {
 	return false
 }
 
+// CloudFormationChangeSetPreview returns whether stack updates should be previewed and confirmed before they are executed
+func (m MockProvider) CloudFormationChangeSetPreview() bool {
+	return false
+}
+
 // ASG returns a representation of the ASG API
 func (m MockProvider) ASG() awsapi.ASG { return m.asg }
 
@@ -0,0 +1,122 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// FormatJSONLines writes each event as a JSON object on its own line
+const FormatJSONLines = "jsonl"
+
+// Type is the type of an Event.
+type Type string
+
+// Values for Type
+const (
+	TaskStarted           Type = "task.started"
+	TaskCompleted         Type = "task.completed"
+	TaskFailed            Type = "task.failed"
+	StackStatus           Type = "stack.status"
+	NodeGroupUpdateStatus Type = "nodegroup.update.status"
+	DrainProgress         Type = "drain.progress"
+	AddonStatus           Type = "addon.status"
+)
+
+// Event is emitted for every step of a long-running operation.
+type Event struct {
+	Time time.Time `json:"time"`
+	Type Type      `json:"type"`
+	// ResourceID identifies the resource the event is about, e.g. a stack name or a nodegroup name
+	ResourceID string `json:"resourceID"`
+	// Kind is the kind of the resource, when known
+	Kind    string `json:"kind,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Error is the cause of a failure
+	Error string `json:"error,omitempty"`
+}
+
+var (
+	mu      sync.Mutex
+	encoder *json.Encoder
+	now     = time.Now
+)
+
+// Configure enables the event stream in the given format, written to w; an empty format disables it.
+func Configure(format string, w io.Writer) error {
+	mu.Lock()
+	defer mu.Unlock()
+	switch format {
+	case "":
+		encoder = nil
+	case FormatJSONLines:
+		encoder = json.NewEncoder(w)
+	default:
+		return fmt.Errorf("unsupported events format %q, valid options are: %s", format, FormatJSONLines)
+	}
+	return nil
+}
+
+// Enabled reports whether events are being emitted.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return encoder != nil
+}
+
+// Emit writes an event if the event stream is enabled, setting its time if it is not set.
+func Emit(e Event) {
+	mu.Lock()
+	defer mu.Unlock()
+	if encoder == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = now().UTC()
+	}
+	// the event stream is best-effort and must not fail the operation
+	_ = encoder.Encode(e)
+}
+
+// ErrorCause returns the message of err, or an empty string if err is nil.
+func ErrorCause(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// StatusTracker emits an event each time the status of a resource changes.
+type StatusTracker struct {
+	Type Type
+	Kind string
+	// Message is set on every event emitted by the tracker, e.g. to identify the operation the status belongs to
+	Message string
+
+	mu   sync.Mutex
+	last map[string]string
+}
+
+// Update emits an event if status differs from the last status seen for resourceID.
+func (t *StatusTracker) Update(resourceID, status, cause string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.last == nil {
+		t.last = map[string]string{}
+	}
+	if last, ok := t.last[resourceID]; ok && last == status {
+		return
+	}
+	t.last[resourceID] = status
+	Emit(Event{
+		Type:       t.Type,
+		ResourceID: resourceID,
+		Kind:       t.Kind,
+		Status:     status,
+		Message:    t.Message,
+		Error:      cause,
+	})
+}
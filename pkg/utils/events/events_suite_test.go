@@ -0,0 +1,11 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestEvents(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
@@ -0,0 +1,80 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func decodeEvents(out *bytes.Buffer) []Event {
+	var events []Event
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var e Event
+		ExpectWithOffset(1, json.Unmarshal([]byte(line), &e)).To(Succeed())
+		events = append(events, e)
+	}
+	return events
+}
+
+var _ = Describe("Events", func() {
+	var out *bytes.Buffer
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+		Expect(Configure(FormatJSONLines, out)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(Configure("", nil)).To(Succeed())
+			now = time.Now
+		})
+	})
+
+	It("writes one JSON object per line", func() {
+		Emit(Event{Type: TaskStarted, ResourceID: "eksctl-dev-cluster", Kind: "cluster"})
+		Emit(Event{Type: TaskFailed, ResourceID: "eksctl-dev-cluster", Kind: "cluster", Error: "timed out"})
+
+		Expect(out.String()).To(Equal(
+			`{"time":"2024-01-02T03:04:05Z","type":"task.started","resourceID":"eksctl-dev-cluster","kind":"cluster"}` + "\n" +
+				`{"time":"2024-01-02T03:04:05Z","type":"task.failed","resourceID":"eksctl-dev-cluster","kind":"cluster","error":"timed out"}` + "\n",
+		))
+	})
+
+	It("does not write anything when disabled", func() {
+		Expect(Configure("", nil)).To(Succeed())
+		Expect(Enabled()).To(BeFalse())
+		Emit(Event{Type: TaskStarted, ResourceID: "eksctl-dev-cluster"})
+		Expect(out.Len()).To(BeZero())
+	})
+
+	It("rejects unsupported formats", func() {
+		Expect(Configure("xml", out)).To(MatchError(`unsupported events format "xml", valid options are: jsonl`))
+	})
+
+	It("only emits status transitions", func() {
+		tracker := &StatusTracker{Type: StackStatus, Kind: "stack"}
+		tracker.Update("stack-1", "CREATE_IN_PROGRESS", "")
+		tracker.Update("stack-1", "CREATE_IN_PROGRESS", "")
+		tracker.Update("stack-2", "CREATE_IN_PROGRESS", "")
+		tracker.Update("stack-1", "ROLLBACK_IN_PROGRESS", "resource creation failed")
+
+		events := decodeEvents(out)
+		Expect(events).To(HaveLen(3))
+		Expect(events[0].ResourceID).To(Equal("stack-1"))
+		Expect(events[1].ResourceID).To(Equal("stack-2"))
+		Expect(events[2]).To(Equal(Event{
+			Time:       now(),
+			Type:       StackStatus,
+			ResourceID: "stack-1",
+			Kind:       "stack",
+			Status:     "ROLLBACK_IN_PROGRESS",
+			Error:      "resource creation failed",
+		}))
+	})
+})
@@ -131,4 +131,77 @@ var _ = Describe("Instance utils", func() {
 			expectedInstanceType: "t3a.nano",
 		}),
 	)
+
+	type networkLimitsEntry struct {
+		limits            instance.NetworkLimits
+		mode              instance.CNIMode
+		maxPods           int
+		expectedMaxPods   int
+		expectedSubnetIPs instance.SubnetIPs
+	}
+
+	m5Large := instance.NetworkLimits{
+		InstanceType: "m5.large",
+		MaxENIs:      3,
+		IPv4PerENI:   10,
+		VCPUs:        2,
+	}
+
+	DescribeTable("NetworkLimits", func(e networkLimitsEntry) {
+		Expect(e.limits.MaxPods(e.mode)).To(Equal(e.expectedMaxPods))
+		maxPods := e.maxPods
+		if maxPods == 0 {
+			maxPods = e.expectedMaxPods
+		}
+		Expect(e.limits.SubnetIPsPerNode(maxPods, e.mode)).To(Equal(e.expectedSubnetIPs))
+	},
+		Entry("secondary IPs", networkLimitsEntry{
+			limits:            m5Large,
+			expectedMaxPods:   29,
+			expectedSubnetIPs: instance.SubnetIPs{Node: 30},
+		}),
+		Entry("secondary IPs with fewer pods than the instance type supports", networkLimitsEntry{
+			limits:            m5Large,
+			maxPods:           10,
+			expectedMaxPods:   29,
+			expectedSubnetIPs: instance.SubnetIPs{Node: 20},
+		}),
+		Entry("prefix delegation", networkLimitsEntry{
+			limits:            m5Large,
+			mode:              instance.CNIMode{PrefixDelegation: true},
+			expectedMaxPods:   110,
+			expectedSubnetIPs: instance.SubnetIPs{Node: 129},
+		}),
+		Entry("prefix delegation on an instance type with more than 30 vCPUs", networkLimitsEntry{
+			limits: instance.NetworkLimits{
+				InstanceType: "m5.24xlarge",
+				MaxENIs:      15,
+				IPv4PerENI:   50,
+				VCPUs:        96,
+			},
+			mode:              instance.CNIMode{PrefixDelegation: true},
+			expectedMaxPods:   250,
+			expectedSubnetIPs: instance.SubnetIPs{Node: 273},
+		}),
+		Entry("custom networking", networkLimitsEntry{
+			limits:            m5Large,
+			mode:              instance.CNIMode{CustomNetworking: true},
+			expectedMaxPods:   20,
+			expectedSubnetIPs: instance.SubnetIPs{Node: 1, Pod: 20},
+		}),
+	)
+
+	It("returns the network limits of an instance type", func() {
+		limits := instance.NewNetworkLimits(ec2types.InstanceTypeInfo{
+			InstanceType: "m5.large",
+			VCpuInfo: &ec2types.VCpuInfo{
+				DefaultVCpus: aws.Int32(2),
+			},
+			NetworkInfo: &ec2types.NetworkInfo{
+				MaximumNetworkInterfaces:  aws.Int32(3),
+				Ipv4AddressesPerInterface: aws.Int32(10),
+			},
+		})
+		Expect(limits).To(Equal(m5Large))
+	})
 })
@@ -0,0 +1,110 @@
+package instance
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	// IPv4PrefixSize is the number of addresses in the /28 prefixes assigned to ENIs when prefix delegation is enabled
+	IPv4PrefixSize = 16
+
+	// hostNetworkPods is the number of host network pods (aws-node and kube-proxy) that the EKS max pods formula allows for
+	hostNetworkPods = 2
+)
+
+// NetworkLimits holds the limits of an instance type that determine how many pod IPs the VPC CNI can assign to it.
+type NetworkLimits struct {
+	InstanceType string
+	MaxENIs      int
+	IPv4PerENI   int
+	VCPUs        int
+}
+
+// CNIMode describes how the VPC CNI assigns IP addresses to pods.
+type CNIMode struct {
+	// PrefixDelegation is true when /28 prefixes rather than secondary IPs are assigned to ENIs
+	PrefixDelegation bool
+	// CustomNetworking is true when pod ENIs are created in subnets other than the node's subnet
+	CustomNetworking bool
+}
+
+// SubnetIPs is the number of IP addresses used by a node from its own subnet and from pod subnets.
+type SubnetIPs struct {
+	Node int
+	Pod  int
+}
+
+// NewNetworkLimits returns the network limits of an instance type described by EC2.
+func NewNetworkLimits(info ec2types.InstanceTypeInfo) NetworkLimits {
+	limits := NetworkLimits{
+		InstanceType: string(info.InstanceType),
+	}
+	if info.NetworkInfo != nil {
+		limits.MaxENIs = int(aws.ToInt32(info.NetworkInfo.MaximumNetworkInterfaces))
+		limits.IPv4PerENI = int(aws.ToInt32(info.NetworkInfo.Ipv4AddressesPerInterface))
+	}
+	if info.VCpuInfo != nil {
+		limits.VCPUs = int(aws.ToInt32(info.VCpuInfo.DefaultVCpus))
+	}
+	return limits
+}
+
+// MaxPods returns the maximum number of pods that the VPC CNI can run on a node, using the same formula as EKS.
+func (l NetworkLimits) MaxPods(mode CNIMode) int {
+	podIPs := l.podENIs(mode) * l.slotsPerENI()
+	if !mode.PrefixDelegation {
+		return podIPs + hostNetworkPods
+	}
+	maxPods := podIPs*IPv4PrefixSize + hostNetworkPods
+	// EKS recommends capping the number of pods when prefix delegation is enabled
+	limit := 110
+	if l.VCPUs > 30 {
+		limit = 250
+	}
+	return min(maxPods, limit)
+}
+
+// SubnetIPsPerNode returns the number of IP addresses used by a node running maxPods pods, including the addresses
+// kept warm by the VPC CNI with its default warm targets.
+func (l NetworkLimits) SubnetIPsPerNode(maxPods int, mode CNIMode) SubnetIPs {
+	podENIs, slotsPerENI := l.podENIs(mode), l.slotsPerENI()
+	if podENIs <= 0 || slotsPerENI <= 0 {
+		return SubnetIPs{Node: 1}
+	}
+	pods := max(maxPods-hostNetworkPods, 0)
+
+	var enis, ips int
+	if mode.PrefixDelegation {
+		// WARM_PREFIX_TARGET defaults to 1
+		prefixes := min(ceilDiv(pods, IPv4PrefixSize)+1, podENIs*slotsPerENI)
+		enis = max(ceilDiv(prefixes, slotsPerENI), 1)
+		ips = enis + prefixes*IPv4PrefixSize
+	} else {
+		// WARM_ENI_TARGET defaults to 1, and attached ENIs are fully allocated
+		enis = min(ceilDiv(pods, slotsPerENI)+1, podENIs)
+		ips = enis * l.IPv4PerENI
+	}
+
+	if mode.CustomNetworking {
+		// pod ENIs are created in pod subnets, and the primary ENI only uses its primary address
+		return SubnetIPs{Node: 1, Pod: ips}
+	}
+	return SubnetIPs{Node: ips}
+}
+
+func (l NetworkLimits) podENIs(mode CNIMode) int {
+	if mode.CustomNetworking {
+		return l.MaxENIs - 1
+	}
+	return l.MaxENIs
+}
+
+func (l NetworkLimits) slotsPerENI() int {
+	// the primary address of each ENI cannot be assigned to pods
+	return l.IPv4PerENI - 1
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
@@ -1,6 +1,7 @@
 package kubeconfig
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"text/template"
 
 	"github.com/gofrs/flock"
 	"github.com/kballard/go-shellquote"
@@ -96,6 +98,50 @@ func (cb *ConfigBuilder) Build() *clientcmdapi.Config {
 	}
 }
 
+// ContextNameData holds the values available to a context name template.
+type ContextNameData struct {
+	// ClusterName is the name of the EKS cluster.
+	ClusterName string
+	// Region is the region of the EKS cluster.
+	Region string
+	// Username is the name of the IAM identity used to access the cluster.
+	Username string
+}
+
+// RenderContextName executes the context name template tmpl, e.g. "{{.ClusterName}}-{{.Region}}".
+func RenderContextName(tmpl string, data ContextNameData) (string, error) {
+	t, err := template.New("context-name").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parsing context name template %q: %w", tmpl, err)
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("executing context name template %q: %w", tmpl, err)
+	}
+	if out.Len() == 0 {
+		return "", fmt.Errorf("context name template %q produced an empty name", tmpl)
+	}
+	return out.String(), nil
+}
+
+// RenameContext renames the current context of config, and the user it refers to, to name.
+func RenameContext(config *clientcmdapi.Config, name string) {
+	current := config.CurrentContext
+	if current == name {
+		return
+	}
+	if context, ok := config.Contexts[current]; ok {
+		delete(config.Contexts, current)
+		if authInfo, ok := config.AuthInfos[context.AuthInfo]; ok && context.AuthInfo == current {
+			delete(config.AuthInfos, current)
+			config.AuthInfos[name] = authInfo
+			context.AuthInfo = name
+		}
+		config.Contexts[name] = context
+	}
+	config.CurrentContext = name
+}
+
 // NewBuilder returns a minimal ConfigBuilder
 func NewBuilder(metadata *api.ClusterMeta, status *api.ClusterStatus, username string) *ConfigBuilder {
 	cluster := clientcmdapi.Cluster{
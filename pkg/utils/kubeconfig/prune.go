@@ -0,0 +1,223 @@
+package kubeconfig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/kris-nova/logger"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// ClusterRef identifies the EKS cluster of a cluster entry in a kubeconfig file.
+type ClusterRef struct {
+	// Entry is the name of the cluster entry.
+	Entry string
+	// ClusterName is the name of the EKS cluster.
+	ClusterName string
+	// Region is the region of the EKS cluster.
+	Region string
+	// AccountID is the account of the EKS cluster, if the entry is named after the cluster ARN.
+	AccountID string
+	// Profile is the AWS profile used by the users of the contexts of the entry, if any.
+	Profile string
+	// RoleARN is the IAM role assumed by the users of the contexts of the entry, if any.
+	RoleARN string
+}
+
+// ErrSkipCluster is returned by a ClusterLookup to leave a cluster entry unchanged.
+var ErrSkipCluster = errors.New("skip cluster")
+
+// ClusterLookup returns the current status of the EKS cluster of ref, or nil if it no longer exists.
+type ClusterLookup func(ref ClusterRef) (*api.ClusterStatus, error)
+
+// PruneResult lists the cluster entries changed by Prune.
+type PruneResult struct {
+	// Removed are the entries of clusters that no longer exist.
+	Removed []string
+	// Refreshed are the entries whose endpoint or certificate authority have been updated.
+	Refreshed []string
+}
+
+// Prune removes the clusters, contexts and users of EKS clusters that no longer exist from the kubeconfig file at path,
+// and refreshes the endpoint and certificate authority of the clusters that do.
+// Only entries named after an EKS cluster, either by eksctl or by the cluster ARN, are considered.
+// In plan mode, the entries that would be changed are returned and the kubeconfig file is left untouched.
+func Prune(path string, lookup ClusterLookup, plan bool) (*PruneResult, error) {
+	configAccess := getConfigAccess(path)
+	configFileName := configAccess.GetDefaultFilename()
+	fl, err := lockConfigFile(configFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := unlockConfigFile(fl); err != nil {
+			logger.Critical(err.Error())
+		}
+	}()
+
+	config, err := configAccess.GetStartingConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read existing kubeconfig file %q: %w", path, err)
+	}
+
+	result, err := prune(config, lookup)
+	if err != nil {
+		return nil, err
+	}
+	if plan || (len(result.Removed) == 0 && len(result.Refreshed) == 0) {
+		return result, nil
+	}
+	if err := clientcmd.ModifyConfig(configAccess, *config, true); err != nil {
+		return nil, fmt.Errorf("unable to modify kubeconfig %s: %w", path, err)
+	}
+	return result, nil
+}
+
+func prune(config *clientcmdapi.Config, lookup ClusterLookup) (*PruneResult, error) {
+	var entries []string
+	for entry := range config.Clusters {
+		entries = append(entries, entry)
+	}
+	sort.Strings(entries)
+
+	result := &PruneResult{}
+	for _, entry := range entries {
+		ref, ok := parseClusterRef(entry)
+		if !ok {
+			continue
+		}
+		ref.Profile, ref.RoleARN = credentialsOf(config, entry)
+		status, err := lookup(ref)
+		if errors.Is(err, ErrSkipCluster) {
+			logger.Debug("skipping cluster %q", entry)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("looking up cluster %q: %w", entry, err)
+		}
+		if status == nil {
+			removeCluster(config, entry)
+			result.Removed = append(result.Removed, entry)
+			continue
+		}
+		if refreshCluster(config.Clusters[entry], status) {
+			result.Refreshed = append(result.Refreshed, entry)
+		}
+	}
+	return result, nil
+}
+
+// parseClusterRef parses cluster entries named "<name>.<region>.eksctl.io" by eksctl, or named after the cluster ARN.
+func parseClusterRef(entry string) (ClusterRef, bool) {
+	if clusterARN, err := arn.Parse(entry); err == nil {
+		if clusterARN.Service != "eks" || !strings.HasPrefix(clusterARN.Resource, "cluster/") {
+			return ClusterRef{}, false
+		}
+		return ClusterRef{
+			Entry:       entry,
+			ClusterName: strings.TrimPrefix(clusterARN.Resource, "cluster/"),
+			Region:      clusterARN.Region,
+			AccountID:   clusterARN.AccountID,
+		}, true
+	}
+	name, ok := strings.CutSuffix(entry, ".eksctl.io")
+	if !ok {
+		return ClusterRef{}, false
+	}
+	i := strings.LastIndex(name, ".")
+	if i <= 0 || i == len(name)-1 {
+		return ClusterRef{}, false
+	}
+	return ClusterRef{
+		Entry:       entry,
+		ClusterName: name[:i],
+		Region:      name[i+1:],
+	}, true
+}
+
+// credentialsOf returns the AWS profile and IAM role the users of the contexts of cluster authenticate with.
+func credentialsOf(config *clientcmdapi.Config, cluster string) (profile, roleARN string) {
+	for _, context := range config.Contexts {
+		if context.Cluster != cluster {
+			continue
+		}
+		authInfo, ok := config.AuthInfos[context.AuthInfo]
+		if !ok || authInfo.Exec == nil {
+			continue
+		}
+		for _, env := range authInfo.Exec.Env {
+			if env.Name == "AWS_PROFILE" && profile == "" {
+				profile = env.Value
+			}
+		}
+		if roleARN == "" {
+			roleARN = roleARNOf(authInfo.Exec.Args)
+		}
+	}
+	return profile, roleARN
+}
+
+// roleARNOf returns the role passed to aws-iam-authenticator or aws eks get-token in args.
+func roleARNOf(args []string) string {
+	for i, arg := range args {
+		for _, flag := range []string{"-r", "--role", "--role-arn"} {
+			if arg == flag && i+1 < len(args) {
+				return args[i+1]
+			}
+			if value, ok := strings.CutPrefix(arg, flag+"="); ok {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+func removeCluster(config *clientcmdapi.Config, cluster string) {
+	delete(config.Clusters, cluster)
+	logger.Debug("removed cluster %q from kubeconfig", cluster)
+
+	removedAuthInfos := map[string]struct{}{}
+	for name, context := range config.Contexts {
+		if context.Cluster != cluster {
+			continue
+		}
+		delete(config.Contexts, name)
+		logger.Debug("removed context %q from kubeconfig", name)
+		removedAuthInfos[context.AuthInfo] = struct{}{}
+		if config.CurrentContext == name {
+			config.CurrentContext = ""
+		}
+	}
+	// keep the users still referred to by other contexts
+	for _, context := range config.Contexts {
+		delete(removedAuthInfos, context.AuthInfo)
+	}
+	for name := range removedAuthInfos {
+		delete(config.AuthInfos, name)
+		logger.Debug("removed user %q from kubeconfig", name)
+	}
+}
+
+// refreshCluster updates the endpoint and certificate authority of cluster, and reports whether they changed.
+// The certificate authority is only updated when it is embedded in the kubeconfig.
+func refreshCluster(cluster *clientcmdapi.Cluster, status *api.ClusterStatus) bool {
+	changed := false
+	if status.Endpoint != "" && cluster.Server != status.Endpoint {
+		cluster.Server = status.Endpoint
+		changed = true
+	}
+	if len(cluster.CertificateAuthorityData) > 0 && len(status.CertificateAuthorityData) > 0 &&
+		!bytes.Equal(cluster.CertificateAuthorityData, status.CertificateAuthorityData) {
+		cluster.CertificateAuthorityData = status.CertificateAuthorityData
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,160 @@
+package kubeconfig_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
+)
+
+var _ = Describe("Context names", func() {
+	It("renders the context name template", func() {
+		name, err := kubeconfig.RenderContextName("{{.ClusterName}}-{{.Region}}", kubeconfig.ContextNameData{
+			ClusterName: "dev",
+			Region:      "us-west-2",
+			Username:    "admin",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("dev-us-west-2"))
+	})
+
+	It("rejects unknown fields", func() {
+		_, err := kubeconfig.RenderContextName("{{.Account}}", kubeconfig.ContextNameData{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("renames the current context and its user", func() {
+		config := &clientcmdapi.Config{
+			Clusters:       map[string]*clientcmdapi.Cluster{"dev.us-west-2.eksctl.io": {}},
+			Contexts:       map[string]*clientcmdapi.Context{"admin@dev.us-west-2.eksctl.io": {Cluster: "dev.us-west-2.eksctl.io", AuthInfo: "admin@dev.us-west-2.eksctl.io"}},
+			AuthInfos:      map[string]*clientcmdapi.AuthInfo{"admin@dev.us-west-2.eksctl.io": {Token: "token"}},
+			CurrentContext: "admin@dev.us-west-2.eksctl.io",
+		}
+		kubeconfig.RenameContext(config, "dev")
+		Expect(config.CurrentContext).To(Equal("dev"))
+		Expect(config.Contexts).To(HaveLen(1))
+		Expect(config.Contexts["dev"].AuthInfo).To(Equal("dev"))
+		Expect(config.AuthInfos).To(HaveKeyWithValue("dev", &clientcmdapi.AuthInfo{Token: "token"}))
+	})
+})
+
+var _ = Describe("Prune", func() {
+	const (
+		existing = "existing.us-west-2.eksctl.io"
+		deleted  = "deleted.eu-west-1.eksctl.io"
+		arnEntry = "arn:aws:eks:us-east-1:123456789012:cluster/gone"
+		other    = "minikube"
+	)
+
+	var configFile *os.File
+
+	BeforeEach(func() {
+		var err error
+		configFile, err = os.CreateTemp("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		authInfo := func(profile string) *clientcmdapi.AuthInfo {
+			return &clientcmdapi.AuthInfo{Exec: &clientcmdapi.ExecConfig{
+				Command: "aws",
+				Env:     []clientcmdapi.ExecEnvVar{{Name: "AWS_PROFILE", Value: profile}},
+			}}
+		}
+		config := clientcmdapi.Config{
+			Clusters: map[string]*clientcmdapi.Cluster{
+				existing: {Server: "https://old.example.com", CertificateAuthorityData: []byte("old-ca")},
+				deleted:  {Server: "https://deleted.example.com"},
+				arnEntry: {Server: "https://gone.example.com"},
+				other:    {Server: "https://127.0.0.1:8443"},
+			},
+			Contexts: map[string]*clientcmdapi.Context{
+				"admin@" + existing: {Cluster: existing, AuthInfo: "admin@" + existing},
+				"admin@" + deleted:  {Cluster: deleted, AuthInfo: "admin@" + deleted},
+				arnEntry:            {Cluster: arnEntry, AuthInfo: arnEntry},
+				other:               {Cluster: other, AuthInfo: other},
+			},
+			AuthInfos: map[string]*clientcmdapi.AuthInfo{
+				"admin@" + existing: authInfo("dev"),
+				"admin@" + deleted:  authInfo("dev"),
+				arnEntry: {Exec: &clientcmdapi.ExecConfig{
+					Command: "aws",
+					Args:    []string{"eks", "get-token", "--cluster-name", "gone", "--role-arn", "arn:aws:iam::123456789012:role/admin"},
+					Env:     []clientcmdapi.ExecEnvVar{{Name: "AWS_PROFILE", Value: "prod"}},
+				}},
+				other: {Token: "token"},
+			},
+			CurrentContext: "admin@" + deleted,
+		}
+		Expect(clientcmd.WriteToFile(config, configFile.Name())).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.Remove(configFile.Name())).To(Succeed())
+	})
+
+	It("removes deleted clusters and refreshes existing ones", func() {
+		var refs []kubeconfig.ClusterRef
+		result, err := kubeconfig.Prune(configFile.Name(), func(ref kubeconfig.ClusterRef) (*api.ClusterStatus, error) {
+			refs = append(refs, ref)
+			switch ref.Entry {
+			case existing:
+				return &api.ClusterStatus{Endpoint: "https://new.example.com", CertificateAuthorityData: []byte("new-ca")}, nil
+			case arnEntry:
+				return nil, kubeconfig.ErrSkipCluster
+			default:
+				return nil, nil
+			}
+		}, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Removed).To(ConsistOf(deleted))
+		Expect(result.Refreshed).To(ConsistOf(existing))
+		Expect(refs).To(ConsistOf(
+			kubeconfig.ClusterRef{Entry: arnEntry, ClusterName: "gone", Region: "us-east-1", AccountID: "123456789012", Profile: "prod", RoleARN: "arn:aws:iam::123456789012:role/admin"},
+			kubeconfig.ClusterRef{Entry: deleted, ClusterName: "deleted", Region: "eu-west-1", Profile: "dev"},
+			kubeconfig.ClusterRef{Entry: existing, ClusterName: "existing", Region: "us-west-2", Profile: "dev"},
+		))
+
+		config, err := clientcmd.LoadFromFile(configFile.Name())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.Clusters).To(HaveLen(3))
+		Expect(config.Clusters).NotTo(HaveKey(deleted))
+		Expect(config.Contexts).NotTo(HaveKey("admin@" + deleted))
+		Expect(config.AuthInfos).NotTo(HaveKey("admin@" + deleted))
+		Expect(config.CurrentContext).To(BeEmpty())
+		Expect(config.Clusters[existing].Server).To(Equal("https://new.example.com"))
+		Expect(config.Clusters[existing].CertificateAuthorityData).To(Equal([]byte("new-ca")))
+		Expect(config.Clusters).To(HaveKey(arnEntry))
+		Expect(config.Clusters).To(HaveKey(other))
+	})
+
+	It("does not change the kubeconfig when all clusters are up to date", func() {
+		before, err := os.ReadFile(configFile.Name())
+		Expect(err).NotTo(HaveOccurred())
+		result, err := kubeconfig.Prune(configFile.Name(), func(ref kubeconfig.ClusterRef) (*api.ClusterStatus, error) {
+			return nil, kubeconfig.ErrSkipCluster
+		}, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Removed).To(BeEmpty())
+		Expect(result.Refreshed).To(BeEmpty())
+		after, err := os.ReadFile(configFile.Name())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(after).To(Equal(before))
+	})
+
+	It("does not change the kubeconfig in plan mode", func() {
+		before, err := os.ReadFile(configFile.Name())
+		Expect(err).NotTo(HaveOccurred())
+		result, err := kubeconfig.Prune(configFile.Name(), func(ref kubeconfig.ClusterRef) (*api.ClusterStatus, error) {
+			return nil, nil
+		}, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Removed).To(ConsistOf(existing, deleted, arnEntry))
+		after, err := os.ReadFile(configFile.Name())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(after).To(Equal(before))
+	})
+})
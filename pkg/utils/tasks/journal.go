@@ -0,0 +1,199 @@
+package tasks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/kris-nova/logger"
+)
+
+// CompletionChecker reports whether a task that is not in the journal has nevertheless been completed,
+// e.g. because the stack it creates already exists.
+type CompletionChecker func(task Task) (bool, error)
+
+// Journal persists the tasks of a TaskTree that completed successfully, so that a failed run can be
+// resumed without repeating them. Only tasks that operate on a resource (see ResourceDescriber) are journaled,
+// other tasks such as waiting for the control plane are expected to be idempotent and always run.
+type Journal struct {
+	// IsCompleted is consulted for tasks that are not in the journal, it may be nil
+	IsCompleted CompletionChecker
+
+	path      string
+	mu        sync.Mutex
+	completed map[string]bool
+}
+
+type journalFile struct {
+	Completed []string `json:"completed"`
+}
+
+// NewJournal returns an empty Journal that will be written to path.
+func NewJournal(path string) *Journal {
+	return &Journal{
+		path:      path,
+		completed: map[string]bool{},
+	}
+}
+
+// LoadJournal reads the Journal at path, it returns an empty Journal if the file does not exist.
+func LoadJournal(path string) (*Journal, error) {
+	j := NewJournal(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return j, nil
+		}
+		return nil, fmt.Errorf("reading journal: %w", err)
+	}
+	var f journalFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing journal %s: %w", path, err)
+	}
+	for _, key := range f.Completed {
+		j.completed[key] = true
+	}
+	return j, nil
+}
+
+// Path returns the location of the journal file.
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Len returns the number of completed tasks in the journal.
+func (j *Journal) Len() int {
+	if j == nil {
+		return 0
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.completed)
+}
+
+// Track wraps the resource tasks of taskTree so that they are skipped when they have already been
+// completed and recorded once they complete; name must identify taskTree among all trees tracked
+// by the same journal. Track does nothing on a nil Journal.
+func (j *Journal) Track(taskTree *TaskTree, name string) {
+	if j == nil || taskTree == nil {
+		return
+	}
+	j.track(taskTree, name)
+}
+
+func (j *Journal) track(taskTree *TaskTree, parentID string) {
+	for i, task := range taskTree.Tasks {
+		id := fmt.Sprintf("%s.%d", parentID, i+1)
+		if subTree, ok := task.(*TaskTree); ok {
+			if subTree != nil {
+				j.track(subTree, id)
+			}
+			continue
+		}
+		if describer, ok := resourceDescriber(task); !ok || describer.ResourceInfo().Kind == "" {
+			continue
+		}
+		taskTree.Tasks[i] = &journaledTask{
+			Task:    task,
+			key:     fmt.Sprintf("%s: %s", id, task.Describe()),
+			journal: j,
+		}
+	}
+}
+
+// Remove deletes the journal file, it should be called once all tasks have completed.
+func (j *Journal) Remove() error {
+	if j == nil {
+		return nil
+	}
+	if err := os.Remove(j.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing journal: %w", err)
+	}
+	return nil
+}
+
+func (j *Journal) isCompleted(key string, task Task) (bool, error) {
+	j.mu.Lock()
+	completed := j.completed[key]
+	j.mu.Unlock()
+	if completed || j.IsCompleted == nil {
+		return completed, nil
+	}
+
+	completed, err := j.IsCompleted(task)
+	if err != nil || !completed {
+		return false, err
+	}
+	if err := j.record(key); err != nil {
+		logger.Warning("failed to record completion of task %q: %v", task.Describe(), err)
+	}
+	return true, nil
+}
+
+func (j *Journal) record(key string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.completed[key] = true
+
+	f := journalFile{Completed: make([]string, 0, len(j.completed))}
+	for k := range j.completed {
+		f.Completed = append(f.Completed, k)
+	}
+	sort.Strings(f.Completed)
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return fmt.Errorf("creating journal directory: %w", err)
+	}
+	// write to a temporary file first so that an interrupted write does not corrupt the journal
+	tmpPath := j.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("writing journal: %w", err)
+	}
+	return os.Rename(tmpPath, j.path)
+}
+
+type journaledTask struct {
+	Task
+	key     string
+	journal *Journal
+}
+
+func (t *journaledTask) ResourceInfo() ResourceInfo {
+	describer, _ := resourceDescriber(t.Task)
+	return describer.ResourceInfo()
+}
+
+func (t *journaledTask) Do(errs chan error) error {
+	completed, err := t.journal.isCompleted(t.key, t)
+	if err != nil {
+		return err
+	}
+	if completed {
+		logger.Info("skipping task %q as it was completed by a previous run", t.Describe())
+		close(errs)
+		return nil
+	}
+
+	taskErrs := make(chan error)
+	if err := t.Task.Do(taskErrs); err != nil {
+		return err
+	}
+	go func() {
+		defer close(errs)
+		if err := <-taskErrs; err != nil {
+			errs <- err
+			return
+		}
+		if err := t.journal.record(t.key); err != nil {
+			logger.Warning("failed to record completion of task %q: %v", t.Describe(), err)
+		}
+	}()
+	return nil
+}
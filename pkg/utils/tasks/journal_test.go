@@ -0,0 +1,125 @@
+package tasks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Journal", func() {
+	var (
+		path  string
+		mu    sync.Mutex
+		calls map[string]int
+	)
+
+	newTask := func(description string, kind ResourceKind, err error) Task {
+		return &GenericTask{
+			Description: description,
+			Doer: func() error {
+				mu.Lock()
+				defer mu.Unlock()
+				calls[description]++
+				return err
+			},
+			Resource: ResourceInfo{Kind: kind, StackName: "stack-" + description},
+		}
+	}
+
+	newTaskTree := func(failing error) *TaskTree {
+		nodeGroups := &TaskTree{Parallel: true, IsSubTask: true}
+		nodeGroups.Append(
+			newTask("ng-1", KindNodeGroup, nil),
+			newTask("ng-2", KindNodeGroup, failing),
+		)
+		taskTree := &TaskTree{}
+		taskTree.Append(
+			newTask("cluster", KindCluster, nil),
+			nodeGroups,
+			newTask("wait", "", nil),
+		)
+		return taskTree
+	}
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "region", "cluster", "create-cluster.json")
+		calls = map[string]int{}
+	})
+
+	It("skips the resource tasks completed by a previous run", func() {
+		journal := NewJournal(path)
+		taskTree := newTaskTree(errors.New("ng-2 failed"))
+		journal.Track(taskTree, "cluster")
+		Expect(taskTree.DoAllSync()).To(HaveLen(1))
+		// the first error of a parallel sub-tree is reported before its other tasks complete
+		Eventually(journal.Len).Should(Equal(2))
+
+		journal, err := LoadJournal(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(journal.Len()).To(Equal(2))
+
+		taskTree = newTaskTree(nil)
+		journal.Track(taskTree, "cluster")
+		Expect(taskTree.DoAllSync()).To(BeEmpty())
+		Expect(calls).To(Equal(map[string]int{
+			"cluster": 1,
+			"ng-1":    1,
+			"ng-2":    2,
+			"wait":    1,
+		}))
+
+		Expect(journal.Remove()).To(Succeed())
+		_, err = os.Stat(path)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("consults IsCompleted for tasks that are not in the journal", func() {
+		journal := NewJournal(path)
+		var checked []string
+		journal.IsCompleted = func(task Task) (bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			checked = append(checked, task.Describe())
+			return task.Describe() == "cluster", nil
+		}
+		taskTree := newTaskTree(nil)
+		journal.Track(taskTree, "cluster")
+		Expect(taskTree.DoAllSync()).To(BeEmpty())
+		Expect(checked).To(ConsistOf("cluster", "ng-1", "ng-2"))
+		Expect(calls).NotTo(HaveKey("cluster"))
+		Expect(journal.Len()).To(Equal(3))
+	})
+
+	It("fails the task when IsCompleted returns an error", func() {
+		journal := NewJournal(path)
+		journal.IsCompleted = func(task Task) (bool, error) {
+			return false, errors.New("stack is in state CREATE_IN_PROGRESS")
+		}
+		taskTree := newTaskTree(nil)
+		journal.Track(taskTree, "cluster")
+		errs := taskTree.DoAllSync()
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0]).To(MatchError(ContainSubstring("CREATE_IN_PROGRESS")))
+		Expect(calls).To(BeEmpty())
+	})
+
+	It("returns an empty journal when the file does not exist", func() {
+		journal, err := LoadJournal(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(journal.Len()).To(BeZero())
+		Expect(journal.Remove()).To(Succeed())
+	})
+
+	It("does nothing when nil", func() {
+		var journal *Journal
+		taskTree := newTaskTree(nil)
+		journal.Track(taskTree, "cluster")
+		Expect(taskTree.DoAllSync()).To(BeEmpty())
+		Expect(journal.Len()).To(BeZero())
+		Expect(journal.Remove()).To(Succeed())
+	})
+})
@@ -0,0 +1,159 @@
+package tasks
+
+import (
+	"fmt"
+)
+
+// ResourceKind is the kind of resource a task operates on.
+type ResourceKind string
+
+// Values for ResourceKind
+const (
+	KindCluster                ResourceKind = "cluster"
+	KindNodeGroup              ResourceKind = "nodegroup"
+	KindManagedNodeGroup       ResourceKind = "managednodegroup"
+	KindAddon                  ResourceKind = "addon"
+	KindAccessEntry            ResourceKind = "accessentry"
+	KindIAMServiceAccount      ResourceKind = "iamserviceaccount"
+	KindPodIdentityAssociation ResourceKind = "podidentityassociation"
+	KindOIDCProvider           ResourceKind = "oidcprovider"
+	KindFargateProfile         ResourceKind = "fargateprofile"
+)
+
+// ExecutionMode is the way the sub-tasks of a TaskTree are run.
+type ExecutionMode string
+
+// Values for ExecutionMode
+const (
+	ModeSequential ExecutionMode = "sequential"
+	ModeParallel   ExecutionMode = "parallel"
+)
+
+// ResourceInfo describes the resource a task operates on.
+type ResourceInfo struct {
+	Kind      ResourceKind
+	StackName string
+}
+
+// ResourceDescriber is implemented by tasks that know which resource they operate on,
+// it is used to annotate the nodes of a Plan.
+type ResourceDescriber interface {
+	ResourceInfo() ResourceInfo
+}
+
+// ResourceInfo returns the resource the task operates on, if it was set.
+func (t *GenericTask) ResourceInfo() ResourceInfo {
+	return t.Resource
+}
+
+// Plan is a machine-readable representation of a TaskTree.
+type Plan struct {
+	Mode    ExecutionMode `json:"mode"`
+	Tasks   []PlanNode    `json:"tasks"`
+	Summary PlanSummary   `json:"summary"`
+}
+
+// PlanNode is a task in a Plan, nodes that have tasks of their own represent a nested TaskTree.
+type PlanNode struct {
+	// ID is unique within the Plan and reflects the position of the node in the tree, e.g. "2.1"
+	ID          string        `json:"id"`
+	Description string        `json:"description"`
+	Kind        ResourceKind  `json:"kind,omitempty"`
+	StackName   string        `json:"stackName,omitempty"`
+	Mode        ExecutionMode `json:"mode,omitempty"`
+	// DependsOn lists the IDs of the sibling tasks that must complete before this task is started
+	DependsOn []string   `json:"dependsOn,omitempty"`
+	Tasks     []PlanNode `json:"tasks,omitempty"`
+}
+
+// PlanSummary counts the tasks of a Plan.
+type PlanSummary struct {
+	// TotalTasks is the number of leaf tasks
+	TotalTasks int                  `json:"totalTasks"`
+	Kinds      map[ResourceKind]int `json:"kinds,omitempty"`
+	Stacks     []string             `json:"stacks,omitempty"`
+}
+
+// NewPlan walks a TaskTree and returns its Plan, no task is executed.
+func NewPlan(t *TaskTree) *Plan {
+	plan := &Plan{
+		Mode:  modeOf(t),
+		Tasks: []PlanNode{},
+		Summary: PlanSummary{
+			Kinds: map[ResourceKind]int{},
+		},
+	}
+	if t != nil {
+		plan.Tasks = plan.nodesFor(t, "")
+	}
+	return plan
+}
+
+func (p *Plan) nodesFor(t *TaskTree, parentID string) []PlanNode {
+	nodes := make([]PlanNode, 0, len(t.Tasks))
+	for i, task := range t.Tasks {
+		id := fmt.Sprintf("%d", i+1)
+		if parentID != "" {
+			id = parentID + "." + id
+		}
+		node := PlanNode{ID: id}
+		if !t.Parallel && i > 0 {
+			node.DependsOn = []string{nodes[i-1].ID}
+		}
+
+		if subTree, ok := task.(*TaskTree); ok {
+			node.Mode = modeOf(subTree)
+			node.Tasks = p.nodesFor(subTree, id)
+			node.Description = describeSubTree(subTree)
+		} else {
+			node.Description = task.Describe()
+			if describer, ok := resourceDescriber(task); ok {
+				info := describer.ResourceInfo()
+				node.Kind = info.Kind
+				node.StackName = info.StackName
+			}
+			p.count(node)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func (p *Plan) count(node PlanNode) {
+	p.Summary.TotalTasks++
+	if node.Kind != "" {
+		p.Summary.Kinds[node.Kind]++
+	}
+	if node.StackName == "" {
+		return
+	}
+	for _, s := range p.Summary.Stacks {
+		if s == node.StackName {
+			return
+		}
+	}
+	p.Summary.Stacks = append(p.Summary.Stacks, node.StackName)
+}
+
+func resourceDescriber(task Task) (ResourceDescriber, bool) {
+	if t, ok := task.(SynchronousTask); ok {
+		describer, ok := t.SynchronousTaskIface.(ResourceDescriber)
+		return describer, ok
+	}
+	describer, ok := task.(ResourceDescriber)
+	return describer, ok
+}
+
+func describeSubTree(t *TaskTree) string {
+	if t.Len() == 1 {
+		return "1 sub-task"
+	}
+	return fmt.Sprintf("%d %s sub-tasks", t.Len(), modeOf(t))
+}
+
+func modeOf(t *TaskTree) ExecutionMode {
+	if t != nil && t.Parallel {
+		return ModeParallel
+	}
+	return ModeSequential
+}
@@ -0,0 +1,90 @@
+package tasks
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type resourceTask struct {
+	TaskWithoutParams
+	info ResourceInfo
+}
+
+func (t *resourceTask) ResourceInfo() ResourceInfo { return t.info }
+
+var _ = Describe("Plan", func() {
+	It("returns an empty plan for an empty tree", func() {
+		plan := NewPlan(&TaskTree{})
+		Expect(plan.Mode).To(Equal(ModeSequential))
+		Expect(plan.Tasks).To(BeEmpty())
+		Expect(plan.Summary.TotalTasks).To(BeZero())
+	})
+
+	It("describes nested tasks, their resources and dependencies", func() {
+		nodeGroups := &TaskTree{Parallel: true, IsSubTask: true}
+		nodeGroups.Append(
+			&resourceTask{
+				TaskWithoutParams: TaskWithoutParams{Info: "create nodegroup ng-1"},
+				info:              ResourceInfo{Kind: KindNodeGroup, StackName: "eksctl-test-nodegroup-ng-1"},
+			},
+			&GenericTask{
+				Description: "create managed nodegroup mng-1",
+				Resource:    ResourceInfo{Kind: KindManagedNodeGroup, StackName: "eksctl-test-nodegroup-mng-1"},
+			},
+		)
+		taskTree := &TaskTree{}
+		taskTree.Append(
+			&GenericTask{
+				Description: "create cluster control plane",
+				Resource:    ResourceInfo{Kind: KindCluster, StackName: "eksctl-test-cluster"},
+			},
+			nodeGroups,
+			&TaskWithoutParams{Info: "wait for nodes"},
+		)
+
+		plan := NewPlan(taskTree)
+		Expect(plan.Mode).To(Equal(ModeSequential))
+		Expect(plan.Tasks).To(Equal([]PlanNode{
+			{
+				ID:          "1",
+				Description: "create cluster control plane",
+				Kind:        KindCluster,
+				StackName:   "eksctl-test-cluster",
+			},
+			{
+				ID:          "2",
+				Description: "2 parallel sub-tasks",
+				Mode:        ModeParallel,
+				DependsOn:   []string{"1"},
+				Tasks: []PlanNode{
+					{
+						ID:          "2.1",
+						Description: "create nodegroup ng-1",
+						Kind:        KindNodeGroup,
+						StackName:   "eksctl-test-nodegroup-ng-1",
+					},
+					{
+						ID:          "2.2",
+						Description: "create managed nodegroup mng-1",
+						Kind:        KindManagedNodeGroup,
+						StackName:   "eksctl-test-nodegroup-mng-1",
+					},
+				},
+			},
+			{
+				ID:          "3",
+				Description: "wait for nodes",
+				DependsOn:   []string{"2"},
+			},
+		}))
+		Expect(plan.Summary).To(Equal(PlanSummary{
+			TotalTasks: 4,
+			Kinds: map[ResourceKind]int{
+				KindCluster:          1,
+				KindNodeGroup:        1,
+				KindManagedNodeGroup: 1,
+			},
+			Stacks: []string{"eksctl-test-cluster", "eksctl-test-nodegroup-ng-1", "eksctl-test-nodegroup-mng-1"},
+		}))
+	})
+})
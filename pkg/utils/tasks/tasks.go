@@ -7,6 +7,8 @@ import (
 
 	"github.com/kris-nova/logger"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/weaveworks/eksctl/pkg/utils/events"
 )
 
 // Task is a common interface for the stack manager tasks.
@@ -21,6 +23,8 @@ type Task interface {
 type GenericTask struct {
 	Description string
 	Doer        func() error
+	// Resource optionally describes the resource the task operates on
+	Resource ResourceInfo
 }
 
 func (t *GenericTask) Describe() string {
@@ -198,19 +202,45 @@ func (t *TaskTree) DoAllSync() []error {
 func doSingleTask(allErrs chan error, task Task) bool {
 	desc := task.Describe()
 	logger.Debug("started task: %s", desc)
+	emitTaskEvent(events.TaskStarted, task, nil)
 	errs := make(chan error)
 	if err := task.Do(errs); err != nil {
+		emitTaskEvent(events.TaskFailed, task, err)
 		allErrs <- err
 		return false
 	}
 	if err := <-errs; err != nil {
+		emitTaskEvent(events.TaskFailed, task, err)
 		allErrs <- err
 		return false
 	}
 	logger.Debug("completed task: %s", desc)
+	emitTaskEvent(events.TaskCompleted, task, nil)
 	return true
 }
 
+// emitTaskEvent emits an event for a task that operates on a resource, sub-trees are reported
+// through their own tasks
+func emitTaskEvent(eventType events.Type, task Task, err error) {
+	if _, ok := task.(*TaskTree); ok || !events.Enabled() {
+		return
+	}
+	e := events.Event{
+		Type:       eventType,
+		ResourceID: task.Describe(),
+		Message:    task.Describe(),
+		Error:      events.ErrorCause(err),
+	}
+	if describer, ok := resourceDescriber(task); ok {
+		info := describer.ResourceInfo()
+		e.Kind = string(info.Kind)
+		if info.StackName != "" {
+			e.ResourceID = info.StackName
+		}
+	}
+	events.Emit(e)
+}
+
 func doParallelTasks(allErrs chan error, tasks []Task) {
 	wg := &sync.WaitGroup{}
 	wg.Add(len(tasks))
@@ -1,6 +1,8 @@
 package tasks
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -8,6 +10,8 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/utils/events"
 )
 
 var _ = Describe("TaskTree", func() {
@@ -622,4 +626,43 @@ var _ = Describe("TaskTree", func() {
 			}
 		})
 	})
+
+	It("emits an event for each task that starts, completes or fails", func() {
+		out := &bytes.Buffer{}
+		Expect(events.Configure(events.FormatJSONLines, out)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(events.Configure("", nil)).To(Succeed())
+		})
+
+		subTree := &TaskTree{IsSubTask: true}
+		subTree.Append(&GenericTask{
+			Description: "create nodegroup ng-1",
+			Doer:        func() error { return fmt.Errorf("stack failed") },
+			Resource:    ResourceInfo{Kind: KindNodeGroup, StackName: "eksctl-test-nodegroup-ng-1"},
+		})
+		taskTree := &TaskTree{}
+		taskTree.Append(&TaskWithoutParams{
+			Info: "wait for control plane",
+			Call: func(errs chan error) error {
+				close(errs)
+				return nil
+			},
+		}, subTree)
+		Expect(taskTree.DoAllSync()).To(HaveLen(1))
+
+		var emitted []events.Event
+		decoder := json.NewDecoder(out)
+		for decoder.More() {
+			var e events.Event
+			Expect(decoder.Decode(&e)).To(Succeed())
+			e.Time = time.Time{}
+			emitted = append(emitted, e)
+		}
+		Expect(emitted).To(Equal([]events.Event{
+			{Type: events.TaskStarted, ResourceID: "wait for control plane", Message: "wait for control plane"},
+			{Type: events.TaskCompleted, ResourceID: "wait for control plane", Message: "wait for control plane"},
+			{Type: events.TaskStarted, ResourceID: "eksctl-test-nodegroup-ng-1", Kind: "nodegroup", Message: "create nodegroup ng-1"},
+			{Type: events.TaskFailed, ResourceID: "eksctl-test-nodegroup-ng-1", Kind: "nodegroup", Message: "create nodegroup ng-1", Error: "stack failed"},
+		}))
+	})
 })
@@ -0,0 +1,184 @@
+package vpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+const (
+	vpcCNIENIConfigLabelEnv = "ENI_CONFIG_LABEL_DEF"
+	// ENIConfigs are named after availability zones, and selected by the zone label of nodes
+	eniConfigLabel = "topology.kubernetes.io/zone"
+
+	eniConfigAPIVersion = "crd.k8s.amazonaws.com/v1alpha1"
+	eniConfigKind       = "ENIConfig"
+)
+
+// eniConfigPollInterval is the interval at which applying ENIConfigs is retried while their CRD is not installed.
+var eniConfigPollInterval = 5 * time.Second
+
+// ResourceClient creates or replaces Kubernetes resources.
+type ResourceClient interface {
+	CreateOrReplace(manifest []byte, plan bool) error
+}
+
+// SetCustomNetworkingMaxPods sets maxPodsPerNode for nodegroups that do not set it when VPC CNI custom networking
+// is enabled, as the default max pods of EKS AMIs assume that pods can be assigned addresses of the primary ENI.
+// The lowest max pods of the instance types of a nodegroup is used.
+func SetCustomNetworkingMaxPods(ctx context.Context, ec2API awsapi.EC2, clusterConfig *api.ClusterConfig, nodePools []api.NodePool) error {
+	mode, err := GetCNIMode(clusterConfig)
+	if err != nil {
+		return err
+	}
+	if !mode.CustomNetworking {
+		return nil
+	}
+
+	var eligibleNodePools []api.NodePool
+	for _, np := range nodePools {
+		ng := np.BaseNodeGroup()
+		// pods on Windows nodes are assigned secondary addresses of the primary ENI
+		if ng.GetMaxPodsPerNode() == 0 && !api.IsWindowsImage(ng.AMIFamily) {
+			eligibleNodePools = append(eligibleNodePools, np)
+		}
+	}
+	if len(eligibleNodePools) == 0 {
+		return nil
+	}
+	networkLimits, err := describeNetworkLimits(ctx, ec2API, eligibleNodePools)
+	if err != nil {
+		return err
+	}
+
+	for _, np := range eligibleNodePools {
+		var maxPods int
+		for _, instanceType := range np.InstanceTypeList() {
+			limits, ok := networkLimits[instanceType]
+			if !ok {
+				continue
+			}
+			if instanceMaxPods := limits.MaxPods(mode); maxPods == 0 || instanceMaxPods < maxPods {
+				maxPods = instanceMaxPods
+			}
+		}
+		if maxPods <= 0 {
+			continue
+		}
+		ng := np.BaseNodeGroup()
+		ng.MaxPodsPerNode = maxPods
+		logger.Info("setting maxPodsPerNode to %d for nodegroup %q as VPC CNI custom networking is enabled", maxPods, ng.Name)
+	}
+	return nil
+}
+
+// WithCustomNetworkingEnv returns the configuration values of the vpc-cni addon with the environment variables
+// that enable custom networking added to them. Variables that are already set are left unchanged.
+func WithCustomNetworkingEnv(configurationValues string) (string, error) {
+	values := map[string]interface{}{}
+	if configurationValues != "" {
+		if err := yaml.Unmarshal([]byte(configurationValues), &values); err != nil {
+			return "", fmt.Errorf("parsing configurationValues of addon %s: %w", api.VPCCNIAddon, err)
+		}
+	}
+	env, ok := values["env"].(map[string]interface{})
+	if !ok {
+		if values["env"] != nil {
+			return "", fmt.Errorf("unexpected type %T for env in configurationValues of addon %s", values["env"], api.VPCCNIAddon)
+		}
+		env = map[string]interface{}{}
+	}
+	for name, value := range map[string]string{
+		vpcCNICustomNetworkingEnv: "true",
+		vpcCNIENIConfigLabelEnv:   eniConfigLabel,
+	} {
+		if _, ok := env[name]; !ok {
+			env[name] = value
+		}
+	}
+	values["env"] = env
+
+	ret, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("unexpected error marshalling configurationValues of addon %s: %w", api.VPCCNIAddon, err)
+	}
+	return string(ret), nil
+}
+
+// MakeENIConfigs returns a manifest with an ENIConfig for the pod subnet in each availability zone.
+func MakeENIConfigs(clusterConfig *api.ClusterConfig) ([]byte, error) {
+	podSubnets := clusterConfig.VPC.PodSubnetMapping()
+	if len(podSubnets) == 0 {
+		return nil, errors.New("no pod subnets found in vpc.podSubnets")
+	}
+	zoneSubnets := map[string]string{}
+	for alias, s := range podSubnets {
+		if s.ID == "" || s.AZ == "" {
+			return nil, fmt.Errorf("unexpected error creating ENIConfig: pod subnet %q has not been imported", alias)
+		}
+		zoneSubnets[s.AZ] = s.ID
+	}
+	zones := make([]string, 0, len(zoneSubnets))
+	for zone := range zoneSubnets {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	var manifest []byte
+	for _, zone := range zones {
+		spec := map[string]interface{}{
+			"subnet": zoneSubnets[zone],
+		}
+		if securityGroups := clusterConfig.VPC.PodSubnets.SecurityGroups; len(securityGroups) > 0 {
+			spec["securityGroups"] = securityGroups
+		}
+		eniConfig, err := json.Marshal(map[string]interface{}{
+			"apiVersion": eniConfigAPIVersion,
+			"kind":       eniConfigKind,
+			"metadata": map[string]interface{}{
+				"name": zone,
+			},
+			"spec": spec,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unexpected error marshalling ENIConfig for %s: %w", zone, err)
+		}
+		manifest = append(manifest, eniConfig...)
+		manifest = append(manifest, '\n')
+	}
+	return manifest, nil
+}
+
+// ApplyENIConfigs creates or replaces the ENIConfigs in manifest. As the ENIConfig CRD is installed by the vpc-cni
+// addon, applying them is retried with a new client until the CRD is available or timeout expires.
+func ApplyENIConfigs(ctx context.Context, newClient func() (ResourceClient, error), manifest []byte, timeout time.Duration) error {
+	err := wait.PollUntilContextTimeout(ctx, eniConfigPollInterval, timeout, true, func(context.Context) (bool, error) {
+		client, err := newClient()
+		if err != nil {
+			return false, err
+		}
+		if err := client.CreateOrReplace(manifest, false); err != nil {
+			if meta.IsNoMatchError(err) {
+				logger.Info("waiting for the %s CRD to be installed by the %s addon", eniConfigKind, api.VPCCNIAddon)
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error applying ENIConfigs for pod subnets: %w", err)
+	}
+	return nil
+}
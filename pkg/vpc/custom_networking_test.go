@@ -0,0 +1,226 @@
+package vpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+)
+
+type fakeResourceClient struct {
+	errs      []error
+	manifests [][]byte
+}
+
+func (f *fakeResourceClient) CreateOrReplace(manifest []byte, _ bool) error {
+	f.manifests = append(f.manifests, manifest)
+	if len(f.errs) == 0 {
+		return nil
+	}
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+var _ = Describe("VPC CNI custom networking", func() {
+	parseCIDR := func(cidr string) *ipnet.IPNet {
+		ret, err := ipnet.ParseCIDR(cidr)
+		Expect(err).NotTo(HaveOccurred())
+		return ret
+	}
+
+	Describe("SetSubnets with pod subnets", func() {
+		var clusterVPC *api.ClusterVPC
+
+		BeforeEach(func() {
+			clusterVPC = api.NewClusterVPC(false)
+			clusterVPC.PodSubnets = &api.PodSubnets{
+				CIDR: parseCIDR("100.64.0.0/16"),
+			}
+		})
+
+		It("splits vpc.podSubnets.cidr into a subnet per AZ", func() {
+			Expect(SetSubnets(clusterVPC, []string{"us-west-2a", "us-west-2b", "us-west-2c"}, nil)).To(Succeed())
+			Expect(clusterVPC.PodSubnets.Subnets).To(Equal(api.AZSubnetMapping{
+				"secondary-us-west-2a-100064000000": api.AZSubnetSpec{AZ: "us-west-2a", CIDR: parseCIDR("100.64.0.0/19")},
+				"secondary-us-west-2b-100064032000": api.AZSubnetSpec{AZ: "us-west-2b", CIDR: parseCIDR("100.64.32.0/19")},
+				"secondary-us-west-2c-100064064000": api.AZSubnetSpec{AZ: "us-west-2c", CIDR: parseCIDR("100.64.64.0/19")},
+			}))
+			Expect(clusterVPC.SecondaryCIDRs).To(Equal([]*ipnet.IPNet{parseCIDR("100.64.0.0/16")}))
+		})
+
+		It("requires vpc.podSubnets.cidr", func() {
+			clusterVPC.PodSubnets.CIDR = nil
+			err := SetSubnets(clusterVPC, []string{"us-west-2a", "us-west-2b"}, nil)
+			Expect(err).To(MatchError("vpc.podSubnets.cidr must be set to create pod subnets in a VPC created by eksctl"))
+		})
+
+		It("rejects a CIDR that overlaps with the VPC CIDR", func() {
+			clusterVPC.PodSubnets.CIDR = parseCIDR("192.168.128.0/17")
+			err := SetSubnets(clusterVPC, []string{"us-west-2a", "us-west-2b"}, nil)
+			Expect(err).To(MatchError("vpc.podSubnets.cidr 192.168.128.0/17 overlaps with the VPC CIDR 192.168.0.0/16"))
+		})
+	})
+
+	DescribeTable("WithCustomNetworkingEnv", func(configurationValues, expected string) {
+		values, err := WithCustomNetworkingEnv(configurationValues)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(MatchJSON(expected))
+	},
+		Entry("no configuration values", "",
+			`{"env":{"AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG":"true","ENI_CONFIG_LABEL_DEF":"topology.kubernetes.io/zone"}}`),
+		Entry("existing variables are kept", `{"env":{"ENI_CONFIG_LABEL_DEF":"custom-label","WARM_IP_TARGET":"2"},"init":{"env":{}}}`,
+			`{"env":{"AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG":"true","ENI_CONFIG_LABEL_DEF":"custom-label","WARM_IP_TARGET":"2"},"init":{"env":{}}}`),
+		Entry("YAML configuration values", "env:\n  ENABLE_PREFIX_DELEGATION: \"true\"\n",
+			`{"env":{"AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG":"true","ENABLE_PREFIX_DELEGATION":"true","ENI_CONFIG_LABEL_DEF":"topology.kubernetes.io/zone"}}`),
+	)
+
+	It("WithCustomNetworkingEnv rejects invalid configuration values", func() {
+		_, err := WithCustomNetworkingEnv(`{"env":["invalid"]}`)
+		Expect(err).To(MatchError(ContainSubstring("unexpected type []interface {} for env")))
+	})
+
+	Describe("MakeENIConfigs", func() {
+		var cfg *api.ClusterConfig
+
+		BeforeEach(func() {
+			cfg = api.NewClusterConfig()
+			cfg.VPC.PodSubnets = &api.PodSubnets{
+				Subnets: api.AZSubnetMapping{
+					"pods-b": api.AZSubnetSpec{ID: "subnet-pods-b", AZ: "us-west-2b"},
+					"pods-a": api.AZSubnetSpec{ID: "subnet-pods-a", AZ: "us-west-2a"},
+				},
+			}
+		})
+
+		It("creates an ENIConfig named after each AZ", func() {
+			manifest, err := MakeENIConfigs(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(manifest)).To(Equal(
+				`{"apiVersion":"crd.k8s.amazonaws.com/v1alpha1","kind":"ENIConfig","metadata":{"name":"us-west-2a"},"spec":{"subnet":"subnet-pods-a"}}` + "\n" +
+					`{"apiVersion":"crd.k8s.amazonaws.com/v1alpha1","kind":"ENIConfig","metadata":{"name":"us-west-2b"},"spec":{"subnet":"subnet-pods-b"}}` + "\n",
+			))
+		})
+
+		It("sets security groups", func() {
+			cfg.VPC.PodSubnets.SecurityGroups = []string{"sg-1", "sg-2"}
+			manifest, err := MakeENIConfigs(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(manifest)).To(ContainSubstring(`"spec":{"securityGroups":["sg-1","sg-2"],"subnet":"subnet-pods-a"}`))
+		})
+
+		It("fails for pod subnets that have not been imported", func() {
+			cfg.VPC.PodSubnets.Subnets["pods-c"] = api.AZSubnetSpec{AZ: "us-west-2c"}
+			_, err := MakeENIConfigs(cfg)
+			Expect(err).To(MatchError(ContainSubstring(`pod subnet "pods-c" has not been imported`)))
+		})
+	})
+
+	Describe("ApplyENIConfigs", func() {
+		BeforeEach(func() {
+			pollInterval := eniConfigPollInterval
+			eniConfigPollInterval = time.Millisecond
+			DeferCleanup(func() {
+				eniConfigPollInterval = pollInterval
+			})
+		})
+
+		It("retries with a new client until the ENIConfig CRD is installed", func() {
+			client := &fakeResourceClient{
+				errs: []error{&meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "crd.k8s.amazonaws.com", Kind: "ENIConfig"}}},
+			}
+			var clientsCreated int
+			newClient := func() (ResourceClient, error) {
+				clientsCreated++
+				return client, nil
+			}
+			Expect(ApplyENIConfigs(context.Background(), newClient, []byte("manifest"), time.Minute)).To(Succeed())
+			Expect(clientsCreated).To(Equal(2))
+			Expect(client.manifests).To(Equal([][]byte{[]byte("manifest"), []byte("manifest")}))
+		})
+
+		It("does not retry other errors", func() {
+			client := &fakeResourceClient{
+				errs: []error{errors.New("forbidden")},
+			}
+			err := ApplyENIConfigs(context.Background(), func() (ResourceClient, error) {
+				return client, nil
+			}, []byte("manifest"), time.Minute)
+			Expect(err).To(MatchError("error applying ENIConfigs for pod subnets: forbidden"))
+			Expect(client.manifests).To(HaveLen(1))
+		})
+	})
+
+	Describe("SetCustomNetworkingMaxPods", func() {
+		var (
+			p   *mockprovider.MockProvider
+			cfg *api.ClusterConfig
+		)
+
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+			p.MockEC2().On("DescribeInstanceTypes", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeInstanceTypesOutput{
+				InstanceTypes: []ec2types.InstanceTypeInfo{
+					{
+						InstanceType: "m5.large",
+						NetworkInfo: &ec2types.NetworkInfo{
+							MaximumNetworkInterfaces:  aws.Int32(3),
+							Ipv4AddressesPerInterface: aws.Int32(10),
+						},
+					},
+					{
+						InstanceType: "m5.xlarge",
+						NetworkInfo: &ec2types.NetworkInfo{
+							MaximumNetworkInterfaces:  aws.Int32(4),
+							Ipv4AddressesPerInterface: aws.Int32(15),
+						},
+					},
+				},
+			}, nil)
+
+			cfg = api.NewClusterConfig()
+			cfg.VPC.PodSubnets = &api.PodSubnets{}
+		})
+
+		newNodeGroup := func(name string, instanceTypes ...string) *api.ManagedNodeGroup {
+			ng := api.NewManagedNodeGroup()
+			ng.Name = name
+			ng.InstanceTypes = instanceTypes
+			return ng
+		}
+
+		It("sets the lowest max pods of the instance types of each nodegroup", func() {
+			mixed := newNodeGroup("mixed", "m5.large", "m5.xlarge")
+			xlarge := newNodeGroup("xlarge", "m5.xlarge")
+			maxPodsSet := newNodeGroup("max-pods-set", "m5.large")
+			maxPodsSet.MaxPodsPerNode = 8
+
+			Expect(SetCustomNetworkingMaxPods(context.Background(), p.EC2(), cfg, []api.NodePool{mixed, xlarge, maxPodsSet})).To(Succeed())
+			Expect(mixed.MaxPodsPerNode).To(Equal(20))
+			Expect(xlarge.MaxPodsPerNode).To(Equal(44))
+			Expect(maxPodsSet.MaxPodsPerNode).To(Equal(8))
+		})
+
+		It("does nothing when custom networking is not enabled", func() {
+			cfg.VPC.PodSubnets = nil
+			ng := newNodeGroup("ng", "m5.large")
+
+			Expect(SetCustomNetworkingMaxPods(context.Background(), p.EC2(), cfg, []api.NodePool{ng})).To(Succeed())
+			Expect(ng.MaxPodsPerNode).To(BeZero())
+			p.MockEC2().AssertNotCalled(GinkgoT(), "DescribeInstanceTypes", mock.Anything, mock.Anything)
+		})
+	})
+})
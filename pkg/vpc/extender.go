@@ -0,0 +1,281 @@
+package vpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+)
+
+// SubnetType is the type of subnet created in a secondary CIDR block.
+type SubnetType string
+
+// Values for `SubnetType`
+const (
+	SubnetTypePublic  SubnetType = "public"
+	SubnetTypePrivate SubnetType = "private"
+	// SubnetTypePod is a private subnet for pod ENIs with VPC CNI custom networking, nodes are not launched in it
+	SubnetTypePod SubnetType = "pod"
+)
+
+// SubnetTypes returns the supported subnet types.
+func SubnetTypes() []SubnetType {
+	return []SubnetType{SubnetTypePublic, SubnetTypePrivate, SubnetTypePod}
+}
+
+// A ClusterExtender extends the VPC of a cluster with a secondary CIDR block and subnets in that CIDR block.
+type ClusterExtender struct {
+	StackUpdater stackUpdater
+	EC2API       awsapi.EC2
+}
+
+//counterfeiter:generate -o fakes/fake_stack_updater.go . stackUpdater
+type stackUpdater interface {
+	AppendNewClusterStackResource(ctx context.Context, extendForOutposts, plan bool) (bool, error)
+	DescribeClusterStack(ctx context.Context) (*cfntypes.Stack, error)
+}
+
+// ExtendOptions holds the options for extending a VPC with a secondary CIDR block.
+type ExtendOptions struct {
+	// CIDR is the IPv4 CIDR block to associate with the VPC
+	CIDR *ipnet.IPNet
+	// SubnetTypes are the types of subnets to create in each AZ
+	SubnetTypes []SubnetType
+	// AvailabilityZones are the AZs to create subnets in, defaults to the AZs of the private subnets of the VPC
+	AvailabilityZones []string
+	// Plan only logs the changes to the cluster stack
+	Plan bool
+}
+
+// SecondarySubnet represents a subnet created in a secondary CIDR block.
+type SecondarySubnet struct {
+	Alias string
+	Type  SubnetType
+	api.AZSubnetSpec
+}
+
+// ExtendWithSecondaryCIDR associates options.CIDR with the VPC of a cluster created by eksctl, splits it into subnets
+// of the requested types in each AZ and updates the cluster stack to create them.
+// Private and pod subnets share the route table of the private subnet in their AZ, and new subnets are
+// exported in stack outputs so that they are imported into clusterConfig.VPC when nodegroups are created.
+func (e *ClusterExtender) ExtendWithSecondaryCIDR(ctx context.Context, clusterConfig *api.ClusterConfig, options ExtendOptions) ([]SecondarySubnet, error) {
+	clusterVPC := clusterConfig.VPC
+	if clusterConfig.IPv6Enabled() || api.IsEnabled(clusterVPC.AutoAllocateIPv6) {
+		return nil, errors.New("extending the VPC with a secondary CIDR block is only supported for IPv4 clusters without auto-allocated IPv6 CIDRs")
+	}
+	if options.CIDR == nil || options.CIDR.IP.To4() == nil {
+		return nil, errors.New("secondary CIDR block must be an IPv4 CIDR block")
+	}
+	if prefix, _ := options.CIDR.Mask.Size(); prefix < 16 || prefix > 28 {
+		return nil, errors.New("secondary CIDR block prefix must be between /16 and /28")
+	}
+
+	subnetTypes, err := validateSubnetTypes(options.SubnetTypes)
+	if err != nil {
+		return nil, err
+	}
+	if clusterConfig.IsFullyPrivate() && slices.Contains(subnetTypes, SubnetTypePublic) {
+		return nil, errors.New("public subnets cannot be added to a fully-private cluster")
+	}
+
+	zones, err := selectZonesForSecondaryCIDR(clusterConfig, options.AvailabilityZones)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.validateSecondaryCIDR(ctx, clusterVPC.ID, options.CIDR); err != nil {
+		return nil, err
+	}
+	if slices.Contains(subnetTypes, SubnetTypePod) {
+		if err := e.validatePodSubnetZones(ctx, clusterConfig, zones); err != nil {
+			return nil, err
+		}
+	}
+
+	subnetsTotal := len(subnetTypes) * len(zones)
+	subnetSize, networkLength, err := getSubnetNetworkSize(options.CIDR.IPNet, subnetsTotal)
+	if err != nil {
+		return nil, err
+	}
+	cidrs, err := SplitInto(&options.CIDR.IPNet, subnetSize, networkLength)
+	if err != nil {
+		return nil, fmt.Errorf("cannot split secondary CIDR block %s into %d subnets: %w", options.CIDR, subnetsTotal, err)
+	}
+
+	subnetMappings := map[SubnetType]api.AZSubnetMapping{
+		SubnetTypePublic:  clusterVPC.Subnets.Public,
+		SubnetTypePrivate: clusterVPC.Subnets.Private,
+	}
+	// a non-nil vpc.podSubnets enables custom networking, so it is only set when pod subnets are added
+	if slices.Contains(subnetTypes, SubnetTypePod) {
+		if clusterVPC.PodSubnets == nil {
+			clusterVPC.PodSubnets = &api.PodSubnets{}
+		}
+		if clusterVPC.PodSubnets.Subnets == nil {
+			clusterVPC.PodSubnets.Subnets = api.NewAZSubnetMapping()
+		}
+		subnetMappings[SubnetTypePod] = clusterVPC.PodSubnets.Subnets
+	}
+
+	var newSubnets []SecondarySubnet
+	for i, subnetType := range subnetTypes {
+		for j, zone := range zones {
+			cidr := cidrs[i*len(zones)+j]
+			subnet := SecondarySubnet{
+				Alias: MakeSecondarySubnetAlias(zone, cidr.IP),
+				Type:  subnetType,
+				AZSubnetSpec: api.AZSubnetSpec{
+					AZ:   zone,
+					CIDR: &ipnet.IPNet{IPNet: *cidr},
+				},
+			}
+			subnetMapping := subnetMappings[subnetType]
+			if _, ok := subnetMapping[subnet.Alias]; ok {
+				return nil, fmt.Errorf("unexpected error adding subnets in secondary CIDR block: subnet alias %q already exists", subnet.Alias)
+			}
+			subnetMapping[subnet.Alias] = subnet.AZSubnetSpec
+			newSubnets = append(newSubnets, subnet)
+			logger.Info("%s subnet for %s in secondary CIDR block: %s", subnetType, zone, cidr)
+		}
+	}
+	clusterVPC.SecondaryCIDRs = append(clusterVPC.SecondaryCIDRs, options.CIDR)
+
+	if _, err := e.StackUpdater.AppendNewClusterStackResource(ctx, false, options.Plan); err != nil {
+		return nil, fmt.Errorf("error updating cluster stack with secondary CIDR block: %w", err)
+	}
+
+	// pick up the IDs of the new subnets collected from the updated stack
+	for i, s := range newSubnets {
+		if subnet, ok := subnetMappings[s.Type][s.Alias]; ok {
+			newSubnets[i].AZSubnetSpec = subnet
+		}
+	}
+	return newSubnets, nil
+}
+
+func validateSubnetTypes(subnetTypes []SubnetType) ([]SubnetType, error) {
+	if len(subnetTypes) == 0 {
+		return nil, errors.New("at least one subnet type must be specified")
+	}
+	var ret []SubnetType
+	// use a stable order so that subnet CIDRs are assigned predictably
+	for _, t := range SubnetTypes() {
+		if slices.Contains(subnetTypes, t) {
+			ret = append(ret, t)
+		}
+	}
+	for _, t := range subnetTypes {
+		if !slices.Contains(ret, t) {
+			return nil, fmt.Errorf("invalid subnet type %q; must be one of %v", t, SubnetTypes())
+		}
+	}
+	return ret, nil
+}
+
+// selectZonesForSecondaryCIDR returns the AZs to create subnets in. Subnets in secondary CIDR blocks can only be created
+// in AZs that have a private subnet created with the VPC, as they share its route table.
+func selectZonesForSecondaryCIDR(clusterConfig *api.ClusterConfig, zones []string) ([]string, error) {
+	primaryZones := map[string]struct{}{}
+	for alias, s := range clusterConfig.VPC.Subnets.Private {
+		if !IsSecondarySubnetAlias(alias) {
+			primaryZones[s.AZ] = struct{}{}
+		}
+	}
+	if len(zones) == 0 {
+		for zone := range primaryZones {
+			zones = append(zones, zone)
+		}
+		if len(zones) == 0 {
+			return nil, errors.New("VPC has no private subnets created by eksctl")
+		}
+		sort.Strings(zones)
+		return zones, nil
+	}
+	for _, zone := range zones {
+		if _, ok := primaryZones[zone]; !ok {
+			return nil, fmt.Errorf("VPC has no private subnet created by eksctl in %s", zone)
+		}
+	}
+	return zones, nil
+}
+
+// validatePodSubnetZones checks that none of zones has a pod subnet yet, as the VPC CNI selects the pod subnet of a node
+// by the ENIConfig of its AZ. Pod subnets of earlier secondary CIDR blocks are read from the cluster stack outputs,
+// as they are only imported into clusterConfig when vpc.podSubnets is set.
+func (e *ClusterExtender) validatePodSubnetZones(ctx context.Context, clusterConfig *api.ClusterConfig, zones []string) error {
+	podSubnetZones := map[string]string{}
+	var subnetIDs []string
+	for alias, s := range clusterConfig.VPC.PodSubnetMapping() {
+		if s.ID == "" {
+			podSubnetZones[s.AZ] = alias
+			continue
+		}
+		subnetIDs = append(subnetIDs, s.ID)
+	}
+	stack, err := e.StackUpdater.DescribeClusterStack(ctx)
+	if err != nil {
+		return fmt.Errorf("error describing cluster stack: %w", err)
+	}
+	for _, o := range stack.Outputs {
+		if strings.HasPrefix(aws.ToString(o.OutputKey), outputs.ClusterSubnetsPodSecondary) {
+			subnetIDs = append(subnetIDs, strings.Split(aws.ToString(o.OutputValue), ",")...)
+		}
+	}
+	if len(subnetIDs) > 0 {
+		subnets, err := describeSubnets(ctx, e.EC2API, clusterConfig.VPC.ID, subnetIDs, nil, nil)
+		if err != nil {
+			return fmt.Errorf("error describing pod subnets: %w", err)
+		}
+		for _, s := range subnets {
+			podSubnetZones[aws.ToString(s.AvailabilityZone)] = aws.ToString(s.SubnetId)
+		}
+	}
+	for _, zone := range zones {
+		if subnet, ok := podSubnetZones[zone]; ok {
+			return fmt.Errorf("%s already has a pod subnet (%s); custom networking supports one pod subnet per availability zone", zone, subnet)
+		}
+	}
+	return nil
+}
+
+// validateSecondaryCIDR checks that cidr does not overlap with any CIDR block associated with the VPC.
+func (e *ClusterExtender) validateSecondaryCIDR(ctx context.Context, vpcID string, cidr *ipnet.IPNet) error {
+	vpc, err := describeVPC(ctx, e.EC2API, vpcID)
+	if err != nil {
+		return err
+	}
+	newPrefix, err := netip.ParsePrefix(cidr.String())
+	if err != nil {
+		return fmt.Errorf("unexpected error parsing CIDR %q: %w", cidr, err)
+	}
+	for _, association := range vpc.CidrBlockAssociationSet {
+		if association.CidrBlockState != nil && !isActiveCIDRBlockState(association.CidrBlockState.State) {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(aws.ToString(association.CidrBlock))
+		if err != nil {
+			return fmt.Errorf("unexpected error parsing VPC CIDR %q: %w", aws.ToString(association.CidrBlock), err)
+		}
+		if prefix.Overlaps(newPrefix) {
+			return fmt.Errorf("secondary CIDR block %s overlaps with CIDR block %s of VPC %s", cidr, prefix, vpcID)
+		}
+	}
+	return nil
+}
+
+func isActiveCIDRBlockState(state ec2types.VpcCidrBlockStateCode) bool {
+	return state == ec2types.VpcCidrBlockStateCodeAssociated || state == ec2types.VpcCidrBlockStateCodeAssociating
+}
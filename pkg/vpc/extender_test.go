@@ -0,0 +1,348 @@
+package vpc_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+	"github.com/weaveworks/eksctl/pkg/vpc/fakes"
+)
+
+var _ = Describe("ClusterExtender", func() {
+	var (
+		provider      *mockprovider.MockProvider
+		stackUpdater  *fakes.FakeStackUpdater
+		clusterConfig *api.ClusterConfig
+	)
+
+	parseCIDR := func(cidr string) *ipnet.IPNet {
+		ret, err := ipnet.ParseCIDR(cidr)
+		Expect(err).NotTo(HaveOccurred())
+		return ret
+	}
+
+	extend := func(options vpc.ExtendOptions) ([]vpc.SecondarySubnet, error) {
+		extender := &vpc.ClusterExtender{
+			StackUpdater: stackUpdater,
+			EC2API:       provider.EC2(),
+		}
+		return extender.ExtendWithSecondaryCIDR(context.Background(), clusterConfig, options)
+	}
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		provider.MockEC2().On("DescribeVpcs", mock.Anything, &ec2.DescribeVpcsInput{
+			VpcIds: []string{"vpc-1"},
+		}).Return(&ec2.DescribeVpcsOutput{
+			Vpcs: []ec2types.Vpc{
+				{
+					VpcId: aws.String("vpc-1"),
+					CidrBlockAssociationSet: []ec2types.VpcCidrBlockAssociation{
+						{
+							CidrBlock: aws.String("192.168.0.0/16"),
+							CidrBlockState: &ec2types.VpcCidrBlockState{
+								State: ec2types.VpcCidrBlockStateCodeAssociated,
+							},
+						},
+						{
+							CidrBlock: aws.String("10.0.0.0/16"),
+							CidrBlockState: &ec2types.VpcCidrBlockState{
+								State: ec2types.VpcCidrBlockStateCodeDisassociated,
+							},
+						},
+					},
+				},
+			},
+		}, nil)
+		stackUpdater = &fakes.FakeStackUpdater{}
+		stackUpdater.DescribeClusterStackReturns(&cfntypes.Stack{}, nil)
+
+		clusterConfig = api.NewClusterConfig()
+		clusterConfig.VPC.ID = "vpc-1"
+		clusterConfig.VPC.Subnets = &api.ClusterSubnets{
+			Private: api.AZSubnetMapping{
+				"us-west-2a": api.AZSubnetSpec{ID: "subnet-private-a", AZ: "us-west-2a", CIDR: parseCIDR("192.168.96.0/19")},
+				"us-west-2b": api.AZSubnetSpec{ID: "subnet-private-b", AZ: "us-west-2b", CIDR: parseCIDR("192.168.128.0/19")},
+			},
+			Public: api.AZSubnetMapping{
+				"us-west-2a": api.AZSubnetSpec{ID: "subnet-public-a", AZ: "us-west-2a", CIDR: parseCIDR("192.168.0.0/19")},
+				"us-west-2b": api.AZSubnetSpec{ID: "subnet-public-b", AZ: "us-west-2b", CIDR: parseCIDR("192.168.32.0/19")},
+			},
+		}
+		clusterConfig.AvailabilityZones = []string{"us-west-2a", "us-west-2b"}
+	})
+
+	It("adds subnets of each type in each AZ and updates the cluster stack", func() {
+		subnets, err := extend(vpc.ExtendOptions{
+			CIDR:        parseCIDR("100.64.0.0/16"),
+			SubnetTypes: []vpc.SubnetType{vpc.SubnetTypePod, vpc.SubnetTypePrivate},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(subnets).To(Equal([]vpc.SecondarySubnet{
+			{
+				Alias:        "secondary-us-west-2a-100064000000",
+				Type:         vpc.SubnetTypePrivate,
+				AZSubnetSpec: api.AZSubnetSpec{AZ: "us-west-2a", CIDR: parseCIDR("100.64.0.0/19")},
+			},
+			{
+				Alias:        "secondary-us-west-2b-100064032000",
+				Type:         vpc.SubnetTypePrivate,
+				AZSubnetSpec: api.AZSubnetSpec{AZ: "us-west-2b", CIDR: parseCIDR("100.64.32.0/19")},
+			},
+			{
+				Alias:        "secondary-us-west-2a-100064064000",
+				Type:         vpc.SubnetTypePod,
+				AZSubnetSpec: api.AZSubnetSpec{AZ: "us-west-2a", CIDR: parseCIDR("100.64.64.0/19")},
+			},
+			{
+				Alias:        "secondary-us-west-2b-100064096000",
+				Type:         vpc.SubnetTypePod,
+				AZSubnetSpec: api.AZSubnetSpec{AZ: "us-west-2b", CIDR: parseCIDR("100.64.96.0/19")},
+			},
+		}))
+		clusterVPC := clusterConfig.VPC
+		Expect(clusterVPC.SecondaryCIDRs).To(Equal([]*ipnet.IPNet{parseCIDR("100.64.0.0/16")}))
+		Expect(clusterVPC.Subnets.Private).To(HaveLen(4))
+		Expect(clusterVPC.Subnets.Private).To(HaveKey("secondary-us-west-2b-100064032000"))
+		Expect(clusterVPC.Subnets.Public).To(HaveLen(2))
+		Expect(clusterVPC.PodSubnets.Subnets).To(HaveLen(2))
+		Expect(clusterVPC.PodSubnets.Subnets).To(HaveKey("secondary-us-west-2a-100064064000"))
+
+		Expect(stackUpdater.AppendNewClusterStackResourceCallCount()).To(Equal(1))
+		_, extendForOutposts, plan := stackUpdater.AppendNewClusterStackResourceArgsForCall(0)
+		Expect(extendForOutposts).To(BeFalse())
+		Expect(plan).To(BeFalse())
+	})
+
+	It("only creates subnets in the requested AZs", func() {
+		subnets, err := extend(vpc.ExtendOptions{
+			CIDR:              parseCIDR("100.64.0.0/24"),
+			SubnetTypes:       []vpc.SubnetType{vpc.SubnetTypePublic},
+			AvailabilityZones: []string{"us-west-2b"},
+			Plan:              true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subnets).To(HaveLen(1))
+		Expect(subnets[0].CIDR.String()).To(Equal("100.64.0.0/27"))
+		Expect(clusterConfig.VPC.Subnets.Public).To(HaveKey("secondary-us-west-2b-100064000000"))
+		Expect(clusterConfig.VPC.PodSubnets).To(BeNil())
+		Expect(stackUpdater.DescribeClusterStackCallCount()).To(BeZero())
+
+		_, _, plan := stackUpdater.AppendNewClusterStackResourceArgsForCall(0)
+		Expect(plan).To(BeTrue())
+	})
+
+	It("does not add pod subnets in AZs that already have one", func() {
+		stackUpdater.DescribeClusterStackReturns(&cfntypes.Stack{
+			Outputs: []cfntypes.Output{
+				{OutputKey: aws.String("SubnetsPrivate"), OutputValue: aws.String("subnet-private-a,subnet-private-b")},
+				{OutputKey: aws.String("SubnetsPodSecondary100064000000"), OutputValue: aws.String("subnet-pod-a")},
+			},
+		}, nil)
+		provider.MockEC2().On("DescribeSubnets", mock.Anything, &ec2.DescribeSubnetsInput{
+			SubnetIds: []string{"subnet-pod-a"},
+		}).Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []ec2types.Subnet{
+				{
+					SubnetId:         aws.String("subnet-pod-a"),
+					VpcId:            aws.String("vpc-1"),
+					AvailabilityZone: aws.String("us-west-2a"),
+					CidrBlock:        aws.String("100.64.0.0/17"),
+				},
+			},
+		}, nil)
+
+		_, err := extend(vpc.ExtendOptions{
+			CIDR:        parseCIDR("100.65.0.0/16"),
+			SubnetTypes: []vpc.SubnetType{vpc.SubnetTypePod},
+		})
+		Expect(err).To(MatchError("us-west-2a already has a pod subnet (subnet-pod-a); custom networking supports one pod subnet per availability zone"))
+		Expect(stackUpdater.AppendNewClusterStackResourceCallCount()).To(BeZero())
+		Expect(clusterConfig.VPC.PodSubnets).To(BeNil())
+
+		subnets, err := extend(vpc.ExtendOptions{
+			CIDR:              parseCIDR("100.65.0.0/16"),
+			SubnetTypes:       []vpc.SubnetType{vpc.SubnetTypePod},
+			AvailabilityZones: []string{"us-west-2b"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subnets).To(HaveLen(1))
+		Expect(clusterConfig.VPC.PodSubnets.Subnets).To(HaveKey("secondary-us-west-2b-100065000000"))
+	})
+
+	It("extends the same cluster twice", func() {
+		associatedCIDR := func(cidr string) ec2types.VpcCidrBlockAssociation {
+			return ec2types.VpcCidrBlockAssociation{
+				CidrBlock:      aws.String(cidr),
+				CidrBlockState: &ec2types.VpcCidrBlockState{State: ec2types.VpcCidrBlockStateCodeAssociated},
+			}
+		}
+		makeSubnet := func(id, az, cidr string) ec2types.Subnet {
+			return ec2types.Subnet{
+				SubnetId:         aws.String(id),
+				VpcId:            aws.String("vpc-1"),
+				AvailabilityZone: aws.String(az),
+				CidrBlock:        aws.String(cidr),
+			}
+		}
+		var (
+			clusterVPC = ec2types.Vpc{
+				VpcId:                   aws.String("vpc-1"),
+				CidrBlock:               aws.String("192.168.0.0/16"),
+				CidrBlockAssociationSet: []ec2types.VpcCidrBlockAssociation{associatedCIDR("192.168.0.0/16")},
+			}
+			subnets = []ec2types.Subnet{
+				makeSubnet("subnet-private-a", "us-west-2a", "192.168.96.0/19"),
+				makeSubnet("subnet-private-b", "us-west-2b", "192.168.128.0/19"),
+			}
+			stack = &cfntypes.Stack{
+				Outputs: []cfntypes.Output{
+					{OutputKey: aws.String("VPC"), OutputValue: aws.String("vpc-1")},
+					{OutputKey: aws.String("SecurityGroup"), OutputValue: aws.String("sg-1")},
+					{OutputKey: aws.String("SubnetsPrivate"), OutputValue: aws.String("subnet-private-a,subnet-private-b")},
+				},
+			}
+		)
+
+		provider = mockprovider.NewMockProvider()
+		provider.MockEKS().On("DescribeCluster", mock.Anything, mock.Anything).Return(&eks.DescribeClusterOutput{
+			Cluster: &ekstypes.Cluster{ResourcesVpcConfig: &ekstypes.VpcConfigResponse{}},
+		}, nil)
+		provider.MockEC2().On("DescribeVpcs", mock.Anything, mock.Anything).Return(func(context.Context, *ec2.DescribeVpcsInput, ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
+			return &ec2.DescribeVpcsOutput{Vpcs: []ec2types.Vpc{clusterVPC}}, nil
+		})
+		provider.MockEC2().On("DescribeSubnets", mock.Anything, mock.Anything).Return(func(_ context.Context, input *ec2.DescribeSubnetsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+			if len(input.SubnetIds) == 0 {
+				return &ec2.DescribeSubnetsOutput{Subnets: subnets}, nil
+			}
+			var ret []ec2types.Subnet
+			for _, s := range subnets {
+				if slices.Contains(input.SubnetIds, *s.SubnetId) {
+					ret = append(ret, s)
+				}
+			}
+			return &ec2.DescribeSubnetsOutput{Subnets: ret}, nil
+		})
+
+		// the stack update associates the secondary CIDR block with the VPC and exports the subnets created in it
+		stackUpdater.AppendNewClusterStackResourceStub = func(context.Context, bool, bool) (bool, error) {
+			secondaryCIDRs := clusterConfig.VPC.SecondaryCIDRs
+			cidr := secondaryCIDRs[len(secondaryCIDRs)-1]
+			clusterVPC.CidrBlockAssociationSet = append(clusterVPC.CidrBlockAssociationSet, associatedCIDR(cidr.String()))
+			var ids []string
+			for alias, s := range clusterConfig.VPC.Subnets.Private {
+				if s.ID != "" {
+					continue
+				}
+				s.ID = "subnet-" + alias
+				clusterConfig.VPC.Subnets.Private[alias] = s
+				subnets = append(subnets, makeSubnet(s.ID, s.AZ, s.CIDR.String()))
+				ids = append(ids, s.ID)
+			}
+			stack.Outputs = append(stack.Outputs, cfntypes.Output{
+				OutputKey:   aws.String("SubnetsPrivateSecondary" + vpc.MakeSecondaryCIDRName(cidr.IP)),
+				OutputValue: aws.String(strings.Join(ids, ",")),
+			})
+			return true, nil
+		}
+
+		loadClusterVPC := func() {
+			clusterConfig = api.NewClusterConfig()
+			clusterConfig.Metadata.Name = "cluster"
+			Expect(vpc.UseFromClusterStack(context.Background(), provider, stack, clusterConfig, false)).To(Succeed())
+		}
+
+		loadClusterVPC()
+		Expect(clusterConfig.VPC.SecondaryCIDRs).To(BeEmpty())
+		_, err := extend(vpc.ExtendOptions{
+			CIDR:        parseCIDR("100.64.0.0/16"),
+			SubnetTypes: []vpc.SubnetType{vpc.SubnetTypePrivate},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		loadClusterVPC()
+		Expect(clusterConfig.VPC.SecondaryCIDRs).To(Equal([]*ipnet.IPNet{parseCIDR("100.64.0.0/16")}))
+		Expect(clusterConfig.VPC.Subnets.Private).To(HaveLen(4))
+		_, err = extend(vpc.ExtendOptions{
+			CIDR:        parseCIDR("100.64.0.0/17"),
+			SubnetTypes: []vpc.SubnetType{vpc.SubnetTypePrivate},
+		})
+		Expect(err).To(MatchError(ContainSubstring("overlaps with CIDR block 100.64.0.0/16 of VPC vpc-1")))
+		_, err = extend(vpc.ExtendOptions{
+			CIDR:        parseCIDR("100.65.0.0/16"),
+			SubnetTypes: []vpc.SubnetType{vpc.SubnetTypePrivate},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusterConfig.VPC.SecondaryCIDRs).To(Equal([]*ipnet.IPNet{parseCIDR("100.64.0.0/16"), parseCIDR("100.65.0.0/16")}))
+
+		loadClusterVPC()
+		Expect(clusterConfig.VPC.SecondaryCIDRs).To(Equal([]*ipnet.IPNet{parseCIDR("100.64.0.0/16"), parseCIDR("100.65.0.0/16")}))
+		Expect(clusterConfig.VPC.Subnets.Private).To(HaveLen(6))
+		Expect(clusterConfig.VPC.Subnets.Private).To(HaveKeyWithValue("secondary-us-west-2a-100064000000", api.AZSubnetSpec{
+			ID:   "subnet-secondary-us-west-2a-100064000000",
+			AZ:   "us-west-2a",
+			CIDR: parseCIDR("100.64.0.0/19"),
+		}))
+		Expect(stackUpdater.AppendNewClusterStackResourceCallCount()).To(Equal(2))
+	})
+
+	DescribeTable("invalid options", func(updateConfig func(*api.ClusterConfig), options vpc.ExtendOptions, expectedErr string) {
+		if updateConfig != nil {
+			updateConfig(clusterConfig)
+		}
+		_, err := extend(options)
+		Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		Expect(stackUpdater.AppendNewClusterStackResourceCallCount()).To(BeZero())
+	},
+		Entry("CIDR overlaps with the VPC", nil, vpc.ExtendOptions{
+			CIDR:        parseCIDR("192.168.128.0/17"),
+			SubnetTypes: []vpc.SubnetType{vpc.SubnetTypePrivate},
+		}, "secondary CIDR block 192.168.128.0/17 overlaps with CIDR block 192.168.0.0/16 of VPC vpc-1"),
+
+		Entry("CIDR is too small", nil, vpc.ExtendOptions{
+			CIDR:        parseCIDR("100.64.0.0/29"),
+			SubnetTypes: []vpc.SubnetType{vpc.SubnetTypePrivate},
+		}, "secondary CIDR block prefix must be between /16 and /28"),
+
+		Entry("unknown subnet type", nil, vpc.ExtendOptions{
+			CIDR:        parseCIDR("100.64.0.0/16"),
+			SubnetTypes: []vpc.SubnetType{"isolated"},
+		}, `invalid subnet type "isolated"`),
+
+		Entry("AZ without a private subnet", nil, vpc.ExtendOptions{
+			CIDR:              parseCIDR("100.64.0.0/16"),
+			SubnetTypes:       []vpc.SubnetType{vpc.SubnetTypePrivate},
+			AvailabilityZones: []string{"us-west-2c"},
+		}, "VPC has no private subnet created by eksctl in us-west-2c"),
+
+		Entry("public subnets in a fully-private cluster", func(c *api.ClusterConfig) {
+			c.PrivateCluster.Enabled = true
+		}, vpc.ExtendOptions{
+			CIDR:        parseCIDR("100.64.0.0/16"),
+			SubnetTypes: []vpc.SubnetType{vpc.SubnetTypePublic},
+		}, "public subnets cannot be added to a fully-private cluster"),
+
+		Entry("IPv6 cluster", func(c *api.ClusterConfig) {
+			c.KubernetesNetworkConfig.IPFamily = api.IPV6Family
+		}, vpc.ExtendOptions{
+			CIDR:        parseCIDR("100.64.0.0/16"),
+			SubnetTypes: []vpc.SubnetType{vpc.SubnetTypePrivate},
+		}, "only supported for IPv4 clusters"),
+	)
+})
@@ -0,0 +1,198 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+type FakeStackUpdater struct {
+	AppendNewClusterStackResourceStub        func(context.Context, bool, bool) (bool, error)
+	appendNewClusterStackResourceMutex       sync.RWMutex
+	appendNewClusterStackResourceArgsForCall []struct {
+		arg1 context.Context
+		arg2 bool
+		arg3 bool
+	}
+	appendNewClusterStackResourceReturns struct {
+		result1 bool
+		result2 error
+	}
+	appendNewClusterStackResourceReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	DescribeClusterStackStub        func(context.Context) (*types.Stack, error)
+	describeClusterStackMutex       sync.RWMutex
+	describeClusterStackArgsForCall []struct {
+		arg1 context.Context
+	}
+	describeClusterStackReturns struct {
+		result1 *types.Stack
+		result2 error
+	}
+	describeClusterStackReturnsOnCall map[int]struct {
+		result1 *types.Stack
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStackUpdater) AppendNewClusterStackResource(arg1 context.Context, arg2 bool, arg3 bool) (bool, error) {
+	fake.appendNewClusterStackResourceMutex.Lock()
+	ret, specificReturn := fake.appendNewClusterStackResourceReturnsOnCall[len(fake.appendNewClusterStackResourceArgsForCall)]
+	fake.appendNewClusterStackResourceArgsForCall = append(fake.appendNewClusterStackResourceArgsForCall, struct {
+		arg1 context.Context
+		arg2 bool
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.AppendNewClusterStackResourceStub
+	fakeReturns := fake.appendNewClusterStackResourceReturns
+	fake.recordInvocation("AppendNewClusterStackResource", []interface{}{arg1, arg2, arg3})
+	fake.appendNewClusterStackResourceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStackUpdater) AppendNewClusterStackResourceCallCount() int {
+	fake.appendNewClusterStackResourceMutex.RLock()
+	defer fake.appendNewClusterStackResourceMutex.RUnlock()
+	return len(fake.appendNewClusterStackResourceArgsForCall)
+}
+
+func (fake *FakeStackUpdater) AppendNewClusterStackResourceCalls(stub func(context.Context, bool, bool) (bool, error)) {
+	fake.appendNewClusterStackResourceMutex.Lock()
+	defer fake.appendNewClusterStackResourceMutex.Unlock()
+	fake.AppendNewClusterStackResourceStub = stub
+}
+
+func (fake *FakeStackUpdater) AppendNewClusterStackResourceArgsForCall(i int) (context.Context, bool, bool) {
+	fake.appendNewClusterStackResourceMutex.RLock()
+	defer fake.appendNewClusterStackResourceMutex.RUnlock()
+	argsForCall := fake.appendNewClusterStackResourceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStackUpdater) AppendNewClusterStackResourceReturns(result1 bool, result2 error) {
+	fake.appendNewClusterStackResourceMutex.Lock()
+	defer fake.appendNewClusterStackResourceMutex.Unlock()
+	fake.AppendNewClusterStackResourceStub = nil
+	fake.appendNewClusterStackResourceReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStackUpdater) AppendNewClusterStackResourceReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.appendNewClusterStackResourceMutex.Lock()
+	defer fake.appendNewClusterStackResourceMutex.Unlock()
+	fake.AppendNewClusterStackResourceStub = nil
+	if fake.appendNewClusterStackResourceReturnsOnCall == nil {
+		fake.appendNewClusterStackResourceReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.appendNewClusterStackResourceReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStackUpdater) DescribeClusterStack(arg1 context.Context) (*types.Stack, error) {
+	fake.describeClusterStackMutex.Lock()
+	ret, specificReturn := fake.describeClusterStackReturnsOnCall[len(fake.describeClusterStackArgsForCall)]
+	fake.describeClusterStackArgsForCall = append(fake.describeClusterStackArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DescribeClusterStackStub
+	fakeReturns := fake.describeClusterStackReturns
+	fake.recordInvocation("DescribeClusterStack", []interface{}{arg1})
+	fake.describeClusterStackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStackUpdater) DescribeClusterStackCallCount() int {
+	fake.describeClusterStackMutex.RLock()
+	defer fake.describeClusterStackMutex.RUnlock()
+	return len(fake.describeClusterStackArgsForCall)
+}
+
+func (fake *FakeStackUpdater) DescribeClusterStackCalls(stub func(context.Context) (*types.Stack, error)) {
+	fake.describeClusterStackMutex.Lock()
+	defer fake.describeClusterStackMutex.Unlock()
+	fake.DescribeClusterStackStub = stub
+}
+
+func (fake *FakeStackUpdater) DescribeClusterStackArgsForCall(i int) context.Context {
+	fake.describeClusterStackMutex.RLock()
+	defer fake.describeClusterStackMutex.RUnlock()
+	argsForCall := fake.describeClusterStackArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStackUpdater) DescribeClusterStackReturns(result1 *types.Stack, result2 error) {
+	fake.describeClusterStackMutex.Lock()
+	defer fake.describeClusterStackMutex.Unlock()
+	fake.DescribeClusterStackStub = nil
+	fake.describeClusterStackReturns = struct {
+		result1 *types.Stack
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStackUpdater) DescribeClusterStackReturnsOnCall(i int, result1 *types.Stack, result2 error) {
+	fake.describeClusterStackMutex.Lock()
+	defer fake.describeClusterStackMutex.Unlock()
+	fake.DescribeClusterStackStub = nil
+	if fake.describeClusterStackReturnsOnCall == nil {
+		fake.describeClusterStackReturnsOnCall = make(map[int]struct {
+			result1 *types.Stack
+			result2 error
+		})
+	}
+	fake.describeClusterStackReturnsOnCall[i] = struct {
+		result1 *types.Stack
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStackUpdater) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.appendNewClusterStackResourceMutex.RLock()
+	defer fake.appendNewClusterStackResourceMutex.RUnlock()
+	fake.describeClusterStackMutex.RLock()
+	defer fake.describeClusterStackMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeStackUpdater) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,446 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/utils/instance"
+	"github.com/weaveworks/eksctl/pkg/utils/nodes"
+)
+
+const (
+	// awsReservedIPsPerSubnet is the number of addresses AWS reserves in every subnet
+	awsReservedIPsPerSubnet = 5
+	// maxVPCCIDRPrefixLength is the prefix length of the largest CIDR block that can be associated with a VPC
+	maxVPCCIDRPrefixLength = 16
+
+	vpcCNIPrefixDelegationEnv = "ENABLE_PREFIX_DELEGATION"
+	vpcCNICustomNetworkingEnv = "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG"
+
+	// maxDescribeInstanceTypes is the maximum number of instance types that can be described in one call
+	maxDescribeInstanceTypes = 100
+)
+
+// IPCapacityPlan describes whether the subnets of a cluster have enough IPv4 addresses for its nodegroups
+// at their maximum size.
+type IPCapacityPlan struct {
+	PrefixDelegation bool                  `json:"prefixDelegation"`
+	CustomNetworking bool                  `json:"customNetworking"`
+	NodeGroups       []NodeGroupIPCapacity `json:"nodeGroups"`
+	Subnets          []SubnetIPCapacity    `json:"subnets"`
+	// PodSubnets are the subnets pods are assigned addresses from when custom networking is enabled with vpc.podSubnets
+	PodSubnets []SubnetIPCapacity `json:"podSubnets,omitempty"`
+	// SuggestedLayout is the smallest layout of eksctl-created subnets that fits all nodegroups at their maximum size
+	SuggestedLayout *SubnetLayout `json:"suggestedLayout,omitempty"`
+}
+
+// NodeGroupIPCapacity is the IPv4 address demand of a nodegroup.
+type NodeGroupIPCapacity struct {
+	Name           string   `json:"name"`
+	InstanceType   string   `json:"instanceType"`
+	MaxSize        int      `json:"maxSize"`
+	MaxPodsPerNode int      `json:"maxPodsPerNode"`
+	IPsPerNode     int      `json:"ipsPerNode"`
+	RequiredIPs    int      `json:"requiredIPs"`
+	Subnets        []string `json:"subnets"`
+	// PodSubnetIPsPerNode is the number of addresses used by each node in pod subnets when custom networking is enabled
+	PodSubnetIPsPerNode int `json:"podSubnetIPsPerNode,omitempty"`
+	// MaxNodes is the projected number of nodes at which the subnets of the nodegroup run out of addresses,
+	// assuming all nodegroups sharing them grow proportionally
+	MaxNodes int `json:"maxNodes"`
+}
+
+// SubnetIPCapacity is the IPv4 address capacity and demand of a subnet.
+type SubnetIPCapacity struct {
+	Name         string `json:"name"`
+	ID           string `json:"id,omitempty"`
+	AZ           string `json:"availabilityZone,omitempty"`
+	CIDR         string `json:"cidr,omitempty"`
+	Private      bool   `json:"private"`
+	PodSubnet    bool   `json:"podSubnet,omitempty"`
+	TotalIPs     int    `json:"totalIPs"`
+	AvailableIPs int    `json:"availableIPs"`
+	RequiredIPs  int    `json:"requiredIPs"`
+	HeadroomIPs  int    `json:"headroomIPs"`
+	Undersized   bool   `json:"undersized"`
+}
+
+// SubnetLayout is a layout of the subnets created by eksctl.
+type SubnetLayout struct {
+	SubnetPrefixLength int `json:"subnetPrefixLength"`
+	VPCPrefixLength    int `json:"vpcPrefixLength"`
+	// ExceedsVPCCIDR is true when VPCPrefixLength is shorter than /16, the largest CIDR block of a VPC, so the
+	// demand can only be met with secondary CIDR blocks or prefix delegation
+	ExceedsVPCCIDR bool `json:"exceedsVPCCIDR,omitempty"`
+}
+
+// UndersizedSubnets returns the subnets and pod subnets that do not have enough addresses for the nodegroups using them.
+func (p *IPCapacityPlan) UndersizedSubnets() []SubnetIPCapacity {
+	var undersized []SubnetIPCapacity
+	for _, s := range slices.Concat(p.Subnets, p.PodSubnets) {
+		if s.Undersized {
+			undersized = append(undersized, s)
+		}
+	}
+	return undersized
+}
+
+// GetCNIMode returns how the VPC CNI is configured to assign addresses to pods, from the configuration values
+// of the vpc-cni addon in clusterConfig.
+func GetCNIMode(clusterConfig *api.ClusterConfig) (instance.CNIMode, error) {
+	var mode instance.CNIMode
+	for _, addon := range clusterConfig.Addons {
+		if addon.Name != api.VPCCNIAddon || addon.ConfigurationValues == "" {
+			continue
+		}
+		// configuration values can be JSON or YAML, where environment variables may not be quoted
+		var values struct {
+			Env map[string]interface{} `json:"env"`
+		}
+		if err := yaml.Unmarshal([]byte(addon.ConfigurationValues), &values); err != nil {
+			return mode, fmt.Errorf("parsing configurationValues of addon %s: %w", api.VPCCNIAddon, err)
+		}
+		isTrue := func(name string) bool {
+			value, ok := values.Env[name]
+			if !ok {
+				return false
+			}
+			enabled, _ := strconv.ParseBool(fmt.Sprint(value))
+			return enabled
+		}
+		mode.PrefixDelegation = isTrue(vpcCNIPrefixDelegationEnv)
+		mode.CustomNetworking = isTrue(vpcCNICustomNetworkingEnv)
+	}
+	if clusterConfig.VPC != nil && clusterConfig.VPC.PodSubnets != nil {
+		// eksctl enables custom networking in the vpc-cni addon when vpc.podSubnets is set
+		mode.CustomNetworking = true
+	}
+	return mode, nil
+}
+
+// PlanIPCapacity plans the IPv4 address capacity of the subnets used by the nodegroups in clusterConfig, and of
+// the pod subnets used by their pods when custom networking is enabled with vpc.podSubnets.
+// The number of free addresses of subnets that already exist is looked up in EC2, while subnets that will be
+// created by eksctl are assumed to be empty.
+func PlanIPCapacity(ctx context.Context, ec2API awsapi.EC2, clusterConfig *api.ClusterConfig) (*IPCapacityPlan, error) {
+	if clusterConfig.IPv6Enabled() {
+		return nil, fmt.Errorf("IP capacity planning is not supported for clusters using the %s IP family", api.IPV6Family)
+	}
+	mode, err := GetCNIMode(clusterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	nodePools := nodes.ToNodePools(clusterConfig)
+	networkLimits, err := describeNetworkLimits(ctx, ec2API, nodePools)
+	if err != nil {
+		return nil, err
+	}
+	var subnets, podSubnets []SubnetIPCapacity
+	if clusterConfig.VPC.Subnets != nil {
+		subnets = append(newSubnetIPCapacities(clusterConfig.VPC.Subnets.Public, false), newSubnetIPCapacities(clusterConfig.VPC.Subnets.Private, true)...)
+	}
+	if mode.CustomNetworking {
+		podSubnets = newSubnetIPCapacities(clusterConfig.VPC.PodSubnetMapping(), true)
+		for i := range podSubnets {
+			podSubnets[i].PodSubnet = true
+		}
+	}
+	allSubnets := slices.Concat(subnets, podSubnets)
+	if err := describeSubnetIPCapacities(ctx, ec2API, allSubnets); err != nil {
+		return nil, err
+	}
+	subnets, podSubnets = allSubnets[:len(subnets)], allSubnets[len(subnets):]
+
+	plan := &IPCapacityPlan{
+		PrefixDelegation: mode.PrefixDelegation,
+		CustomNetworking: mode.CustomNetworking,
+	}
+	var nodeGroupSubnets [][]int
+	for _, np := range nodePools {
+		ng := np.BaseNodeGroup()
+		ngCapacity, ok := planNodeGroupIPs(np, networkLimits, mode)
+		if !ok {
+			continue
+		}
+		subnetIndexes := selectSubnetsForNodeGroup(ng, subnets)
+		for _, i := range subnetIndexes {
+			ngCapacity.Subnets = append(ngCapacity.Subnets, subnets[i].displayName())
+			// nodes are spread evenly across the subnets of a nodegroup
+			subnets[i].RequiredIPs += ceilDiv(ngCapacity.RequiredIPs, len(subnetIndexes))
+			// pods are assigned addresses from the pod subnet in the availability zone of their node
+			if j := slices.IndexFunc(podSubnets, func(podSubnet SubnetIPCapacity) bool {
+				return podSubnet.AZ != "" && podSubnet.AZ == subnets[i].AZ
+			}); j >= 0 {
+				podSubnets[j].RequiredIPs += ceilDiv(ngCapacity.MaxSize*ngCapacity.PodSubnetIPsPerNode, len(subnetIndexes))
+			}
+		}
+		plan.NodeGroups = append(plan.NodeGroups, ngCapacity)
+		nodeGroupSubnets = append(nodeGroupSubnets, subnetIndexes)
+	}
+
+	setHeadroom(subnets)
+	setHeadroom(podSubnets)
+	plan.Subnets = subnets
+	plan.PodSubnets = podSubnets
+
+	for i := range plan.NodeGroups {
+		plan.NodeGroups[i].MaxNodes = projectMaxNodes(plan.NodeGroups[i], nodeGroupSubnets[i], subnets)
+	}
+	plan.SuggestedLayout = suggestSubnetLayout(subnets, len(clusterConfig.AvailabilityZones)+len(clusterConfig.LocalZones))
+	return plan, nil
+}
+
+func planNodeGroupIPs(np api.NodePool, networkLimits map[string]instance.NetworkLimits, mode instance.CNIMode) (NodeGroupIPCapacity, bool) {
+	ng := np.BaseNodeGroup()
+	var (
+		capacity NodeGroupIPCapacity
+		found    bool
+	)
+	// size the nodegroup for the instance type that uses the most addresses
+	for _, instanceType := range np.InstanceTypeList() {
+		limits, ok := networkLimits[instanceType]
+		if !ok {
+			continue
+		}
+		ngMode := mode
+		if api.IsWindowsImage(ng.AMIFamily) {
+			// pods on Windows nodes are assigned secondary addresses of the primary ENI
+			limits.MaxENIs = 1
+			ngMode = instance.CNIMode{}
+		}
+		maxPods := ng.GetMaxPodsPerNode()
+		if maxPods == 0 {
+			maxPods = limits.MaxPods(ngMode)
+		}
+		ips := limits.SubnetIPsPerNode(maxPods, ngMode)
+		if !found || ips.Node > capacity.IPsPerNode {
+			capacity = NodeGroupIPCapacity{
+				Name:                ng.Name,
+				InstanceType:        instanceType,
+				MaxPodsPerNode:      maxPods,
+				IPsPerNode:          ips.Node,
+				PodSubnetIPsPerNode: ips.Pod,
+			}
+			found = true
+		}
+	}
+	if !found {
+		return capacity, false
+	}
+	capacity.MaxSize = getMaxSize(ng)
+	capacity.RequiredIPs = capacity.MaxSize * capacity.IPsPerNode
+	return capacity, true
+}
+
+func getMaxSize(ng *api.NodeGroupBase) int {
+	if ng.ScalingConfig == nil {
+		return api.DefaultNodeCount
+	}
+	for _, size := range []*int{ng.MaxSize, ng.DesiredCapacity, ng.MinSize} {
+		if size != nil {
+			return *size
+		}
+	}
+	return api.DefaultNodeCount
+}
+
+// projectMaxNodes returns the number of nodes of ngCapacity that fit in its subnets when the addresses of each
+// subnet are shared between nodegroups in proportion to their demand.
+func projectMaxNodes(ngCapacity NodeGroupIPCapacity, subnetIndexes []int, subnets []SubnetIPCapacity) int {
+	if ngCapacity.IPsPerNode == 0 {
+		return 0
+	}
+	maxNodes := 0
+	for _, i := range subnetIndexes {
+		s := subnets[i]
+		if s.RequiredIPs == 0 {
+			continue
+		}
+		demand := ceilDiv(ngCapacity.RequiredIPs, len(subnetIndexes))
+		share := s.AvailableIPs * demand / s.RequiredIPs
+		maxNodes += share / ngCapacity.IPsPerNode
+	}
+	return maxNodes
+}
+
+// suggestSubnetLayout returns the smallest subnet and VPC prefix lengths for subnets created by eksctl that fit the
+// demand of the busiest subnet, or nil if the existing subnets are not created by eksctl.
+func suggestSubnetLayout(subnets []SubnetIPCapacity, zonesTotal int) *SubnetLayout {
+	maxRequired := 0
+	for _, s := range subnets {
+		if s.ID != "" {
+			return nil
+		}
+		maxRequired = max(maxRequired, s.RequiredIPs)
+	}
+	if maxRequired == 0 || zonesTotal == 0 {
+		return nil
+	}
+	addresses := maxRequired + awsReservedIPsPerSubnet
+	subnetPrefixLength := min(32-bits.Len(uint(addresses-1)), 28)
+	// the VPC CIDR is split into 8 or 16 subnets depending on the number of subnets, see getSubnetNetworkSize
+	vpcPrefixLength := subnetPrefixLength - 3
+	if zonesTotal*2 > 8 {
+		vpcPrefixLength = subnetPrefixLength - 4
+	}
+	return &SubnetLayout{
+		SubnetPrefixLength: subnetPrefixLength,
+		VPCPrefixLength:    vpcPrefixLength,
+		ExceedsVPCCIDR:     vpcPrefixLength < maxVPCCIDRPrefixLength,
+	}
+}
+
+// selectSubnetsForNodeGroup returns the indexes of the subnets used by ng, following the same rules as SelectNodeGroupSubnets.
+func selectSubnetsForNodeGroup(ng *api.NodeGroupBase, subnets []SubnetIPCapacity) []int {
+	var selected []int
+	for i, s := range subnets {
+		switch {
+		case len(ng.Subnets) > 0:
+			if slices.ContainsFunc(ng.Subnets, func(name string) bool {
+				return (s.ID != "" && name == s.ID) || (name == s.Name && s.Private == ng.PrivateNetworking)
+			}) {
+				selected = append(selected, i)
+			}
+		case s.Private != ng.PrivateNetworking:
+		case len(ng.AvailabilityZones) > 0:
+			if slices.Contains(ng.AvailabilityZones, s.AZ) {
+				selected = append(selected, i)
+			}
+		default:
+			selected = append(selected, i)
+		}
+	}
+	if api.IsEnabled(ng.EFAEnabled) && len(selected) > 0 {
+		selected = selected[:1]
+	}
+	return selected
+}
+
+// displayName returns the ID of s if it exists, or its name in the config otherwise.
+func (s SubnetIPCapacity) displayName() string {
+	if s.ID != "" {
+		return s.ID
+	}
+	return s.Name
+}
+
+// setHeadroom sets the headroom of subnets, and marks those that do not have enough addresses as undersized.
+func setHeadroom(subnets []SubnetIPCapacity) {
+	for i, s := range subnets {
+		s.HeadroomIPs = s.AvailableIPs - s.RequiredIPs
+		// the capacity of subnets specified only by their availability zone is unknown
+		s.Undersized = s.CIDR != "" && s.HeadroomIPs < 0
+		subnets[i] = s
+	}
+}
+
+// newSubnetIPCapacities returns the capacity of the subnets in mapping, sorted by name, assuming that the
+// subnets that do not exist yet are empty.
+func newSubnetIPCapacities(mapping api.AZSubnetMapping, private bool) []SubnetIPCapacity {
+	var subnets []SubnetIPCapacity
+	for name, spec := range mapping {
+		s := SubnetIPCapacity{
+			Name:    name,
+			ID:      spec.ID,
+			AZ:      spec.AZ,
+			Private: private,
+		}
+		if spec.CIDR != nil {
+			s.CIDR = spec.CIDR.String()
+			ones, bits := spec.CIDR.Mask.Size()
+			s.TotalIPs = 1<<(bits-ones) - awsReservedIPsPerSubnet
+			s.AvailableIPs = s.TotalIPs
+		}
+		subnets = append(subnets, s)
+	}
+	sort.Slice(subnets, func(i, j int) bool {
+		return subnets[i].Name < subnets[j].Name
+	})
+	return subnets
+}
+
+// describeSubnetIPCapacities sets the availability zone, CIDR and free addresses of the subnets that already exist.
+func describeSubnetIPCapacities(ctx context.Context, ec2API awsapi.EC2, subnets []SubnetIPCapacity) error {
+	var subnetIDs []string
+	for _, s := range subnets {
+		if s.ID != "" && !slices.Contains(subnetIDs, s.ID) {
+			subnetIDs = append(subnetIDs, s.ID)
+		}
+	}
+	if len(subnetIDs) == 0 {
+		return nil
+	}
+	output, err := ec2API.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: subnetIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("describing subnets %s: %w", strings.Join(subnetIDs, ", "), err)
+	}
+	ec2Subnets := map[string]ec2types.Subnet{}
+	for _, s := range output.Subnets {
+		ec2Subnets[aws.ToString(s.SubnetId)] = s
+	}
+	for i, s := range subnets {
+		ec2Subnet, ok := ec2Subnets[s.ID]
+		if !ok {
+			continue
+		}
+		s.AZ = aws.ToString(ec2Subnet.AvailabilityZone)
+		s.CIDR = aws.ToString(ec2Subnet.CidrBlock)
+		if _, cidr, err := net.ParseCIDR(s.CIDR); err == nil {
+			ones, bits := cidr.Mask.Size()
+			s.TotalIPs = 1<<(bits-ones) - awsReservedIPsPerSubnet
+		}
+		s.AvailableIPs = int(aws.ToInt32(ec2Subnet.AvailableIpAddressCount))
+		subnets[i] = s
+	}
+	return nil
+}
+
+func describeNetworkLimits(ctx context.Context, ec2API awsapi.EC2, nodePools []api.NodePool) (map[string]instance.NetworkLimits, error) {
+	var instanceTypes []ec2types.InstanceType
+	for _, np := range nodePools {
+		for _, it := range np.InstanceTypeList() {
+			if !slices.Contains(instanceTypes, ec2types.InstanceType(it)) {
+				instanceTypes = append(instanceTypes, ec2types.InstanceType(it))
+			}
+		}
+	}
+	limits := map[string]instance.NetworkLimits{}
+	if len(instanceTypes) == 0 {
+		return limits, nil
+	}
+	for chunk := range slices.Chunk(instanceTypes, maxDescribeInstanceTypes) {
+		paginator := ec2.NewDescribeInstanceTypesPaginator(ec2API, &ec2.DescribeInstanceTypesInput{
+			InstanceTypes: chunk,
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't retrieve instance type description for %v: %w", chunk, err)
+			}
+			for _, it := range output.InstanceTypes {
+				limits[string(it.InstanceType)] = instance.NewNetworkLimits(it)
+			}
+		}
+	}
+	return limits, nil
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
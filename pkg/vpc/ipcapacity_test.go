@@ -0,0 +1,264 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	"github.com/weaveworks/eksctl/pkg/utils/instance"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+)
+
+var _ = Describe("PlanIPCapacity", func() {
+	var (
+		p   *mockprovider.MockProvider
+		cfg *api.ClusterConfig
+	)
+
+	newNodeGroup := func(name string, maxSize int) *api.ManagedNodeGroup {
+		ng := api.NewManagedNodeGroup()
+		ng.Name = name
+		ng.InstanceType = "m5.large"
+		ng.PrivateNetworking = true
+		ng.ScalingConfig = &api.ScalingConfig{
+			MaxSize: aws.Int(maxSize),
+		}
+		return ng
+	}
+
+	setVPCCIDR := func(cidr string) {
+		vpcCIDR, err := ipnet.ParseCIDR(cidr)
+		Expect(err).NotTo(HaveOccurred())
+		cfg.VPC.CIDR = vpcCIDR
+		Expect(SetSubnets(cfg.VPC, cfg.AvailabilityZones, nil)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		p.MockEC2().On("DescribeInstanceTypes", mock.Anything, &ec2.DescribeInstanceTypesInput{
+			InstanceTypes: []ec2types.InstanceType{"m5.large"},
+		}, mock.Anything).Return(&ec2.DescribeInstanceTypesOutput{
+			InstanceTypes: []ec2types.InstanceTypeInfo{
+				{
+					InstanceType: "m5.large",
+					VCpuInfo: &ec2types.VCpuInfo{
+						DefaultVCpus: aws.Int32(2),
+					},
+					NetworkInfo: &ec2types.NetworkInfo{
+						MaximumNetworkInterfaces:  aws.Int32(3),
+						Ipv4AddressesPerInterface: aws.Int32(10),
+					},
+				},
+			},
+		}, nil)
+
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "cluster"
+		cfg.AvailabilityZones = []string{"us-west-2a", "us-west-2b", "us-west-2c"}
+	})
+
+	It("reports headroom for subnets created by eksctl", func() {
+		setVPCCIDR("192.168.0.0/16")
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{newNodeGroup("mng", 10)}
+
+		plan, err := PlanIPCapacity(context.Background(), p.EC2(), cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.NodeGroups).To(Equal([]NodeGroupIPCapacity{
+			{
+				Name:           "mng",
+				InstanceType:   "m5.large",
+				MaxSize:        10,
+				MaxPodsPerNode: 29,
+				IPsPerNode:     30,
+				RequiredIPs:    300,
+				Subnets:        []string{"us-west-2a", "us-west-2b", "us-west-2c"},
+				MaxNodes:       816,
+			},
+		}))
+		Expect(plan.Subnets).To(HaveLen(6))
+		for _, s := range plan.Subnets {
+			Expect(s.TotalIPs).To(Equal(8187))
+			if s.Private {
+				Expect(s.RequiredIPs).To(Equal(100))
+				Expect(s.HeadroomIPs).To(Equal(8087))
+			} else {
+				Expect(s.RequiredIPs).To(BeZero())
+			}
+		}
+		Expect(plan.UndersizedSubnets()).To(BeEmpty())
+	})
+
+	It("suggests a larger layout when subnets created by eksctl are undersized", func() {
+		setVPCCIDR("192.168.0.0/24")
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{newNodeGroup("mng", 10)}
+
+		plan, err := PlanIPCapacity(context.Background(), p.EC2(), cfg)
+		Expect(err).NotTo(HaveOccurred())
+		undersized := plan.UndersizedSubnets()
+		Expect(undersized).To(HaveLen(3))
+		Expect(undersized[0].AvailableIPs).To(Equal(27))
+		Expect(undersized[0].HeadroomIPs).To(Equal(-73))
+		Expect(plan.NodeGroups[0].MaxNodes).To(BeZero())
+		Expect(plan.SuggestedLayout).To(Equal(&SubnetLayout{
+			SubnetPrefixLength: 25,
+			VPCPrefixLength:    22,
+		}))
+	})
+
+	It("uses the free IP addresses of existing subnets", func() {
+		cfg.VPC.ID = "vpc-1"
+		cfg.VPC.Subnets = &api.ClusterSubnets{
+			Private: api.AZSubnetMapping{
+				"private-a": api.AZSubnetSpec{ID: "subnet-a"},
+				"private-b": api.AZSubnetSpec{ID: "subnet-b"},
+			},
+		}
+		cfg.Addons = []*api.Addon{
+			{
+				Name:                api.VPCCNIAddon,
+				ConfigurationValues: `{"env":{"ENABLE_PREFIX_DELEGATION":"true"}}`,
+			},
+		}
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{newNodeGroup("mng", 4)}
+		p.MockEC2().On("DescribeSubnets", mock.Anything, &ec2.DescribeSubnetsInput{
+			SubnetIds: []string{"subnet-a", "subnet-b"},
+		}).Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []ec2types.Subnet{
+				{
+					SubnetId:                aws.String("subnet-a"),
+					AvailabilityZone:        aws.String("us-west-2a"),
+					CidrBlock:               aws.String("10.0.0.0/24"),
+					AvailableIpAddressCount: aws.Int32(200),
+				},
+				{
+					SubnetId:                aws.String("subnet-b"),
+					AvailabilityZone:        aws.String("us-west-2b"),
+					CidrBlock:               aws.String("10.0.1.0/24"),
+					AvailableIpAddressCount: aws.Int32(300),
+				},
+			},
+		}, nil)
+
+		plan, err := PlanIPCapacity(context.Background(), p.EC2(), cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.PrefixDelegation).To(BeTrue())
+		Expect(plan.NodeGroups[0].IPsPerNode).To(Equal(129))
+		Expect(plan.NodeGroups[0].MaxNodes).To(Equal(3))
+		Expect(plan.SuggestedLayout).To(BeNil())
+		Expect(plan.Subnets).To(Equal([]SubnetIPCapacity{
+			{
+				Name:         "private-a",
+				ID:           "subnet-a",
+				AZ:           "us-west-2a",
+				CIDR:         "10.0.0.0/24",
+				Private:      true,
+				TotalIPs:     251,
+				AvailableIPs: 200,
+				RequiredIPs:  258,
+				HeadroomIPs:  -58,
+				Undersized:   true,
+			},
+			{
+				Name:         "private-b",
+				ID:           "subnet-b",
+				AZ:           "us-west-2b",
+				CIDR:         "10.0.1.0/24",
+				Private:      true,
+				TotalIPs:     251,
+				AvailableIPs: 300,
+				RequiredIPs:  258,
+				HeadroomIPs:  42,
+			},
+		}))
+	})
+
+	It("reports headroom for pod subnets when custom networking is enabled", func() {
+		podCIDR, err := ipnet.ParseCIDR("100.64.0.0/24")
+		Expect(err).NotTo(HaveOccurred())
+		cfg.VPC.PodSubnets = &api.PodSubnets{CIDR: podCIDR}
+		setVPCCIDR("192.168.0.0/16")
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{newNodeGroup("mng", 10)}
+
+		plan, err := PlanIPCapacity(context.Background(), p.EC2(), cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.CustomNetworking).To(BeTrue())
+		Expect(plan.NodeGroups[0].PodSubnetIPsPerNode).To(Equal(20))
+		Expect(plan.PodSubnets).To(HaveLen(3))
+		for _, s := range plan.PodSubnets {
+			Expect(s.PodSubnet).To(BeTrue())
+			Expect(s.AvailableIPs).To(Equal(27))
+			Expect(s.RequiredIPs).To(Equal(67))
+			Expect(s.HeadroomIPs).To(Equal(-40))
+		}
+		Expect(plan.UndersizedSubnets()).To(Equal(plan.PodSubnets))
+	})
+
+	It("describes instance types in batches", func() {
+		var instanceTypes []string
+		for i := range 150 {
+			instanceTypes = append(instanceTypes, fmt.Sprintf("type-%d.large", i))
+		}
+		ng := newNodeGroup("mng", 10)
+		ng.InstanceTypes = instanceTypes
+		p = mockprovider.NewMockProvider()
+		p.MockEC2().On("DescribeInstanceTypes", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeInstanceTypesInput) bool {
+			return len(input.InstanceTypes) <= 100
+		}), mock.Anything).Return(
+			func(_ context.Context, input *ec2.DescribeInstanceTypesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+				output := &ec2.DescribeInstanceTypesOutput{}
+				for _, it := range input.InstanceTypes {
+					output.InstanceTypes = append(output.InstanceTypes, ec2types.InstanceTypeInfo{InstanceType: it})
+				}
+				return output, nil
+			}, nil)
+
+		limits, err := describeNetworkLimits(context.Background(), p.EC2(), []api.NodePool{ng})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limits).To(HaveLen(150))
+		p.MockEC2().AssertNumberOfCalls(GinkgoT(), "DescribeInstanceTypes", 2)
+	})
+
+	It("rejects IPv6 clusters", func() {
+		cfg.KubernetesNetworkConfig.IPFamily = api.IPV6Family
+		_, err := PlanIPCapacity(context.Background(), p.EC2(), cfg)
+		Expect(err).To(MatchError("IP capacity planning is not supported for clusters using the IPv6 IP family"))
+	})
+})
+
+var _ = DescribeTable("suggestSubnetLayout", func(requiredIPs, zonesTotal int, expectedLayout SubnetLayout) {
+	subnets := []SubnetIPCapacity{{Name: "private-a", RequiredIPs: requiredIPs}}
+	Expect(suggestSubnetLayout(subnets, zonesTotal)).To(Equal(&expectedLayout))
+},
+	Entry("largest subnets that fit in a /16 VPC CIDR with 3 AZs", 8187, 3, SubnetLayout{SubnetPrefixLength: 19, VPCPrefixLength: 16}),
+	Entry("subnets that do not fit in a /16 VPC CIDR with 3 AZs", 8188, 3, SubnetLayout{SubnetPrefixLength: 18, VPCPrefixLength: 15, ExceedsVPCCIDR: true}),
+	Entry("largest subnets that fit in a /16 VPC CIDR with 5 AZs", 4091, 5, SubnetLayout{SubnetPrefixLength: 20, VPCPrefixLength: 16}),
+	Entry("subnets that do not fit in a /16 VPC CIDR with 5 AZs", 4092, 5, SubnetLayout{SubnetPrefixLength: 19, VPCPrefixLength: 15, ExceedsVPCCIDR: true}),
+)
+
+var _ = DescribeTable("GetCNIMode", func(configurationValues string, expectedMode instance.CNIMode) {
+	cfg := api.NewClusterConfig()
+	cfg.Addons = []*api.Addon{
+		{
+			Name:                api.VPCCNIAddon,
+			ConfigurationValues: configurationValues,
+		},
+	}
+	mode, err := GetCNIMode(cfg)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(mode).To(Equal(expectedMode))
+},
+	Entry("no configuration values", "", instance.CNIMode{}),
+	Entry("prefix delegation", `{"env":{"ENABLE_PREFIX_DELEGATION":"true"}}`, instance.CNIMode{PrefixDelegation: true}),
+	Entry("custom networking", `{"env":{"AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG":"true","ENABLE_PREFIX_DELEGATION":"false"}}`, instance.CNIMode{CustomNetworking: true}),
+	Entry("YAML", "env:\n  ENABLE_PREFIX_DELEGATION: \"true\"\n", instance.CNIMode{PrefixDelegation: true}),
+	Entry("YAML with unquoted values", "env:\n  ENABLE_PREFIX_DELEGATION: true\n  AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG: true\n", instance.CNIMode{PrefixDelegation: true, CustomNetworking: true}),
+)
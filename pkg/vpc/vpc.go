@@ -91,6 +91,43 @@ func SetSubnets(vpc *api.ClusterVPC, availabilityZones, localZones []string) err
 
 	setSubnets(availabilityZones, 0, vpc.Subnets)
 	setSubnets(localZones, len(availabilityZones), vpc.LocalZoneSubnets)
+	return setPodSubnets(vpc, availabilityZones)
+}
+
+// setPodSubnets splits vpc.podSubnets.cidr into a pod subnet in each availability zone, and associates it
+// with the VPC as a secondary CIDR block.
+func setPodSubnets(vpc *api.ClusterVPC, availabilityZones []string) error {
+	if vpc.PodSubnets == nil {
+		return nil
+	}
+	podCIDR := vpc.PodSubnets.CIDR
+	if podCIDR == nil {
+		return errors.New("vpc.podSubnets.cidr must be set to create pod subnets in a VPC created by eksctl")
+	}
+	if vpc.CIDR.Contains(podCIDR.IP) || podCIDR.Contains(vpc.CIDR.IP) {
+		return fmt.Errorf("vpc.podSubnets.cidr %s overlaps with the VPC CIDR %s", podCIDR, vpc.CIDR)
+	}
+
+	subnetSize, networkLength, err := getSubnetNetworkSize(podCIDR.IPNet, len(availabilityZones))
+	if err != nil {
+		return err
+	}
+	zoneCIDRs, err := SplitInto(&podCIDR.IPNet, subnetSize, networkLength)
+	if err != nil {
+		return fmt.Errorf("cannot split vpc.podSubnets.cidr %s into %d subnets: %w", podCIDR, len(availabilityZones), err)
+	}
+
+	vpc.PodSubnets.Subnets = api.NewAZSubnetMapping()
+	for i, zone := range availabilityZones {
+		cidr := zoneCIDRs[i]
+		// pod subnets use the same aliases as those added by ClusterExtender, so that they match the stack outputs
+		vpc.PodSubnets.Subnets[MakeSecondarySubnetAlias(zone, cidr.IP)] = api.AZSubnetSpec{
+			AZ:   zone,
+			CIDR: &ipnet.IPNet{IPNet: *cidr},
+		}
+		logger.Info("pod subnet for %s: %s", zone, cidr)
+	}
+	vpc.SecondaryCIDRs = []*ipnet.IPNet{podCIDR}
 	return nil
 }
 
@@ -401,14 +438,76 @@ func UseFromClusterStack(ctx context.Context, provider api.ClusterProvider, stac
 		}
 	}
 
+	// subnets in secondary CIDR blocks are exported in an output per CIDR block
+	importSecondarySubnets := func(subnetMapping api.AZSubnetMapping) outputs.Collector {
+		return func(v string) error {
+			return ImportSubnetsByIDsWithAlias(ctx, provider.EC2(), spec, subnetMapping, splitOutputValue(v), MakeSecondarySubnetAliasFunc())
+		}
+	}
+	// secondaryCIDRNames are the names of the secondary CIDR blocks that have subnets exported in the stack outputs
+	secondaryCIDRNames := map[string]struct{}{}
+	for _, o := range stack.Outputs {
+		switch key := aws.ToString(o.OutputKey); {
+		case strings.HasPrefix(key, outputs.ClusterSubnetsPrivateSecondary):
+			secondaryCIDRNames[strings.TrimPrefix(key, outputs.ClusterSubnetsPrivateSecondary)] = struct{}{}
+			optionalCollectors[key] = importSecondarySubnets(spec.VPC.Subnets.Private)
+		case strings.HasPrefix(key, outputs.ClusterSubnetsPublicSecondary):
+			secondaryCIDRNames[strings.TrimPrefix(key, outputs.ClusterSubnetsPublicSecondary)] = struct{}{}
+			optionalCollectors[key] = importSecondarySubnets(spec.VPC.Subnets.Public)
+		case strings.HasPrefix(key, outputs.ClusterSubnetsPodSecondary):
+			secondaryCIDRNames[strings.TrimPrefix(key, outputs.ClusterSubnetsPodSecondary)] = struct{}{}
+			// pod subnets are only used when custom networking is enabled in vpc.podSubnets
+			if spec.VPC.PodSubnets == nil {
+				continue
+			}
+			if spec.VPC.PodSubnets.Subnets == nil {
+				spec.VPC.PodSubnets.Subnets = api.NewAZSubnetMapping()
+			}
+			optionalCollectors[key] = importSecondarySubnets(spec.VPC.PodSubnets.Subnets)
+		}
+	}
+
 	if err := outputs.Collect(*stack, requiredCollectors, optionalCollectors); err != nil {
 		return err
 	}
+	if err := importSecondaryCIDRs(ctx, provider.EC2(), spec.VPC, secondaryCIDRNames); err != nil {
+		return err
+	}
 	// to clean up invalid subnets based on AZ after importing valid subnets from stack
 	cleanupSubnets(spec)
 	return nil
 }
 
+// importSecondaryCIDRs sets the secondary CIDR blocks of clusterVPC to the CIDR blocks associated with the VPC
+// whose names are in cidrNames, so that the cluster stack can be extended again without recreating them.
+func importSecondaryCIDRs(ctx context.Context, ec2API awsapi.EC2, clusterVPC *api.ClusterVPC, cidrNames map[string]struct{}) error {
+	// this call is authoritative, like the import of the VPC CIDR
+	clusterVPC.SecondaryCIDRs = nil
+	if len(cidrNames) == 0 {
+		return nil
+	}
+	vpc, err := describeVPC(ctx, ec2API, clusterVPC.ID)
+	if err != nil {
+		return err
+	}
+	for _, association := range vpc.CidrBlockAssociationSet {
+		if association.CidrBlockState != nil && !isActiveCIDRBlockState(association.CidrBlockState.State) {
+			continue
+		}
+		cidr, err := ipnet.ParseCIDR(aws.ToString(association.CidrBlock))
+		if err != nil {
+			return fmt.Errorf("unexpected error parsing VPC CIDR %q: %w", aws.ToString(association.CidrBlock), err)
+		}
+		if _, ok := cidrNames[MakeSecondaryCIDRName(cidr.IP)]; ok {
+			clusterVPC.SecondaryCIDRs = append(clusterVPC.SecondaryCIDRs, cidr)
+		}
+	}
+	if len(clusterVPC.SecondaryCIDRs) < len(cidrNames) {
+		logger.Warning("found subnets of %d secondary CIDR block(s) in the cluster stack outputs, but only %d are associated with VPC %s", len(cidrNames), len(clusterVPC.SecondaryCIDRs), clusterVPC.ID)
+	}
+	return nil
+}
+
 // MakeExtendedSubnetAliasFunc returns a function for creating an alias for a subnet that was added as part of extending
 // the VPC with Outpost subnets.
 func MakeExtendedSubnetAliasFunc() MakeSubnetAlias {
@@ -425,6 +524,41 @@ func MakeExtendedSubnetAlias(az string, ordinal int) string {
 	return fmt.Sprintf("outpost-%s-%d", az, ordinal)
 }
 
+const secondarySubnetAliasPrefix = "secondary-"
+
+// MakeSecondarySubnetAliasFunc returns a function for creating an alias for a subnet in a secondary CIDR block of the VPC.
+func MakeSecondarySubnetAliasFunc() MakeSubnetAlias {
+	return func(subnet *ec2types.Subnet) string {
+		ip, _, err := net.ParseCIDR(aws.ToString(subnet.CidrBlock))
+		if err != nil {
+			return *subnet.AvailabilityZone
+		}
+		return MakeSecondarySubnetAlias(*subnet.AvailabilityZone, ip)
+	}
+}
+
+// MakeSecondarySubnetAlias generates an alias for a subnet in a secondary CIDR block of the VPC.
+// Unlike the aliases of Outpost subnets, it is derived from the network address of the subnet so that
+// the names of the stack resources for the subnet do not depend on the order in which subnets are imported.
+func MakeSecondarySubnetAlias(az string, ip net.IP) string {
+	return fmt.Sprintf("%s%s-%s", secondarySubnetAliasPrefix, az, MakeSecondaryCIDRName(ip))
+}
+
+// IsSecondarySubnetAlias returns true if alias was generated by MakeSecondarySubnetAlias.
+func IsSecondarySubnetAlias(alias string) bool {
+	return strings.HasPrefix(alias, secondarySubnetAliasPrefix)
+}
+
+// MakeSecondaryCIDRName returns a name for an IPv4 network address that can be used in stack resource and output names.
+func MakeSecondaryCIDRName(ip net.IP) string {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return strings.NewReplacer(".", "", ":", "").Replace(ip.String())
+	}
+	// pad each octet so that distinct addresses never map to the same name
+	return fmt.Sprintf("%03d%03d%03d%03d", ip4[0], ip4[1], ip4[2], ip4[3])
+}
+
 // importVPC will update spec with VPC ID/CIDR
 // NOTE: it does respect all fields set in spec.VPC, and will error if
 // there is a mismatch of local vs remote states
@@ -677,6 +811,34 @@ func ImportSubnetsFromSpec(ctx context.Context, ec2API awsapi.EC2, spec *api.Clu
 	}
 	// to clean up invalid subnets based on AZ after importing both private and public subnets
 	cleanupSubnets(spec)
+	return importPodSubnets(ctx, ec2API, spec)
+}
+
+// importPodSubnets imports the subnets in vpc.podSubnets, and checks that there is at most one pod subnet
+// in each availability zone, as the VPC CNI selects the pod subnet of a node by its availability zone.
+func importPodSubnets(ctx context.Context, ec2API awsapi.EC2, spec *api.ClusterConfig) error {
+	podSubnets := spec.VPC.PodSubnetMapping()
+	if len(podSubnets) == 0 {
+		return nil
+	}
+	subnets, err := describeSubnets(ctx, ec2API, spec.VPC.ID, podSubnets.WithIDs(), podSubnets.WithCIDRs(), podSubnets.WithAZs())
+	if err != nil {
+		return err
+	}
+	// unlike node subnets, pod subnets do not add availability zones to the cluster
+	availabilityZones := spec.AvailabilityZones
+	if err := ImportSubnets(ctx, ec2API, spec, podSubnets, subnets, nil); err != nil {
+		return err
+	}
+	spec.AvailabilityZones = availabilityZones
+
+	zoneSubnets := map[string]string{}
+	for _, s := range podSubnets {
+		if id, ok := zoneSubnets[s.AZ]; ok && id != s.ID {
+			return fmt.Errorf("found more than one pod subnet in %s (%s and %s); only one pod subnet per availability zone is supported", s.AZ, id, s.ID)
+		}
+		zoneSubnets[s.AZ] = s.ID
+	}
 	return nil
 }
 